@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle"
+	grpcv1 "github.com/ojo-network/price-feeder/router/grpc/v1"
+)
+
+// startGRPCServer serves the gRPC price API on the configured listen address
+// until the context is done.
+func startGRPCServer(ctx context.Context, logger zerolog.Logger, cfg config.Config, oracle *oracle.Oracle) error {
+	listenAddr := cfg.Server.GRPCListenAddr
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to start gRPC server")
+		return err
+	}
+
+	srv := grpc.NewServer()
+	grpcv1.NewServer(logger, cfg, oracle).Register(srv)
+
+	srvErrCh := make(chan error, 1)
+	go func() {
+		logger.Info().Str("listen_addr", listenAddr).Msg("starting gRPC server...")
+		srvErrCh <- srv.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Info().Str("listen_addr", listenAddr).Msg("shutting down gRPC server...")
+		stopped := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(stopped)
+		}()
+
+		// streams only end once their clients cancel them
+		select {
+		case <-stopped:
+		case <-time.After(15 * time.Second):
+			srv.Stop()
+		}
+		return nil
+
+	case err := <-srvErrCh:
+		logger.Error().Err(err).Msg("failed to start gRPC server")
+		return err
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// startPprofServer serves the pprof endpoints on their own server, apart from
+// the API server whose write timeout would cut the CPU profiles and traces
+// short, until the context is done.
+func startPprofServer(ctx context.Context, logger zerolog.Logger, listenAddr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	srvErrCh := make(chan error, 1)
+	srv := &http.Server{
+		Handler:           mux,
+		Addr:              listenAddr,
+		ReadHeaderTimeout: 15 * time.Second,
+	}
+
+	go func() {
+		logger.Info().Str("listen_addr", listenAddr).Msg("starting pprof server...")
+		srvErrCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		logger.Info().Str("listen_addr", listenAddr).Msg("shutting down pprof server...")
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error().Err(err).Msg("failed to gracefully shutdown pprof server")
+			return err
+		}
+		return nil
+
+	case err := <-srvErrCh:
+		logger.Error().Err(err).Msg("failed to start pprof server")
+		return err
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle"
+)
+
+// runPreflight creates each configured provider before the price feeder
+// starts and logs the ones which failed to be created. It fails if any
+// provider is misconfigured, while unreachable providers are only logged
+// since the oracle keeps trying to create them when collecting prices.
+func runPreflight(ctx context.Context, logger zerolog.Logger, cfg config.Config) error {
+	results := oracle.PreflightWithConcurrency(
+		ctx,
+		logger,
+		cfg.ProviderPairs(),
+		cfg.ProviderEndpointsMap(),
+		cfg.ProviderSymbolAliases(),
+		cfg.StartupConcurrency,
+	)
+
+	misconfigured := []string{}
+	for _, result := range results {
+		switch result.Failure {
+		case oracle.PreflightMisconfigured:
+			misconfigured = append(misconfigured, result.Provider.String())
+			logger.Error().
+				Err(result.Err).
+				Str("provider", result.Provider.String()).
+				Msg("preflight: provider is misconfigured")
+
+		case oracle.PreflightUnreachable:
+			logger.Warn().
+				Err(result.Err).
+				Str("provider", result.Provider.String()).
+				Msg("preflight: provider is unreachable")
+		}
+	}
+
+	if len(misconfigured) > 0 {
+		return fmt.Errorf("preflight found misconfigured providers: %s", strings.Join(misconfigured, ", "))
+	}
+	logger.Info().Int("providers", len(results)).Msg("preflight: providers created")
+	return nil
+}
@@ -21,28 +21,29 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/cosmos/cosmos-sdk/telemetry"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ojo-network/ojo/app/params"
 	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle"
 	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/publisher"
 	v1 "github.com/ojo-network/price-feeder/router/v1"
 )
 
 const (
-	logLevelJSON = "json"
-	logLevelText = "text"
+	logLevelJSON = config.LogFormatJSON
+	logLevelText = config.LogFormatText
 
 	flagLogLevel          = "log-level"
 	flagLogFormat         = "log-format"
 	flagSkipProviderCheck = "skip-provider-check"
+	flagPreflight         = "preflight"
 
 	envVariablePass = "PRICE_FEEDER_PASS"
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "price-feeder [config-file]",
-	Args:  cobra.ExactArgs(1),
+	Use:   "price-feeder [config-file|config-dir]...",
+	Args:  cobra.MinimumNArgs(1),
 	Short: "price-feeder is a side-car process for providing Ojo's on-chain oracle with price data",
 	Long: `A side-car process that Ojo validators must run in order to provide
 Ojo's on-chain price oracle with price information. The price-feeder performs
@@ -61,8 +62,10 @@ func init() {
 	rootCmd.PersistentFlags().String(flagLogLevel, zerolog.InfoLevel.String(), "logging level")
 	rootCmd.PersistentFlags().String(flagLogFormat, logLevelText, "logging format; must be either json or text")
 	rootCmd.PersistentFlags().Bool(flagSkipProviderCheck, false, "skip the coingecko API provider check")
+	rootCmd.Flags().Bool(flagPreflight, false, "create each configured provider before starting and report the ones failing")
 
 	rootCmd.AddCommand(getVersionCmd())
+	rootCmd.AddCommand(getSelfTestCmd())
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -75,41 +78,23 @@ func Execute() {
 }
 
 func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
-	logLvlStr, err := cmd.Flags().GetString(flagLogLevel)
-	if err != nil {
-		return err
-	}
-
-	logLvl, err := zerolog.ParseLevel(logLvlStr)
+	cfg, err := config.ParseConfigFiles(args...)
 	if err != nil {
 		return err
 	}
 
-	logFormatStr, err := cmd.Flags().GetString(flagLogFormat)
+	logger, err := getLogger(cmd, cfg.Log)
 	if err != nil {
 		return err
 	}
+	warnInsecureEndpoints(logger, cfg)
 
 	skipProviderCheck, err := cmd.Flags().GetBool(flagSkipProviderCheck)
 	if err != nil {
 		return err
 	}
 
-	var logWriter io.Writer
-	switch strings.ToLower(logFormatStr) {
-	case logLevelJSON:
-		logWriter = os.Stderr
-
-	case logLevelText:
-		logWriter = zerolog.ConsoleWriter{Out: os.Stderr}
-
-	default:
-		return fmt.Errorf("invalid logging format: %s", logFormatStr)
-	}
-
-	logger := zerolog.New(logWriter).Level(logLvl).With().Timestamp().Logger()
-
-	cfg, err := config.ParseConfig(args[0])
+	preflight, err := cmd.Flags().GetBool(flagPreflight)
 	if err != nil {
 		return err
 	}
@@ -121,6 +106,12 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if preflight {
+		if err := runPreflight(cmd.Context(), logger, cfg); err != nil {
+			return err
+		}
+	}
+
 	ctx, cancel := context.WithCancel(cmd.Context())
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -155,19 +146,31 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	retryPolicy := cfg.BroadcastRetryPolicy()
+	oracleClient.BroadcastRetry = client.BroadcastRetryPolicy{
+		MaxAttempts: retryPolicy.MaxAttempts,
+		Backoff:     retryPolicy.Backoff,
+		MaxBackoff:  retryPolicy.MaxBackoff,
+	}
 
 	providerTimeout, err := time.ParseDuration(cfg.ProviderTimeout)
 	if err != nil {
 		return fmt.Errorf("failed to parse provider timeout: %w", err)
 	}
 
-	deviations := make(map[string]sdk.Dec, len(cfg.Deviations))
-	for _, deviation := range cfg.Deviations {
-		threshold, err := sdk.NewDecFromStr(deviation.Threshold)
-		if err != nil {
-			return err
-		}
-		deviations[deviation.Base] = threshold
+	priceInterval, err := time.ParseDuration(cfg.PriceInterval)
+	if err != nil {
+		return fmt.Errorf("failed to parse price interval: %w", err)
+	}
+
+	voteInterval, err := time.ParseDuration(cfg.VoteInterval)
+	if err != nil {
+		return fmt.Errorf("failed to parse vote interval: %w", err)
+	}
+
+	deviations, err := cfg.DeviationThresholds()
+	if err != nil {
+		return err
 	}
 
 	oracle := oracle.New(
@@ -178,6 +181,55 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 		deviations,
 		cfg.ProviderEndpointsMap(),
 	)
+	oracle.SetAggregations(cfg.Aggregations())
+	oracle.SetPriceBounds(cfg.PriceBounds())
+	oracle.SetMidPricePairs(cfg.MidPricePairs())
+	oracle.SetPriceSources(cfg.PriceSources())
+	oracle.SetScaleFactors(cfg.ScaleFactors())
+	oracle.SetDuplicateQuotes(cfg.DuplicateQuotePolicy())
+	oracle.SetObservedPairs(cfg.ObservedPairs())
+	oracle.SetMaintenanceSchedules(cfg.MaintenanceSchedules())
+	oracle.SetRounding(cfg.Rounding())
+	oracle.SetConversionFeeds(cfg.ConversionFeedsMap())
+	oracle.SetAbstainThreshold(cfg.VoteAbstainThreshold())
+	oracle.SetReadinessGate(cfg.ReadinessGate())
+	oracle.SetBiasAnalysis(cfg.BiasAnalysis())
+	oracle.SetIntervals(priceInterval, voteInterval)
+	oracle.SetProviderConcurrency(cfg.ProviderConcurrency)
+	oracle.SetStartupConcurrency(cfg.StartupConcurrency)
+	oracle.SetPriceHistorySize(cfg.Server.PriceHistorySize)
+	oracle.SetSymbolAliases(cfg.ProviderSymbolAliases())
+	oracle.SetAPIKeyFiles(cfg.APIKeyFiles())
+	oracle.SetStrict(cfg.Strict)
+
+	for providerName, routes := range cfg.DuplicateQuoteRoutes() {
+		for base, quotes := range routes {
+			logger.Info().
+				Str("provider", providerName.String()).
+				Str("asset", base).
+				Strs("quotes", quotes).
+				Str("policy", cfg.DuplicateQuotePolicy()).
+				Msg("provider lists an asset through several quotes")
+		}
+	}
+
+	if cfg.Strict {
+		if err := oracle.CheckProviders(ctx); err != nil {
+			return fmt.Errorf("strict mode: %w", err)
+		}
+	}
+
+	if len(cfg.Publisher.URL) > 0 {
+		pricePublisher, err := publisher.New(logger, cfg.Publisher.URL, cfg.Publisher.Subject)
+		if err != nil {
+			return err
+		}
+		defer pricePublisher.Close()
+		oracle.SetPublisher(pricePublisher)
+	}
+
+	// reload the oracle's pairs and providers from the config file on SIGHUP
+	trapReloadSignal(ctx, logger, args, skipProviderCheck, oracle)
 
 	telemetryCfg := telemetry.Config{}
 	err = mapstructure.Decode(cfg.Telemetry, &telemetryCfg)
@@ -197,6 +249,18 @@ func priceFeederCmdHandler(cmd *cobra.Command, args []string) error {
 		// start the process that calculates oracle prices and votes
 		return startPriceOracle(ctx, logger, oracle)
 	})
+	if len(cfg.Server.GRPCListenAddr) > 0 {
+		g.Go(func() error {
+			// start the process that serves the gRPC price API
+			return startGRPCServer(ctx, logger, cfg, oracle)
+		})
+	}
+	if cfg.Server.EnablePprof {
+		g.Go(func() error {
+			// start the process that serves the pprof endpoints
+			return startPprofServer(ctx, logger, cfg.Server.PprofListenAddr)
+		})
+	}
 
 	// Block main process until all spawned goroutines have gracefully exited and
 	// signal has been captured in the main process or if an error occurs.
@@ -213,6 +277,58 @@ func getKeyringPassword() (string, error) {
 	return pass, nil
 }
 
+// getLogger returns a logger using the log level and format flags, or the
+// level and format of the config for the flags that weren't set.
+func getLogger(cmd *cobra.Command, logCfg config.Log) (zerolog.Logger, error) {
+	logLvlStr, err := cmd.Flags().GetString(flagLogLevel)
+	if err != nil {
+		return zerolog.Logger{}, err
+	}
+	if len(logCfg.Level) > 0 && !cmd.Flags().Changed(flagLogLevel) {
+		logLvlStr = logCfg.Level
+	}
+
+	logLvl, err := zerolog.ParseLevel(logLvlStr)
+	if err != nil {
+		return zerolog.Logger{}, err
+	}
+
+	logFormatStr, err := cmd.Flags().GetString(flagLogFormat)
+	if err != nil {
+		return zerolog.Logger{}, err
+	}
+	if len(logCfg.Format) > 0 && !cmd.Flags().Changed(flagLogFormat) {
+		logFormatStr = logCfg.Format
+	}
+
+	var logWriter io.Writer
+	switch strings.ToLower(logFormatStr) {
+	case logLevelJSON:
+		logWriter = os.Stderr
+
+	case logLevelText:
+		logWriter = zerolog.ConsoleWriter{Out: os.Stderr}
+
+	default:
+		return zerolog.Logger{}, fmt.Errorf("invalid logging format: %s", logFormatStr)
+	}
+
+	return zerolog.New(logWriter).Level(logLvl).With().Timestamp().Logger(), nil
+}
+
+// warnInsecureEndpoints logs a warning for each provider endpoint skipping the
+// verification of TLS certificates, which must only be set against test
+// endpoints.
+func warnInsecureEndpoints(logger zerolog.Logger, cfg config.Config) {
+	for _, endpoint := range cfg.ProviderEndpoints {
+		if endpoint.InsecureSkipVerify {
+			logger.Warn().
+				Str("provider", endpoint.Name.String()).
+				Msg("TLS CERTIFICATE VERIFICATION IS DISABLED, ONLY USE insecure_skip_verify AGAINST TEST ENDPOINTS")
+		}
+	}
+}
+
 // trapSignal will listen for any OS signal and invoke Done on the main
 // WaitGroup allowing the main process to gracefully exit.
 func trapSignal(cancel context.CancelFunc, logger zerolog.Logger) {
@@ -228,6 +344,96 @@ func trapSignal(cancel context.CancelFunc, logger zerolog.Logger) {
 	}()
 }
 
+// trapReloadSignal listens for SIGHUP and reloads the oracle's configuration
+// from the config files. The running configuration is kept if the new one is
+// invalid.
+func trapReloadSignal(
+	ctx context.Context,
+	logger zerolog.Logger,
+	configPaths []string,
+	skipProviderCheck bool,
+	oracle *oracle.Oracle,
+) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-sigCh:
+				logger.Info().Strs("config", configPaths).Msg("caught SIGHUP; reloading config...")
+				if err := reloadConfig(ctx, logger, configPaths, skipProviderCheck, oracle); err != nil {
+					logger.Error().Err(err).Msg("failed to reload config; keeping the running config")
+				}
+			}
+		}
+	}()
+}
+
+// reloadConfig parses and validates the config files and applies its pairs,
+// providers, deviations, aggregations, price bounds, mid prices, ticker
+// sources, scale factors, duplicate quotes, observe-only providers,
+// maintenance windows, price rounding, conversion feeds, abstain threshold,
+// readiness gate, bias analysis, symbol aliases, API key files and strict
+// mode to the oracle. Other settings, such as the server or account, require
+// a restart.
+func reloadConfig(
+	ctx context.Context,
+	logger zerolog.Logger,
+	configPaths []string,
+	skipProviderCheck bool,
+	oracle *oracle.Oracle,
+) error {
+	cfg, err := config.ParseConfigFiles(configPaths...)
+	if err != nil {
+		return err
+	}
+	warnInsecureEndpoints(logger, cfg)
+
+	if !skipProviderCheck {
+		if err := config.CheckProviderMins(ctx, logger, cfg); err != nil {
+			return err
+		}
+	}
+
+	deviations, err := cfg.DeviationThresholds()
+	if err != nil {
+		return err
+	}
+
+	if err := oracle.Reload(
+		ctx,
+		cfg.ProviderPairs(),
+		deviations,
+		cfg.ProviderEndpointsMap(),
+		cfg.Aggregations(),
+		cfg.ProviderSymbolAliases(),
+	); err != nil {
+		return err
+	}
+
+	oracle.SetPriceBounds(cfg.PriceBounds())
+	oracle.SetMidPricePairs(cfg.MidPricePairs())
+	oracle.SetPriceSources(cfg.PriceSources())
+	oracle.SetScaleFactors(cfg.ScaleFactors())
+	oracle.SetDuplicateQuotes(cfg.DuplicateQuotePolicy())
+	oracle.SetObservedPairs(cfg.ObservedPairs())
+	oracle.SetMaintenanceSchedules(cfg.MaintenanceSchedules())
+	oracle.SetRounding(cfg.Rounding())
+	oracle.SetConversionFeeds(cfg.ConversionFeedsMap())
+	oracle.SetAbstainThreshold(cfg.VoteAbstainThreshold())
+	oracle.SetReadinessGate(cfg.ReadinessGate())
+	oracle.SetBiasAnalysis(cfg.BiasAnalysis())
+	oracle.SetAPIKeyFiles(cfg.APIKeyFiles())
+	oracle.SetStrict(cfg.Strict)
+	return nil
+}
+
 func startPriceFeeder(
 	ctx context.Context,
 	logger zerolog.Logger,
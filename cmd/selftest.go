@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle"
+)
+
+const (
+	flagTimeout = "timeout"
+
+	defaultSelfTestTimeout = 30 * time.Second
+)
+
+func getSelfTestCmd() *cobra.Command {
+	selfTestCmd := &cobra.Command{
+		Use:   "self-test [config-file|config-dir]...",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "Check that each configured provider returns prices from its live venue",
+		Long: `Connect to each provider of the configuration file, subscribe to its first
+currency pair and wait for at least one ticker and one candle. A summary of the
+providers is printed once all of them succeeded or timed out, and the command
+fails if any provider didn't return both.`,
+		RunE: selfTestCmdHandler,
+	}
+
+	selfTestCmd.Flags().Duration(flagTimeout, defaultSelfTestTimeout, "time to wait for the prices of each provider")
+
+	return selfTestCmd
+}
+
+func selfTestCmdHandler(cmd *cobra.Command, args []string) error {
+	cfg, err := config.ParseConfigFiles(args...)
+	if err != nil {
+		return err
+	}
+
+	logger, err := getLogger(cmd, cfg.Log)
+	if err != nil {
+		return err
+	}
+
+	timeout, err := cmd.Flags().GetDuration(flagTimeout)
+	if err != nil {
+		return err
+	}
+
+	results := oracle.SelfTest(
+		cmd.Context(),
+		logger,
+		cfg.ProviderPairs(),
+		cfg.ProviderEndpointsMap(),
+		cfg.ProviderSymbolAliases(),
+		timeout,
+	)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tPAIR\tTICKER\tCANDLE\tDURATION\tERROR")
+
+	failed := 0
+	for _, result := range results {
+		errStr := ""
+		if result.Err != nil {
+			errStr = result.Err.Error()
+		}
+		if !result.OK() {
+			failed++
+		}
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%t\t%t\t%s\t%s\n",
+			result.Provider,
+			result.Pair,
+			result.Ticker,
+			result.Candle,
+			result.Duration.Round(time.Millisecond),
+			errStr,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d providers failed the self-test", failed, len(results))
+	}
+	return nil
+}
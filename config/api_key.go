@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+// APIKeyFilePrefix prefixes the apikey of a provider endpoint which is read
+// from a file rather than set in the config, ex. "file:/run/secrets/numia".
+const APIKeyFilePrefix = "file:"
+
+// ReadAPIKeyFile returns the API key held by the file, without its
+// surrounding whitespace.
+func ReadAPIKeyFile(path string) (string, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	apiKey := strings.TrimSpace(string(bz))
+	if len(apiKey) == 0 {
+		return "", fmt.Errorf("API key file %s is empty", path)
+	}
+	return apiKey, nil
+}
+
+// resolveAPIKeyFiles replaces the apikey of the endpoints read from a file by
+// the key held in the file, and records the file of each of them.
+func (c *Config) resolveAPIKeyFiles() error {
+	c.apiKeyFiles = make(map[provider.Name]string)
+	for i, endpoint := range c.ProviderEndpoints {
+		if !strings.HasPrefix(endpoint.APIKey, APIKeyFilePrefix) {
+			continue
+		}
+
+		path := strings.TrimPrefix(endpoint.APIKey, APIKeyFilePrefix)
+		apiKey, err := ReadAPIKeyFile(path)
+		if err != nil {
+			return fmt.Errorf("apikey of %s: %w", endpoint.Name, err)
+		}
+		c.ProviderEndpoints[i].APIKey = apiKey
+		c.apiKeyFiles[endpoint.Name] = path
+	}
+	return nil
+}
+
+// APIKeyFiles returns the file the API key of each provider endpoint is read
+// from, for the endpoints whose apikey is prefixed by APIKeyFilePrefix.
+func (c Config) APIKeyFiles() map[provider.Name]string {
+	files := make(map[provider.Name]string, len(c.apiKeyFiles))
+	for providerName, path := range c.apiKeyFiles {
+		files[providerName] = path
+	}
+	return files
+}
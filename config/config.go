@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,16 +16,103 @@ import (
 	"github.com/ojo-network/price-feeder/oracle/types"
 
 	"github.com/rs/zerolog"
-	"github.com/spf13/viper"
 )
 
 const (
 	DenomUSD = "USD"
 
+	// AggregationTVWAP computes an asset's price with the TVWAP of its candles
+	// or, if no candles are available, the VWAP of its tickers.
+	AggregationTVWAP = "tvwap"
+	// AggregationTrimmedMean computes an asset's price by discarding the top
+	// and bottom trim_fraction of its provider prices and averaging the rest.
+	AggregationTrimmedMean = "trimmed_mean"
+	// AggregationFailover uses an asset's price from the first of its
+	// providers, in the configured order, which reported a price that passed
+	// the filters, the other providers only being used as backups.
+	AggregationFailover = "failover"
+	// AggregationCompositeTWAP computes an asset's price with the time
+	// weighted average of its composite candles, merged across providers into
+	// one minute buckets, or, if no candles are available, the VWAP of its
+	// tickers.
+	AggregationCompositeTWAP = "composite_twap"
+
+	// TickerSourceLast uses the last trade price of a pair's tickers.
+	TickerSourceLast = "last"
+	// TickerSourceMid uses the mid price of the best bid and ask of a pair's
+	// tickers, falling back to the last trade price when a provider doesn't
+	// report both.
+	TickerSourceMid = "mid"
+
+	// PriceSourceTicker makes a provider contribute its ticker price to the
+	// aggregation of a pair, even when it reports candles.
+	PriceSourceTicker = "ticker"
+	// PriceSourceCandle makes a provider contribute the close of its latest
+	// candle to the aggregation of a pair, even when falling back to tickers.
+	PriceSourceCandle = "candle"
+
+	// RoundingHalfEven rounds the aggregated prices to the nearest value at
+	// the configured precision, and halfway values to the even one.
+	RoundingHalfEven = "half_even"
+	// RoundingHalfUp rounds the aggregated prices to the nearest value at the
+	// configured precision, and halfway values away from zero.
+	RoundingHalfUp = "half_up"
+	// RoundingTruncate drops the digits of the aggregated prices beyond the
+	// configured precision.
+	RoundingTruncate = "truncate"
+
+	// DuplicateQuotesMerge merges the routes of a provider listing an asset
+	// through several quotes, ex. ATOM/USD and ATOM/USDT, into one price of
+	// the provider once they're converted to USD.
+	DuplicateQuotesMerge = "merge"
+	// DuplicateQuotesDistinct aggregates each route of a provider listing an
+	// asset through several quotes as a distinct source of the asset's price.
+	DuplicateQuotesDistinct = "distinct"
+
+	// ReadinessOnTimeoutVote votes with the available prices once the
+	// readiness gate's maximum wait elapsed.
+	ReadinessOnTimeoutVote = "vote"
+	// ReadinessOnTimeoutAbstain keeps abstaining until the readiness gate
+	// opens, however long it takes.
+	ReadinessOnTimeoutAbstain = "abstain"
+
+	// LogFormatJSON writes the logs as JSON objects.
+	LogFormatJSON = "json"
+	// LogFormatText writes the logs in a human readable format.
+	LogFormatText = "text"
+
 	defaultListenAddr      = "0.0.0.0:7171"
+	defaultPprofListenAddr = "127.0.0.1:6060"
 	defaultSrvWriteTimeout = 15 * time.Second
 	defaultSrvReadTimeout  = 15 * time.Second
 	defaultProviderTimeout = 100 * time.Millisecond
+	defaultPriceInterval   = time.Second
+	defaultVoteInterval    = time.Second
+
+	// defaultProviderMin is the minimum amount of providers of an asset when
+	// the currency provider tracker is unavailable.
+	defaultProviderMin = 3
+	// forexProviderMin is the minimum amount of providers of a forex
+	// currency, which are only supplied by a few providers.
+	forexProviderMin = 1
+
+	// defaultReadinessMinProviders and defaultReadinessMaxWait are the
+	// defaults of the readiness gate holding the first pre-vote.
+	defaultReadinessMinProviders = 3
+	defaultReadinessMaxWait      = 2 * time.Minute
+
+	// defaultBroadcastBackoff and defaultBroadcastMaxBackoff are the defaults
+	// of the waits between the broadcasts of a transaction.
+	defaultBroadcastBackoff    = time.Second
+	defaultBroadcastMaxBackoff = 8 * time.Second
+
+	// publisherSchemeNATS is the scheme of the message broker urls the
+	// prices can be published to.
+	publisherSchemeNATS = "nats"
+
+	// maxTWAPWindow is the maximum TVWAP window of a currency pair, bounded by
+	// the period the providers retain their candles for.
+	maxTWAPWindow = 10 * time.Minute
 )
 
 var (
@@ -35,6 +124,10 @@ var (
 	// maxDeviationThreshold is the maxmimum allowed amount of standard
 	// deviations which validators are able to set for a given asset.
 	maxDeviationThreshold = sdk.MustNewDecFromStr("3.0")
+
+	// maxTrimFraction is the maximum fraction of prices that can be trimmed
+	// from each side when using the trimmed mean aggregation.
+	maxTrimFraction = sdk.MustNewDecFromStr("0.5")
 )
 
 type (
@@ -43,33 +136,240 @@ type (
 		Server              Server              `mapstructure:"server"`
 		CurrencyPairs       []CurrencyPair      `mapstructure:"currency_pairs" validate:"required,gt=0,dive,required"`
 		Deviations          []Deviation         `mapstructure:"deviation_thresholds"`
+		DeviationClasses    []DeviationClass    `mapstructure:"deviation_classes" validate:"dive"`
 		Account             Account             `mapstructure:"account" validate:"required,gt=0,dive,required"`
 		Keyring             Keyring             `mapstructure:"keyring" validate:"required,gt=0,dive,required"`
 		RPC                 RPC                 `mapstructure:"rpc" validate:"required,gt=0,dive,required"`
 		Telemetry           telemetry.Config    `mapstructure:"telemetry"`
 		GasAdjustment       float64             `mapstructure:"gas_adjustment" validate:"required"`
 		ProviderTimeout     string              `mapstructure:"provider_timeout"`
+		ProviderConcurrency int                 `mapstructure:"provider_concurrency" validate:"gte=0"`
+		StartupConcurrency  int                 `mapstructure:"startup_concurrency" validate:"gte=0"`
+		PriceInterval       string              `mapstructure:"price_interval"`
+		VoteInterval        string              `mapstructure:"vote_interval"`
 		ProviderMinOverride bool                `mapstructure:"provider_min_override"`
+		ProviderMinCache    string              `mapstructure:"provider_min_cache"`
 		ProviderEndpoints   []provider.Endpoint `mapstructure:"provider_endpoints" validate:"dive"`
+		SymbolAliases       []SymbolAlias       `mapstructure:"symbol_aliases" validate:"dive"`
+		MaintenanceWindows  []MaintenanceWindow `mapstructure:"maintenance_windows" validate:"dive"`
+		QuoteSubstitutions  []QuoteSubstitution `mapstructure:"quote_substitutions" validate:"dive"`
+		ConversionFeeds     []ConversionFeed    `mapstructure:"conversion_feeds" validate:"dive"`
+		PriceRounding       PriceRounding       `mapstructure:"price_rounding"`
+		Publisher           Publisher           `mapstructure:"publisher"`
+		AbstainThreshold    string              `mapstructure:"abstain_threshold"`
+		Strict              bool                `mapstructure:"strict"`
+		BroadcastRetry      BroadcastRetry      `mapstructure:"broadcast_retry"`
+		Readiness           Readiness           `mapstructure:"readiness"`
+		ProviderBias        ProviderBias        `mapstructure:"provider_bias"`
+		DuplicateQuotes     string              `mapstructure:"duplicate_quotes"`
+		Log                 Log                 `mapstructure:"log"`
+
+		// apiKeyFiles are the files the API keys of the provider endpoints
+		// were read from, see resolveAPIKeyFiles
+		apiKeyFiles map[provider.Name]string
 	}
 
-	// Server defines the API server configuration.
+	// Server defines the API server configuration. The admin endpoints are
+	// only served when an AdminToken is set, and require it as a bearer token.
+	// The pprof endpoints are only served when EnablePprof is set, on their
+	// own PprofListenAddr which defaults to localhost. The price history
+	// endpoint is only served when PriceHistorySize, the amount of prices
+	// retained per asset, is positive. StreamBuffer is the amount of price
+	// updates buffered for each client of the prices stream, beyond which
+	// updates are dropped for the client. The gRPC price API is only served
+	// when a GRPCListenAddr is set.
 	Server struct {
-		ListenAddr     string   `mapstructure:"listen_addr"`
-		WriteTimeout   string   `mapstructure:"write_timeout"`
-		ReadTimeout    string   `mapstructure:"read_timeout"`
-		VerboseCORS    bool     `mapstructure:"verbose_cors"`
-		AllowedOrigins []string `mapstructure:"allowed_origins"`
+		ListenAddr       string   `mapstructure:"listen_addr"`
+		WriteTimeout     string   `mapstructure:"write_timeout"`
+		ReadTimeout      string   `mapstructure:"read_timeout"`
+		VerboseCORS      bool     `mapstructure:"verbose_cors"`
+		AllowedOrigins   []string `mapstructure:"allowed_origins"`
+		AdminToken       string   `mapstructure:"admin_token"`
+		EnablePprof      bool     `mapstructure:"enable_pprof"`
+		PprofListenAddr  string   `mapstructure:"pprof_listen_addr"`
+		PriceHistorySize int      `mapstructure:"price_history_size" validate:"gte=0"`
+		StreamBuffer     int      `mapstructure:"stream_buffer" validate:"gte=0"`
+		GRPCListenAddr   string   `mapstructure:"grpc_listen_addr"`
 	}
 
 	// CurrencyPair defines a price quote of the exchange rate for two different
 	// currencies and the supported providers for getting the exchange rate.
+	// A pair can be turned off by setting enabled to false, it defaults to true.
+	// Providers listing the pair's base against another quote, ex. USDT instead
+	// of USD, can be given their quote in ProviderQuotes. Their prices are then
+	// converted and aggregated with the pair's other providers.
+	// TickerSource selects the ticker price of the pair, either the last trade
+	// price (the default) or the mid price of the best bid and ask.
+	// Providers listed in ObserveOnly are subscribed to and their prices are
+	// reported, but they are left out of the prices that are voted on.
+	// PriceSources selects whether a provider contributes its ticker price or
+	// its latest candle close, instead of the candles being used when any
+	// provider reports them and the tickers otherwise.
+	// ScaleFactor corrects the unit of the pair's provider prices, which are
+	// multiplied by it before they're aggregated, ex. 1000000 to price a
+	// million units of the base. Their volumes are divided by it so that the
+	// traded value is unchanged.
 	CurrencyPair struct {
-		Base      string          `mapstructure:"base" validate:"required"`
-		Quote     string          `mapstructure:"quote" validate:"required"`
+		Base                 string                   `mapstructure:"base" validate:"required"`
+		Quote                string                   `mapstructure:"quote" validate:"required"`
+		Providers            []provider.Name          `mapstructure:"providers" validate:"required,gt=0,dive,required"`
+		Enabled              *bool                    `mapstructure:"enabled"`
+		Aggregation          string                   `mapstructure:"aggregation"`
+		TrimFraction         string                   `mapstructure:"trim_fraction"`
+		CandleHalfLife       string                   `mapstructure:"candle_half_life"`
+		TWAPWindow           string                   `mapstructure:"twap_window"`
+		MinPrice             string                   `mapstructure:"min_price"`
+		MaxPrice             string                   `mapstructure:"max_price"`
+		ProviderQuotes       map[provider.Name]string `mapstructure:"provider_quotes"`
+		TickerSource         string                   `mapstructure:"ticker_source"`
+		ObserveOnly          []provider.Name          `mapstructure:"observe_only"`
+		PriceSources         map[provider.Name]string `mapstructure:"price_sources"`
+		ScaleFactor          string                   `mapstructure:"scale_factor"`
+		MinWeightedProviders int                      `mapstructure:"min_weighted_providers"`
+	}
+
+	// Aggregation defines how the provider prices of an asset are combined
+	// into the price that is voted on.
+	// A non-zero CandleHalfLife weights the candles of the asset by their
+	// recency, halving the weight of a candle every CandleHalfLife.
+	// A non-zero TWAPWindow averages the candles of the asset over the window
+	// instead of the default TVWAP period.
+	// FailoverOrder lists the providers of an asset using the failover
+	// aggregation, in the order their prices are tried.
+	// A non-zero MinWeightedProviders is the least amount of providers within
+	// the deviation band the volume weighted price of the asset must be
+	// computed from to be voted on.
+	Aggregation struct {
+		Mode                 string
+		TrimFraction         sdk.Dec
+		CandleHalfLife       time.Duration
+		TWAPWindow           time.Duration
+		FailoverOrder        []provider.Name
+		MinWeightedProviders int
+	}
+
+	// PriceBounds defines the range a provider price of a currency pair must
+	// be within to be used. A bound that isn't set is nil.
+	PriceBounds struct {
+		Min sdk.Dec
+		Max sdk.Dec
+	}
+
+	// PriceRounding defines how the aggregated prices are rounded before they
+	// are voted on. Mode defaults to half_even and Precision, the amount of
+	// decimal places kept, to sdk.Precision, which leaves the prices as
+	// computed.
+	PriceRounding struct {
+		Mode      string `mapstructure:"mode"`
+		Precision *int64 `mapstructure:"precision"`
+	}
+
+	// Publisher defines the message broker the aggregated prices are published
+	// to after each collection. Publishing is off when no URL is set. Each
+	// price is published on the Subject, which defaults to "prices", followed
+	// by its base, ex. "prices.ATOM".
+	Publisher struct {
+		URL     string `mapstructure:"url"`
+		Subject string `mapstructure:"subject"`
+	}
+
+	// BroadcastRetry defines how the failed broadcasts of the oracle's
+	// transactions are retried within their voting window. MaxAttempts bounds
+	// the broadcasts of a transaction, only bounded by the voting window when
+	// zero, and the wait after a failed broadcast starts at Backoff, "1s" by
+	// default, doubling after each failure up to MaxBackoff, "8s" by default.
+	BroadcastRetry struct {
+		MaxAttempts int    `mapstructure:"max_attempts" validate:"gte=0"`
+		Backoff     string `mapstructure:"backoff"`
+		MaxBackoff  string `mapstructure:"max_backoff"`
+	}
+
+	// BroadcastRetryPolicy defines the parsed broadcast retry policy of the
+	// oracle's transactions.
+	BroadcastRetryPolicy struct {
+		MaxAttempts int
+		Backoff     time.Duration
+		MaxBackoff  time.Duration
+	}
+
+	// Readiness defines the gate holding the oracle's first pre-vote until
+	// every asset has a price from at least MinProviders providers, 3 by
+	// default, or from all of its providers when it has fewer. Once MaxWait,
+	// "2m" by default, elapsed since startup, the oracle votes with the prices
+	// it has, unless OnTimeout is "abstain", in which case it keeps abstaining
+	// until the gate opens.
+	Readiness struct {
+		MinProviders int    `mapstructure:"min_providers" validate:"gte=0"`
+		MaxWait      string `mapstructure:"max_wait"`
+		OnTimeout    string `mapstructure:"on_timeout"`
+	}
+
+	// ReadinessGate defines the parsed readiness gate of the oracle.
+	ReadinessGate struct {
+		MinProviders     int
+		MaxWait          time.Duration
+		AbstainOnTimeout bool
+	}
+
+	// ProviderBias defines the optional tracking of each provider's signed
+	// deviation from the median of the providers' prices of a pair, averaged
+	// over its last Window price collections. It's off unless Window is
+	// positive. A warning is logged when a provider's mean bias exceeds
+	// WarnThreshold, a percentage of the median, ex. "0.5", and isn't logged
+	// when no WarnThreshold is set.
+	ProviderBias struct {
+		Window        int    `mapstructure:"window" validate:"gte=0"`
+		WarnThreshold string `mapstructure:"warn_threshold"`
+	}
+
+	// BiasAnalysis defines the parsed provider bias tracking of the oracle.
+	BiasAnalysis struct {
+		Window        int
+		WarnThreshold sdk.Dec
+	}
+
+	// Log defines the level and format of the logs, ex. "info" level logs in
+	// the "json" format for ingestion or "debug" level logs in the "text"
+	// format for development. The log-level and log-format flags take
+	// precedence when set.
+	Log struct {
+		Level  string `mapstructure:"level"`
+		Format string `mapstructure:"format"`
+	}
+
+	// Rounding defines the parsed rounding mode and precision of the
+	// aggregated prices.
+	Rounding struct {
+		Mode      string
+		Precision int64
+	}
+
+	// SymbolAlias defines the symbol the given providers list an asset under
+	// when it differs from the symbol used in the currency pairs.
+	SymbolAlias struct {
+		Symbol    string          `mapstructure:"symbol" validate:"required"`
+		Alias     string          `mapstructure:"alias" validate:"required"`
 		Providers []provider.Name `mapstructure:"providers" validate:"required,gt=0,dive,required"`
 	}
 
+	// QuoteSubstitution defines the quote the given providers are read with
+	// instead of Quote, ex. USDT on venues without USD markets. The prices
+	// read with the Substitute are converted back to Quote like the prices of
+	// a pair's ProviderQuotes, which take precedence.
+	QuoteSubstitution struct {
+		Quote      string          `mapstructure:"quote" validate:"required"`
+		Substitute string          `mapstructure:"substitute" validate:"required"`
+		Providers  []provider.Name `mapstructure:"providers" validate:"required,gt=0,dive,required"`
+	}
+
+	// ConversionFeed defines the bases whose USD rates are aggregated into the
+	// conversion rate of Quote, ex. USDT and USDC for USDT, instead of only
+	// using the USD rate of Quote itself.
+	ConversionFeed struct {
+		Quote string   `mapstructure:"quote" validate:"required"`
+		Feeds []string `mapstructure:"feeds" validate:"required,gt=0,dive,required"`
+	}
+
 	// Deviation defines a maximum amount of standard deviations that a given asset can
 	// be from the median without being filtered out before voting.
 	Deviation struct {
@@ -77,6 +377,17 @@ type (
 		Threshold string `mapstructure:"threshold" validate:"required"`
 	}
 
+	// DeviationClass defines the default deviation threshold of a class of
+	// assets without a Deviation of their own. It applies to the listed bases
+	// and to the bases of the pairs quoted in one of the listed quotes, or to
+	// all other assets if it lists neither.
+	DeviationClass struct {
+		Name      string   `mapstructure:"name" validate:"required"`
+		Threshold string   `mapstructure:"threshold" validate:"required"`
+		Bases     []string `mapstructure:"bases"`
+		Quotes    []string `mapstructure:"quotes"`
+	}
+
 	// Account defines account related configuration that is related to the Ojo
 	// network and transaction signing functionality.
 	Account struct {
@@ -112,9 +423,50 @@ func telemetryValidation(sl validator.StructLevel) {
 func endpointValidation(sl validator.StructLevel) {
 	endpoint := sl.Current().Interface().(provider.Endpoint)
 
-	if len(endpoint.Name) < 1 || len(endpoint.Rest) < 1 || len(endpoint.Websocket) < 1 {
+	_, restOnly := restOnlyProviders[endpoint.Name]
+	// hosts left empty are filled in from the environment of the endpoint
+	hasEnvironment := len(endpoint.Environment) > 0
+	if len(endpoint.Name) < 1 ||
+		(!hasEnvironment && (len(endpoint.Rest) < 1 || (len(endpoint.Websocket) < 1 && !restOnly))) {
 		sl.ReportError(endpoint, "endpoint", "Endpoint", "unsupportedEndpointType", "")
 	}
+	if hasEnvironment && !endpoint.Name.HasEnvironment(endpoint.Environment) {
+		sl.ReportError(endpoint.Environment, "environment", "Environment", "unsupportedEndpointEnvironment", "")
+	}
+	if len(endpoint.Proxy) > 0 {
+		if _, err := provider.ParseProxyURL(endpoint.Proxy); err != nil {
+			sl.ReportError(endpoint.Proxy, "proxy", "Proxy", "unsupportedEndpointProxy", "")
+		}
+	}
+	if len(endpoint.MaxSpread) > 0 {
+		if _, err := provider.ParseMaxSpread(endpoint.MaxSpread); err != nil {
+			sl.ReportError(endpoint.MaxSpread, "max_spread", "MaxSpread", "invalidEndpointMaxSpread", "")
+		}
+	}
+	if endpoint.MaxCandles < 0 {
+		sl.ReportError(endpoint.MaxCandles, "max_candles", "MaxCandles", "invalidEndpointMaxCandles", "")
+	}
+	if endpoint.RateLimitBackoff < 0 {
+		sl.ReportError(endpoint.RateLimitBackoff, "rate_limit_backoff", "RateLimitBackoff", "invalidEndpointRateLimitBackoff", "")
+	}
+	if endpoint.SubscribeRetries < 0 {
+		sl.ReportError(endpoint.SubscribeRetries, "subscribe_retries", "SubscribeRetries", "invalidEndpointSubscribeRetries", "")
+	}
+	if endpoint.SubscribeRetryDelay < 0 {
+		sl.ReportError(endpoint.SubscribeRetryDelay, "subscribe_retry_delay", "SubscribeRetryDelay", "invalidEndpointSubscribeRetryDelay", "")
+	}
+	if err := provider.ValidateChainlinkFeeds(endpoint.Feeds); err != nil {
+		sl.ReportError(endpoint.Feeds, "feeds", "Feeds", "invalidEndpointFeed", "")
+	}
+	if err := provider.ValidateOsmosisChainPools(endpoint.Pools); err != nil {
+		sl.ReportError(endpoint.Pools, "pools", "Pools", "invalidEndpointPool", "")
+	}
+	if err := provider.ValidateCoinbaseChannels(endpoint.Channels, endpoint.PairChannels); err != nil {
+		sl.ReportError(endpoint.Channels, "channels", "Channels", "invalidEndpointChannel", "")
+	}
+	if err := provider.ValidateRestFields(endpoint); err != nil {
+		sl.ReportError(endpoint.TickerPath, "ticker_path", "TickerPath", "invalidEndpointRestFields", "")
+	}
 	if _, ok := SupportedProviders[endpoint.Name]; !ok {
 		sl.ReportError(endpoint.Name, "name", "Name", "unsupportedEndpointProvider", "")
 	}
@@ -131,6 +483,242 @@ func hasAPIKey(endpointName provider.Name, endpoints []provider.Endpoint) bool {
 	return false
 }
 
+// checkDuplicateEndpoints returns an error if more than one provider_endpoints
+// entry is configured for the same provider, since only the last one would be
+// used.
+func checkDuplicateEndpoints(endpoints []provider.Endpoint) error {
+	entries := make(map[provider.Name][]string)
+	names := []provider.Name{}
+	for i, endpoint := range endpoints {
+		if _, ok := entries[endpoint.Name]; !ok {
+			names = append(names, endpoint.Name)
+		}
+		entries[endpoint.Name] = append(entries[endpoint.Name], fmt.Sprintf(
+			"#%d (rest: %q, websocket: %q)", i+1, endpoint.Rest, endpoint.Websocket,
+		))
+	}
+
+	for _, name := range names {
+		if len(entries[name]) > 1 {
+			return fmt.Errorf(
+				"duplicate provider endpoints for %s: %s",
+				name,
+				strings.Join(entries[name], ", "),
+			)
+		}
+	}
+	return nil
+}
+
+// checkDuplicateCurrencyPairs returns an error if a provider is listed by more
+// than one enabled currency pair with the same base and quote.
+func checkDuplicateCurrencyPairs(currencyPairs []CurrencyPair) error {
+	pairProviders := make(map[string]map[provider.Name]int)
+	for i, cp := range currencyPairs {
+		if !cp.IsEnabled() {
+			continue
+		}
+
+		for _, prov := range cp.Providers {
+			symbol := strings.ToUpper(cp.Base + "/" + cp.ProviderQuote(prov))
+			if _, ok := pairProviders[symbol]; !ok {
+				pairProviders[symbol] = make(map[provider.Name]int)
+			}
+			if j, ok := pairProviders[symbol][prov]; ok && j != i {
+				return fmt.Errorf(
+					"duplicate currency pair %s for provider %s in currency_pairs entries #%d and #%d",
+					symbol, prov, j+1, i+1,
+				)
+			}
+			pairProviders[symbol][prov] = i
+		}
+	}
+	return nil
+}
+
+// checkSymbolAliases returns an error if a symbol alias uses an unsupported
+// provider or if a symbol or alias is listed twice for the same provider, as
+// the prices of the provider couldn't be told apart.
+func checkSymbolAliases(symbolAliases []SymbolAlias) error {
+	symbols := make(map[provider.Name]map[string]struct{})
+	aliases := make(map[provider.Name]map[string]struct{})
+	for _, sa := range symbolAliases {
+		if sa.Symbol == sa.Alias {
+			return fmt.Errorf("symbol alias of %s must differ from the symbol", sa.Symbol)
+		}
+		for _, prov := range sa.Providers {
+			if _, ok := SupportedProviders[prov]; !ok {
+				return fmt.Errorf("unsupported provider in symbol aliases: %s", prov)
+			}
+			if _, ok := symbols[prov]; !ok {
+				symbols[prov] = make(map[string]struct{})
+				aliases[prov] = make(map[string]struct{})
+			}
+			if _, ok := symbols[prov][sa.Symbol]; ok {
+				return fmt.Errorf("duplicate symbol alias of %s for provider %s", sa.Symbol, prov)
+			}
+			if _, ok := aliases[prov][sa.Alias]; ok {
+				return fmt.Errorf("duplicate alias %s for provider %s", sa.Alias, prov)
+			}
+			symbols[prov][sa.Symbol] = struct{}{}
+			aliases[prov][sa.Alias] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// checkPublisherURL returns an error if the url of the publisher's message
+// broker isn't a nats url with a host.
+func checkPublisherURL(rawURL string) error {
+	brokerURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid publisher url: %w", err)
+	}
+	if strings.ToLower(brokerURL.Scheme) != publisherSchemeNATS {
+		return fmt.Errorf("unsupported publisher url scheme %q, must be %s", brokerURL.Scheme, publisherSchemeNATS)
+	}
+	if len(brokerURL.Host) == 0 {
+		return fmt.Errorf("publisher url %s has no host", rawURL)
+	}
+	return nil
+}
+
+// checkForexProviders returns an error if a forex currency with fewer than
+// defaultProviderMin providers, which relies on the forex provider minimum,
+// doesn't list any provider supplying forex rates. Its minimum would
+// otherwise be satisfied by providers which can't report its price.
+func checkForexProviders(currencyPairs []CurrencyPair) error {
+	providers := make(map[string]map[provider.Name]struct{})
+	bases := []string{}
+	for _, cp := range currencyPairs {
+		if _, ok := SupportedForexCurrencies[cp.Base]; !ok {
+			continue
+		}
+		if _, ok := providers[cp.Base]; !ok {
+			providers[cp.Base] = make(map[provider.Name]struct{})
+			bases = append(bases, cp.Base)
+		}
+		for _, prov := range cp.Providers {
+			providers[cp.Base][prov] = struct{}{}
+		}
+	}
+
+	for _, base := range bases {
+		baseProviders := providers[base]
+		if _, ok := baseProviders[provider.ProviderMock]; ok || len(baseProviders) >= defaultProviderMin {
+			continue
+		}
+		hasForexProvider := false
+		for prov := range baseProviders {
+			if _, ok := forexProviders[prov]; ok {
+				hasForexProvider = true
+			}
+		}
+		if !hasForexProvider {
+			supported := make([]string, 0, len(forexProviders))
+			for prov := range forexProviders {
+				supported = append(supported, prov.String())
+			}
+			sort.Strings(supported)
+			return fmt.Errorf(
+				"forex currency %s requires a provider supplying forex rates (%s)",
+				base, strings.Join(supported, ", "),
+			)
+		}
+	}
+	return nil
+}
+
+// checkQuoteSubstitutions returns an error if a quote substitution uses an
+// unsupported provider or quote, or if a provider substitutes a quote twice.
+func checkQuoteSubstitutions(quoteSubstitutions []QuoteSubstitution) error {
+	substituted := make(map[provider.Name]map[string]struct{})
+	for _, qs := range quoteSubstitutions {
+		if qs.Quote == qs.Substitute {
+			return fmt.Errorf("quote substitute of %s must differ from the quote", qs.Quote)
+		}
+		if _, ok := SupportedQuotes[qs.Substitute]; !ok {
+			return fmt.Errorf("unsupported quote substitute: %s", qs.Substitute)
+		}
+		for _, prov := range qs.Providers {
+			if _, ok := SupportedProviders[prov]; !ok {
+				return fmt.Errorf("unsupported provider in quote substitutions: %s", prov)
+			}
+			if _, ok := substituted[prov]; !ok {
+				substituted[prov] = make(map[string]struct{})
+			}
+			if _, ok := substituted[prov][qs.Quote]; ok {
+				return fmt.Errorf("duplicate quote substitution of %s for provider %s", qs.Quote, prov)
+			}
+			substituted[prov][qs.Quote] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// applyQuoteSubstitutions sets the substitute of the quote of each pair as
+// the provider quote of the substitution's providers, unless the pair already
+// sets one or its base is the substitute, ex. USDT/USD.
+func (c *Config) applyQuoteSubstitutions() {
+	for _, qs := range c.QuoteSubstitutions {
+		for i, cp := range c.CurrencyPairs {
+			if cp.Quote != qs.Quote || cp.Base == qs.Substitute {
+				continue
+			}
+			for _, prov := range qs.Providers {
+				if !cp.hasProvider(prov) {
+					continue
+				}
+				if _, ok := cp.ProviderQuotes[prov]; ok {
+					continue
+				}
+				if cp.ProviderQuotes == nil {
+					cp.ProviderQuotes = make(map[provider.Name]string)
+				}
+				cp.ProviderQuotes[prov] = qs.Substitute
+			}
+			c.CurrencyPairs[i] = cp
+		}
+	}
+}
+
+// checkConversionFeeds returns the conversion feeds keyed by quote, or an
+// error if a quote is listed twice or if one of its feeds has no USD pair in
+// usdBases.
+func checkConversionFeeds(
+	conversionFeeds []ConversionFeed,
+	usdBases map[string]struct{},
+) (map[string]struct{}, error) {
+	quotes := make(map[string]struct{}, len(conversionFeeds))
+	for _, cf := range conversionFeeds {
+		if cf.Quote == DenomUSD {
+			return nil, fmt.Errorf("conversion feeds can't be set for %s", DenomUSD)
+		}
+		if _, ok := quotes[cf.Quote]; ok {
+			return nil, fmt.Errorf("duplicate conversion feeds for %s", cf.Quote)
+		}
+		for _, feed := range cf.Feeds {
+			if _, ok := usdBases[feed]; !ok {
+				return nil, fmt.Errorf("conversion feed %s of %s requires a %s/%s pair", feed, cf.Quote, feed, DenomUSD)
+			}
+		}
+		quotes[cf.Quote] = struct{}{}
+	}
+	return quotes, nil
+}
+
+// checkInterval returns an error if the interval isn't a positive duration.
+func checkInterval(name, interval string) error {
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return fmt.Errorf("%s must be a duration: %w", name, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("%s must be positive", name)
+	}
+	return nil
+}
+
 // Validate returns an error if the Config object is invalid.
 func (c Config) Validate() error {
 	validate.RegisterStructValidation(telemetryValidation, telemetry.Config{})
@@ -138,14 +726,674 @@ func (c Config) Validate() error {
 	return validate.Struct(c)
 }
 
+// IsEnabled returns false only if the currency pair was explicitly disabled.
+func (cp CurrencyPair) IsEnabled() bool {
+	return cp.Enabled == nil || *cp.Enabled
+}
+
+// ProviderQuote returns the quote the provider uses for the pair's base.
+func (cp CurrencyPair) ProviderQuote(prov provider.Name) string {
+	if quote, ok := cp.ProviderQuotes[prov]; ok {
+		return quote
+	}
+	return cp.Quote
+}
+
+// hasProvider returns true if the provider is listed by the pair.
+func (cp CurrencyPair) hasProvider(prov provider.Name) bool {
+	for _, p := range cp.Providers {
+		if p == prov {
+			return true
+		}
+	}
+	return false
+}
+
+// quotes returns the distinct quotes used by the pair's providers.
+func (cp CurrencyPair) quotes() []string {
+	seen := map[string]struct{}{cp.Quote: {}}
+	quotes := []string{cp.Quote}
+	for _, prov := range cp.Providers {
+		quote := cp.ProviderQuote(prov)
+		if _, ok := seen[quote]; !ok {
+			seen[quote] = struct{}{}
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes
+}
+
+// EnabledCurrencyPairs returns the currency pairs that have not been disabled.
+func (c Config) EnabledCurrencyPairs() []CurrencyPair {
+	pairs := make([]CurrencyPair, 0, len(c.CurrencyPairs))
+	for _, cp := range c.CurrencyPairs {
+		if cp.IsEnabled() {
+			pairs = append(pairs, cp)
+		}
+	}
+	return pairs
+}
+
+// Aggregations returns the aggregation of each base that doesn't use the
+// default TVWAP aggregation with uniform candle weighting. It assumes the
+// config has been validated by ParseConfig. The failover order of a base
+// quoted in several pairs follows the order of its pairs, then of their
+// providers.
+func (c Config) Aggregations() map[string]Aggregation {
+	aggregations := make(map[string]Aggregation)
+	for _, cp := range c.EnabledCurrencyPairs() {
+		aggregation, err := cp.aggregation()
+		if err != nil || (aggregation.Mode == AggregationTVWAP && aggregation.CandleHalfLife == 0 &&
+			aggregation.TWAPWindow == 0 && aggregation.MinWeightedProviders == 0) {
+			continue
+		}
+		if existing, ok := aggregations[cp.Base]; ok && aggregation.Mode == AggregationFailover {
+			aggregation.FailoverOrder = appendMissingProviders(existing.FailoverOrder, aggregation.FailoverOrder)
+		}
+		aggregations[cp.Base] = aggregation
+	}
+	return aggregations
+}
+
+// appendMissingProviders appends the providers which aren't in order yet.
+func appendMissingProviders(order, providers []provider.Name) []provider.Name {
+	seen := make(map[provider.Name]struct{}, len(order))
+	for _, providerName := range order {
+		seen[providerName] = struct{}{}
+	}
+	for _, providerName := range providers {
+		if _, ok := seen[providerName]; !ok {
+			seen[providerName] = struct{}{}
+			order = append(order, providerName)
+		}
+	}
+	return order
+}
+
+// aggregation parses and validates the aggregation settings of the pair.
+func (cp CurrencyPair) aggregation() (Aggregation, error) {
+	aggregation := Aggregation{
+		Mode:         strings.ToLower(cp.Aggregation),
+		TrimFraction: sdk.ZeroDec(),
+	}
+	if aggregation.Mode == "" {
+		aggregation.Mode = AggregationTVWAP
+	}
+
+	switch aggregation.Mode {
+	case AggregationTVWAP, AggregationFailover, AggregationCompositeTWAP:
+		if len(cp.TrimFraction) > 0 {
+			return aggregation, fmt.Errorf("trim_fraction requires the %s aggregation", AggregationTrimmedMean)
+		}
+		if aggregation.Mode == AggregationFailover {
+			aggregation.FailoverOrder = appendMissingProviders(nil, cp.Providers)
+		}
+
+	case AggregationTrimmedMean:
+		if len(cp.TrimFraction) == 0 {
+			break
+		}
+		trimFraction, err := sdk.NewDecFromStr(cp.TrimFraction)
+		if err != nil {
+			return aggregation, fmt.Errorf("trim_fraction must be numeric: %w", err)
+		}
+		if trimFraction.IsNegative() || trimFraction.GT(maxTrimFraction) {
+			return aggregation, fmt.Errorf("trim_fraction must be between 0 and %s", maxTrimFraction)
+		}
+		aggregation.TrimFraction = trimFraction
+
+	default:
+		return aggregation, fmt.Errorf("unsupported aggregation: %s", cp.Aggregation)
+	}
+
+	if len(cp.CandleHalfLife) > 0 {
+		halfLife, err := time.ParseDuration(cp.CandleHalfLife)
+		if err != nil {
+			return aggregation, fmt.Errorf("candle_half_life must be a duration: %w", err)
+		}
+		if halfLife <= 0 {
+			return aggregation, fmt.Errorf("candle_half_life must be positive")
+		}
+		aggregation.CandleHalfLife = halfLife
+	}
+
+	if len(cp.TWAPWindow) > 0 {
+		window, err := time.ParseDuration(cp.TWAPWindow)
+		if err != nil {
+			return aggregation, fmt.Errorf("twap_window must be a duration: %w", err)
+		}
+		if window <= 0 || window > maxTWAPWindow {
+			return aggregation, fmt.Errorf("twap_window must be positive and at most %s", maxTWAPWindow)
+		}
+		aggregation.TWAPWindow = window
+	}
+
+	if cp.MinWeightedProviders < 0 {
+		return aggregation, fmt.Errorf("min_weighted_providers must not be negative")
+	}
+	if cp.MinWeightedProviders > 0 && aggregation.Mode != AggregationTVWAP {
+		return aggregation, fmt.Errorf("min_weighted_providers requires the %s aggregation", AggregationTVWAP)
+	}
+	aggregation.MinWeightedProviders = cp.MinWeightedProviders
+
+	return aggregation, nil
+}
+
+// Contains returns true if the price is within the bounds.
+func (b PriceBounds) Contains(price sdk.Dec) bool {
+	if !b.Min.IsNil() && price.LT(b.Min) {
+		return false
+	}
+	if !b.Max.IsNil() && price.GT(b.Max) {
+		return false
+	}
+	return true
+}
+
+// PriceBounds returns the price bounds of the enabled currency pairs setting
+// at least one bound, keyed by provider name and currency pair symbol. The
+// symbols use the quote of the pair on the provider. It assumes the config has
+// been validated by ParseConfig.
+func (c Config) PriceBounds() map[provider.Name]map[string]PriceBounds {
+	priceBounds := make(map[provider.Name]map[string]PriceBounds)
+	for _, cp := range c.EnabledCurrencyPairs() {
+		bounds, err := cp.priceBounds()
+		if err != nil || (bounds.Min.IsNil() && bounds.Max.IsNil()) {
+			continue
+		}
+		for _, prov := range cp.Providers {
+			if _, ok := priceBounds[prov]; !ok {
+				priceBounds[prov] = make(map[string]PriceBounds)
+			}
+			pair := types.CurrencyPair{Base: cp.Base, Quote: cp.ProviderQuote(prov)}
+			priceBounds[prov][pair.String()] = bounds
+		}
+	}
+	return priceBounds
+}
+
+// MidPricePairs returns the symbols of the enabled currency pairs using the
+// mid price as their ticker price, keyed by provider name. The symbols use the
+// quote of each provider. It assumes the config has been validated by
+// ParseConfig.
+func (c Config) MidPricePairs() map[provider.Name]map[string]struct{} {
+	midPricePairs := make(map[provider.Name]map[string]struct{})
+	for _, cp := range c.EnabledCurrencyPairs() {
+		source, err := cp.tickerSource()
+		if err != nil || source != TickerSourceMid {
+			continue
+		}
+		for _, prov := range cp.Providers {
+			if _, ok := midPricePairs[prov]; !ok {
+				midPricePairs[prov] = make(map[string]struct{})
+			}
+			pair := types.CurrencyPair{Base: cp.Base, Quote: cp.ProviderQuote(prov)}
+			midPricePairs[prov][pair.String()] = struct{}{}
+		}
+	}
+	return midPricePairs
+}
+
+// PriceSources returns the price source of the enabled currency pairs whose
+// providers have one, keyed by provider name and currency pair symbol. The
+// symbols use the quote of the pair on the provider.
+func (c Config) PriceSources() map[provider.Name]map[string]string {
+	priceSources := make(map[provider.Name]map[string]string)
+	for _, cp := range c.EnabledCurrencyPairs() {
+		sources, err := cp.priceSources()
+		if err != nil {
+			continue
+		}
+		for prov, source := range sources {
+			if _, ok := priceSources[prov]; !ok {
+				priceSources[prov] = make(map[string]string)
+			}
+			pair := types.CurrencyPair{Base: cp.Base, Quote: cp.ProviderQuote(prov)}
+			priceSources[prov][pair.String()] = source
+		}
+	}
+	return priceSources
+}
+
+// ScaleFactors returns the scale factor of the enabled currency pairs setting
+// one, keyed by provider name and currency pair symbol. The symbols use the
+// quote of the pair on the provider. It assumes the config has been validated
+// by ParseConfig.
+func (c Config) ScaleFactors() map[provider.Name]map[string]sdk.Dec {
+	scaleFactors := make(map[provider.Name]map[string]sdk.Dec)
+	for _, cp := range c.EnabledCurrencyPairs() {
+		factor, err := cp.scaleFactor()
+		if err != nil || factor.IsNil() {
+			continue
+		}
+		for _, prov := range cp.Providers {
+			if _, ok := scaleFactors[prov]; !ok {
+				scaleFactors[prov] = make(map[string]sdk.Dec)
+			}
+			pair := types.CurrencyPair{Base: cp.Base, Quote: cp.ProviderQuote(prov)}
+			scaleFactors[prov][pair.String()] = factor
+		}
+	}
+	return scaleFactors
+}
+
+// ObservedPairs returns the symbols of the enabled currency pairs whose
+// provider is observe-only, keyed by provider name. The symbols use the quote
+// of the pair on the provider.
+func (c Config) ObservedPairs() map[provider.Name]map[string]struct{} {
+	observedPairs := make(map[provider.Name]map[string]struct{})
+	for _, cp := range c.EnabledCurrencyPairs() {
+		for _, prov := range cp.ObserveOnly {
+			if _, ok := observedPairs[prov]; !ok {
+				observedPairs[prov] = make(map[string]struct{})
+			}
+			pair := types.CurrencyPair{Base: cp.Base, Quote: cp.ProviderQuote(prov)}
+			observedPairs[prov][pair.String()] = struct{}{}
+		}
+	}
+	return observedPairs
+}
+
+// tickerSource parses and validates the ticker source of the pair.
+func (cp CurrencyPair) tickerSource() (string, error) {
+	source := strings.ToLower(cp.TickerSource)
+	switch source {
+	case "":
+		return TickerSourceLast, nil
+	case TickerSourceLast, TickerSourceMid:
+		return source, nil
+	default:
+		return "", fmt.Errorf("unsupported ticker_source %s of %s/%s", cp.TickerSource, cp.Base, cp.Quote)
+	}
+}
+
+// priceSources returns the validated price sources of the pair's providers.
+func (cp CurrencyPair) priceSources() (map[provider.Name]string, error) {
+	sources := make(map[provider.Name]string, len(cp.PriceSources))
+	for prov, source := range cp.PriceSources {
+		if !cp.hasProvider(prov) {
+			return nil, fmt.Errorf("price source of %s/%s set for unlisted provider %s", cp.Base, cp.Quote, prov)
+		}
+		source = strings.ToLower(source)
+		if source != PriceSourceTicker && source != PriceSourceCandle {
+			return nil, fmt.Errorf("unsupported price source %s of %s/%s for %s", source, cp.Base, cp.Quote, prov)
+		}
+		sources[prov] = source
+	}
+	return sources, nil
+}
+
+// scaleFactor parses and validates the scale factor of the pair, which is nil
+// if it isn't set.
+func (cp CurrencyPair) scaleFactor() (sdk.Dec, error) {
+	if len(cp.ScaleFactor) == 0 {
+		return sdk.Dec{}, nil
+	}
+	factor, err := sdk.NewDecFromStr(cp.ScaleFactor)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("scale_factor of %s/%s must be numeric: %w", cp.Base, cp.Quote, err)
+	}
+	if !factor.IsPositive() {
+		return sdk.Dec{}, fmt.Errorf("scale_factor of %s/%s must be positive", cp.Base, cp.Quote)
+	}
+	return factor, nil
+}
+
+// DeviationThresholds returns the deviation thresholds keyed by base. Assets
+// without a Deviation use the threshold of the deviation class listing their
+// base, or else listing the quote of one of their enabled pairs, or else of the
+// class listing neither. An error is returned if an asset matches several
+// classes at the same level.
+func (c Config) DeviationThresholds() (map[string]sdk.Dec, error) {
+	deviations := make(map[string]sdk.Dec, len(c.Deviations))
+	for _, deviation := range c.Deviations {
+		threshold, err := parseDeviationThreshold(deviation.Threshold)
+		if err != nil {
+			return nil, err
+		}
+		deviations[deviation.Base] = threshold
+	}
+	if len(c.DeviationClasses) == 0 {
+		return deviations, nil
+	}
+
+	var (
+		baseClasses  = make(map[string]DeviationClass)
+		quoteClasses = make(map[string]DeviationClass)
+		defaultClass *DeviationClass
+	)
+	for i, class := range c.DeviationClasses {
+		if len(class.Bases) == 0 && len(class.Quotes) == 0 {
+			if defaultClass != nil {
+				return nil, fmt.Errorf("deviation classes %s and %s both apply to all assets", defaultClass.Name, class.Name)
+			}
+			defaultClass = &c.DeviationClasses[i]
+		}
+		for _, base := range class.Bases {
+			if existing, ok := baseClasses[base]; ok {
+				return nil, fmt.Errorf("deviation classes %s and %s both list base %s", existing.Name, class.Name, base)
+			}
+			baseClasses[base] = class
+		}
+		for _, quote := range class.Quotes {
+			if existing, ok := quoteClasses[quote]; ok {
+				return nil, fmt.Errorf("deviation classes %s and %s both list quote %s", existing.Name, class.Name, quote)
+			}
+			quoteClasses[quote] = class
+		}
+	}
+
+	classes := make(map[string]DeviationClass)
+	for _, cp := range c.EnabledCurrencyPairs() {
+		if _, ok := deviations[cp.Base]; ok {
+			continue
+		}
+		if class, ok := baseClasses[cp.Base]; ok {
+			classes[cp.Base] = class
+			continue
+		}
+		class, ok := quoteClasses[cp.Quote]
+		if !ok {
+			continue
+		}
+		if existing, ok := classes[cp.Base]; ok && existing.Name != class.Name {
+			return nil, fmt.Errorf("pairs of %s are quoted in deviation classes %s and %s", cp.Base, existing.Name, class.Name)
+		}
+		classes[cp.Base] = class
+	}
+	for _, cp := range c.EnabledCurrencyPairs() {
+		if _, ok := classes[cp.Base]; ok || defaultClass == nil {
+			continue
+		}
+		if _, ok := deviations[cp.Base]; !ok {
+			classes[cp.Base] = *defaultClass
+		}
+	}
+
+	for base, class := range classes {
+		threshold, err := parseDeviationThreshold(class.Threshold)
+		if err != nil {
+			return nil, fmt.Errorf("deviation class %s: %w", class.Name, err)
+		}
+		deviations[base] = threshold
+	}
+	return deviations, nil
+}
+
+// parseDeviationThreshold parses and validates a deviation threshold.
+func parseDeviationThreshold(threshold string) (sdk.Dec, error) {
+	thresholdDec, err := sdk.NewDecFromStr(threshold)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("deviation thresholds must be numeric: %w", err)
+	}
+	if thresholdDec.GT(maxDeviationThreshold) {
+		return sdk.Dec{}, fmt.Errorf("deviation thresholds must not exceed 3.0")
+	}
+	return thresholdDec, nil
+}
+
+// Rounding returns the rounding of the aggregated prices. It assumes the config
+// has been validated by ParseConfig.
+func (c Config) Rounding() Rounding {
+	rounding, _ := c.PriceRounding.parse()
+	return rounding
+}
+
+// BroadcastRetryPolicy returns the retry policy of the oracle's transactions.
+// It assumes the config has been validated by ParseConfig.
+func (c Config) BroadcastRetryPolicy() BroadcastRetryPolicy {
+	policy, _ := c.BroadcastRetry.parse()
+	return policy
+}
+
+// parse parses and validates the broadcast retry policy, filling in its
+// defaults.
+func (br BroadcastRetry) parse() (BroadcastRetryPolicy, error) {
+	policy := BroadcastRetryPolicy{
+		MaxAttempts: br.MaxAttempts,
+		Backoff:     defaultBroadcastBackoff,
+		MaxBackoff:  defaultBroadcastMaxBackoff,
+	}
+	if len(br.Backoff) > 0 {
+		backoff, err := time.ParseDuration(br.Backoff)
+		if err != nil || backoff <= 0 {
+			return policy, fmt.Errorf("invalid broadcast_retry backoff: %s", br.Backoff)
+		}
+		policy.Backoff = backoff
+	}
+	if len(br.MaxBackoff) > 0 {
+		maxBackoff, err := time.ParseDuration(br.MaxBackoff)
+		if err != nil || maxBackoff <= 0 {
+			return policy, fmt.Errorf("invalid broadcast_retry max_backoff: %s", br.MaxBackoff)
+		}
+		policy.MaxBackoff = maxBackoff
+	}
+	if policy.MaxBackoff < policy.Backoff {
+		return policy, fmt.Errorf("broadcast_retry max_backoff must be at least its backoff")
+	}
+	return policy, nil
+}
+
+// DuplicateQuotePolicy returns how the routes of a provider listing an asset
+// through several quotes are aggregated, merged by default. It assumes the
+// config has been validated by ParseConfig.
+func (c Config) DuplicateQuotePolicy() string {
+	policy, _ := parseDuplicateQuotes(c.DuplicateQuotes)
+	return policy
+}
+
+// parseDuplicateQuotes parses and validates the duplicate quotes policy.
+func parseDuplicateQuotes(policy string) (string, error) {
+	switch strings.ToLower(policy) {
+	case "", DuplicateQuotesMerge:
+		return DuplicateQuotesMerge, nil
+	case DuplicateQuotesDistinct:
+		return DuplicateQuotesDistinct, nil
+	default:
+		return "", fmt.Errorf("unsupported duplicate_quotes %s", policy)
+	}
+}
+
+// DuplicateQuoteRoutes returns the quotes of the enabled currency pairs of
+// the providers listing a base through several quotes, keyed by provider name
+// and base.
+func (c Config) DuplicateQuoteRoutes() map[provider.Name]map[string][]string {
+	routes := make(map[provider.Name]map[string][]string)
+	for providerName, pairs := range c.ProviderPairs() {
+		quotes := make(map[string]map[string]struct{})
+		for _, pair := range pairs {
+			if _, ok := quotes[pair.Base]; !ok {
+				quotes[pair.Base] = make(map[string]struct{})
+			}
+			quotes[pair.Base][pair.Quote] = struct{}{}
+		}
+		for base, baseQuotes := range quotes {
+			if len(baseQuotes) < 2 {
+				continue
+			}
+			if _, ok := routes[providerName]; !ok {
+				routes[providerName] = make(map[string][]string)
+			}
+			for quote := range baseQuotes {
+				routes[providerName][base] = append(routes[providerName][base], quote)
+			}
+			sort.Strings(routes[providerName][base])
+		}
+	}
+	return routes
+}
+
+// ReadinessGate returns the gate holding the oracle's first pre-vote. It
+// assumes the config has been validated by ParseConfig.
+func (c Config) ReadinessGate() ReadinessGate {
+	gate, _ := c.Readiness.parse()
+	return gate
+}
+
+// parse parses and validates the readiness gate, filling in its defaults.
+func (r Readiness) parse() (ReadinessGate, error) {
+	gate := ReadinessGate{
+		MinProviders: r.MinProviders,
+		MaxWait:      defaultReadinessMaxWait,
+	}
+	if gate.MinProviders == 0 {
+		gate.MinProviders = defaultReadinessMinProviders
+	}
+	if len(r.MaxWait) > 0 {
+		maxWait, err := time.ParseDuration(r.MaxWait)
+		if err != nil || maxWait < 0 {
+			return gate, fmt.Errorf("invalid readiness max_wait: %s", r.MaxWait)
+		}
+		gate.MaxWait = maxWait
+	}
+	switch strings.ToLower(r.OnTimeout) {
+	case "", ReadinessOnTimeoutVote:
+	case ReadinessOnTimeoutAbstain:
+		gate.AbstainOnTimeout = true
+	default:
+		return gate, fmt.Errorf("unsupported readiness on_timeout %s", r.OnTimeout)
+	}
+	return gate, nil
+}
+
+// BiasAnalysis returns the tracking of the providers' bias from the median
+// price. It assumes the config has been validated by ParseConfig.
+func (c Config) BiasAnalysis() BiasAnalysis {
+	analysis, _ := c.ProviderBias.parse()
+	return analysis
+}
+
+// parse parses and validates the provider bias tracking. Its WarnThreshold
+// is zero when no warnings are logged.
+func (b ProviderBias) parse() (BiasAnalysis, error) {
+	analysis := BiasAnalysis{
+		Window:        b.Window,
+		WarnThreshold: sdk.ZeroDec(),
+	}
+	if len(b.WarnThreshold) > 0 {
+		threshold, err := sdk.NewDecFromStr(b.WarnThreshold)
+		if err != nil || threshold.IsNegative() {
+			return analysis, fmt.Errorf("invalid provider_bias warn_threshold: %s", b.WarnThreshold)
+		}
+		analysis.WarnThreshold = threshold
+	}
+	return analysis, nil
+}
+
+// VoteAbstainThreshold returns the fraction of the configured assets that
+// must have a price for the oracle to vote, below which it abstains. It's
+// zero, so that the oracle always votes, when no threshold is set.
+func (c Config) VoteAbstainThreshold() sdk.Dec {
+	threshold, _ := parseAbstainThreshold(c.AbstainThreshold)
+	return threshold
+}
+
+// parseAbstainThreshold parses and validates the abstain threshold, which
+// must be a fraction between 0 and 1.
+func parseAbstainThreshold(threshold string) (sdk.Dec, error) {
+	if len(threshold) == 0 {
+		return sdk.ZeroDec(), nil
+	}
+	fraction, err := sdk.NewDecFromStr(threshold)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("invalid abstain_threshold %s: %w", threshold, err)
+	}
+	if fraction.IsNegative() || fraction.GT(sdk.OneDec()) {
+		return sdk.Dec{}, fmt.Errorf("abstain_threshold must be between 0 and 1, got %s", threshold)
+	}
+	return fraction, nil
+}
+
+// validate returns an error if the log level or format isn't supported.
+func (l Log) validate() error {
+	if len(l.Level) > 0 {
+		if _, err := zerolog.ParseLevel(l.Level); err != nil {
+			return fmt.Errorf("invalid log level %s: %w", l.Level, err)
+		}
+	}
+	switch strings.ToLower(l.Format) {
+	case "", LogFormatJSON, LogFormatText:
+		return nil
+	default:
+		return fmt.Errorf("invalid log format %s, must be either %s or %s", l.Format, LogFormatJSON, LogFormatText)
+	}
+}
+
+// parse parses and validates the price rounding, filling in its defaults.
+func (pr PriceRounding) parse() (Rounding, error) {
+	rounding := Rounding{
+		Mode:      strings.ToLower(pr.Mode),
+		Precision: sdk.Precision,
+	}
+	switch rounding.Mode {
+	case "":
+		rounding.Mode = RoundingHalfEven
+	case RoundingHalfEven, RoundingHalfUp, RoundingTruncate:
+	default:
+		return rounding, fmt.Errorf("unsupported price_rounding mode: %s", pr.Mode)
+	}
+
+	if pr.Precision != nil {
+		if *pr.Precision < 0 || *pr.Precision > sdk.Precision {
+			return rounding, fmt.Errorf("price_rounding precision must be between 0 and %d", sdk.Precision)
+		}
+		rounding.Precision = *pr.Precision
+	}
+	return rounding, nil
+}
+
+// ProviderSymbolAliases returns the symbol aliases keyed by provider name.
+func (c Config) ProviderSymbolAliases() map[provider.Name]provider.SymbolAliases {
+	symbolAliases := make(map[provider.Name]provider.SymbolAliases)
+	for _, sa := range c.SymbolAliases {
+		for _, prov := range sa.Providers {
+			if _, ok := symbolAliases[prov]; !ok {
+				symbolAliases[prov] = make(provider.SymbolAliases)
+			}
+			symbolAliases[prov][sa.Symbol] = sa.Alias
+		}
+	}
+	return symbolAliases
+}
+
+// priceBounds parses and validates the price bounds of the pair.
+func (cp CurrencyPair) priceBounds() (PriceBounds, error) {
+	var bounds PriceBounds
+	if len(cp.MinPrice) > 0 {
+		minPrice, err := sdk.NewDecFromStr(cp.MinPrice)
+		if err != nil {
+			return bounds, fmt.Errorf("min_price must be numeric: %w", err)
+		}
+		if minPrice.IsNegative() {
+			return bounds, fmt.Errorf("min_price must not be negative")
+		}
+		bounds.Min = minPrice
+	}
+	if len(cp.MaxPrice) > 0 {
+		maxPrice, err := sdk.NewDecFromStr(cp.MaxPrice)
+		if err != nil {
+			return bounds, fmt.Errorf("max_price must be numeric: %w", err)
+		}
+		if !maxPrice.IsPositive() {
+			return bounds, fmt.Errorf("max_price must be positive")
+		}
+		bounds.Max = maxPrice
+	}
+	if !bounds.Min.IsNil() && !bounds.Max.IsNil() && bounds.Min.GTE(bounds.Max) {
+		return bounds, fmt.Errorf("min_price of %s/%s must be lower than its max_price", cp.Base, cp.Quote)
+	}
+	return bounds, nil
+}
+
+// ProviderPairs returns the enabled currency pairs of each provider.
 func (c Config) ProviderPairs() map[provider.Name][]types.CurrencyPair {
 	providerPairs := make(map[provider.Name][]types.CurrencyPair)
 
-	for _, pair := range c.CurrencyPairs {
+	for _, pair := range c.EnabledCurrencyPairs() {
 		for _, provider := range pair.Providers {
 			providerPairs[provider] = append(providerPairs[provider], types.CurrencyPair{
 				Base:  pair.Base,
-				Quote: pair.Quote,
+				Quote: pair.ProviderQuote(provider),
 			})
 		}
 	}
@@ -162,31 +1410,80 @@ func (c Config) ProviderEndpointsMap() map[provider.Name]provider.Endpoint {
 	return endpoints
 }
 
+// ConversionFeedsMap converts the conversion_feeds from the config file into a
+// map of the feeds of each quote.
+func (c Config) ConversionFeedsMap() map[string][]string {
+	feeds := make(map[string][]string, len(c.ConversionFeeds))
+	for _, cf := range c.ConversionFeeds {
+		feeds[cf.Quote] = cf.Feeds
+	}
+	return feeds
+}
+
+// normalizeDenoms upper cases the denoms of the currency pairs, deviations,
+// quote substitutions and conversion feeds, which are matched against the upper
+// case symbols of the providers and of the chain's denoms.
+func (c *Config) normalizeDenoms() {
+	for i, cp := range c.CurrencyPairs {
+		cp.Base = strings.ToUpper(cp.Base)
+		cp.Quote = strings.ToUpper(cp.Quote)
+		for prov, quote := range cp.ProviderQuotes {
+			cp.ProviderQuotes[prov] = strings.ToUpper(quote)
+		}
+		c.CurrencyPairs[i] = cp
+	}
+	for i := range c.Deviations {
+		c.Deviations[i].Base = strings.ToUpper(c.Deviations[i].Base)
+	}
+	for i, qs := range c.QuoteSubstitutions {
+		c.QuoteSubstitutions[i].Quote = strings.ToUpper(qs.Quote)
+		c.QuoteSubstitutions[i].Substitute = strings.ToUpper(qs.Substitute)
+	}
+	for i, cf := range c.ConversionFeeds {
+		c.ConversionFeeds[i].Quote = strings.ToUpper(cf.Quote)
+		for j, feed := range cf.Feeds {
+			c.ConversionFeeds[i].Feeds[j] = strings.ToUpper(feed)
+		}
+	}
+}
+
 // ParseConfig attempts to read and parse configuration from the given file path.
 // An error is returned if reading or parsing the config fails.
 func ParseConfig(configPath string) (Config, error) {
+	return ParseConfigFiles(configPath)
+}
+
+// ParseConfigFiles attempts to read and merge the config files, ex. base
+// settings, provider endpoints and currency pairs kept apart, and validates
+// the merged config. A directory is read as its config files in name order.
+// Later files override the values of earlier ones and append to their arrays
+// of tables, ex. their currency_pairs.
+func ParseConfigFiles(configPaths ...string) (Config, error) {
 	var cfg Config
 
-	if configPath == "" {
+	if len(configPaths) == 0 {
 		return cfg, ErrEmptyConfigPath
 	}
 
-	viper.AutomaticEnv()
-	// Allow nested env vars to be read with underscore separators.
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	viper.SetConfigFile(configPath)
-
-	if err := viper.ReadInConfig(); err != nil {
+	v, err := readConfigFiles(configPaths)
+	if err != nil {
+		if errors.Is(err, ErrEmptyConfigPath) {
+			return cfg, err
+		}
 		return cfg, fmt.Errorf("failed to read config: %w", err)
 	}
 
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg); err != nil {
 		return cfg, fmt.Errorf("failed to decode config: %w", err)
 	}
+	cfg.normalizeDenoms()
 
 	if cfg.Server.ListenAddr == "" {
 		cfg.Server.ListenAddr = defaultListenAddr
 	}
+	if len(cfg.Server.PprofListenAddr) == 0 {
+		cfg.Server.PprofListenAddr = defaultPprofListenAddr
+	}
 	if len(cfg.Server.WriteTimeout) == 0 {
 		cfg.Server.WriteTimeout = defaultSrvWriteTimeout.String()
 	}
@@ -196,6 +1493,46 @@ func ParseConfig(configPath string) (Config, error) {
 	if len(cfg.ProviderTimeout) == 0 {
 		cfg.ProviderTimeout = defaultProviderTimeout.String()
 	}
+	if len(cfg.PriceInterval) == 0 {
+		cfg.PriceInterval = defaultPriceInterval.String()
+	}
+	if len(cfg.VoteInterval) == 0 {
+		cfg.VoteInterval = defaultVoteInterval.String()
+	}
+	if err := checkInterval("price_interval", cfg.PriceInterval); err != nil {
+		return cfg, err
+	}
+	if err := checkInterval("vote_interval", cfg.VoteInterval); err != nil {
+		return cfg, err
+	}
+
+	if err := checkDuplicateEndpoints(cfg.ProviderEndpoints); err != nil {
+		return cfg, err
+	}
+	if err := cfg.resolveAPIKeyFiles(); err != nil {
+		return cfg, err
+	}
+	if err := checkQuoteSubstitutions(cfg.QuoteSubstitutions); err != nil {
+		return cfg, err
+	}
+	cfg.applyQuoteSubstitutions()
+	if err := checkDuplicateCurrencyPairs(cfg.CurrencyPairs); err != nil {
+		return cfg, err
+	}
+	if err := checkSymbolAliases(cfg.SymbolAliases); err != nil {
+		return cfg, err
+	}
+	if err := checkMaintenanceWindows(cfg.MaintenanceWindows); err != nil {
+		return cfg, err
+	}
+	if err := checkForexProviders(cfg.EnabledCurrencyPairs()); err != nil {
+		return cfg, err
+	}
+	if len(cfg.Publisher.URL) > 0 {
+		if err := checkPublisherURL(cfg.Publisher.URL); err != nil {
+			return cfg, err
+		}
+	}
 
 	pairs := make(map[string]map[provider.Name]struct{})
 	coinQuotes := make(map[string]struct{})
@@ -203,11 +1540,25 @@ func ParseConfig(configPath string) (Config, error) {
 		if _, ok := pairs[cp.Base]; !ok {
 			pairs[cp.Base] = make(map[provider.Name]struct{})
 		}
-		if strings.ToUpper(cp.Quote) != DenomUSD {
-			coinQuotes[cp.Quote] = struct{}{}
+		// disabled pairs are still validated but don't require a conversion
+		// rate feed for their quotes
+		for _, quote := range cp.quotes() {
+			if strings.ToUpper(quote) != DenomUSD && cp.IsEnabled() {
+				coinQuotes[quote] = struct{}{}
+			}
+			if _, ok := SupportedQuotes[strings.ToUpper(quote)]; !ok {
+				return cfg, fmt.Errorf("unsupported quote: %s", quote)
+			}
 		}
-		if _, ok := SupportedQuotes[strings.ToUpper(cp.Quote)]; !ok {
-			return cfg, fmt.Errorf("unsupported quote: %s", cp.Quote)
+		for prov := range cp.ProviderQuotes {
+			if !cp.hasProvider(prov) {
+				return cfg, fmt.Errorf("provider quote of %s/%s set for unlisted provider %s", cp.Base, cp.Quote, prov)
+			}
+		}
+		for _, prov := range cp.ObserveOnly {
+			if !cp.hasProvider(prov) {
+				return cfg, fmt.Errorf("observe_only of %s/%s lists unlisted provider %s", cp.Base, cp.Quote, prov)
+			}
 		}
 
 		for _, prov := range cp.Providers {
@@ -221,28 +1572,94 @@ func ParseConfig(configPath string) (Config, error) {
 		}
 	}
 
-	// Use coinQuotes to ensure that any quotes can be converted to USD.
+	// Use coinQuotes to ensure that any quotes can be converted to USD by an
+	// enabled currency pair, or by the enabled currency pairs of all of their
+	// conversion feeds.
+	usdBases := make(map[string]struct{})
+	for _, pair := range cfg.EnabledCurrencyPairs() {
+		if pair.Quote == DenomUSD {
+			usdBases[pair.Base] = struct{}{}
+		}
+	}
+	conversionFeeds, err := checkConversionFeeds(cfg.ConversionFeeds, usdBases)
+	if err != nil {
+		return cfg, err
+	}
 	for quote := range coinQuotes {
-		for index, pair := range cfg.CurrencyPairs {
-			if pair.Base == quote && pair.Quote == DenomUSD {
-				break
-			}
-			if index == len(cfg.CurrencyPairs)-1 {
-				return cfg, fmt.Errorf("all non-usd quotes require a conversion rate feed")
-			}
+		_, ok := usdBases[quote]
+		if _, hasFeeds := conversionFeeds[quote]; !ok && !hasFeeds {
+			return cfg, fmt.Errorf("all non-usd quotes require a conversion rate feed")
 		}
 	}
 
-	for _, deviation := range cfg.Deviations {
-		threshold, err := sdk.NewDecFromStr(deviation.Threshold)
+	aggregations := make(map[string]Aggregation)
+	for _, cp := range cfg.CurrencyPairs {
+		aggregation, err := cp.aggregation()
 		if err != nil {
-			return cfg, fmt.Errorf("deviation thresholds must be numeric: %w", err)
+			return cfg, err
+		}
+		if !cp.IsEnabled() {
+			continue
+		}
+		if existing, ok := aggregations[cp.Base]; ok && (existing.Mode != aggregation.Mode ||
+			!existing.TrimFraction.Equal(aggregation.TrimFraction) ||
+			existing.CandleHalfLife != aggregation.CandleHalfLife ||
+			existing.TWAPWindow != aggregation.TWAPWindow ||
+			existing.MinWeightedProviders != aggregation.MinWeightedProviders) {
+			return cfg, fmt.Errorf("currency pairs of %s must use the same aggregation", cp.Base)
+		}
+		aggregations[cp.Base] = aggregation
+	}
+
+	for _, cp := range cfg.CurrencyPairs {
+		if _, err := cp.priceBounds(); err != nil {
+			return cfg, err
+		}
+		if _, err := cp.tickerSource(); err != nil {
+			return cfg, err
+		}
+		if _, err := cp.priceSources(); err != nil {
+			return cfg, err
 		}
+		if _, err := cp.scaleFactor(); err != nil {
+			return cfg, err
+		}
+	}
 
-		if threshold.GT(maxDeviationThreshold) {
-			return cfg, fmt.Errorf("deviation thresholds must not exceed 3.0")
+	for _, deviation := range cfg.Deviations {
+		if _, err := parseDeviationThreshold(deviation.Threshold); err != nil {
+			return cfg, err
 		}
 	}
+	for _, class := range cfg.DeviationClasses {
+		if _, err := parseDeviationThreshold(class.Threshold); err != nil {
+			return cfg, fmt.Errorf("deviation class %s: %w", class.Name, err)
+		}
+	}
+	if _, err := cfg.DeviationThresholds(); err != nil {
+		return cfg, err
+	}
+	if _, err := cfg.PriceRounding.parse(); err != nil {
+		return cfg, err
+	}
+	if _, err := parseAbstainThreshold(cfg.AbstainThreshold); err != nil {
+		return cfg, err
+	}
+	if _, err := cfg.BroadcastRetry.parse(); err != nil {
+		return cfg, err
+	}
+	if _, err := cfg.Readiness.parse(); err != nil {
+		return cfg, err
+	}
+	if _, err := cfg.ProviderBias.parse(); err != nil {
+		return cfg, err
+	}
+	if _, err := parseDuplicateQuotes(cfg.DuplicateQuotes); err != nil {
+		return cfg, err
+	}
+	if err := cfg.Log.validate(); err != nil {
+		return cfg, err
+	}
 
 	return cfg, cfg.Validate()
 }
@@ -250,9 +1667,32 @@ func ParseConfig(configPath string) (Config, error) {
 // CheckProviderMins starts the currency provider tracker to check the amount of
 // providers available for a currency by querying CoinGecko's API. It will enforce
 // a provider minimum for a given currency based on its available providers.
+// When a ProviderMinCache is set, the minimums are saved to it after they were
+// fetched and read from it when CoinGecko can't be reached.
 func CheckProviderMins(ctx context.Context, logger zerolog.Logger, cfg Config) error {
-	currencyProviderTracker, err := NewCurrencyProviderTracker(ctx, logger, cfg.CurrencyPairs...)
-	if err != nil {
+	enabledPairs := cfg.EnabledCurrencyPairs()
+	var providerMins map[string]int
+	currencyProviderTracker, err := NewCurrencyProviderTracker(ctx, logger, enabledPairs...)
+	switch {
+	case err == nil:
+		providerMins = currencyProviderTracker.CurrencyProviderMin
+		if len(cfg.ProviderMinCache) > 0 {
+			if err := saveProviderMins(cfg.ProviderMinCache, providerMins); err != nil {
+				logger.Warn().Err(err).Msg("failed to cache provider minimums")
+			}
+		}
+
+	case len(cfg.ProviderMinCache) > 0:
+		logger.Error().Err(err).Msg("failed to start currency provider tracker, using cached provider minimums")
+		providerMins, err = loadProviderMins(cfg.ProviderMinCache)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to load cached provider minimums")
+			if cfg.ProviderMinOverride {
+				return nil
+			}
+		}
+
+	default:
 		logger.Error().Err(err).Msg("failed to start currency provider tracker")
 		// If currency tracker errors out and override flag is set, the price-feeder
 		// will run without enforcing provider minimums.
@@ -262,7 +1702,7 @@ func CheckProviderMins(ctx context.Context, logger zerolog.Logger, cfg Config) e
 	}
 
 	pairs := make(map[string]map[provider.Name]struct{})
-	for _, cp := range cfg.CurrencyPairs {
+	for _, cp := range enabledPairs {
 		if _, ok := pairs[cp.Base]; !ok {
 			pairs[cp.Base] = make(map[provider.Name]struct{})
 		}
@@ -272,15 +1712,15 @@ func CheckProviderMins(ctx context.Context, logger zerolog.Logger, cfg Config) e
 	}
 
 	for base, providers := range pairs {
-		// If currency provider tracker errored, default to three providers as
-		// the minimum.
-		var minProviders int
-		if currencyProviderTracker != nil {
-			minProviders = currencyProviderTracker.CurrencyProviderMin[base]
-		} else if _, ok := SupportedForexCurrencies[base]; ok {
-			minProviders = 1
-		} else {
-			minProviders = 3
+		// If currency provider tracker errored and the base isn't cached,
+		// default to three providers as the minimum.
+		minProviders, ok := providerMins[base]
+		if !ok && currencyProviderTracker == nil {
+			if _, ok := SupportedForexCurrencies[base]; ok {
+				minProviders = forexProviderMin
+			} else {
+				minProviders = defaultProviderMin
+			}
 		}
 
 		if _, ok := pairs[base][provider.ProviderMock]; !ok && len(providers) < minProviders {
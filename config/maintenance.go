@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// embed the time zone database so that the maintenance window time zones
+	// load on hosts without one
+	_ "time/tzdata"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+const (
+	// maintenanceDateTimeLayout is the layout of the start and end of a
+	// one-off maintenance window, ex. "2024-03-05T02:00".
+	maintenanceDateTimeLayout = "2006-01-02T15:04"
+	// maintenanceTimeLayout is the layout of the start and end of a
+	// recurring maintenance window, ex. "02:00".
+	maintenanceTimeLayout = "15:04"
+)
+
+type (
+	// MaintenanceWindow defines a scheduled maintenance of a provider, during
+	// which its prices are only observed and left out of the voted prices.
+	// Start and End are either a date and time, ex. "2024-03-05T02:00", for a
+	// one-off window, or a time of day, ex. "02:00", for a window recurring on
+	// the Days of the week, ex. ["tue"], or every day when no Days are set. A
+	// recurring window ending before it starts ends on the next day. Times
+	// are in the Timezone, ex. "Asia/Tokyo", UTC by default.
+	MaintenanceWindow struct {
+		Provider provider.Name `mapstructure:"provider" validate:"required"`
+		Start    string        `mapstructure:"start" validate:"required"`
+		End      string        `mapstructure:"end" validate:"required"`
+		Days     []string      `mapstructure:"days"`
+		Timezone string        `mapstructure:"timezone"`
+	}
+
+	// MaintenanceSchedule defines a parsed maintenance window. A one-off
+	// window spans from Start to End. A recurring window spans from From to
+	// To, the times of day since midnight in its Location, on its Days.
+	MaintenanceSchedule struct {
+		Start    time.Time
+		End      time.Time
+		From     time.Duration
+		To       time.Duration
+		Days     map[time.Weekday]struct{}
+		Location *time.Location
+	}
+)
+
+// Active returns whether the maintenance window spans the given time.
+func (s MaintenanceSchedule) Active(t time.Time) bool {
+	if !s.Start.IsZero() {
+		return !t.Before(s.Start) && t.Before(s.End)
+	}
+
+	local := t.In(s.Location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	if s.From < s.To {
+		return s.onDay(local.Weekday()) && sinceMidnight >= s.From && sinceMidnight < s.To
+	}
+
+	// the window spans midnight, starting on one of its days
+	if sinceMidnight >= s.From {
+		return s.onDay(local.Weekday())
+	}
+	return sinceMidnight < s.To && s.onDay((local.Weekday()+6)%7)
+}
+
+// onDay returns whether the recurring window starts on the day of the week.
+func (s MaintenanceSchedule) onDay(day time.Weekday) bool {
+	if len(s.Days) == 0 {
+		return true
+	}
+	_, ok := s.Days[day]
+	return ok
+}
+
+// parse parses and validates the maintenance window.
+func (w MaintenanceWindow) parse() (MaintenanceSchedule, error) {
+	if _, ok := SupportedProviders[w.Provider]; !ok {
+		return MaintenanceSchedule{}, fmt.Errorf("unsupported provider in maintenance windows: %s", w.Provider)
+	}
+
+	location := time.UTC
+	if len(w.Timezone) > 0 {
+		var err error
+		location, err = time.LoadLocation(w.Timezone)
+		if err != nil {
+			return MaintenanceSchedule{}, fmt.Errorf("invalid maintenance window timezone of %s: %w", w.Provider, err)
+		}
+	}
+	schedule := MaintenanceSchedule{Location: location}
+
+	start, startErr := time.ParseInLocation(maintenanceDateTimeLayout, w.Start, location)
+	end, endErr := time.ParseInLocation(maintenanceDateTimeLayout, w.End, location)
+	if startErr == nil && endErr == nil {
+		if len(w.Days) > 0 {
+			return schedule, fmt.Errorf("one-off maintenance window of %s can't have days", w.Provider)
+		}
+		if !end.After(start) {
+			return schedule, fmt.Errorf("maintenance window of %s must end after it starts", w.Provider)
+		}
+		schedule.Start, schedule.End = start, end
+		return schedule, nil
+	}
+
+	from, fromErr := time.Parse(maintenanceTimeLayout, w.Start)
+	to, toErr := time.Parse(maintenanceTimeLayout, w.End)
+	if fromErr != nil || toErr != nil {
+		return schedule, fmt.Errorf(
+			"invalid maintenance window of %s: start and end must both be either a %s date and time or a %s time",
+			w.Provider, maintenanceDateTimeLayout, maintenanceTimeLayout,
+		)
+	}
+	schedule.From = time.Duration(from.Hour())*time.Hour + time.Duration(from.Minute())*time.Minute
+	schedule.To = time.Duration(to.Hour())*time.Hour + time.Duration(to.Minute())*time.Minute
+	if schedule.From == schedule.To {
+		return schedule, fmt.Errorf("maintenance window of %s must end after it starts", w.Provider)
+	}
+
+	schedule.Days = make(map[time.Weekday]struct{}, len(w.Days))
+	for _, d := range w.Days {
+		day, ok := parseWeekday(d)
+		if !ok {
+			return schedule, fmt.Errorf("invalid maintenance window day of %s: %s", w.Provider, d)
+		}
+		schedule.Days[day] = struct{}{}
+	}
+	return schedule, nil
+}
+
+// parseWeekday parses a day of the week from its name or the first three
+// letters of its name, ignoring case.
+func parseWeekday(day string) (time.Weekday, bool) {
+	day = strings.ToLower(day)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if day == name || day == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// checkMaintenanceWindows returns an error if a maintenance window is invalid.
+func checkMaintenanceWindows(windows []MaintenanceWindow) error {
+	for _, w := range windows {
+		if _, err := w.parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaintenanceSchedules returns the maintenance windows of each provider. It
+// assumes the config has been validated by ParseConfig.
+func (c Config) MaintenanceSchedules() map[provider.Name][]MaintenanceSchedule {
+	schedules := make(map[provider.Name][]MaintenanceSchedule)
+	for _, w := range c.MaintenanceWindows {
+		schedule, err := w.parse()
+		if err != nil {
+			continue
+		}
+		schedules[w.Provider] = append(schedules[w.Provider], schedule)
+	}
+	return schedules
+}
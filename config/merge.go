@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// readConfigFiles reads the config files, and the files of the config
+// directories in name order, and merges them in order into a single viper
+// instance also reading the environment.
+func readConfigFiles(configPaths []string) (*viper.Viper, error) {
+	files, err := configFiles(configPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]interface{}{}
+	for _, file := range files {
+		fv := viper.New()
+		fv.SetConfigFile(file)
+		if err := fv.ReadInConfig(); err != nil {
+			return nil, err
+		}
+		mergeConfigMaps(merged, fv.AllSettings())
+	}
+
+	v := viper.New()
+	v.AutomaticEnv()
+	// Allow nested env vars to be read with underscore separators.
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	if err := v.MergeConfigMap(merged); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// configFiles returns the config files of the paths, replacing directories
+// by the files they directly contain with a supported config extension,
+// sorted by name.
+func configFiles(configPaths []string) ([]string, error) {
+	files := []string{}
+	for _, path := range configPaths {
+		if path == "" {
+			return nil, ErrEmptyConfigPath
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		dirFiles := 0
+		for _, entry := range entries {
+			if entry.IsDir() || !isConfigFile(entry.Name()) {
+				continue
+			}
+			files = append(files, filepath.Join(path, entry.Name()))
+			dirFiles++
+		}
+		if dirFiles == 0 {
+			return nil, fmt.Errorf("no config files found in %s", path)
+		}
+	}
+	return files, nil
+}
+
+// isConfigFile returns true if the file has an extension viper can read.
+func isConfigFile(name string) bool {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+	for _, supported := range viper.SupportedExts {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeConfigMaps deep-merges src into dst. Tables are merged key by key,
+// arrays of tables, ex. currency_pairs, are appended to and any other value,
+// including arrays of values such as a pair's providers, is overridden.
+func mergeConfigMaps(dst, src map[string]interface{}) {
+	for key, srcVal := range src {
+		dstVal, ok := dst[key]
+		if !ok {
+			dst[key] = srcVal
+			continue
+		}
+
+		switch srcVal := srcVal.(type) {
+		case map[string]interface{}:
+			if dstMap, ok := dstVal.(map[string]interface{}); ok {
+				mergeConfigMaps(dstMap, srcVal)
+				continue
+			}
+		case []interface{}:
+			if dstTables, ok := dstVal.([]interface{}); ok && isTableArray(dstTables) && isTableArray(srcVal) {
+				dst[key] = append(dstTables, srcVal...)
+				continue
+			}
+		case []map[string]interface{}:
+			if dstTables, ok := dstVal.([]map[string]interface{}); ok {
+				dst[key] = append(dstTables, srcVal...)
+				continue
+			}
+		}
+		dst[key] = srcVal
+	}
+}
+
+// isTableArray returns true if the array holds tables.
+func isTableArray(values []interface{}) bool {
+	for _, v := range values {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return len(values) > 0
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// loadProviderMins reads the minimum amount of providers of each currency from
+// the cache file, a JSON object of the currencies and their minimum, ex:
+// {"ATOM": 3, "OJO": 2}. The file is either written by hand or by
+// saveProviderMins after the minimums were fetched from CoinGecko.
+func loadProviderMins(path string) (map[string]int, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provider minimum cache: %w", err)
+	}
+
+	var mins map[string]int
+	if err := json.Unmarshal(bz, &mins); err != nil {
+		return nil, fmt.Errorf("failed to decode provider minimum cache: %w", err)
+	}
+	for base, min := range mins {
+		if min < 1 {
+			return nil, fmt.Errorf("invalid provider minimum cache: minimum of %s must be positive", base)
+		}
+	}
+	return mins, nil
+}
+
+// saveProviderMins writes the minimum amount of providers of each currency to
+// the cache file. The file is replaced at once so that a failed write doesn't
+// leave a truncated cache behind.
+func saveProviderMins(path string, mins map[string]int) error {
+	bz, err := json.MarshalIndent(mins, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write provider minimum cache: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(bz); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write provider minimum cache: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write provider minimum cache: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to write provider minimum cache: %w", err)
+	}
+	return nil
+}
@@ -10,21 +10,48 @@ var (
 	// SupportedProviders defines a lookup table of all the supported currency API
 	// providers and whether or not they require an API key to be passed in.
 	SupportedProviders = map[provider.Name]APIKeyRequired{
-		provider.ProviderKraken:    false,
-		provider.ProviderBinance:   false,
-		provider.ProviderBinanceUS: false,
-		provider.ProviderOsmosis:   false,
-		provider.ProviderOsmosisV2: false,
-		provider.ProviderOkx:       false,
-		provider.ProviderHuobi:     false,
-		provider.ProviderGate:      false,
-		provider.ProviderCoinbase:  false,
-		provider.ProviderBitget:    false,
-		provider.ProviderMexc:      false,
-		provider.ProviderCrypto:    false,
-		provider.ProviderPolygon:   true,
-		provider.ProviderMock:      false,
-		provider.ProviderFin:       false,
+		provider.ProviderKraken:       false,
+		provider.ProviderBinance:      false,
+		provider.ProviderBinanceUS:    false,
+		provider.ProviderOsmosis:      false,
+		provider.ProviderOsmosisV2:    false,
+		provider.ProviderOsmosisChain: false,
+		provider.ProviderOkx:          false,
+		provider.ProviderHuobi:        false,
+		provider.ProviderGate:         false,
+		provider.ProviderCoinbase:     false,
+		provider.ProviderBitget:       false,
+		provider.ProviderMexc:         false,
+		provider.ProviderCrypto:       false,
+		provider.ProviderDeribit:      false,
+		provider.ProviderPolygon:      true,
+		provider.ProviderMock:         false,
+		provider.ProviderFin:          false,
+		provider.ProviderUniswap:      false,
+		provider.ProviderChainlink:    false,
+		provider.ProviderPriceFeeder:  false,
+		provider.ProviderNumia:        true,
+		provider.ProviderCoinGecko:    false,
+		provider.ProviderRest:         false,
+	}
+
+	// restOnlyProviders defines the providers which poll their rest endpoint
+	// and therefore don't need a websocket endpoint configured.
+	restOnlyProviders = map[provider.Name]struct{}{
+		provider.ProviderUniswap:      {},
+		provider.ProviderOsmosisChain: {},
+		provider.ProviderChainlink:    {},
+		provider.ProviderPriceFeeder:  {},
+		provider.ProviderNumia:        {},
+		provider.ProviderCoinGecko:    {},
+		provider.ProviderRest:         {},
+	}
+
+	// forexProviders defines the providers supplying forex rates, at least one
+	// of which must be listed for the forex currencies relying on the forex
+	// provider minimum.
+	forexProviders = map[provider.Name]struct{}{
+		provider.ProviderPolygon: {},
 	}
 
 	// SupportedQuotes defines a lookup table for which assets we support
@@ -41,7 +68,8 @@ var (
 	}
 
 	// SupportedForexCurrencies defines a lookup table for all the supported
-	// Forex currencies
+	// Forex currencies, which require a single provider as long as one of
+	// their providers supplies forex rates.
 	SupportedForexCurrencies = map[string]struct{}{
 		"AED": {},
 		"AFN": {},
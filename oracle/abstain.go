@@ -0,0 +1,48 @@
+package oracle
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetAbstainThreshold sets the fraction of the configured assets that must
+// have a price for the oracle to pre-vote. Below it the oracle abstains from
+// the voting period rather than voting on a partial set of prices. A zero
+// threshold, the default, never abstains.
+func (o *Oracle) SetAbstainThreshold(threshold sdk.Dec) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.abstainThreshold = threshold
+}
+
+// shouldAbstain returns whether the fraction of the configured assets having
+// a price, which is also returned, is below the abstain threshold.
+func (o *Oracle) shouldAbstain() (bool, sdk.Dec) {
+	o.configMtx.Lock()
+	threshold := o.abstainThreshold
+	o.configMtx.Unlock()
+
+	coverage := o.priceCoverage()
+	if threshold.IsNil() || threshold.IsZero() {
+		return false, coverage
+	}
+	return coverage.LT(threshold), coverage
+}
+
+// priceCoverage returns the fraction of the assets expected by the last price
+// collection that have a price.
+func (o *Oracle) priceCoverage() sdk.Dec {
+	o.pricesMutex.RLock()
+	defer o.pricesMutex.RUnlock()
+
+	if len(o.requiredRates) == 0 {
+		return sdk.OneDec()
+	}
+	covered := 0
+	for base := range o.requiredRates {
+		if _, ok := o.prices[base]; ok {
+			covered++
+		}
+	}
+	return sdk.NewDec(int64(covered)).QuoInt64(int64(len(o.requiredRates)))
+}
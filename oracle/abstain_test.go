@@ -0,0 +1,54 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestOracle_ShouldAbstain(t *testing.T) {
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderKraken: {
+				{Base: "ATOM", Quote: "USD"},
+				{Base: "OJO", Quote: "USD"},
+				{Base: "BTC", Quote: "USD"},
+				{Base: "ETH", Quote: "USD"},
+			},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+
+	// only one of the four assets has a price
+	o.priceProviders[provider.ProviderKraken] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+		},
+	}
+	require.NoError(t, o.SetPrices(context.Background()))
+
+	// the oracle always votes without a threshold
+	abstain, coverage := o.shouldAbstain()
+	require.False(t, abstain)
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), coverage)
+
+	o.SetAbstainThreshold(sdk.MustNewDecFromStr("0.5"))
+	abstain, _ = o.shouldAbstain()
+	require.True(t, abstain)
+
+	o.SetAbstainThreshold(sdk.MustNewDecFromStr("0.25"))
+	abstain, _ = o.shouldAbstain()
+	require.False(t, abstain)
+}
@@ -0,0 +1,94 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// apiKeyFilePollInterval is how often the API key files are read to detect a
+// rotated key.
+const apiKeyFilePollInterval = 10 * time.Second
+
+// SetAPIKeyFiles sets the files the API keys of the providers are read from,
+// which are watched for rotated keys.
+func (o *Oracle) SetAPIKeyFiles(apiKeyFiles map[provider.Name]string) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.apiKeyFiles = apiKeyFiles
+}
+
+// RotateAPIKey replaces the API key of a provider's endpoint. The provider is
+// stopped and connects again with the new key when the prices are next
+// collected, while the other providers keep their connections. A disabled
+// provider uses the new key once enabled. The key of a provider read from a
+// file can't be replaced, since it would be reverted to the file's key: it
+// errors with types.ErrAPIKeyFromFile and the file should be updated instead.
+func (o *Oracle) RotateAPIKey(providerName provider.Name, apiKey string) error {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	if path, ok := o.apiKeyFiles[providerName]; ok {
+		return types.ErrAPIKeyFromFile.Wrapf("provider %s reads its API key from %s", providerName, path)
+	}
+	return o.rotateAPIKey(providerName, apiKey)
+}
+
+// rotateAPIKey replaces the API key of a provider's endpoint, see
+// RotateAPIKey. It must be called with the configMtx held.
+func (o *Oracle) rotateAPIKey(providerName provider.Name, apiKey string) error {
+	if _, ok := o.providerPairs[providerName]; !ok {
+		return fmt.Errorf("provider %s is not configured", providerName)
+	}
+	endpoint, ok := o.endpoints[providerName]
+	if !ok {
+		return fmt.Errorf("provider %s has no configured endpoint", providerName)
+	}
+	if endpoint.APIKey == apiKey {
+		return nil
+	}
+
+	endpoint.APIKey = apiKey
+	o.endpoints[providerName] = endpoint
+	o.stopProvider(providerName)
+
+	o.logger.Info().Str("provider", providerName.String()).Msg("rotated provider API key")
+	return nil
+}
+
+// watchAPIKeyFiles rotates the API key of the providers whose key file holds
+// a new key, every apiKeyFilePollInterval until the context is done. A file
+// that can't be read, ex. while it's being replaced, keeps the current key.
+func (o *Oracle) watchAPIKeyFiles(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(apiKeyFilePollInterval):
+			o.refreshAPIKeyFiles()
+		}
+	}
+}
+
+// refreshAPIKeyFiles reads the API key files and rotates the keys that
+// changed.
+func (o *Oracle) refreshAPIKeyFiles() {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	for providerName, path := range o.apiKeyFiles {
+		apiKey, err := config.ReadAPIKeyFile(path)
+		if err != nil {
+			o.logger.Warn().Err(err).Str("provider", providerName.String()).Msg("failed to read API key file")
+			continue
+		}
+		if err := o.rotateAPIKey(providerName, apiKey); err != nil {
+			o.logger.Warn().Err(err).Str("provider", providerName.String()).Msg("failed to rotate API key")
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package oracle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestOracle_RotateAPIKey(t *testing.T) {
+	providerPairs := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderNumia:   {{Base: "OSMO", Quote: "USD"}},
+		provider.ProviderBinance: {{Base: "ATOM", Quote: "USDT"}},
+	}
+
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		providerPairs,
+		0,
+		make(map[string]sdk.Dec),
+		map[provider.Name]provider.Endpoint{
+			provider.ProviderNumia: {Name: provider.ProviderNumia, APIKey: "old-key"},
+		},
+	)
+	o.priceProviders[provider.ProviderNumia] = mockProvider{}
+	o.priceProviders[provider.ProviderBinance] = mockProvider{}
+
+	require.Error(t, o.RotateAPIKey(provider.ProviderKraken, "new-key"))
+	require.Error(t, o.RotateAPIKey(provider.ProviderBinance, "new-key"))
+
+	// an unchanged key keeps the provider running
+	require.NoError(t, o.RotateAPIKey(provider.ProviderNumia, "old-key"))
+	require.Contains(t, o.priceProviders, provider.ProviderNumia)
+
+	// a new key stops the provider only, which is created again with it
+	require.NoError(t, o.RotateAPIKey(provider.ProviderNumia, "new-key"))
+	require.NotContains(t, o.priceProviders, provider.ProviderNumia)
+	require.Contains(t, o.priceProviders, provider.ProviderBinance)
+	require.Equal(t, "new-key", o.endpoints[provider.ProviderNumia].APIKey)
+}
+
+func TestOracle_RefreshAPIKeyFiles(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "numia")
+	require.NoError(t, os.WriteFile(keyFile, []byte("old-key\n"), 0o600))
+
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderNumia: {{Base: "OSMO", Quote: "USD"}},
+		},
+		0,
+		make(map[string]sdk.Dec),
+		map[provider.Name]provider.Endpoint{
+			provider.ProviderNumia: {Name: provider.ProviderNumia, APIKey: "old-key"},
+		},
+	)
+	o.SetAPIKeyFiles(map[provider.Name]string{provider.ProviderNumia: keyFile})
+	o.priceProviders[provider.ProviderNumia] = mockProvider{}
+
+	o.refreshAPIKeyFiles()
+	require.Contains(t, o.priceProviders, provider.ProviderNumia)
+
+	// a file being replaced keeps the current key
+	require.NoError(t, os.Remove(keyFile))
+	o.refreshAPIKeyFiles()
+	require.Equal(t, "old-key", o.endpoints[provider.ProviderNumia].APIKey)
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("new-key\n"), 0o600))
+	o.refreshAPIKeyFiles()
+	require.NotContains(t, o.priceProviders, provider.ProviderNumia)
+	require.Equal(t, "new-key", o.endpoints[provider.ProviderNumia].APIKey)
+
+	// the key read from a file can only be rotated through the file
+	err := o.RotateAPIKey(provider.ProviderNumia, "admin-key")
+	require.ErrorIs(t, err, types.ErrAPIKeyFromFile)
+	require.Equal(t, "new-key", o.endpoints[provider.ProviderNumia].APIKey)
+}
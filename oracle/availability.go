@@ -0,0 +1,81 @@
+package oracle
+
+import (
+	"context"
+	"time"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// pairAvailabilityInterval is the interval at which the providers try again to
+// subscribe to the configured pairs they couldn't subscribe to, ex. because the
+// pair wasn't listed yet.
+const pairAvailabilityInterval = 5 * time.Minute
+
+// checkPairAvailability subscribes the providers to their unavailable pairs
+// every pairAvailabilityInterval until the context is done.
+func (o *Oracle) checkPairAvailability(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pairAvailabilityInterval):
+		}
+
+		o.subscribeUnavailablePairs()
+	}
+}
+
+// subscribeUnavailablePairs subscribes each provider to the configured pairs it
+// isn't subscribed to, which confirms their availability again, and reports the
+// amount of pairs that are still unavailable. Providers fetching prices on
+// demand are skipped.
+func (o *Oracle) subscribeUnavailablePairs() {
+	// subscribing can take a while, so it's done without holding the lock
+	o.configMtx.Lock()
+	priceProviders := make(map[provider.Name]provider.Provider, len(o.priceProviders))
+	providerPairs := make(map[provider.Name][]types.CurrencyPair, len(o.priceProviders))
+	for providerName, priceProvider := range o.priceProviders {
+		priceProviders[providerName] = priceProvider
+		providerPairs[providerName] = o.providerPairs[providerName]
+	}
+	o.configMtx.Unlock()
+
+	for providerName, priceProvider := range priceProviders {
+		subscribedPairs := priceProvider.GetSubscribedPairs()
+		if subscribedPairs == nil {
+			continue
+		}
+
+		unavailablePairs := unsubscribedPairs(providerPairs[providerName], subscribedPairs)
+		if len(unavailablePairs) > 0 {
+			priceProvider.SubscribeCurrencyPairs(unavailablePairs...)
+
+			stillUnavailable := unsubscribedPairs(unavailablePairs, priceProvider.GetSubscribedPairs())
+			if len(stillUnavailable) < len(unavailablePairs) {
+				o.logger.Info().
+					Str("provider", providerName.String()).
+					Interface("pairs", pairsDifference(unavailablePairs, stillUnavailable)).
+					Msg("subscribed to pairs that became available")
+			}
+			unavailablePairs = stillUnavailable
+		}
+
+		provider.TelemetryUnavailablePairs(providerName, len(unavailablePairs))
+	}
+}
+
+// unsubscribedPairs returns the pairs which are not in the subscribed pairs.
+func unsubscribedPairs(
+	pairs []types.CurrencyPair,
+	subscribedPairs map[string]types.CurrencyPair,
+) []types.CurrencyPair {
+	unsubscribed := []types.CurrencyPair{}
+	for _, cp := range pairs {
+		if _, ok := subscribedPairs[cp.String()]; !ok {
+			unsubscribed = append(unsubscribed, cp)
+		}
+	}
+	return unsubscribed
+}
@@ -0,0 +1,94 @@
+package oracle
+
+import (
+	"sync"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// listingProvider only subscribes to the pairs it currently lists.
+type listingProvider struct {
+	mockProvider
+
+	mtx        sync.Mutex
+	listed     map[string]struct{}
+	subscribed map[string]types.CurrencyPair
+	requested  [][]types.CurrencyPair
+}
+
+func (p *listingProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.requested = append(p.requested, cps)
+	for _, cp := range cps {
+		if _, ok := p.listed[cp.String()]; ok {
+			p.subscribed[cp.String()] = cp
+		}
+	}
+}
+
+func (p *listingProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	subscribed := make(map[string]types.CurrencyPair, len(p.subscribed))
+	for symbol, cp := range p.subscribed {
+		subscribed[symbol] = cp
+	}
+	return subscribed
+}
+
+func TestOracle_SubscribeUnavailablePairs(t *testing.T) {
+	ojoPair := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+	atomPair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	binance := &listingProvider{
+		listed:     map[string]struct{}{"ATOMUSDT": {}},
+		subscribed: map[string]types.CurrencyPair{"ATOMUSDT": atomPair},
+	}
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance: {ojoPair, atomPair},
+			provider.ProviderMock:    {atomPair},
+		},
+		0,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	o.priceProviders[provider.ProviderBinance] = binance
+	o.priceProviders[provider.ProviderMock] = provider.NewMockProvider()
+
+	// only the pair the provider isn't subscribed to is requested again
+	o.subscribeUnavailablePairs()
+	require.Equal(t, [][]types.CurrencyPair{{ojoPair}}, binance.requested)
+	require.NotContains(t, binance.GetSubscribedPairs(), "OJOUSDT")
+
+	// the pair is subscribed to once it's listed
+	binance.listed["OJOUSDT"] = struct{}{}
+	o.subscribeUnavailablePairs()
+	require.Contains(t, binance.GetSubscribedPairs(), "OJOUSDT")
+
+	o.subscribeUnavailablePairs()
+	require.Len(t, binance.requested, 2)
+}
+
+func TestUnsubscribedPairs(t *testing.T) {
+	ojoPair := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+	atomPair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	require.Equal(t,
+		[]types.CurrencyPair{ojoPair},
+		unsubscribedPairs([]types.CurrencyPair{ojoPair, atomPair}, map[string]types.CurrencyPair{"ATOMUSDT": atomPair}),
+	)
+	require.Empty(t, unsubscribedPairs([]types.CurrencyPair{atomPair}, map[string]types.CurrencyPair{"ATOMUSDT": atomPair}))
+}
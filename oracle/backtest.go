@@ -0,0 +1,117 @@
+package oracle
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// maxAsOfAttempts is the number of times the prices as of a past time are
+// computed before giving up on computing them within a single second.
+const maxAsOfAttempts = 3
+
+// ComputePricesAsOf computes the prices the oracle would have submitted at
+// the given time from the candles of its providers' as-of sources, ex.
+// CandleReplays of recorded candles, applying its current configuration. It
+// answers what an aggregation change would have done to a past price without
+// touching a live oracle: build an oracle from the configuration to evaluate
+// and don't start it, since the computation records the prices by provider
+// and the maintenance windows like SetPrices does.
+//
+// The candles are shifted by the time elapsed since asOf, so that the TVWAP
+// windows, the candle decay and the staleness of candles are evaluated as of
+// asOf rather than now.
+func (o *Oracle) ComputePricesAsOf(
+	ctx context.Context,
+	sources map[provider.Name]provider.AsOfCandleSource,
+	asOf time.Time,
+) (map[string]sdk.Dec, error) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.updateMaintenance(asOf)
+	votingPairs := o.votingPairs()
+
+	recorded := make(map[provider.Name]map[string][]types.CandlePrice, len(sources))
+	for providerName, pairs := range votingPairs {
+		source, ok := sources[providerName]
+		if !ok {
+			continue
+		}
+		if _, ok := o.disabledProviders[providerName]; ok {
+			continue
+		}
+		candles, err := source.GetCandlePricesAsOf(ctx, asOf, pairs...)
+		if err != nil {
+			return nil, err
+		}
+		recorded[providerName] = candles
+	}
+
+	// the computation is relative to the current second, so it's retried if
+	// it straddled two of them
+	var (
+		prices map[string]sdk.Dec
+		err    error
+	)
+	for attempt := 0; attempt < maxAsOfAttempts; attempt++ {
+		now := provider.PastUnixTime(0)
+		prices, err = o.computeRecordedPrices(recorded, votingPairs, now-asOf.UnixMilli())
+		if err != nil || provider.PastUnixTime(0) == now {
+			break
+		}
+	}
+	return prices, err
+}
+
+// computeRecordedPrices computes the prices from the recorded candles of each
+// provider, shifted by the given number of milliseconds.
+func (o *Oracle) computeRecordedPrices(
+	recorded map[provider.Name]map[string][]types.CandlePrice,
+	votingPairs map[provider.Name][]types.CurrencyPair,
+	shift int64,
+) (map[string]sdk.Dec, error) {
+	providerPrices := make(provider.AggregatedProviderPrices)
+	providerCandles := make(provider.AggregatedProviderCandles)
+	for providerName, recordedCandles := range recorded {
+		candles := make(map[string][]types.CandlePrice, len(recordedCandles))
+		for symbol, pairCandles := range recordedCandles {
+			shifted := make([]types.CandlePrice, len(pairCandles))
+			for i, candle := range pairCandles {
+				candle.TimeStamp += shift
+				shifted[i] = candle
+			}
+			candles[symbol] = shifted
+		}
+
+		prices, candles := ApplyScaleFactors(map[string]types.TickerPrice{}, candles, o.scaleFactors[providerName])
+		prices, candles = ApplyPriceSources(providerName, prices, candles, o.pairSources[providerName])
+		prices, candles = FilterPriceBounds(o.logger, providerName, prices, candles, o.priceBounds[providerName])
+
+		for _, pair := range votingPairs[providerName] {
+			setProviderTickerPricesAndCandles(
+				providerName,
+				QuoteRouteName(providerName, pair, votingPairs[providerName]),
+				providerPrices,
+				providerCandles,
+				prices,
+				candles,
+				pair,
+			)
+		}
+	}
+
+	providerPrices, providerCandles = FilterDerivedPrices(o.logger, providerPrices, providerCandles)
+
+	prices, _, err := o.computePrices(
+		providerCandles,
+		providerPrices,
+		SplitQuoteRoutes(votingPairs),
+		o.deviations,
+	)
+	return prices, err
+}
@@ -0,0 +1,66 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestComputePricesAsOf(t *testing.T) {
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	asOf := time.Date(2024, 3, 5, 14, 3, 0, 0, time.UTC)
+
+	candle := func(minutes int, price string) types.CandlePrice {
+		return types.CandlePrice{
+			Price:     sdk.MustNewDecFromStr(price),
+			Volume:    sdk.OneDec(),
+			TimeStamp: asOf.Add(time.Duration(minutes) * time.Minute).UnixMilli(),
+		}
+	}
+	recorded := map[string][]types.CandlePrice{
+		"ATOMUSD": {candle(-2, "10"), candle(-1, "10"), candle(0, "10"), candle(1, "20"), candle(2, "20")},
+	}
+	sources := map[provider.Name]provider.AsOfCandleSource{
+		provider.ProviderBinance: provider.NewCandleReplay(recorded),
+		provider.ProviderKraken:  provider.NewCandleReplay(recorded),
+	}
+
+	oracle := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance: {atomUSD},
+			provider.ProviderKraken:  {atomUSD},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+
+	// the candles recorded after the queried time are ignored, and the ones
+	// before it aren't stale even though they're long past
+	prices, err := oracle.ComputePricesAsOf(context.Background(), sources, asOf)
+	require.NoError(t, err)
+	require.Equal(t, map[string]sdk.Dec{"ATOM": sdk.NewDec(10)}, prices)
+
+	prices, err = oracle.ComputePricesAsOf(context.Background(), sources, asOf.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.True(t, prices["ATOM"].GT(sdk.NewDec(10)) && prices["ATOM"].LT(sdk.NewDec(20)))
+
+	// the candles are stale as of long after they were recorded
+	prices, err = oracle.ComputePricesAsOf(context.Background(), sources, asOf.Add(10*time.Minute))
+	require.NoError(t, err)
+	require.Empty(t, prices)
+
+	// a provider without recorded candles at the queried time errors
+	_, err = oracle.ComputePricesAsOf(context.Background(), sources, asOf.Add(-time.Hour))
+	require.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package oracle
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+type (
+	// ProviderBias defines the mean signed deviation of a provider's price of
+	// an asset from the median of the providers' prices of the asset, in
+	// percent of the median. Warn is set the first time the bias exceeds the
+	// warn threshold, until it falls back under it.
+	ProviderBias struct {
+		Provider provider.Name
+		Base     string
+		Bias     sdk.Dec
+		Warn     bool
+	}
+
+	// BiasAnalyzer tracks the signed deviation of each provider's price from
+	// the median of the providers' prices of the asset over its last window
+	// price collections. A provider consistently above or below the median
+	// either leads the others or has a problem. It isn't safe for concurrent
+	// use.
+	BiasAnalyzer struct {
+		window        int
+		warnThreshold sdk.Dec
+		windows       map[string]map[provider.Name]*biasWindow
+	}
+
+	// biasWindow is a ring buffer of the latest deviations of a provider's
+	// price, along with their sum so that their mean is cheap to compute.
+	biasWindow struct {
+		deviations []sdk.Dec
+		next       int
+		sum        sdk.Dec
+		warned     bool
+	}
+)
+
+// NewBiasAnalyzer creates a BiasAnalyzer averaging the deviations over the
+// window of the analysis.
+func NewBiasAnalyzer(analysis config.BiasAnalysis) *BiasAnalyzer {
+	return &BiasAnalyzer{
+		window:        analysis.Window,
+		warnThreshold: analysis.WarnThreshold,
+		windows:       make(map[string]map[provider.Name]*biasWindow),
+	}
+}
+
+// Record adds the deviation of each provider's price from the median of its
+// asset's prices, for the assets priced by at least two providers, and returns
+// the mean bias of the providers whose window is full, sorted by asset and
+// provider.
+func (a *BiasAnalyzer) Record(pricesByProvider map[provider.Name]map[string]sdk.Dec) []ProviderBias {
+	basePrices := make(map[string]map[provider.Name]sdk.Dec)
+	for providerName, prices := range pricesByProvider {
+		for base, price := range prices {
+			if _, ok := basePrices[base]; !ok {
+				basePrices[base] = make(map[provider.Name]sdk.Dec)
+			}
+			basePrices[base][providerName] = price
+		}
+	}
+
+	biases := []ProviderBias{}
+	for base, prices := range basePrices {
+		if len(prices) < 2 {
+			continue
+		}
+		median := medianPrice(prices)
+		if !median.IsPositive() {
+			continue
+		}
+
+		if _, ok := a.windows[base]; !ok {
+			a.windows[base] = make(map[provider.Name]*biasWindow)
+		}
+		for providerName, price := range prices {
+			w, ok := a.windows[base][providerName]
+			if !ok {
+				w = &biasWindow{deviations: make([]sdk.Dec, 0, a.window), sum: sdk.ZeroDec()}
+				a.windows[base][providerName] = w
+			}
+			w.add(price.Sub(median).Quo(median).MulInt64(100), a.window)
+			if len(w.deviations) < a.window {
+				continue
+			}
+
+			bias := ProviderBias{
+				Provider: providerName,
+				Base:     base,
+				Bias:     w.sum.QuoInt64(int64(len(w.deviations))),
+			}
+			exceeded := a.warnThreshold.IsPositive() && bias.Bias.Abs().GT(a.warnThreshold)
+			bias.Warn = exceeded && !w.warned
+			w.warned = exceeded
+			biases = append(biases, bias)
+		}
+	}
+
+	sort.Slice(biases, func(i, j int) bool {
+		if biases[i].Base != biases[j].Base {
+			return biases[i].Base < biases[j].Base
+		}
+		return biases[i].Provider < biases[j].Provider
+	})
+	return biases
+}
+
+// add appends the deviation until the window holds size deviations, then
+// overwrites the oldest one.
+func (w *biasWindow) add(deviation sdk.Dec, size int) {
+	w.sum = w.sum.Add(deviation)
+	if len(w.deviations) < size {
+		w.deviations = append(w.deviations, deviation)
+		return
+	}
+	w.sum = w.sum.Sub(w.deviations[w.next])
+	w.deviations[w.next] = deviation
+	w.next = (w.next + 1) % size
+}
+
+// medianPrice returns the median of the prices, the mean of the two middle
+// prices for an even amount of prices.
+func medianPrice(prices map[provider.Name]sdk.Dec) sdk.Dec {
+	values := make([]sdk.Dec, 0, len(prices))
+	for _, price := range prices {
+		values = append(values, price)
+	}
+	return median(values)
+}
+
+// median returns the median of the values, the mean of the two middle values
+// for an even amount of values. The values are sorted in place.
+func median(values []sdk.Dec) sdk.Dec {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].LT(values[j])
+	})
+
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[middle]
+	}
+	return values[middle-1].Add(values[middle]).QuoInt64(2)
+}
+
+// SetBiasAnalysis sets the tracking of the providers' bias from the median
+// price, which is off unless its window is positive. The deviations recorded
+// so far are kept unless the analysis changed.
+func (o *Oracle) SetBiasAnalysis(analysis config.BiasAnalysis) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	if analysis.Window <= 0 {
+		o.biasAnalyzer = nil
+		return
+	}
+	if o.biasAnalyzer != nil &&
+		o.biasAnalyzer.window == analysis.Window &&
+		o.biasAnalyzer.warnThreshold.Equal(analysis.WarnThreshold) {
+		return
+	}
+	o.biasAnalyzer = NewBiasAnalyzer(analysis)
+}
+
+// recordBias records the providers' prices an aggregated price was computed
+// from in the bias analyzer, if any, reporting the providers' mean bias and
+// warning about the providers whose bias exceeds the warn threshold. It must
+// be called with the configMtx held.
+func (o *Oracle) recordBias(pricesByProvider map[provider.Name]map[string]sdk.Dec) {
+	if o.biasAnalyzer == nil {
+		return
+	}
+
+	for _, bias := range o.biasAnalyzer.Record(pricesByProvider) {
+		provider.TelemetryProviderBias(bias.Provider, bias.Base, bias.Bias)
+		if bias.Warn {
+			o.logger.Warn().
+				Str("provider", bias.Provider.String()).
+				Str("asset", bias.Base).
+				Str("bias", bias.Bias.String()).
+				Int("window", o.biasAnalyzer.window).
+				Msg("provider price consistently deviates from the median")
+		}
+	}
+}
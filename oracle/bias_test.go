@@ -0,0 +1,65 @@
+package oracle
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+func TestBiasAnalyzer_Record(t *testing.T) {
+	analyzer := NewBiasAnalyzer(config.BiasAnalysis{
+		Window:        2,
+		WarnThreshold: sdk.MustNewDecFromStr("1"),
+	})
+
+	pricesByProvider := func(binance, kraken, okx string) map[provider.Name]map[string]sdk.Dec {
+		return map[provider.Name]map[string]sdk.Dec{
+			provider.ProviderBinance: {"ATOM": sdk.MustNewDecFromStr(binance), "OJO": sdk.OneDec()},
+			provider.ProviderKraken:  {"ATOM": sdk.MustNewDecFromStr(kraken)},
+			provider.ProviderOkx:     {"ATOM": sdk.MustNewDecFromStr(okx)},
+		}
+	}
+
+	// nothing is reported until the window is full
+	require.Empty(t, analyzer.Record(pricesByProvider("10", "10.2", "10")))
+
+	// the assets priced by a single provider aren't tracked
+	biases := analyzer.Record(pricesByProvider("10", "10.1", "9.9"))
+	require.Equal(t, []ProviderBias{
+		{Provider: provider.ProviderBinance, Base: "ATOM", Bias: sdk.ZeroDec()},
+		{Provider: provider.ProviderKraken, Base: "ATOM", Bias: sdk.MustNewDecFromStr("1.5"), Warn: true},
+		{Provider: provider.ProviderOkx, Base: "ATOM", Bias: sdk.MustNewDecFromStr("-0.5")},
+	}, biases)
+
+	// the warning isn't repeated while the bias stays above the threshold
+	biases = analyzer.Record(pricesByProvider("10", "10.2", "10"))
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), biases[1].Bias)
+	require.False(t, biases[1].Warn)
+
+	// the oldest deviations leave the window
+	biases = analyzer.Record(pricesByProvider("10", "10", "10"))
+	require.Equal(t, sdk.OneDec(), biases[1].Bias)
+	biases = analyzer.Record(pricesByProvider("10", "10", "10"))
+	require.Equal(t, sdk.ZeroDec(), biases[1].Bias)
+
+	// and the warning is repeated once the bias exceeds the threshold again
+	biases = analyzer.Record(pricesByProvider("10", "10.3", "10"))
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), biases[1].Bias)
+	require.True(t, biases[1].Warn)
+}
+
+func TestMedianPrice(t *testing.T) {
+	require.Equal(t, sdk.MustNewDecFromStr("2"), medianPrice(map[provider.Name]sdk.Dec{
+		provider.ProviderBinance: sdk.MustNewDecFromStr("3"),
+		provider.ProviderKraken:  sdk.MustNewDecFromStr("1"),
+		provider.ProviderOkx:     sdk.MustNewDecFromStr("2"),
+	}))
+	require.Equal(t, sdk.MustNewDecFromStr("1.5"), medianPrice(map[provider.Name]sdk.Dec{
+		provider.ProviderBinance: sdk.MustNewDecFromStr("2"),
+		provider.ProviderKraken:  sdk.MustNewDecFromStr("1"),
+	}))
+}
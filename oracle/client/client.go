@@ -45,6 +45,7 @@ type (
 		GRPCEndpoint        string
 		KeyringPassphrase   string
 		ChainHeight         *ChainHeight
+		BroadcastRetry      BroadcastRetryPolicy
 	}
 
 	passReader struct {
@@ -87,6 +88,7 @@ func NewOracleClient(
 		Encoding:            ojoapp.MakeEncodingConfig(),
 		GasAdjustment:       gasAdjustment,
 		GRPCEndpoint:        grpcEndpoint,
+		BroadcastRetry:      DefaultBroadcastRetryPolicy(),
 	}
 
 	clientCtx, err := oracleClient.CreateClientContext()
@@ -131,8 +133,12 @@ func (r *passReader) Read(p []byte) (n int, err error) {
 	return n, err
 }
 
-// BroadcastTx attempts to broadcast a signed transaction. If it fails, a few re-attempts
-// will be made until the transaction succeeds or ultimately times out or fails.
+// BroadcastTx attempts to broadcast a signed transaction once the chain reaches
+// nextBlockHeight. If it fails, it's retried following the BroadcastRetry
+// policy until the transaction succeeds, the policy's attempts run out or the
+// chain passes the timeout height. The account sequence is refreshed from the
+// node's error when a broadcast fails on a sequence mismatch, ex. after a
+// transaction of the previous voting period was committed late.
 // Ref: https://github.com/terra-money/oracle-feeder/blob/baef2a4a02f57a2ffeaa207932b2e03d7fb0fb25/feeder/src/vote.ts#L230
 func (oc OracleClient) BroadcastTx(nextBlockHeight, timeoutHeight int64, msgs ...sdk.Msg) error {
 	maxBlockHeight := nextBlockHeight + timeoutHeight
@@ -149,23 +155,27 @@ func (oc OracleClient) BroadcastTx(nextBlockHeight, timeoutHeight int64, msgs ..
 	}
 
 	// re-try voting until timeout
+	attempt := 0
 	for lastCheckHeight < maxBlockHeight {
 		latestBlockHeight, err := oc.ChainHeight.GetChainHeight()
 		if err != nil {
 			return err
 		}
 
-		if latestBlockHeight <= lastCheckHeight {
+		// the first broadcast waits for the next block, the retries for
+		// their backoff
+		if attempt == 0 && latestBlockHeight <= lastCheckHeight {
 			continue
 		}
 
 		// set last check height to latest block height
 		lastCheckHeight = latestBlockHeight
+		attempt++
 
 		resp, err := BroadcastTx(clientCtx, factory, msgs...)
 		if resp != nil && resp.Code != 0 {
 			telemetry.IncrCounter(1, "failure", "tx", "code")
-			err = fmt.Errorf("invalid response code from tx: %d", resp.Code)
+			err = fmt.Errorf("invalid response code from tx: %d: %s", resp.Code, resp.RawLog)
 		}
 		if err != nil {
 			var (
@@ -177,15 +187,29 @@ func (oc OracleClient) BroadcastTx(nextBlockHeight, timeoutHeight int64, msgs ..
 				hash = resp.TxHash
 			}
 
-			oc.Logger.Debug().
+			if sequence, ok := accountSequenceMismatch(err); ok {
+				telemetry.IncrCounter(1, "failure", "tx", "sequence")
+				factory = factory.WithSequence(sequence)
+			}
+
+			if oc.BroadcastRetry.exhausted(attempt) {
+				telemetry.IncrCounter(1, "failure", "tx", "attempts")
+				return fmt.Errorf("broadcasting tx failed after %d attempts: %w", attempt, err)
+			}
+
+			backoff := oc.BroadcastRetry.backoff(attempt)
+			oc.Logger.Warn().
 				Err(err).
+				Int("attempt", attempt).
+				Dur("backoff", backoff).
 				Int64("max_height", maxBlockHeight).
 				Int64("last_check_height", lastCheckHeight).
 				Str("tx_hash", hash).
 				Uint32("tx_code", code).
 				Msg("failed to broadcast tx; retrying...")
 
-			time.Sleep(time.Second * 1)
+			telemetry.IncrCounter(1, "tx", "retry")
+			time.Sleep(backoff)
 			continue
 		}
 
@@ -193,6 +217,7 @@ func (oc OracleClient) BroadcastTx(nextBlockHeight, timeoutHeight int64, msgs ..
 			Uint32("tx_code", resp.Code).
 			Str("tx_hash", resp.TxHash).
 			Int64("tx_height", resp.Height).
+			Int("attempts", attempt).
 			Msg("successfully broadcasted tx")
 
 		return nil
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultBroadcastBackoff    = time.Second
+	defaultBroadcastMaxBackoff = 8 * time.Second
+)
+
+// sequenceMismatchRegex matches the error of a transaction signed with an
+// account sequence other than the one expected by the node.
+var sequenceMismatchRegex = regexp.MustCompile(`account sequence mismatch, expected (\d+), got \d+`)
+
+// BroadcastRetryPolicy defines how the failed broadcasts of a transaction are
+// retried within its timeout height. MaxAttempts bounds the broadcasts of the
+// transaction, which are only bounded by the timeout height when it's zero.
+// The wait after a failed broadcast starts at Backoff and doubles after each
+// further failure up to MaxBackoff.
+type BroadcastRetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultBroadcastRetryPolicy returns the policy retrying a transaction until
+// its timeout height, waiting for a second after the first failure.
+func DefaultBroadcastRetryPolicy() BroadcastRetryPolicy {
+	return BroadcastRetryPolicy{
+		Backoff:    defaultBroadcastBackoff,
+		MaxBackoff: defaultBroadcastMaxBackoff,
+	}
+}
+
+// exhausted returns true if no broadcast is left after the given attempt.
+func (p BroadcastRetryPolicy) exhausted(attempt int) bool {
+	return p.MaxAttempts > 0 && attempt >= p.MaxAttempts
+}
+
+// backoff returns the wait after the given failed attempt, starting at 1.
+// Unset durations fall back to their defaults.
+func (p BroadcastRetryPolicy) backoff(attempt int) time.Duration {
+	backoff, maxBackoff := p.Backoff, p.MaxBackoff
+	if backoff <= 0 {
+		backoff = defaultBroadcastBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultBroadcastMaxBackoff
+	}
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// accountSequenceMismatch returns the account sequence expected by the node if
+// the broadcast failed on a sequence mismatch.
+func accountSequenceMismatch(err error) (uint64, bool) {
+	if err == nil {
+		return 0, false
+	}
+	match := sequenceMismatchRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	sequence, parseErr := strconv.ParseUint(match[1], 10, 64)
+	if parseErr != nil {
+		return 0, false
+	}
+	return sequence, true
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastRetryPolicy(t *testing.T) {
+	policy := BroadcastRetryPolicy{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: 3 * time.Second}
+	require.Equal(t, time.Second, policy.backoff(1))
+	require.Equal(t, 2*time.Second, policy.backoff(2))
+	require.Equal(t, 3*time.Second, policy.backoff(3))
+	require.Equal(t, 3*time.Second, policy.backoff(10))
+
+	require.False(t, policy.exhausted(2))
+	require.True(t, policy.exhausted(3))
+
+	// the zero policy retries until the timeout height with the default backoff
+	var zero BroadcastRetryPolicy
+	require.False(t, zero.exhausted(100))
+	require.Equal(t, defaultBroadcastBackoff, zero.backoff(1))
+	require.Equal(t, defaultBroadcastMaxBackoff, zero.backoff(100))
+}
+
+func TestAccountSequenceMismatch(t *testing.T) {
+	err := fmt.Errorf(
+		"invalid response code from tx: 32: %s",
+		"account sequence mismatch, expected 42, got 41: incorrect account sequence",
+	)
+	sequence, ok := accountSequenceMismatch(err)
+	require.True(t, ok)
+	require.Equal(t, uint64(42), sequence)
+
+	_, ok = accountSequenceMismatch(errors.New("post failed: context deadline exceeded"))
+	require.False(t, ok)
+	_, ok = accountSequenceMismatch(nil)
+	require.False(t, ok)
+}
@@ -0,0 +1,119 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// providerReading is the result of reading the prices and candles of a
+// provider's currency pairs.
+type providerReading struct {
+	prices  map[string]types.TickerPrice
+	candles map[string][]types.CandlePrice
+	err     error
+}
+
+// collectProviderPrices reads the prices and candles of each provider's pairs
+// with at most workers providers read concurrently, or all of them at once if
+// workers isn't positive. Each read is given timeout from the moment a worker
+// starts it, and the reads that didn't start before ctx is done fail with its
+// error.
+func collectProviderPrices(
+	ctx context.Context,
+	providers map[provider.Name]provider.Provider,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	timeout time.Duration,
+	workers int,
+) map[provider.Name]providerReading {
+	if workers <= 0 || workers > len(providers) {
+		workers = len(providers)
+	}
+
+	jobs := make(chan provider.Name, len(providers))
+	for providerName := range providers {
+		jobs <- providerName
+	}
+	close(jobs)
+
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		readings = make(map[provider.Name]providerReading, len(providers))
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for providerName := range jobs {
+				var reading providerReading
+				if err := ctx.Err(); err != nil {
+					reading.err = err
+				} else {
+					reading = readProviderPrices(
+						ctx,
+						providerName,
+						providers[providerName],
+						providerPairs[providerName],
+						timeout,
+					)
+				}
+
+				mtx.Lock()
+				readings[providerName] = reading
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return readings
+}
+
+// readProviderPrices reads the prices and then the candles of the provider's
+// pairs, failing if they aren't both read within timeout.
+func readProviderPrices(
+	ctx context.Context,
+	providerName provider.Name,
+	priceProvider provider.Provider,
+	currencyPairs []types.CurrencyPair,
+	timeout time.Duration,
+) providerReading {
+	ch := make(chan providerReading, 1)
+
+	// providers fetching on demand are canceled along with the call so
+	// a slow provider can't stall the whole vote-collection cycle
+	providerCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	go func() {
+		prices, err := priceProvider.GetTickerPrices(providerCtx, currencyPairs...)
+		if err != nil {
+			provider.TelemetryFailure(providerName, provider.MessageTypeTicker)
+			ch <- providerReading{err: err}
+			return
+		}
+
+		candles, err := priceProvider.GetCandlePrices(providerCtx, currencyPairs...)
+		if err != nil {
+			provider.TelemetryFailure(providerName, provider.MessageTypeCandle)
+			ch <- providerReading{err: err}
+			return
+		}
+		ch <- providerReading{prices: prices, candles: candles}
+	}()
+
+	select {
+	case reading := <-ch:
+		return reading
+	case <-providerCtx.Done():
+		telemetry.IncrCounter(1, "failure", "provider", "type", "timeout")
+		return providerReading{err: fmt.Errorf("provider timed out")}
+	}
+}
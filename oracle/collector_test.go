@@ -0,0 +1,133 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// delayedProvider is a mockProvider taking delay to read its prices and keeping
+// track of the amount of concurrent reads.
+type delayedProvider struct {
+	mockProvider
+	delay   time.Duration
+	active  *int64
+	maxSeen *int64
+}
+
+func (p delayedProvider) GetTickerPrices(
+	ctx context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string]types.TickerPrice, error) {
+	if p.active != nil {
+		active := atomic.AddInt64(p.active, 1)
+		defer atomic.AddInt64(p.active, -1)
+		for {
+			maxSeen := atomic.LoadInt64(p.maxSeen)
+			if active <= maxSeen || atomic.CompareAndSwapInt64(p.maxSeen, maxSeen, active) {
+				break
+			}
+		}
+	}
+
+	select {
+	case <-time.After(p.delay):
+		return p.mockProvider.GetTickerPrices(ctx, pairs...)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func newDelayedProviders(
+	n int,
+	delay time.Duration,
+	active, maxSeen *int64,
+) (map[provider.Name]provider.Provider, map[provider.Name][]types.CurrencyPair) {
+	providers := make(map[provider.Name]provider.Provider, n)
+	providerPairs := make(map[provider.Name][]types.CurrencyPair, n)
+	for i := 0; i < n; i++ {
+		name := provider.Name(fmt.Sprintf("provider%d", i))
+		providers[name] = delayedProvider{
+			mockProvider: mockProvider{
+				prices: map[string]types.TickerPrice{
+					"ATOMUSDT": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+				},
+			},
+			delay:   delay,
+			active:  active,
+			maxSeen: maxSeen,
+		}
+		providerPairs[name] = []types.CurrencyPair{{Base: "ATOM", Quote: "USDT"}}
+	}
+	return providers, providerPairs
+}
+
+func TestCollectProviderPrices(t *testing.T) {
+	t.Run("bounded concurrency", func(t *testing.T) {
+		var active, maxSeen int64
+		providers, providerPairs := newDelayedProviders(8, 5*time.Millisecond, &active, &maxSeen)
+
+		readings := collectProviderPrices(context.Background(), providers, providerPairs, time.Second, 3)
+		require.Len(t, readings, 8)
+		for _, reading := range readings {
+			require.NoError(t, reading.err)
+			require.Contains(t, reading.prices, "ATOMUSDT")
+			require.Contains(t, reading.candles, "ATOMUSDT")
+		}
+		require.LessOrEqual(t, maxSeen, int64(3))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		providers, providerPairs := newDelayedProviders(2, time.Second, nil, nil)
+
+		readings := collectProviderPrices(context.Background(), providers, providerPairs, 10*time.Millisecond, 0)
+		require.Len(t, readings, 2)
+		for _, reading := range readings {
+			require.EqualError(t, reading.err, "provider timed out")
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		providers, providerPairs := newDelayedProviders(2, time.Millisecond, nil, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		readings := collectProviderPrices(ctx, providers, providerPairs, time.Second, 1)
+		require.Len(t, readings, 2)
+		for _, reading := range readings {
+			require.Error(t, reading.err)
+		}
+	})
+
+	t.Run("failing provider", func(t *testing.T) {
+		providers := map[provider.Name]provider.Provider{
+			provider.ProviderBinance: failingProvider{},
+		}
+		providerPairs := map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance: {{Base: "ATOM", Quote: "USDT"}},
+		}
+
+		readings := collectProviderPrices(context.Background(), providers, providerPairs, time.Second, 0)
+		require.EqualError(t, readings[provider.ProviderBinance].err, "unable to get ticker prices")
+	})
+}
+
+func BenchmarkCollectProviderPrices(b *testing.B) {
+	providers, providerPairs := newDelayedProviders(32, 2*time.Millisecond, nil, nil)
+
+	for _, workers := range []int{1, 4, 16, 0} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				collectProviderPrices(context.Background(), providers, providerPairs, time.Second, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package oracle
+
+import (
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// compositeCandlePeriod is the period of the composite candles the composite
+// TWAP aggregation averages.
+const compositeCandlePeriod = time.Minute
+
+// ComputeCompositeCandles merges the candles of the base from all providers
+// into a single series of candles, oldest first, aligned on multiples of
+// period. The candles of each provider within a bucket are first combined so
+// that providers with a shorter candle cadence count once per bucket, and
+// the providers' candles are then volume weighted. A provider only
+// contributes to the buckets it has candles in, and buckets without any
+// candle are left out rather than filled in. Candles are timestamped with
+// their close time, either the last millisecond of their interval or its end
+// boundary depending on the provider, so both are bucketed with the interval
+// they close. Composite candles are timestamped with the start of their
+// bucket. The period should be at least the longest candle cadence of the
+// providers.
+func ComputeCompositeCandles(
+	candles provider.AggregatedProviderCandles,
+	base string,
+	period time.Duration,
+) []types.CandlePrice {
+	periodMs := period.Milliseconds()
+	if periodMs <= 0 {
+		return nil
+	}
+
+	buckets := make(map[int64][]types.CandlePrice)
+	for _, providerCandles := range candles {
+		sorted := make([]types.CandlePrice, len(providerCandles[base]))
+		copy(sorted, providerCandles[base])
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].TimeStamp < sorted[j].TimeStamp
+		})
+
+		providerBuckets := make(map[int64]types.CandlePrice)
+		for _, candle := range sorted {
+			bucket := compositeBucket(candle.TimeStamp, periodMs)
+			if previous, ok := providerBuckets[bucket]; ok {
+				candle = mergeProviderCandles(previous, candle)
+			}
+			providerBuckets[bucket] = candle
+		}
+		for bucket, candle := range providerBuckets {
+			buckets[bucket] = append(buckets[bucket], candle)
+		}
+	}
+
+	composite := make([]types.CandlePrice, 0, len(buckets))
+	for bucket, bucketCandles := range buckets {
+		candle := weightCandles(bucketCandles)
+		candle.TimeStamp = bucket
+		composite = append(composite, candle)
+	}
+	sort.Slice(composite, func(i, j int) bool {
+		return composite[i].TimeStamp < composite[j].TimeStamp
+	})
+	return composite
+}
+
+// compositeBucket returns the start of the bucket of the candle closing at
+// the timestamp: a candle closing on a bucket boundary belongs to the bucket
+// ending there.
+func compositeBucket(timeStamp, periodMs int64) int64 {
+	return (timeStamp - 1) / periodMs * periodMs
+}
+
+// ComputeCompositeTWAP returns the time weighted average price of the base
+// over the window, from its composite candles of the period: the mean of the
+// prices of the buckets within the window, each bucket weighing the same
+// whatever the amount of providers and candles it was merged from. It
+// returns false if the base has no composite candle within the window.
+func ComputeCompositeTWAP(
+	candles provider.AggregatedProviderCandles,
+	base string,
+	period time.Duration,
+	window time.Duration,
+) (sdk.Dec, bool) {
+	start := compositeBucket(provider.PastUnixTime(window)+1, period.Milliseconds())
+
+	sum := sdk.ZeroDec()
+	count := int64(0)
+	for _, candle := range ComputeCompositeCandles(candles, base, period) {
+		if candle.TimeStamp < start {
+			continue
+		}
+		sum = sum.Add(candle.Price)
+		count++
+	}
+	if count == 0 {
+		return sdk.Dec{}, false
+	}
+	return sum.QuoInt64(count), true
+}
+
+// mergeProviderCandles combines two consecutive candles of a provider into a
+// single candle.
+func mergeProviderCandles(previous, next types.CandlePrice) types.CandlePrice {
+	merged := types.CandlePrice{
+		Price:            next.Price,
+		Volume:           previous.Volume.Add(next.Volume),
+		TimeStamp:        next.TimeStamp,
+		VolumeUnreliable: previous.VolumeUnreliable || next.VolumeUnreliable,
+	}
+	if !previous.Open.IsNil() && !previous.High.IsNil() && !previous.Low.IsNil() &&
+		!next.High.IsNil() && !next.Low.IsNil() {
+		merged.Open = previous.Open
+		merged.High = sdk.MaxDec(previous.High, next.High)
+		merged.Low = sdk.MinDec(previous.Low, next.Low)
+	}
+	return merged
+}
+
+// weightCandles combines the candles of different providers for the same
+// bucket by weighting their prices by their volume, or equally if none of
+// them has any volume. The open, high and low are only set if all candles
+// have them.
+func weightCandles(candles []types.CandlePrice) types.CandlePrice {
+	volume := sdk.ZeroDec()
+	for _, candle := range candles {
+		volume = volume.Add(candle.Volume)
+	}
+	weightSum := volume
+	if !volume.IsPositive() {
+		weightSum = sdk.NewDec(int64(len(candles)))
+	}
+
+	weighted := types.CandlePrice{
+		Price:  sdk.ZeroDec(),
+		Volume: volume,
+	}
+	hasOHL := true
+	for _, candle := range candles {
+		hasOHL = hasOHL && !candle.Open.IsNil() && !candle.High.IsNil() && !candle.Low.IsNil()
+		weighted.VolumeUnreliable = weighted.VolumeUnreliable || candle.VolumeUnreliable
+	}
+	if hasOHL {
+		weighted.Open, weighted.High, weighted.Low = sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()
+	}
+
+	for _, candle := range candles {
+		weight := candle.Volume
+		if !volume.IsPositive() {
+			weight = sdk.OneDec()
+		}
+		weighted.Price = weighted.Price.Add(candle.Price.Mul(weight))
+		if hasOHL {
+			weighted.Open = weighted.Open.Add(candle.Open.Mul(weight))
+			weighted.High = weighted.High.Add(candle.High.Mul(weight))
+			weighted.Low = weighted.Low.Add(candle.Low.Mul(weight))
+		}
+	}
+
+	weighted.Price = weighted.Price.Quo(weightSum)
+	if hasOHL {
+		weighted.Open = weighted.Open.Quo(weightSum)
+		weighted.High = weighted.High.Quo(weightSum)
+		weighted.Low = weighted.Low.Quo(weightSum)
+	}
+	return weighted
+}
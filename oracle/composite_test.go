@@ -0,0 +1,167 @@
+package oracle_test
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestComputeCompositeCandles(t *testing.T) {
+	const minute = int64(60000)
+	start := 1000 * 5 * minute
+
+	candle := func(price, volume string, timeStamp int64) types.CandlePrice {
+		return types.CandlePrice{
+			Price:     sdk.MustNewDecFromStr(price),
+			Volume:    sdk.MustNewDecFromStr(volume),
+			TimeStamp: timeStamp,
+		}
+	}
+
+	candles := provider.AggregatedProviderCandles{
+		// one minute candles
+		provider.ProviderBinance: {
+			"ATOM": {
+				candle("10", "1", start+minute),
+				candle("11", "2", start+2*minute),
+				candle("12", "1", start+6*minute),
+			},
+		},
+		// five minute candles, missing the second bucket
+		provider.ProviderKraken: {
+			"ATOM": {
+				candle("13", "4", start+4*minute),
+			},
+		},
+		provider.ProviderOkx: {
+			"OJO": {
+				candle("1", "1", start),
+			},
+		},
+	}
+
+	composite := oracle.ComputeCompositeCandles(candles, "ATOM", 5*time.Minute)
+	require.Len(t, composite, 2)
+
+	// the binance minutes are merged into a close of 11 with a volume of 3,
+	// which are weighted with the kraken candle
+	require.Equal(t, start, composite[0].TimeStamp)
+	require.Equal(t, sdk.MustNewDecFromStr("7"), composite[0].Volume)
+	require.Equal(t, sdk.MustNewDecFromStr("85").QuoInt64(7), composite[0].Price)
+
+	// kraken is left out of the bucket it has no candle in
+	require.Equal(t, start+5*minute, composite[1].TimeStamp)
+	require.Equal(t, sdk.MustNewDecFromStr("12"), composite[1].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("1"), composite[1].Volume)
+
+	require.Empty(t, oracle.ComputeCompositeCandles(candles, "BTC", 5*time.Minute))
+	require.Nil(t, oracle.ComputeCompositeCandles(candles, "ATOM", 0))
+}
+
+func TestComputeCompositeCandles_Alignment(t *testing.T) {
+	const minute = int64(60000)
+	start := 1000 * minute
+
+	// the same minute closes at its last millisecond on binance and at its
+	// end boundary on osmosis
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderBinance: {
+			"ATOM": {
+				{Price: sdk.NewDec(10), Volume: sdk.OneDec(), TimeStamp: start + minute - 1},
+				{Price: sdk.NewDec(12), Volume: sdk.OneDec(), TimeStamp: start + 2*minute - 1},
+			},
+		},
+		provider.ProviderOsmosis: {
+			"ATOM": {
+				{Price: sdk.NewDec(20), Volume: sdk.OneDec(), TimeStamp: start + minute},
+				{Price: sdk.NewDec(22), Volume: sdk.OneDec(), TimeStamp: start + 2*minute},
+			},
+		},
+	}
+
+	composite := oracle.ComputeCompositeCandles(candles, "ATOM", time.Minute)
+	require.Len(t, composite, 2)
+	require.Equal(t, start, composite[0].TimeStamp)
+	require.Equal(t, sdk.NewDec(15), composite[0].Price)
+	require.Equal(t, start+minute, composite[1].TimeStamp)
+	require.Equal(t, sdk.NewDec(17), composite[1].Price)
+}
+
+func TestComputeCompositeTWAP(t *testing.T) {
+	const minute = int64(60000)
+	// the start of the current minute
+	start := provider.PastUnixTime(0) / minute * minute
+	candle := func(price int64, volume string, timeStamp int64) types.CandlePrice {
+		return types.CandlePrice{
+			Price:     sdk.NewDec(price),
+			Volume:    sdk.MustNewDecFromStr(volume),
+			TimeStamp: timeStamp,
+		}
+	}
+
+	candles := provider.AggregatedProviderCandles{
+		// the high volume venue reports several candles a minute
+		provider.ProviderBinance: {
+			"ATOM": {
+				candle(10, "100", start-2*minute+10000),
+				candle(10, "100", start-2*minute+20000),
+				candle(10, "100", start-2*minute+30000),
+				candle(30, "1", start-20*minute+30000),
+			},
+		},
+		provider.ProviderKraken: {
+			"ATOM": {candle(20, "1", start-4*minute+30000)},
+		},
+	}
+
+	// each minute weighs the same, and the candle out of the window is left out
+	price, ok := oracle.ComputeCompositeTWAP(candles, "ATOM", time.Minute, 10*time.Minute)
+	require.True(t, ok)
+	require.Equal(t, sdk.NewDec(15), price)
+
+	_, ok = oracle.ComputeCompositeTWAP(candles, "OJO", time.Minute, 10*time.Minute)
+	require.False(t, ok)
+}
+
+func TestComputeCompositeCandles_OHLC(t *testing.T) {
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderBinance: {
+			"ATOM": {
+				{
+					Price: sdk.NewDec(11), Volume: sdk.ZeroDec(), TimeStamp: 1,
+					Open: sdk.NewDec(10), High: sdk.NewDec(12), Low: sdk.NewDec(9),
+				},
+			},
+		},
+		provider.ProviderKraken: {
+			"ATOM": {
+				{
+					Price: sdk.NewDec(13), Volume: sdk.ZeroDec(), TimeStamp: 2,
+					Open: sdk.NewDec(12), High: sdk.NewDec(14), Low: sdk.NewDec(11),
+				},
+			},
+		},
+	}
+
+	// candles without volume are weighted equally
+	composite := oracle.ComputeCompositeCandles(candles, "ATOM", time.Minute)
+	require.Len(t, composite, 1)
+	require.Equal(t, sdk.NewDec(12), composite[0].Price)
+	require.Equal(t, sdk.NewDec(11), composite[0].Open)
+	require.Equal(t, sdk.NewDec(13), composite[0].High)
+	require.Equal(t, sdk.NewDec(10), composite[0].Low)
+
+	// the open, high and low are left out unless all candles have them
+	candles[provider.ProviderOkx] = map[string][]types.CandlePrice{
+		"ATOM": {{Price: sdk.NewDec(12), Volume: sdk.ZeroDec(), TimeStamp: 3}},
+	}
+	composite = oracle.ComputeCompositeCandles(candles, "ATOM", time.Minute)
+	require.Len(t, composite, 1)
+	require.True(t, composite[0].Open.IsNil())
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// minWeightedConversionFeeds is the minimum amount of conversion feeds of a
+// quote for their rates to be weighted by volume.
+const minWeightedConversionFeeds = 4
+
 // getUSDBasedProviders retrieves which providers for an asset have a USD-based pair,
 // given the asset and the map of providers to currency pairs.
 func getUSDBasedProviders(
@@ -43,6 +47,22 @@ func ConvertCandlesToUSD(
 	candles provider.AggregatedProviderCandles,
 	providerPairs map[provider.Name][]types.CurrencyPair,
 	deviationThresholds map[string]sdk.Dec,
+) (provider.AggregatedProviderCandles, error) {
+	return ConvertCandlesToUSDWithFeeds(logger, candles, providerPairs, deviationThresholds, nil)
+}
+
+// ConvertCandlesToUSDWithFeeds converts the candles like ConvertCandlesToUSD,
+// except for the quotes in conversionFeeds. Their conversion rate is the
+// median of the USD rates of their feeds, ex. of USDT/USD and USDC/USD for
+// USDT, so that a single feed depegging moves it less. The rates are weighted
+// by volume once there are minWeightedConversionFeeds of them. Feeds without
+// a rate are left out of the median.
+func ConvertCandlesToUSDWithFeeds(
+	logger zerolog.Logger,
+	candles provider.AggregatedProviderCandles,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviationThresholds map[string]sdk.Dec,
+	conversionFeeds map[string][]string,
 ) (provider.AggregatedProviderCandles, error) {
 	if len(candles) == 0 {
 		return candles, nil
@@ -54,55 +74,20 @@ func ConvertCandlesToUSD(
 	for pairProviderName, pairs := range providerPairs {
 		for _, pair := range pairs {
 			if strings.ToUpper(pair.Quote) != config.DenomUSD {
-				// Get valid providers and use them to generate a USD-based price for this asset.
-				validProviders, err := getUSDBasedProviders(pair.Quote, providerPairs)
-				if err != nil {
-					return nil, err
-				}
-
-				// Find candles which we can use for conversion, and calculate the tvwap
-				// to find the conversion rate.
-				validCandleList := provider.AggregatedProviderCandles{}
-				for providerName, candleSet := range candles {
-					if _, ok := validProviders[providerName]; ok {
-						for base, candle := range candleSet {
-							if base == pair.Quote {
-								if _, ok := validCandleList[providerName]; !ok {
-									validCandleList[providerName] = make(map[string][]types.CandlePrice)
-								}
-
-								validCandleList[providerName][base] = candle
-							}
-						}
+				if _, ok := conversionRates[pair.Quote]; !ok {
+					cvRate, err := candleConversionRate(
+						logger,
+						candles,
+						providerPairs,
+						deviationThresholds,
+						pair,
+						conversionFeeds[pair.Quote],
+					)
+					if err != nil {
+						return nil, err
 					}
+					conversionRates[pair.Quote] = cvRate
 				}
-
-				if len(validCandleList) == 0 {
-					return nil, fmt.Errorf("there are no valid conversion rates for %s", pair.Quote)
-				}
-
-				filteredCandles, err := FilterCandleDeviations(
-					logger,
-					validCandleList,
-					deviationThresholds,
-				)
-				if err != nil {
-					return nil, err
-				}
-
-				// TODO: we should revise ComputeTVWAP to avoid return empty slices
-				// Ref: https://github.com/ojo-network/ojo/issues/1261
-				tvwap, err := ComputeTVWAP(filteredCandles)
-				if err != nil {
-					return nil, err
-				}
-
-				cvRate, ok := tvwap[pair.Quote]
-				if !ok {
-					return nil, fmt.Errorf("error on computing tvwap for quote: %s, base: %s", pair.Quote, pair.Base)
-				}
-
-				conversionRates[pair.Quote] = cvRate
 				requiredConversions[pairProviderName] = append(requiredConversions[pairProviderName], pair)
 			}
 		}
@@ -121,6 +106,10 @@ func ConvertCandlesToUSD(
 						assetCandles[i].Price = assetCandles[i].Price.Mul(
 							conversionRate,
 						)
+						// quote volumes are converted along with the price
+						assetCandles[i].Volume = assetCandles[i].Volume.Mul(
+							conversionRate,
+						)
 					}
 				}
 			}
@@ -130,6 +119,111 @@ func ConvertCandlesToUSD(
 	return candles, nil
 }
 
+// candleConversionRate returns the USD rate of the quote of the pair, computed
+// from the candles of the quote itself if it has no feeds.
+func candleConversionRate(
+	logger zerolog.Logger,
+	candles provider.AggregatedProviderCandles,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviationThresholds map[string]sdk.Dec,
+	pair types.CurrencyPair,
+	feeds []string,
+) (sdk.Dec, error) {
+	quote := pair.Quote
+	if len(feeds) == 0 {
+		feeds = []string{quote}
+	}
+
+	rates := make([]sdk.Dec, 0, len(feeds))
+	volumes := make([]sdk.Dec, 0, len(feeds))
+	for _, feed := range feeds {
+		rate, volume, err := candleFeedRate(logger, candles, providerPairs, deviationThresholds, feed)
+		if err == nil && rate.IsNil() {
+			err = fmt.Errorf("error on computing tvwap for quote: %s, base: %s", feed, pair.Base)
+		}
+		if len(feeds) == 1 && err != nil {
+			return sdk.Dec{}, err
+		}
+		if err != nil {
+			logger.Debug().Err(err).Str("quote", quote).Str("feed", feed).Msg("skipping conversion feed")
+			continue
+		}
+		rates = append(rates, rate)
+		volumes = append(volumes, volume)
+	}
+	if len(rates) == 0 {
+		return sdk.Dec{}, fmt.Errorf("there are no valid conversion rates for %s", quote)
+	}
+	return feedsConversionRate(rates, volumes)
+}
+
+// feedsConversionRate returns the conversion rate of a quote from the USD
+// rates of its feeds. With fewer than minWeightedConversionFeeds feeds, the
+// feed with the most volume would always be the volume weighted median, so
+// their unweighted median is used instead: a single feed depegging then only
+// moves the rate of two feeds halfway, and not at all that of three.
+func feedsConversionRate(rates, volumes []sdk.Dec) (sdk.Dec, error) {
+	if len(rates) < minWeightedConversionFeeds {
+		return median(append([]sdk.Dec{}, rates...)), nil
+	}
+	return WeightedMedian(rates, volumes)
+}
+
+// candleFeedRate returns the TVWAP of the candles of the base from the
+// providers with a USD pair of it, if any, along with their summed volume.
+func candleFeedRate(
+	logger zerolog.Logger,
+	candles provider.AggregatedProviderCandles,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviationThresholds map[string]sdk.Dec,
+	base string,
+) (sdk.Dec, sdk.Dec, error) {
+	// Get valid providers and use them to generate a USD-based price for this asset.
+	validProviders, err := getUSDBasedProviders(base, providerPairs)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+
+	// Find candles which we can use for conversion, and calculate the tvwap
+	// to find the conversion rate.
+	validCandleList := provider.AggregatedProviderCandles{}
+	for providerName, candleSet := range candles {
+		if _, ok := validProviders[providerName]; ok {
+			if candle, ok := candleSet[base]; ok {
+				validCandleList[providerName] = map[string][]types.CandlePrice{base: candle}
+			}
+		}
+	}
+
+	if len(validCandleList) == 0 {
+		return sdk.Dec{}, sdk.Dec{}, fmt.Errorf("there are no valid conversion rates for %s", base)
+	}
+
+	filteredCandles, err := FilterCandleDeviations(
+		logger,
+		validCandleList,
+		deviationThresholds,
+	)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+
+	// TODO: we should revise ComputeTVWAP to avoid return empty slices
+	// Ref: https://github.com/ojo-network/ojo/issues/1261
+	tvwap, err := ComputeTVWAP(filteredCandles)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+
+	volume := sdk.ZeroDec()
+	for _, candleSet := range filteredCandles {
+		for _, candle := range candleSet[base] {
+			volume = volume.Add(candle.Volume)
+		}
+	}
+	return tvwap[base], volume, nil
+}
+
 // ConvertTickersToUSD converts any tickers which are not quoted in USD to USD,
 // using the conversion rates of other tickers. It will also filter out any tickers
 // not within the deviation threshold set by the config.
@@ -140,6 +234,19 @@ func ConvertTickersToUSD(
 	tickers provider.AggregatedProviderPrices,
 	providerPairs map[provider.Name][]types.CurrencyPair,
 	deviationThresholds map[string]sdk.Dec,
+) (provider.AggregatedProviderPrices, error) {
+	return ConvertTickersToUSDWithFeeds(logger, tickers, providerPairs, deviationThresholds, nil)
+}
+
+// ConvertTickersToUSDWithFeeds converts the tickers like ConvertTickersToUSD,
+// using the median of the USD rates of their feeds as the conversion rate of
+// the quotes in conversionFeeds, like ConvertCandlesToUSDWithFeeds.
+func ConvertTickersToUSDWithFeeds(
+	logger zerolog.Logger,
+	tickers provider.AggregatedProviderPrices,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviationThresholds map[string]sdk.Dec,
+	conversionFeeds map[string][]string,
 ) (provider.AggregatedProviderPrices, error) {
 	if len(tickers) == 0 {
 		return tickers, nil
@@ -151,46 +258,20 @@ func ConvertTickersToUSD(
 	for pairProviderName, pairs := range providerPairs {
 		for _, pair := range pairs {
 			if strings.ToUpper(pair.Quote) != config.DenomUSD {
-				// Get valid providers and use them to generate a USD-based price for this asset.
-				validProviders, err := getUSDBasedProviders(pair.Quote, providerPairs)
-				if err != nil {
-					return nil, err
-				}
-
-				// Find valid candles, and then let's re-compute the tvwap.
-				validTickerList := provider.AggregatedProviderPrices{}
-				for providerName, candleSet := range tickers {
-					// Find tickers which we can use for conversion, and calculate the vwap
-					// to find the conversion rate.
-					if _, ok := validProviders[providerName]; ok {
-						for base, ticker := range candleSet {
-							if base == pair.Quote {
-								if _, ok := validTickerList[providerName]; !ok {
-									validTickerList[providerName] = make(map[string]types.TickerPrice)
-								}
-
-								validTickerList[providerName][base] = ticker
-							}
-						}
+				if _, ok := conversionRates[pair.Quote]; !ok {
+					cvRate, err := tickerConversionRate(
+						logger,
+						tickers,
+						providerPairs,
+						deviationThresholds,
+						pair.Quote,
+						conversionFeeds[pair.Quote],
+					)
+					if err != nil {
+						return nil, err
 					}
+					conversionRates[pair.Quote] = cvRate
 				}
-
-				if len(validTickerList) == 0 {
-					return nil, fmt.Errorf("there are no valid conversion rates for %s", pair.Quote)
-				}
-
-				filteredTickers, err := FilterTickerDeviations(
-					logger,
-					validTickerList,
-					deviationThresholds,
-				)
-				if err != nil {
-					return nil, err
-				}
-
-				vwap := ComputeVWAP(filteredTickers)
-
-				conversionRates[pair.Quote] = vwap[pair.Quote]
 				requiredConversions[pairProviderName] = pair
 			}
 		}
@@ -204,7 +285,11 @@ func ConvertTickersToUSD(
 					Price: assetMap[asset].Price.Mul(
 						conversionRates[requiredConversions[providerName].Quote],
 					),
-					Volume: assetMap[asset].Volume,
+					// quote volumes are converted along with the price
+					Volume: assetMap[asset].Volume.Mul(
+						conversionRates[requiredConversions[providerName].Quote],
+					),
+					TimeStamp: assetMap[asset].TimeStamp,
 				}
 			}
 		}
@@ -212,3 +297,86 @@ func ConvertTickersToUSD(
 
 	return tickers, nil
 }
+
+// tickerConversionRate returns the USD rate of the quote, computed from the
+// tickers of the quote itself if it has no feeds.
+func tickerConversionRate(
+	logger zerolog.Logger,
+	tickers provider.AggregatedProviderPrices,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviationThresholds map[string]sdk.Dec,
+	quote string,
+	feeds []string,
+) (sdk.Dec, error) {
+	if len(feeds) == 0 {
+		rate, _, err := tickerFeedRate(logger, tickers, providerPairs, deviationThresholds, quote)
+		return rate, err
+	}
+
+	rates := make([]sdk.Dec, 0, len(feeds))
+	volumes := make([]sdk.Dec, 0, len(feeds))
+	for _, feed := range feeds {
+		rate, volume, err := tickerFeedRate(logger, tickers, providerPairs, deviationThresholds, feed)
+		if err == nil && rate.IsNil() {
+			err = fmt.Errorf("error on computing vwap for quote: %s", feed)
+		}
+		if err != nil {
+			logger.Debug().Err(err).Str("quote", quote).Str("feed", feed).Msg("skipping conversion feed")
+			continue
+		}
+		rates = append(rates, rate)
+		volumes = append(volumes, volume)
+	}
+	if len(rates) == 0 {
+		return sdk.Dec{}, fmt.Errorf("there are no valid conversion rates for %s", quote)
+	}
+	return feedsConversionRate(rates, volumes)
+}
+
+// tickerFeedRate returns the VWAP of the tickers of the base from the
+// providers with a USD pair of it, along with their summed volume.
+func tickerFeedRate(
+	logger zerolog.Logger,
+	tickers provider.AggregatedProviderPrices,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviationThresholds map[string]sdk.Dec,
+	base string,
+) (sdk.Dec, sdk.Dec, error) {
+	// Get valid providers and use them to generate a USD-based price for this asset.
+	validProviders, err := getUSDBasedProviders(base, providerPairs)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+
+	// Find tickers which we can use for conversion, and calculate the vwap
+	// to find the conversion rate.
+	validTickerList := provider.AggregatedProviderPrices{}
+	for providerName, tickerSet := range tickers {
+		if _, ok := validProviders[providerName]; ok {
+			if ticker, ok := tickerSet[base]; ok {
+				validTickerList[providerName] = map[string]types.TickerPrice{base: ticker}
+			}
+		}
+	}
+
+	if len(validTickerList) == 0 {
+		return sdk.Dec{}, sdk.Dec{}, fmt.Errorf("there are no valid conversion rates for %s", base)
+	}
+
+	filteredTickers, err := FilterTickerDeviations(
+		logger,
+		validTickerList,
+		deviationThresholds,
+	)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+
+	volume := sdk.ZeroDec()
+	for _, tickerSet := range filteredTickers {
+		if ticker, ok := tickerSet[base]; ok {
+			volume = volume.Add(ticker.Volume)
+		}
+	}
+	return ComputeVWAP(filteredTickers)[base], volume, nil
+}
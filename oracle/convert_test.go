@@ -175,6 +175,81 @@ func TestConvertCandlesToUSDFiltering(t *testing.T) {
 	)
 }
 
+func TestConvertCandlesToUSDWithFeeds(t *testing.T) {
+	usdcPair := types.CurrencyPair{Base: "USDC", Quote: "USD"}
+	candle := func(price sdk.Dec) []types.CandlePrice {
+		return []types.CandlePrice{{
+			Price:     price,
+			Volume:    usdtVolume,
+			TimeStamp: provider.PastUnixTime(1 * time.Minute),
+		}}
+	}
+
+	newCandles := func() provider.AggregatedProviderCandles {
+		return provider.AggregatedProviderCandles{
+			provider.ProviderBinance: {"ATOM": candle(atomPrice)},
+			// USDT momentarily depegs on kraken
+			provider.ProviderKraken: {"USDT": candle(sdk.MustNewDecFromStr("0.90"))},
+			provider.ProviderGate:   {"USDC": candle(sdk.MustNewDecFromStr("1.001"))},
+			provider.ProviderOkx:    {"USDC": candle(sdk.MustNewDecFromStr("0.999"))},
+		}
+	}
+	providerPairs := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderBinance: {atomPair},
+		provider.ProviderKraken:  {usdtPair},
+		provider.ProviderGate:    {usdcPair},
+		provider.ProviderOkx:     {usdcPair},
+	}
+
+	// the USDC feed has twice the volume of the USDT feed, but the rate of two
+	// feeds is their mean
+	convertedCandles, err := ConvertCandlesToUSDWithFeeds(
+		zerolog.Nop(),
+		newCandles(),
+		providerPairs,
+		make(map[string]sdk.Dec),
+		map[string][]string{"USDT": {"USDT", "USDC"}},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		atomPrice.Mul(sdk.MustNewDecFromStr("0.95")),
+		convertedCandles[provider.ProviderBinance]["ATOM"][0].Price,
+	)
+
+	// without feeds the quote's own rate is used
+	convertedCandles, err = ConvertCandlesToUSDWithFeeds(
+		zerolog.Nop(),
+		newCandles(),
+		providerPairs,
+		make(map[string]sdk.Dec),
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		atomPrice.Mul(sdk.MustNewDecFromStr("0.90")),
+		convertedCandles[provider.ProviderBinance]["ATOM"][0].Price,
+	)
+
+	// feeds without candles are left out
+	candles := newCandles()
+	delete(candles, provider.ProviderKraken)
+	convertedCandles, err = ConvertCandlesToUSDWithFeeds(
+		zerolog.Nop(),
+		candles,
+		providerPairs,
+		make(map[string]sdk.Dec),
+		map[string][]string{"USDT": {"USDT", "USDC"}},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		atomPrice.Mul(sdk.MustNewDecFromStr("1.0")),
+		convertedCandles[provider.ProviderBinance]["ATOM"][0].Price,
+	)
+}
+
 func TestConvertTickersToUSD(t *testing.T) {
 	providerPrices := make(provider.AggregatedProviderPrices, 2)
 
@@ -214,6 +289,70 @@ func TestConvertTickersToUSD(t *testing.T) {
 	)
 }
 
+func TestConvertTickersToUSDWithFeeds(t *testing.T) {
+	usdcPair := types.CurrencyPair{Base: "USDC", Quote: "USD"}
+	providerPrices := provider.AggregatedProviderPrices{
+		provider.ProviderBinance: {"ATOM": {Price: atomPrice, Volume: atomVolume}},
+		provider.ProviderKraken:  {"USDT": {Price: sdk.MustNewDecFromStr("0.90"), Volume: usdtVolume}},
+		provider.ProviderGate:    {"USDC": {Price: sdk.MustNewDecFromStr("0.999"), Volume: usdtVolume.MulInt64(2)}},
+	}
+	providerPairs := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderBinance: {atomPair},
+		provider.ProviderKraken:  {usdtPair},
+		provider.ProviderGate:    {usdcPair},
+	}
+
+	convertedTickers, err := ConvertTickersToUSDWithFeeds(
+		zerolog.Nop(),
+		providerPrices,
+		providerPairs,
+		make(map[string]sdk.Dec),
+		map[string][]string{"USDT": {"USDT", "USDC"}},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		atomPrice.Mul(sdk.MustNewDecFromStr("0.9495")),
+		convertedTickers[provider.ProviderBinance]["ATOM"].Price,
+	)
+}
+
+func TestConvertCandlesToUSDWithFeeds_DominantFeedDepeg(t *testing.T) {
+	usdcPair := types.CurrencyPair{Base: "USDC", Quote: "USD"}
+	daiPair := types.CurrencyPair{Base: "DAI", Quote: "USD"}
+	candle := func(price sdk.Dec, volume sdk.Dec) []types.CandlePrice {
+		return []types.CandlePrice{{
+			Price:     price,
+			Volume:    volume,
+			TimeStamp: provider.PastUnixTime(1 * time.Minute),
+		}}
+	}
+
+	// USDT depegs on the feed with ten times the volume of the others
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderBinance: {"ATOM": candle(atomPrice, atomVolume)},
+		provider.ProviderKraken:  {"USDT": candle(sdk.MustNewDecFromStr("0.90"), usdtVolume.MulInt64(10))},
+		provider.ProviderGate:    {"USDC": candle(sdk.MustNewDecFromStr("1.0"), usdtVolume)},
+		provider.ProviderOkx:     {"DAI": candle(sdk.MustNewDecFromStr("1.0"), usdtVolume)},
+	}
+	providerPairs := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderBinance: {atomPair},
+		provider.ProviderKraken:  {usdtPair},
+		provider.ProviderGate:    {usdcPair},
+		provider.ProviderOkx:     {daiPair},
+	}
+
+	convertedCandles, err := ConvertCandlesToUSDWithFeeds(
+		zerolog.Nop(),
+		candles,
+		providerPairs,
+		make(map[string]sdk.Dec),
+		map[string][]string{"USDT": {"USDT", "USDC", "DAI"}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, atomPrice, convertedCandles[provider.ProviderBinance]["ATOM"][0].Price)
+}
+
 func TestConvertTickersToUSDFiltering(t *testing.T) {
 	providerPrices := make(provider.AggregatedProviderPrices, 2)
 
@@ -0,0 +1,75 @@
+package oracle
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+// DisableProvider stops a configured provider and leaves it out of the prices
+// collected from then on, ex. when it's feeding bad data. The other providers
+// keep their connections. It stays disabled across config reloads until it's
+// enabled again.
+func (o *Oracle) DisableProvider(providerName provider.Name) error {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	if _, ok := o.providerPairs[providerName]; !ok {
+		return fmt.Errorf("provider %s is not configured", providerName)
+	}
+
+	o.stopProvider(providerName)
+	o.disabledProviders[providerName] = struct{}{}
+
+	o.logger.Warn().Str("provider", providerName.String()).Msg("disabled provider")
+	return nil
+}
+
+// EnableProvider enables a provider disabled by DisableProvider. The provider
+// connects again when the prices are next collected.
+func (o *Oracle) EnableProvider(providerName provider.Name) error {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	if _, ok := o.providerPairs[providerName]; !ok {
+		return fmt.Errorf("provider %s is not configured", providerName)
+	}
+
+	delete(o.disabledProviders, providerName)
+
+	o.logger.Info().Str("provider", providerName.String()).Msg("enabled provider")
+	return nil
+}
+
+// GetDisabledProviders returns the providers disabled at runtime, sorted by
+// name.
+func (o *Oracle) GetDisabledProviders() []provider.Name {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	disabled := make([]provider.Name, 0, len(o.disabledProviders))
+	for providerName := range o.disabledProviders {
+		disabled = append(disabled, providerName)
+	}
+	sort.Slice(disabled, func(i, j int) bool {
+		return disabled[i] < disabled[j]
+	})
+	return disabled
+}
+
+// DescribeProviders returns the capabilities of the providers created so far,
+// sorted by name.
+func (o *Oracle) DescribeProviders() []provider.Description {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	descriptions := make([]provider.Description, 0, len(o.priceProviders))
+	for _, priceProvider := range o.priceProviders {
+		descriptions = append(descriptions, priceProvider.Describe())
+	}
+	sort.Slice(descriptions, func(i, j int) bool {
+		return descriptions[i].Name < descriptions[j].Name
+	})
+	return descriptions
+}
@@ -0,0 +1,61 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestOracle_DisableProvider(t *testing.T) {
+	atomPair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	providerPairs := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderBinance: {atomPair},
+	}
+
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		providerPairs,
+		0,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	o.priceProviders[provider.ProviderBinance] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSDT": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+		},
+	}
+
+	require.Error(t, o.DisableProvider(provider.ProviderKraken))
+
+	require.NoError(t, o.DisableProvider(provider.ProviderBinance))
+	require.NotContains(t, o.priceProviders, provider.ProviderBinance)
+	require.Equal(t, []provider.Name{provider.ProviderBinance}, o.GetDisabledProviders())
+
+	// the disabled provider isn't created again to collect prices
+	require.NoError(t, o.SetPrices(context.Background()))
+	require.Empty(t, o.GetPrices())
+	require.NotContains(t, o.priceProviders, provider.ProviderBinance)
+
+	// nor by reloading the config
+	require.NoError(t, o.Reload(
+		context.Background(),
+		providerPairs,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+		make(map[string]config.Aggregation),
+		nil,
+	))
+	require.NotContains(t, o.priceProviders, provider.ProviderBinance)
+
+	require.NoError(t, o.EnableProvider(provider.ProviderBinance))
+	require.Empty(t, o.GetDisabledProviders())
+}
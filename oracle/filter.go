@@ -2,6 +2,7 @@ package oracle
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle/provider"
 	"github.com/ojo-network/price-feeder/oracle/types"
 	"github.com/rs/zerolog"
@@ -39,6 +40,7 @@ func FilterTickerDeviations(
 	if err != nil {
 		return nil, err
 	}
+	telemetryDeviations(provider.MessageTypeTicker, priceMap, deviations, means)
 
 	// We accept any prices that are within (2 * T)𝜎, or for which we couldn't get 𝜎.
 	// T is defined as the deviation threshold, either set by the config
@@ -113,6 +115,7 @@ func FilterCandleDeviations(
 	if err != nil {
 		return nil, err
 	}
+	telemetryDeviations(provider.MessageTypeCandle, tvwaps, deviations, means)
 
 	// We accept any prices that are within (2 * T)𝜎, or for which we couldn't get 𝜎.
 	// T is defined as the deviation threshold, either set by the config
@@ -145,7 +148,251 @@ func FilterCandleDeviations(
 	return filteredCandles, nil
 }
 
+// telemetryDeviations reports the standard deviation of the provider prices of
+// each asset the deviation filter computed one for, and the largest distance
+// of a provider price from their mean in standard deviations, which is
+// comparable to the asset's deviation threshold.
+func telemetryDeviations(
+	mt provider.MessageType,
+	prices map[provider.Name]map[string]sdk.Dec,
+	deviations map[string]sdk.Dec,
+	means map[string]sdk.Dec,
+) {
+	for base, sigma := range deviationSigmas(prices, deviations, means) {
+		provider.TelemetryPriceDeviation(base, mt, deviations[base], sigma)
+	}
+}
+
+// deviationSigmas returns the largest distance of a provider price of each
+// asset from the mean, in standard deviations. Assets without a standard
+// deviation are left out, and the distance is zero when an asset's prices are
+// all equal.
+func deviationSigmas(
+	prices map[provider.Name]map[string]sdk.Dec,
+	deviations map[string]sdk.Dec,
+	means map[string]sdk.Dec,
+) map[string]sdk.Dec {
+	sigmas := make(map[string]sdk.Dec, len(deviations))
+	for _, providerPrices := range prices {
+		for base, price := range providerPrices {
+			deviation, ok := deviations[base]
+			if !ok {
+				continue
+			}
+			sigma := sdk.ZeroDec()
+			if deviation.IsPositive() {
+				sigma = price.Sub(means[base]).Abs().Quo(deviation)
+			}
+			if largest, ok := sigmas[base]; !ok || sigma.GT(largest) {
+				sigmas[base] = sigma
+			}
+		}
+	}
+	return sigmas
+}
+
+// FilterPriceBounds removes the tickers and candles of a provider whose price
+// is outside of the configured bounds of their currency pair. The provided
+// prices and candles are keyed by currency pair symbol and are not modified.
+func FilterPriceBounds(
+	logger zerolog.Logger,
+	providerName provider.Name,
+	prices map[string]types.TickerPrice,
+	candles map[string][]types.CandlePrice,
+	priceBounds map[string]config.PriceBounds,
+) (map[string]types.TickerPrice, map[string][]types.CandlePrice) {
+	if len(priceBounds) == 0 {
+		return prices, candles
+	}
+
+	filteredPrices := make(map[string]types.TickerPrice, len(prices))
+	for symbol, tp := range prices {
+		if bounds, ok := priceBounds[symbol]; ok && !bounds.Contains(tp.Price) {
+			provider.TelemetryOutOfBounds(providerName, provider.MessageTypeTicker)
+			logger.Warn().
+				Str("pair", symbol).
+				Str("provider", string(providerName)).
+				Str("price", tp.Price.String()).
+				Msg("provider ticker price out of bounds")
+			continue
+		}
+		filteredPrices[symbol] = tp
+	}
+
+	filteredCandles := make(map[string][]types.CandlePrice, len(candles))
+	for symbol, cp := range candles {
+		bounds, ok := priceBounds[symbol]
+		if !ok {
+			filteredCandles[symbol] = cp
+			continue
+		}
+
+		inBounds := make([]types.CandlePrice, 0, len(cp))
+		for _, candle := range cp {
+			if bounds.Contains(candle.Price) {
+				inBounds = append(inBounds, candle)
+			}
+		}
+		if rejected := len(cp) - len(inBounds); rejected > 0 {
+			provider.TelemetryOutOfBounds(providerName, provider.MessageTypeCandle)
+			logger.Warn().
+				Str("pair", symbol).
+				Str("provider", string(providerName)).
+				Int("candles", rejected).
+				Msg("provider candle prices out of bounds")
+		}
+		if len(inBounds) > 0 {
+			filteredCandles[symbol] = inBounds
+		}
+	}
+
+	return filteredPrices, filteredCandles
+}
+
+// FilterNonPositivePrices removes the aggregated prices which are not
+// positive, ex. when the only provider left after filtering returned a zero
+// price, so that the asset is reported as missing instead of being submitted.
+// The provider prices the removed price was aggregated from are logged.
+func FilterNonPositivePrices(
+	logger zerolog.Logger,
+	prices map[string]sdk.Dec,
+	pricesByProvider map[provider.Name]map[string]sdk.Dec,
+) map[string]sdk.Dec {
+	filteredPrices := make(map[string]sdk.Dec, len(prices))
+	for base, price := range prices {
+		if !price.IsNil() && price.IsPositive() {
+			filteredPrices[base] = price
+			continue
+		}
+
+		providerValues := make(map[string]string)
+		for providerName, providerPrices := range pricesByProvider {
+			if p, ok := providerPrices[base]; ok && !p.IsNil() {
+				providerValues[string(providerName)] = p.String()
+			}
+		}
+
+		priceStr := "nil"
+		if !price.IsNil() {
+			priceStr = price.String()
+		}
+		logger.Error().
+			Str("asset", base).
+			Str("price", priceStr).
+			Interface("provider_prices", providerValues).
+			Msg("aggregated price is not positive, skipping asset")
+	}
+	return filteredPrices
+}
+
+// FilterMinWeightedProviders removes the volume weighted prices of the bases
+// in minProviders computed from fewer providers than their minimum, so that a
+// single high volume venue can't set a price the other providers of the asset
+// disagree with. The asset is then reported as missing, like an asset without
+// enough providers. The provided pricesByProvider argument reflects the
+// deviation filtered provider prices the prices were computed from, keyed by
+// provider or quote route, and the routes of a provider count once.
+func FilterMinWeightedProviders(
+	logger zerolog.Logger,
+	prices map[string]sdk.Dec,
+	pricesByProvider map[provider.Name]map[string]sdk.Dec,
+	minProviders map[string]int,
+) map[string]sdk.Dec {
+	// the quote routes of a provider count as a single provider
+	baseProviders := make(map[string]map[provider.Name]struct{})
+	for routeName, providerPrices := range pricesByProvider {
+		for base := range providerPrices {
+			if _, ok := baseProviders[base]; !ok {
+				baseProviders[base] = make(map[provider.Name]struct{})
+			}
+			baseProviders[base][quoteRouteProvider(routeName)] = struct{}{}
+		}
+	}
+	providerCounts := make(map[string]int, len(baseProviders))
+	for base, providers := range baseProviders {
+		providerCounts[base] = len(providers)
+	}
+
+	filteredPrices := make(map[string]sdk.Dec, len(prices))
+	for base, price := range prices {
+		if minimum, ok := minProviders[base]; ok && providerCounts[base] < minimum {
+			logger.Warn().
+				Str("asset", base).
+				Int("providers", providerCounts[base]).
+				Int("min_weighted_providers", minimum).
+				Msg("too few providers within the deviation band for the weighted price, skipping asset")
+			continue
+		}
+		filteredPrices[base] = price
+	}
+	return filteredPrices
+}
+
 func isBetween(p, mean, margin sdk.Dec) bool {
 	return p.GTE(mean.Sub(margin)) &&
 		p.LTE(mean.Add(margin))
 }
+
+// FilterDerivedPrices removes the tickers and candles of the derived providers
+// for the assets no other provider reported a ticker or candle of, so that a
+// derived provider is never the only source of a price.
+func FilterDerivedPrices(
+	logger zerolog.Logger,
+	prices provider.AggregatedProviderPrices,
+	candles provider.AggregatedProviderCandles,
+) (provider.AggregatedProviderPrices, provider.AggregatedProviderCandles) {
+	sourced := make(map[string]struct{})
+	for providerName, providerPrices := range prices {
+		if isDerivedRoute(providerName) {
+			continue
+		}
+		for base := range providerPrices {
+			sourced[base] = struct{}{}
+		}
+	}
+	for providerName, providerCandles := range candles {
+		if isDerivedRoute(providerName) {
+			continue
+		}
+		for base, cp := range providerCandles {
+			if len(cp) > 0 {
+				sourced[base] = struct{}{}
+			}
+		}
+	}
+
+	filteredPrices := make(provider.AggregatedProviderPrices, len(prices))
+	for providerName, providerPrices := range prices {
+		if !isDerivedRoute(providerName) {
+			filteredPrices[providerName] = providerPrices
+			continue
+		}
+		filteredPrices[providerName] = make(map[string]types.TickerPrice, len(providerPrices))
+		for base, tp := range providerPrices {
+			if _, ok := sourced[base]; !ok {
+				logger.Warn().
+					Str("asset", base).
+					Str("provider", string(providerName)).
+					Msg("skipping derived price without another source")
+				continue
+			}
+			filteredPrices[providerName][base] = tp
+		}
+	}
+
+	filteredCandles := make(provider.AggregatedProviderCandles, len(candles))
+	for providerName, providerCandles := range candles {
+		if !isDerivedRoute(providerName) {
+			filteredCandles[providerName] = providerCandles
+			continue
+		}
+		filteredCandles[providerName] = make(map[string][]types.CandlePrice, len(providerCandles))
+		for base, cp := range providerCandles {
+			if _, ok := sourced[base]; ok {
+				filteredCandles[providerName][base] = cp
+			}
+		}
+	}
+
+	return filteredPrices, filteredCandles
+}
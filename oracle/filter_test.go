@@ -5,6 +5,7 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle/provider"
 	"github.com/ojo-network/price-feeder/oracle/types"
 	"github.com/rs/zerolog"
@@ -126,3 +127,176 @@ func TestSuccessFilterTickerDeviations(t *testing.T) {
 	require.NoError(t, err, "It should successfully not filter out coinbase")
 	require.True(t, ok, "The filtered candle deviation price of coinbase should remain")
 }
+
+func TestFilterPriceBounds(t *testing.T) {
+	now := provider.PastUnixTime(0)
+	prices := map[string]types.TickerPrice{
+		"USDCUSD": {Price: sdk.MustNewDecFromStr("0.5"), Volume: sdk.OneDec()},
+		"ATOMUSD": {Price: sdk.MustNewDecFromStr("12"), Volume: sdk.OneDec()},
+	}
+	candles := map[string][]types.CandlePrice{
+		"USDCUSD": {
+			{Price: sdk.MustNewDecFromStr("0.5"), Volume: sdk.OneDec(), TimeStamp: now},
+			{Price: sdk.MustNewDecFromStr("1.01"), Volume: sdk.OneDec(), TimeStamp: now},
+		},
+		"ATOMUSD": {
+			{Price: sdk.MustNewDecFromStr("12"), Volume: sdk.OneDec(), TimeStamp: now},
+		},
+	}
+	priceBounds := map[string]config.PriceBounds{
+		"USDCUSD": {Min: sdk.MustNewDecFromStr("0.9"), Max: sdk.MustNewDecFromStr("1.1")},
+	}
+
+	filteredPrices, filteredCandles := FilterPriceBounds(
+		zerolog.Nop(),
+		provider.ProviderKraken,
+		prices,
+		candles,
+		priceBounds,
+	)
+	require.NotContains(t, filteredPrices, "USDCUSD")
+	require.Equal(t, prices["ATOMUSD"], filteredPrices["ATOMUSD"])
+	require.Len(t, filteredCandles["USDCUSD"], 1)
+	require.Equal(t, sdk.MustNewDecFromStr("1.01"), filteredCandles["USDCUSD"][0].Price)
+	require.Equal(t, candles["ATOMUSD"], filteredCandles["ATOMUSD"])
+	require.Len(t, candles["USDCUSD"], 2)
+
+	// pairs whose candles are all out of bounds are removed
+	_, filteredCandles = FilterPriceBounds(
+		zerolog.Nop(),
+		provider.ProviderKraken,
+		prices,
+		candles,
+		map[string]config.PriceBounds{"ATOMUSD": {Max: sdk.MustNewDecFromStr("10")}},
+	)
+	require.NotContains(t, filteredCandles, "ATOMUSD")
+}
+
+func TestFilterNonPositivePrices(t *testing.T) {
+	prices := map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("12"),
+		"OJO":  sdk.ZeroDec(),
+		"UMEE": sdk.MustNewDecFromStr("-1"),
+		"JUNO": {},
+	}
+	pricesByProvider := map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderBinance: {
+			"ATOM": sdk.MustNewDecFromStr("12"),
+			"OJO":  sdk.ZeroDec(),
+		},
+	}
+
+	filtered := FilterNonPositivePrices(zerolog.Nop(), prices, pricesByProvider)
+	require.Equal(t, map[string]sdk.Dec{"ATOM": sdk.MustNewDecFromStr("12")}, filtered)
+}
+
+func TestDeviationSigmas(t *testing.T) {
+	prices := map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderBinance: {
+			"ATOM": sdk.MustNewDecFromStr("10"),
+			"OJO":  sdk.MustNewDecFromStr("0.5"),
+			"UMEE": sdk.MustNewDecFromStr("0.01"),
+		},
+		provider.ProviderKraken: {
+			"ATOM": sdk.MustNewDecFromStr("11"),
+			"OJO":  sdk.MustNewDecFromStr("0.5"),
+		},
+		provider.ProviderCoinbase: {
+			"ATOM": sdk.MustNewDecFromStr("12"),
+			"OJO":  sdk.MustNewDecFromStr("0.5"),
+		},
+		provider.ProviderOkx: {
+			"ATOM": sdk.MustNewDecFromStr("15"),
+			"OJO":  sdk.MustNewDecFromStr("0.5"),
+		},
+	}
+	deviations, means, err := StandardDeviation(prices)
+	require.NoError(t, err)
+
+	// ATOM has a mean of 12 and a standard deviation of sqrt(3.5), which okx
+	// is the furthest from; UMEE has too few prices for a standard deviation
+	sigmas := deviationSigmas(prices, deviations, means)
+	require.Len(t, sigmas, 2)
+	atomSigma, err := sigmas["ATOM"].Float64()
+	require.NoError(t, err)
+	require.InDelta(t, 1.6036, atomSigma, 0.0001)
+	require.True(t, sigmas["OJO"].IsZero())
+}
+
+func TestFilterMinWeightedProviders(t *testing.T) {
+	prices := map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("12"),
+		"OJO":  sdk.MustNewDecFromStr("0.5"),
+		"UMEE": sdk.MustNewDecFromStr("0.01"),
+	}
+	pricesByProvider := map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderBinance: {
+			"ATOM": sdk.MustNewDecFromStr("12"),
+			"OJO":  sdk.MustNewDecFromStr("0.5"),
+			"UMEE": sdk.MustNewDecFromStr("0.01"),
+		},
+		provider.ProviderKraken: {
+			"ATOM": sdk.MustNewDecFromStr("12.1"),
+		},
+	}
+
+	// OJO only has one provider left within the deviation band, and UMEE
+	// has no minimum
+	filtered := FilterMinWeightedProviders(
+		zerolog.Nop(),
+		prices,
+		pricesByProvider,
+		map[string]int{"ATOM": 2, "OJO": 2},
+	)
+	require.Equal(t, map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("12"),
+		"UMEE": sdk.MustNewDecFromStr("0.01"),
+	}, filtered)
+}
+
+func TestFilterMinWeightedProviders_QuoteRoutes(t *testing.T) {
+	prices := map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("12"),
+	}
+	// the routes of kraken, kept apart with distinct quote routes, count as
+	// a single provider
+	pricesByProvider := map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderKraken: {
+			"ATOM": sdk.MustNewDecFromStr("12"),
+		},
+		"kraken:USDT": {
+			"ATOM": sdk.MustNewDecFromStr("12.1"),
+		},
+	}
+
+	filtered := FilterMinWeightedProviders(
+		zerolog.Nop(),
+		prices,
+		pricesByProvider,
+		map[string]int{"ATOM": 2},
+	)
+	require.Empty(t, filtered)
+}
+
+func TestFilterDerivedPrices(t *testing.T) {
+	atom := types.TickerPrice{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")}
+	ojo := types.TickerPrice{Price: sdk.MustNewDecFromStr("1"), Volume: sdk.ZeroDec()}
+	candle := types.CandlePrice{Price: sdk.MustNewDecFromStr("3"), Volume: sdk.ZeroDec()}
+
+	prices := provider.AggregatedProviderPrices{
+		provider.ProviderBinance:     {"ATOM": atom},
+		provider.ProviderPriceFeeder: {"ATOM": atom, "OJO": ojo, "UMEE": ojo},
+	}
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderKraken:      {"UMEE": {candle}},
+		provider.ProviderPriceFeeder: {"ATOM": {candle}, "OJO": {candle}},
+	}
+
+	filteredPrices, filteredCandles := FilterDerivedPrices(zerolog.Nop(), prices, candles)
+
+	// OJO is only priced by the derived provider
+	require.Equal(t, prices[provider.ProviderBinance], filteredPrices[provider.ProviderBinance])
+	require.Equal(t, map[string]types.TickerPrice{"ATOM": atom, "UMEE": ojo}, filteredPrices[provider.ProviderPriceFeeder])
+	require.Equal(t, candles[provider.ProviderKraken], filteredCandles[provider.ProviderKraken])
+	require.Equal(t, map[string][]types.CandlePrice{"ATOM": {candle}}, filteredCandles[provider.ProviderPriceFeeder])
+}
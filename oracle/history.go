@@ -0,0 +1,102 @@
+package oracle
+
+import (
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type (
+	// PriceSample defines an aggregated price and the time it was computed at.
+	PriceSample struct {
+		Price     sdk.Dec   `json:"price"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+
+	// PriceHistory retains the latest aggregated prices of each asset, up to a
+	// fixed amount of samples per asset, overwriting the oldest ones.
+	PriceHistory struct {
+		size    int
+		samples map[string]*priceRing
+		mx      sync.RWMutex
+	}
+
+	// priceRing is a fixed size ring buffer of price samples, where next is
+	// the index of the oldest sample once the ring is full.
+	priceRing struct {
+		samples []PriceSample
+		next    int
+	}
+)
+
+// NewPriceHistory creates a PriceHistory retaining up to size samples per
+// asset.
+func NewPriceHistory(size int) *PriceHistory {
+	return &PriceHistory{
+		size:    size,
+		samples: make(map[string]*priceRing),
+	}
+}
+
+// Record adds the aggregated prices computed at the given time to the history
+// of their assets.
+func (h *PriceHistory) Record(timestamp time.Time, prices map[string]sdk.Dec) {
+	if h.size <= 0 {
+		return
+	}
+
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	for base, price := range prices {
+		ring, ok := h.samples[base]
+		if !ok {
+			ring = &priceRing{samples: make([]PriceSample, 0, h.size)}
+			h.samples[base] = ring
+		}
+		ring.add(PriceSample{Price: price, Timestamp: timestamp}, h.size)
+	}
+}
+
+// Get returns the retained samples of the asset, oldest first.
+func (h *PriceHistory) Get(base string) []PriceSample {
+	h.mx.RLock()
+	defer h.mx.RUnlock()
+
+	ring, ok := h.samples[base]
+	if !ok {
+		return []PriceSample{}
+	}
+
+	samples := make([]PriceSample, 0, len(ring.samples))
+	samples = append(samples, ring.samples[ring.next:]...)
+	return append(samples, ring.samples[:ring.next]...)
+}
+
+// add appends the sample until the ring holds size samples, then overwrites
+// the oldest one.
+func (r *priceRing) add(sample PriceSample, size int) {
+	if len(r.samples) < size {
+		r.samples = append(r.samples, sample)
+		return
+	}
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % size
+}
+
+// SetPriceHistorySize sets the amount of aggregated prices retained per asset,
+// which aren't retained if it isn't positive, the default. It must be called
+// before Start.
+func (o *Oracle) SetPriceHistorySize(size int) {
+	o.priceHistory = NewPriceHistory(size)
+}
+
+// GetPriceHistory returns the retained aggregated prices of the asset, oldest
+// first.
+func (o *Oracle) GetPriceHistory(base string) []PriceSample {
+	if o.priceHistory == nil {
+		return []PriceSample{}
+	}
+	return o.priceHistory.Get(base)
+}
@@ -0,0 +1,36 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceHistory(t *testing.T) {
+	history := NewPriceHistory(3)
+	start := time.Unix(1700000000, 0)
+
+	for i := int64(0); i < 5; i++ {
+		history.Record(start.Add(time.Duration(i)*time.Minute), map[string]sdk.Dec{
+			"ATOM": sdk.NewDec(10 + i),
+		})
+	}
+	history.Record(start.Add(5*time.Minute), map[string]sdk.Dec{"OJO": sdk.NewDec(1)})
+
+	// only the latest samples are retained, oldest first
+	samples := history.Get("ATOM")
+	require.Len(t, samples, 3)
+	for i, sample := range samples {
+		require.Equal(t, sdk.NewDec(12+int64(i)), sample.Price)
+		require.Equal(t, start.Add(time.Duration(2+i)*time.Minute), sample.Timestamp)
+	}
+	require.Len(t, history.Get("OJO"), 1)
+	require.Empty(t, history.Get("FOO"))
+
+	// a history without samples retains nothing
+	history = NewPriceHistory(0)
+	history.Record(start, map[string]sdk.Dec{"ATOM": sdk.NewDec(10)})
+	require.Empty(t, history.Get("ATOM"))
+}
@@ -0,0 +1,62 @@
+package oracle
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// providerLimiter bounds the amount of providers being created at once, since
+// creating most providers fetches their available pairs from their REST API.
+// A nil providerLimiter doesn't bound them.
+type providerLimiter chan struct{}
+
+// newProviderLimiter returns a providerLimiter letting up to limit providers
+// be created at once, or nil if limit isn't positive.
+func newProviderLimiter(limit int) providerLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return make(providerLimiter, limit)
+}
+
+// acquire blocks until a provider can be created or the context is done.
+func (l providerLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release lets another provider be created.
+func (l providerLimiter) release() {
+	if l != nil {
+		<-l
+	}
+}
+
+// newPriceProvider creates a provider like newPriceProvider once the limiter
+// lets it be created.
+func (l providerLimiter) newPriceProvider(
+	ctx context.Context,
+	providerName provider.Name,
+	logger zerolog.Logger,
+	endpoint provider.Endpoint,
+	aliases provider.SymbolAliases,
+	providerPairs ...types.CurrencyPair,
+) (provider.Provider, context.CancelFunc, error) {
+	if err := l.acquire(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer l.release()
+
+	return newPriceProvider(ctx, providerName, logger, endpoint, aliases, providerPairs...)
+}
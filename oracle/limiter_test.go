@@ -0,0 +1,28 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderLimiter(t *testing.T) {
+	// an unbounded limiter never blocks
+	var unbounded providerLimiter
+	require.NoError(t, unbounded.acquire(context.Background()))
+	unbounded.release()
+	require.Nil(t, newProviderLimiter(0))
+
+	limiter := newProviderLimiter(2)
+	require.NoError(t, limiter.acquire(context.Background()))
+	require.NoError(t, limiter.acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, limiter.acquire(ctx), context.DeadlineExceeded)
+
+	limiter.release()
+	require.NoError(t, limiter.acquire(context.Background()))
+}
@@ -0,0 +1,52 @@
+package oracle
+
+import (
+	"time"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+// SetMaintenanceSchedules sets the scheduled maintenance windows of the
+// providers, during which their prices are only observed.
+func (o *Oracle) SetMaintenanceSchedules(schedules map[provider.Name][]config.MaintenanceSchedule) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.maintenanceSchedules = schedules
+}
+
+// updateMaintenance sets the providers in one of their maintenance windows at
+// the given time, logging the providers entering and leaving maintenance. A
+// provider in maintenance stays connected, but its pairs are observe-only
+// until its window ends. It must be called with the configMtx held.
+func (o *Oracle) updateMaintenance(now time.Time) {
+	inMaintenance := make(map[provider.Name]struct{})
+	for providerName, schedules := range o.maintenanceSchedules {
+		for _, schedule := range schedules {
+			if schedule.Active(now) {
+				inMaintenance[providerName] = struct{}{}
+				break
+			}
+		}
+	}
+
+	for providerName := range inMaintenance {
+		if _, ok := o.inMaintenance[providerName]; !ok {
+			o.logger.Info().Str("provider", providerName.String()).Msg("provider entered its maintenance window, observing its prices only")
+		}
+	}
+	for providerName := range o.inMaintenance {
+		if _, ok := inMaintenance[providerName]; !ok {
+			o.logger.Info().Str("provider", providerName.String()).Msg("provider left its maintenance window")
+		}
+	}
+	o.inMaintenance = inMaintenance
+}
+
+// isInMaintenance returns true if the provider is in one of its maintenance
+// windows.
+func (o *Oracle) isInMaintenance(providerName provider.Name) bool {
+	_, ok := o.inMaintenance[providerName]
+	return ok
+}
@@ -0,0 +1,70 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestOracle_MaintenanceWindows(t *testing.T) {
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderKraken:   {atomUSD},
+			provider.ProviderCoinbase: {atomUSD},
+			provider.ProviderMexc:     {atomUSD},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+
+	volume := sdk.MustNewDecFromStr("100")
+	for providerName, price := range map[provider.Name]string{
+		provider.ProviderKraken:   "10",
+		provider.ProviderCoinbase: "10.4",
+		provider.ProviderMexc:     "10.1",
+	} {
+		o.priceProviders[providerName] = mockProvider{
+			prices: map[string]types.TickerPrice{
+				"ATOMUSD": {Price: sdk.MustNewDecFromStr(price), Volume: volume},
+			},
+		}
+	}
+
+	now := time.Now()
+	o.SetMaintenanceSchedules(map[provider.Name][]config.MaintenanceSchedule{
+		provider.ProviderMexc: {{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}},
+	})
+	require.NoError(t, o.SetPrices(context.Background()))
+
+	// the provider in maintenance doesn't affect the voted price
+	require.Contains(t, o.GetPrices(), "ATOM")
+	require.NotContains(t, o.GetTvwapPrices(), provider.ProviderMexc)
+
+	// but its price is still observed
+	require.Equal(t,
+		PricesByProvider{provider.ProviderMexc: {"ATOM": sdk.MustNewDecFromStr("10.1")}},
+		o.GetObservedPrices(),
+	)
+
+	// and it contributes again once its window ended
+	o.SetMaintenanceSchedules(map[provider.Name][]config.MaintenanceSchedule{
+		provider.ProviderMexc: {{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}},
+	})
+	require.NoError(t, o.SetPrices(context.Background()))
+	require.Contains(t, o.GetTvwapPrices(), provider.ProviderMexc)
+	require.Empty(t, o.GetObservedPrices())
+}
@@ -0,0 +1,104 @@
+package oracle
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// SetObservedPairs sets the currency pairs of the observe-only providers,
+// which are subscribed to and reported but left out of the voted prices,
+// keyed by provider name and currency pair symbol.
+func (o *Oracle) SetObservedPairs(observedPairs map[provider.Name]map[string]struct{}) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.observedPairs = observedPairs
+}
+
+// GetObservedPrices returns a copy of the USD prices of the observe-only
+// providers, keyed by provider name and base.
+func (o *Oracle) GetObservedPrices() PricesByProvider {
+	return o.observedPrices.GetPricesClone()
+}
+
+// isObserved returns true if the provider is observe-only for the pair, or in
+// one of its maintenance windows.
+func (o *Oracle) isObserved(providerName provider.Name, pair types.CurrencyPair) bool {
+	if o.isInMaintenance(providerName) {
+		return true
+	}
+	_, ok := o.observedPairs[providerName][pair.String()]
+	return ok
+}
+
+// votingPairs returns the pairs of each provider which contribute to the
+// voted prices, leaving out the observe-only ones.
+func (o *Oracle) votingPairs() map[provider.Name][]types.CurrencyPair {
+	if len(o.observedPairs) == 0 && len(o.inMaintenance) == 0 {
+		return o.providerPairs
+	}
+
+	votingPairs := make(map[provider.Name][]types.CurrencyPair, len(o.providerPairs))
+	for providerName, pairs := range o.providerPairs {
+		for _, pair := range pairs {
+			if !o.isObserved(providerName, pair) {
+				votingPairs[providerName] = append(votingPairs[providerName], pair)
+			}
+		}
+	}
+	return votingPairs
+}
+
+// setObservedPrices computes the price of each observe-only provider pair from
+// its candles, or from its ticker if it has no candles, and reports it. Prices
+// quoted in another currency than USD are converted with the computed price
+// of their quote, and left out if there is none.
+func (o *Oracle) setObservedPrices(
+	prices provider.AggregatedProviderPrices,
+	candles provider.AggregatedProviderCandles,
+	computedPrices map[string]sdk.Dec,
+) {
+	tvwaps, err := ComputeTvwapsByProvider(candles)
+	if err != nil {
+		o.logger.Warn().Err(err).Msg("failed to compute the prices of observe-only providers")
+		tvwaps = make(map[provider.Name]map[string]sdk.Dec)
+	}
+	vwaps := ComputeVwapsByProvider(prices)
+
+	observedPrices := make(PricesByProvider)
+	for providerName, pairs := range o.providerPairs {
+		for _, pair := range pairs {
+			if !o.isObserved(providerName, pair) {
+				continue
+			}
+
+			price, ok := tvwaps[providerName][pair.Base]
+			if !ok {
+				price, ok = vwaps[providerName][pair.Base]
+			}
+			if !ok {
+				continue
+			}
+			if strings.ToUpper(pair.Quote) != config.DenomUSD {
+				rate, ok := computedPrices[pair.Quote]
+				if !ok {
+					continue
+				}
+				price = price.Mul(rate)
+			}
+
+			if _, ok := observedPrices[providerName]; !ok {
+				observedPrices[providerName] = make(map[string]sdk.Dec)
+			}
+			observedPrices[providerName][pair.Base] = price
+			provider.TelemetryObservedPrice(providerName, pair.Base, price)
+		}
+	}
+
+	o.observedPrices.SetPrices(observedPrices)
+}
@@ -0,0 +1,67 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestOracle_ObservedPairs(t *testing.T) {
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	usdtUSD := types.CurrencyPair{Base: "USDT", Quote: "USD"}
+
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderKraken:   {atomUSD, usdtUSD},
+			provider.ProviderCoinbase: {atomUSD},
+			provider.ProviderMexc:     {atomUSDT},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	o.SetObservedPairs(map[provider.Name]map[string]struct{}{
+		provider.ProviderMexc: {"ATOMUSDT": {}},
+	})
+
+	volume := sdk.MustNewDecFromStr("100")
+	o.priceProviders[provider.ProviderKraken] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: volume},
+			"USDTUSD": {Price: sdk.MustNewDecFromStr("2"), Volume: volume},
+		},
+	}
+	o.priceProviders[provider.ProviderCoinbase] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: volume},
+		},
+	}
+	o.priceProviders[provider.ProviderMexc] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSDT": {Price: sdk.MustNewDecFromStr("50"), Volume: sdk.MustNewDecFromStr("1000000")},
+		},
+	}
+
+	require.NoError(t, o.SetPrices(context.Background()))
+
+	// the observe-only provider doesn't affect the voted price
+	require.Equal(t, sdk.MustNewDecFromStr("10"), o.GetPrices()["ATOM"])
+	require.NotContains(t, o.GetTvwapPrices(), provider.ProviderMexc)
+
+	// but its price is reported, converted to USD
+	require.Equal(t,
+		PricesByProvider{provider.ProviderMexc: {"ATOM": sdk.MustNewDecFromStr("100")}},
+		o.GetObservedPrices(),
+	)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"reflect"
 	"sort"
 	"strings"
 	"sync"
@@ -13,24 +14,30 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/rs/zerolog"
-	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	oracletypes "github.com/ojo-network/ojo/x/oracle/types"
+	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle/client"
 	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/publisher"
 	"github.com/ojo-network/price-feeder/oracle/types"
 	pfsync "github.com/ojo-network/price-feeder/pkg/sync"
 )
 
-// We define tickerSleep as the minimum timeout between each oracle loop. We
-// define this value empirically based on enough time to collect exchange rates,
-// and broadcast pre-vote and vote transactions such that they're committed in
-// at least one block during each voting period.
+// We define tickerSleep as the default minimum timeout between each price
+// collection and each oracle loop. We define this value empirically based on
+// enough time to collect exchange rates, and broadcast pre-vote and vote
+// transactions such that they're committed in at least one block during each
+// voting period.
 const (
 	tickerSleep = 1000 * time.Millisecond
+
+	// minPriceMaxAge is the minimum age collected prices may have when they
+	// are pre-voted, see priceMaxAge.
+	minPriceMaxAge = 30 * time.Second
 )
 
 // PreviousPrevote defines a structure for defining the previous prevote
@@ -56,22 +63,88 @@ type Oracle struct {
 	logger zerolog.Logger
 	closer *pfsync.Closer
 
-	providerTimeout    time.Duration
-	providerPairs      map[provider.Name][]types.CurrencyPair
-	previousPrevote    *PreviousPrevote
-	previousVotePeriod float64
-	priceProviders     map[provider.Name]provider.Provider
-	oracleClient       client.OracleClient
-	deviations         map[string]sdk.Dec
-	endpoints          map[provider.Name]provider.Endpoint
-	paramCache         ParamCache
+	providerTimeout     time.Duration
+	providerConcurrency int
+	providerLimiter     providerLimiter
+	priceInterval       time.Duration
+	voteInterval        time.Duration
+	previousPrevote     *PreviousPrevote
+	previousVotePeriod  float64
+	oracleClient        client.OracleClient
+	paramCache          ParamCache
+
+	// configMtx guards the configuration and providers which can be
+	// replaced at runtime by Reload
+	configMtx       sync.Mutex
+	providerPairs   map[provider.Name][]types.CurrencyPair
+	priceProviders  map[provider.Name]provider.Provider
+	providerCancels map[provider.Name]context.CancelFunc
+	deviations      map[string]sdk.Dec
+	aggregations    map[string]config.Aggregation
+	priceBounds     map[provider.Name]map[string]config.PriceBounds
+	midPricePairs   map[provider.Name]map[string]struct{}
+	pairSources     map[provider.Name]map[string]string
+	scaleFactors    map[provider.Name]map[string]sdk.Dec
+	duplicateQuotes string
+	observedPairs   map[provider.Name]map[string]struct{}
+
+	// maintenanceSchedules are the maintenance windows of the providers, and
+	// inMaintenance the providers in one of them as of the last price
+	// collection, whose pairs are observe-only meanwhile
+	maintenanceSchedules map[provider.Name][]config.MaintenanceSchedule
+	inMaintenance        map[provider.Name]struct{}
+
+	rounding        config.Rounding
+	symbolAliases   map[provider.Name]provider.SymbolAliases
+	endpoints       map[provider.Name]provider.Endpoint
+	conversionFeeds map[string][]string
+
+	// apiKeyFiles are the files the API keys of the providers' endpoints are
+	// read from, which are watched for rotated keys
+	apiKeyFiles map[provider.Name]string
+
+	// abstainThreshold is the fraction of the expected assets that must have
+	// a price for a pre-vote to be submitted
+	abstainThreshold sdk.Dec
+
+	// strict abstains from voting whenever a provider or asset failed to
+	// report a price during the last price collection
+	strict bool
+
+	// readinessGate, when set, holds the first pre-vote until every asset
+	// has a price from enough providers, or its maximum wait elapsed since
+	// readinessStart. ready is set once the gate opens.
+	readinessGate  *config.ReadinessGate
+	readinessStart time.Time
+	ready          bool
+
+	// disabledProviders are the configured providers that were disabled at
+	// runtime, which are stopped and left out of the prices until enabled
+	disabledProviders map[provider.Name]struct{}
 
 	pricesMutex     sync.RWMutex
 	lastPriceSyncTS time.Time
 	prices          map[string]sdk.Dec
+	priceSources    map[string]priceSource
+	requiredRates   map[string]struct{}
+	degraded        []string
+
+	// priceHistory, when set, retains the latest prices of each asset
+	priceHistory *PriceHistory
+
+	// biasAnalyzer, when set, tracks the providers' bias from the median
+	// price. It's guarded by the configMtx.
+	biasAnalyzer *BiasAnalyzer
+
+	// priceStream receives the prices after each price sync
+	priceStream *PriceStream
+
+	// publisher, when set, receives the prices after each collection
+	publisher publisher.Publisher
 
 	tvwapsByProvider PricesWithMutex
 	vwapsByProvider  PricesWithMutex
+	observedPrices   PricesWithMutex
 }
 
 func New(
@@ -83,21 +156,148 @@ func New(
 	endpoints map[provider.Name]provider.Endpoint,
 ) *Oracle {
 	return &Oracle{
-		logger:          logger.With().Str("module", "oracle").Logger(),
-		closer:          pfsync.NewCloser(),
-		oracleClient:    oc,
-		providerPairs:   providerPairs,
-		priceProviders:  make(map[provider.Name]provider.Provider),
-		previousPrevote: nil,
-		providerTimeout: providerTimeout,
-		deviations:      deviations,
-		paramCache:      ParamCache{},
-		endpoints:       endpoints,
+		logger:            logger.With().Str("module", "oracle").Logger(),
+		closer:            pfsync.NewCloser(),
+		oracleClient:      oc,
+		providerPairs:     providerPairs,
+		priceProviders:    make(map[provider.Name]provider.Provider),
+		providerCancels:   make(map[provider.Name]context.CancelFunc),
+		disabledProviders: make(map[provider.Name]struct{}),
+		previousPrevote:   nil,
+		providerTimeout:   providerTimeout,
+		priceInterval:     tickerSleep,
+		voteInterval:      tickerSleep,
+		deviations:        deviations,
+		paramCache:        ParamCache{},
+		endpoints:         endpoints,
+		priceStream:       NewPriceStream(),
 	}
 }
 
-// Start starts the oracle process in a blocking fashion.
+// SetAggregations sets the aggregation of the assets that don't use the
+// default TVWAP aggregation, keyed by base.
+func (o *Oracle) SetAggregations(aggregations map[string]config.Aggregation) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.aggregations = aggregations
+}
+
+// SetPriceBounds sets the range the provider prices of currency pairs must be
+// within to be used, keyed by provider name and currency pair symbol.
+func (o *Oracle) SetPriceBounds(priceBounds map[provider.Name]map[string]config.PriceBounds) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.priceBounds = priceBounds
+}
+
+// SetMidPricePairs sets the currency pairs whose ticker price is the mid price
+// of their best bid and ask instead of their last trade price, keyed by
+// provider name and currency pair symbol.
+func (o *Oracle) SetMidPricePairs(midPricePairs map[provider.Name]map[string]struct{}) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.midPricePairs = midPricePairs
+}
+
+// SetPriceSources sets whether the providers contribute their ticker price or
+// their latest candle close to the aggregation of the currency pairs with a
+// price source, keyed by provider name and currency pair symbol.
+func (o *Oracle) SetPriceSources(priceSources map[provider.Name]map[string]string) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.pairSources = priceSources
+}
+
+// SetScaleFactors sets the factors the provider prices of the currency pairs
+// with a scale factor are multiplied by, keyed by provider name and currency
+// pair symbol.
+func (o *Oracle) SetScaleFactors(scaleFactors map[provider.Name]map[string]sdk.Dec) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.scaleFactors = scaleFactors
+}
+
+// SetDuplicateQuotes sets how the quote routes of a provider listing an asset
+// through several quotes are aggregated, either config.DuplicateQuotesMerge,
+// the default, or config.DuplicateQuotesDistinct.
+func (o *Oracle) SetDuplicateQuotes(policy string) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.duplicateQuotes = policy
+}
+
+// SetRounding sets the rounding applied to the aggregated prices before they
+// are voted on.
+func (o *Oracle) SetRounding(rounding config.Rounding) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.rounding = rounding
+}
+
+// SetSymbolAliases sets the symbols the providers use for the assets in the
+// config. Providers whose aliases changed are stopped and created again with
+// the new aliases when their prices are next requested.
+func (o *Oracle) SetSymbolAliases(symbolAliases map[provider.Name]provider.SymbolAliases) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	for providerName := range o.priceProviders {
+		if !reflect.DeepEqual(o.symbolAliases[providerName], symbolAliases[providerName]) {
+			o.stopProvider(providerName)
+		}
+	}
+	o.symbolAliases = symbolAliases
+}
+
+// SetConversionFeeds sets the bases whose USD rates are aggregated into the
+// conversion rate of a quote, keyed by quote. Other quotes are converted with
+// their own USD rate.
+func (o *Oracle) SetConversionFeeds(conversionFeeds map[string][]string) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.conversionFeeds = conversionFeeds
+}
+
+// SetIntervals sets the minimum timeout between each price collection and
+// between each oracle loop submitting votes, which both default to
+// tickerSleep. It must be called before Start.
+func (o *Oracle) SetIntervals(priceInterval, voteInterval time.Duration) {
+	o.priceInterval = priceInterval
+	o.voteInterval = voteInterval
+}
+
+// SetProviderConcurrency sets the maximum amount of providers whose prices are
+// read concurrently, or no limit if it isn't positive, which is the default.
+// It must be called before Start.
+func (o *Oracle) SetProviderConcurrency(concurrency int) {
+	o.providerConcurrency = concurrency
+}
+
+// SetStartupConcurrency sets the maximum amount of providers created at once,
+// each of them fetching its available pairs, or no limit if it isn't
+// positive, which is the default. It must be called before Start.
+func (o *Oracle) SetStartupConcurrency(concurrency int) {
+	o.providerLimiter = newProviderLimiter(concurrency)
+}
+
+// Start starts the oracle process in a blocking fashion. Prices are collected
+// every priceInterval in the background, and each oracle loop votes on the
+// most recently collected prices. The configured pairs the providers couldn't
+// subscribe to are periodically checked again in the background, as are the
+// API key files for rotated keys.
 func (o *Oracle) Start(ctx context.Context) error {
+	go o.collectPrices(ctx)
+	go o.checkPairAvailability(ctx)
+	go o.watchAPIKeyFiles(ctx)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -113,14 +313,44 @@ func (o *Oracle) Start(ctx context.Context) error {
 				o.logger.Err(err).Msg("oracle tick failed")
 			}
 
-			o.lastPriceSyncTS = time.Now()
-
 			telemetry.MeasureSince(startTime, "runtime", "tick")
 			telemetry.IncrCounter(1, "new", "tick")
 
-			time.Sleep(tickerSleep)
+			time.Sleep(o.voteInterval)
+		}
+	}
+}
+
+// collectPrices sets the prices from the providers every priceInterval until
+// the context is done.
+func (o *Oracle) collectPrices(ctx context.Context) {
+	for {
+		startTime := time.Now()
+
+		if err := o.SetPrices(ctx); err != nil {
+			telemetry.IncrCounter(1, "failure", "prices")
+			o.logger.Err(err).Msg("failed to set prices")
+		} else {
+			o.publishPrices(ctx)
 		}
+
+		telemetry.MeasureSince(startTime, "runtime", "prices")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(o.priceInterval):
+		}
+	}
+}
+
+// priceMaxAge returns the maximum age of the collected prices for them to be
+// pre-voted, which is three price intervals but at least minPriceMaxAge.
+func (o *Oracle) priceMaxAge() time.Duration {
+	if maxAge := 3 * o.priceInterval; maxAge > minPriceMaxAge {
+		return maxAge
 	}
+	return minPriceMaxAge
 }
 
 // Stop stops the oracle process and waits for it to gracefully exit.
@@ -170,120 +400,173 @@ func (o *Oracle) GetVwapPrices() PricesByProvider {
 // with VWAP. Warns the the user of any missing prices, and filters out any faulty
 // providers which do not report prices or candles within 2𝜎 of the others.
 func (o *Oracle) SetPrices(ctx context.Context) error {
-	g := new(errgroup.Group)
-	mtx := new(sync.Mutex)
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.updateMaintenance(time.Now())
+
 	providerPrices := make(provider.AggregatedProviderPrices)
 	providerCandles := make(provider.AggregatedProviderCandles)
+	observedPrices := make(provider.AggregatedProviderPrices)
+	observedCandles := make(provider.AggregatedProviderCandles)
 	requiredRates := make(map[string]struct{})
+	priceProviders := make(map[provider.Name]provider.Provider, len(o.providerPairs))
+	votingPairs := o.votingPairs()
 
-	for providerName, currencyPairs := range o.providerPairs {
-		providerName := providerName
-		currencyPairs := currencyPairs
+	for providerName := range o.providerPairs {
+		for _, pair := range votingPairs[providerName] {
+			if _, ok := requiredRates[pair.Base]; !ok {
+				requiredRates[pair.Base] = struct{}{}
+			}
+		}
 
+		if _, ok := o.disabledProviders[providerName]; ok {
+			continue
+		}
 		priceProvider, err := o.getOrSetProvider(ctx, providerName)
 		if err != nil {
 			return err
 		}
+		priceProviders[providerName] = priceProvider
+	}
 
-		for _, pair := range currencyPairs {
-			if _, ok := requiredRates[pair.Base]; !ok {
-				requiredRates[pair.Base] = struct{}{}
-			}
+	collectStart := time.Now()
+	readings := collectProviderPrices(ctx, priceProviders, o.providerPairs, o.providerTimeout, o.providerConcurrency)
+	telemetry.MeasureSince(collectStart, "runtime", "prices", "collect")
+	degraded := []string{}
+	for providerName, reading := range readings {
+		if reading.err != nil {
+			o.logger.Err(reading.err).
+				Str("provider", providerName.String()).
+				Msg("failed to get ticker prices from provider")
+			degraded = append(degraded, fmt.Sprintf("%s failed to report prices: %s", providerName, reading.err))
+			continue
 		}
 
-		g.Go(func() error {
-			prices := make(map[string]types.TickerPrice, 0)
-			candles := make(map[string][]types.CandlePrice, 0)
-			ch := make(chan struct{})
-			errCh := make(chan error, 1)
-
-			go func() {
-				defer close(ch)
-				prices, err = priceProvider.GetTickerPrices(currencyPairs...)
-				if err != nil {
-					provider.TelemetryFailure(providerName, provider.MessageTypeTicker)
-					errCh <- err
-				}
-
-				candles, err = priceProvider.GetCandlePrices(currencyPairs...)
-				if err != nil {
-					provider.TelemetryFailure(providerName, provider.MessageTypeCandle)
-					errCh <- err
-				}
-			}()
-
-			select {
-			case <-ch:
-				break
-			case err := <-errCh:
-				return err
-			case <-time.After(o.providerTimeout):
-				telemetry.IncrCounter(1, "failure", "provider", "type", "timeout")
-				return fmt.Errorf("provider timed out")
-			}
+		prices := ApplyMidPrices(reading.prices, o.midPricePairs[providerName])
+		prices, candles := ApplyScaleFactors(prices, reading.candles, o.scaleFactors[providerName])
+		prices, candles = ApplyPriceSources(providerName, prices, candles, o.pairSources[providerName])
+		prices, candles = FilterPriceBounds(o.logger, providerName, prices, candles, o.priceBounds[providerName])
 
-			// flatten and collect prices based on the base currency per provider
+		// flatten and collect prices based on the base currency per provider
+		//
+		// e.g.: {ProviderKraken: {"ATOM": <price, volume>, ...}}
+		for _, pair := range o.providerPairs[providerName] {
+			// observe-only pairs are kept apart so that they don't affect
+			// the filtering and aggregation of the voted prices
 			//
-			// e.g.: {ProviderKraken: {"ATOM": <price, volume>, ...}}
-			mtx.Lock()
-			for _, pair := range currencyPairs {
-				success := SetProviderTickerPricesAndCandles(providerName, providerPrices, providerCandles, prices, candles, pair)
-				if !success {
-					mtx.Unlock()
-					return fmt.Errorf("failed to find any exchange rates in provider responses")
-				}
+			// the voted pairs of a base listed through several quotes are
+			// collected under the name of their quote route
+			pairPrices, pairCandles := providerPrices, providerCandles
+			routeName := QuoteRouteName(providerName, pair, votingPairs[providerName])
+			if o.isObserved(providerName, pair) {
+				pairPrices, pairCandles = observedPrices, observedCandles
+				routeName = providerName
 			}
-
-			mtx.Unlock()
-			return nil
-		})
+			success := setProviderTickerPricesAndCandles(
+				providerName,
+				routeName,
+				pairPrices,
+				pairCandles,
+				prices,
+				candles,
+				pair,
+			)
+			if !success {
+				o.logger.Error().
+					Str("provider", providerName.String()).
+					Msg("failed to find any exchange rates in provider responses")
+				degraded = append(degraded, fmt.Sprintf("%s reported no price for %s", providerName, pair))
+				break
+			}
+		}
 	}
 
-	if err := g.Wait(); err != nil {
-		o.logger.Err(err).Msg("failed to get ticker prices from provider")
-	}
+	providerPrices, providerCandles = FilterDerivedPrices(o.logger, providerPrices, providerCandles)
 
-	computedPrices, err := o.GetComputedPrices(
+	computeStart := time.Now()
+	computedPrices, sources, err := o.computePrices(
 		providerCandles,
 		providerPrices,
-		o.providerPairs,
+		SplitQuoteRoutes(votingPairs),
 		o.deviations,
 	)
+	telemetry.MeasureSince(computeStart, "runtime", "prices", "compute")
 	if err != nil {
 		return err
 	}
+	o.setObservedPrices(observedPrices, observedCandles, computedPrices)
 
 	for base := range requiredRates {
 		if _, ok := computedPrices[base]; !ok {
 			o.logger.Warn().Str("asset", base).Msg("unable to report price for expected asset")
+			degraded = append(degraded, fmt.Sprintf("no price for expected asset %s", base))
 		}
 	}
+	sort.Strings(degraded)
 
 	o.pricesMutex.Lock()
 	o.prices = computedPrices
+	o.priceSources = sources
+	o.requiredRates = requiredRates
+	o.degraded = degraded
+	o.lastPriceSyncTS = time.Now()
+	syncTS := o.lastPriceSyncTS
 	o.pricesMutex.Unlock()
+
+	if o.priceHistory != nil {
+		o.priceHistory.Record(syncTS, computedPrices)
+	}
+	if dropped := o.priceStream.Publish(syncTS, computedPrices); dropped > 0 {
+		o.logger.Debug().Int("subscribers", dropped).Msg("dropped prices update for slow stream subscribers")
+	}
 	return nil
 }
 
 // GetComputedPrices gets the candle and ticker prices and computes it.
 // It returns candles' TVWAP if possible, if not possible (not available
 // or due to some staleness) it will use the most recent ticker prices
-// and the VWAP formula instead.
+// and the VWAP formula instead. Assets configured with a trimmed mean
+// aggregation use the trimmed mean of the filtered provider prices. Assets
+// whose computed price isn't positive are left out.
 func (o *Oracle) GetComputedPrices(
 	providerCandles provider.AggregatedProviderCandles,
 	providerPrices provider.AggregatedProviderPrices,
 	providerPairs map[provider.Name][]types.CurrencyPair,
 	deviations map[string]sdk.Dec,
 ) (prices map[string]sdk.Dec, err error) {
+	prices, _, err = o.computePrices(providerCandles, providerPrices, providerPairs, deviations)
+	return prices, err
+}
+
+// computePrices computes the prices like GetComputedPrices and returns the
+// providers and volume each price was computed from.
+func (o *Oracle) computePrices(
+	providerCandles provider.AggregatedProviderCandles,
+	providerPrices provider.AggregatedProviderPrices,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviations map[string]sdk.Dec,
+) (map[string]sdk.Dec, map[string]priceSource, error) {
+	// weight the candles of assets configured with a half-life by recency
+	providerCandles, err := ApplyCandleDecay(providerCandles, o.candleHalfLives())
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// convert any non-USD denominated candles into USD
-	convertedCandles, err := ConvertCandlesToUSD(
+	convertedCandles, err := ConvertCandlesToUSDWithFeeds(
 		o.logger,
 		providerCandles,
 		providerPairs,
 		deviations,
+		o.conversionFeeds,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if o.duplicateQuotes != config.DuplicateQuotesDistinct {
+		convertedCandles = MergeQuoteRouteCandles(convertedCandles)
 	}
 
 	// filter out any erroneous candles
@@ -293,29 +576,34 @@ func (o *Oracle) GetComputedPrices(
 		deviations,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	computedPrices, _ := ComputeTvwapsByProvider(filteredCandles)
 	o.tvwapsByProvider.SetPrices(computedPrices)
 
 	// attempt to use candles for TVWAP calculations
-	tvwapPrices, err := ComputeTVWAP(filteredCandles)
+	tvwapPrices, err := ComputeTVWAPWithWindows(filteredCandles, o.twapWindows())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	o.applyCompositeTWAPs(tvwapPrices, filteredCandles)
 
 	// If TVWAP candles are not available or were filtered out due to staleness,
 	// use most recent prices & VWAP instead.
 	if len(tvwapPrices) == 0 {
-		convertedTickers, err := ConvertTickersToUSD(
+		convertedTickers, err := ConvertTickersToUSDWithFeeds(
 			o.logger,
 			providerPrices,
 			providerPairs,
 			deviations,
+			o.conversionFeeds,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if o.duplicateQuotes != config.DuplicateQuotesDistinct {
+			convertedTickers = MergeQuoteRouteTickers(convertedTickers)
 		}
 
 		filteredProviderPrices, err := FilterTickerDeviations(
@@ -324,21 +612,144 @@ func (o *Oracle) GetComputedPrices(
 			deviations,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		o.vwapsByProvider.SetPrices(ComputeVwapsByProvider(filteredProviderPrices))
+		vwapsByProvider := ComputeVwapsByProvider(filteredProviderPrices)
+		o.vwapsByProvider.SetPrices(vwapsByProvider)
 
 		vwapPrices := ComputeVWAP(filteredProviderPrices)
 
-		return vwapPrices, nil
+		prices, err := o.aggregatePrices(vwapPrices, vwapsByProvider)
+		return prices, tickerSources(filteredProviderPrices), err
+	}
+
+	prices, err := o.aggregatePrices(tvwapPrices, computedPrices)
+	return prices, candleSources(filteredCandles), err
+}
+
+// aggregatePrices applies the configured aggregations and rounding to the
+// prices and removes the resulting prices which aren't positive. The prices
+// of each provider they're aggregated from are recorded for the bias
+// analysis.
+func (o *Oracle) aggregatePrices(
+	prices map[string]sdk.Dec,
+	pricesByProvider map[provider.Name]map[string]sdk.Dec,
+) (map[string]sdk.Dec, error) {
+	o.recordBias(pricesByProvider)
+
+	prices, err := o.applyAggregations(prices, pricesByProvider)
+	if err != nil {
+		return nil, err
+	}
+	prices = RoundPrices(prices, o.rounding)
+	return FilterNonPositivePrices(o.logger, prices, pricesByProvider), nil
+}
+
+// candleHalfLives returns the candle half-life of the assets weighting their
+// candles by recency.
+func (o *Oracle) candleHalfLives() map[string]time.Duration {
+	halfLives := make(map[string]time.Duration)
+	for base, aggregation := range o.aggregations {
+		if aggregation.CandleHalfLife > 0 {
+			halfLives[base] = aggregation.CandleHalfLife
+		}
+	}
+	return halfLives
+}
+
+// applyCompositeTWAPs replaces the TVWAP of the assets configured with the
+// composite TWAP aggregation by the TWAP of their composite candles, over
+// their twap_window or the default TVWAP period.
+func (o *Oracle) applyCompositeTWAPs(prices map[string]sdk.Dec, candles provider.AggregatedProviderCandles) {
+	for base, aggregation := range o.aggregations {
+		if aggregation.Mode != config.AggregationCompositeTWAP {
+			continue
+		}
+		window := aggregation.TWAPWindow
+		if window == 0 {
+			window = tvwapCandlePeriod
+		}
+		if price, ok := ComputeCompositeTWAP(candles, base, compositeCandlePeriod, window); ok {
+			prices[base] = price
+		}
+	}
+}
+
+// twapWindows returns the TVWAP window of the assets averaging their candles
+// over another window than the default.
+func (o *Oracle) twapWindows() map[string]time.Duration {
+	windows := make(map[string]time.Duration)
+	for base, aggregation := range o.aggregations {
+		if aggregation.TWAPWindow > 0 {
+			windows[base] = aggregation.TWAPWindow
+		}
+	}
+	return windows
+}
+
+// applyAggregations replaces the prices of assets configured with a trimmed
+// mean or failover aggregation by the trimmed mean or the failover price of
+// their filtered provider prices, and removes the TVWAP prices computed from
+// fewer providers than their configured minimum and the failover prices none
+// of the failover providers has a price for.
+func (o *Oracle) applyAggregations(
+	prices map[string]sdk.Dec,
+	pricesByProvider map[provider.Name]map[string]sdk.Dec,
+) (map[string]sdk.Dec, error) {
+	trimFractions := make(map[string]sdk.Dec)
+	failoverOrders := make(map[string][]provider.Name)
+	minProviders := make(map[string]int)
+	for base, aggregation := range o.aggregations {
+		switch aggregation.Mode {
+		case config.AggregationTrimmedMean:
+			trimFractions[base] = aggregation.TrimFraction
+		case config.AggregationFailover:
+			failoverOrders[base] = aggregation.FailoverOrder
+		case config.AggregationTVWAP:
+			if aggregation.MinWeightedProviders > 0 {
+				minProviders[base] = aggregation.MinWeightedProviders
+			}
+		}
+	}
+	if len(minProviders) > 0 {
+		prices = FilterMinWeightedProviders(o.logger, prices, pricesByProvider, minProviders)
+	}
+	if len(trimFractions) == 0 && len(failoverOrders) == 0 {
+		return prices, nil
+	}
+
+	trimmedMeans, err := ComputeTrimmedMeans(pricesByProvider, trimFractions)
+	if err != nil {
+		return nil, err
+	}
+	for base, price := range trimmedMeans {
+		prices[base] = price
+	}
+	// a failover base doesn't fall back to its median price when none of its
+	// providers has a price
+	failoverPrices := ComputeFailoverPrices(pricesByProvider, failoverOrders)
+	for base, order := range failoverOrders {
+		price, ok := failoverPrices[base]
+		if !ok {
+			if _, ok := prices[base]; ok {
+				o.logger.Warn().
+					Str("asset", base).
+					Interface("failover_order", order).
+					Msg("none of the failover providers has a price, skipping asset")
+				delete(prices, base)
+			}
+			continue
+		}
+		prices[base] = price
 	}
 
-	return tvwapPrices, nil
+	return prices, nil
 }
 
 // SetProviderTickerPricesAndCandles flattens and collects prices for
-// candles and tickers based on the base currency per provider.
+// candles and tickers based on the base currency per provider, converting
+// their volumes to quote volume and the candle volumes to volume per minute.
 // Returns true if at least one of price or candle exists.
 func SetProviderTickerPricesAndCandles(
 	providerName provider.Name,
@@ -348,21 +759,53 @@ func SetProviderTickerPricesAndCandles(
 	candles map[string][]types.CandlePrice,
 	pair types.CurrencyPair,
 ) (success bool) {
-	if _, ok := providerPrices[providerName]; !ok {
-		providerPrices[providerName] = make(map[string]types.TickerPrice)
+	return setProviderTickerPricesAndCandles(
+		providerName,
+		providerName,
+		providerPrices,
+		providerCandles,
+		prices,
+		candles,
+		pair,
+	)
+}
+
+// setProviderTickerPricesAndCandles collects the prices like
+// SetProviderTickerPricesAndCandles, under the name of the pair's quote route.
+func setProviderTickerPricesAndCandles(
+	providerName provider.Name,
+	routeName provider.Name,
+	providerPrices provider.AggregatedProviderPrices,
+	providerCandles provider.AggregatedProviderCandles,
+	prices map[string]types.TickerPrice,
+	candles map[string][]types.CandlePrice,
+	pair types.CurrencyPair,
+) (success bool) {
+	if _, ok := providerPrices[routeName]; !ok {
+		providerPrices[routeName] = make(map[string]types.TickerPrice)
 	}
-	if _, ok := providerCandles[providerName]; !ok {
-		providerCandles[providerName] = make(map[string][]types.CandlePrice)
+	if _, ok := providerCandles[routeName]; !ok {
+		providerCandles[routeName] = make(map[string][]types.CandlePrice)
 	}
 
 	tp, pricesOk := prices[pair.String()]
 	cp, candlesOk := candles[pair.String()]
 
+	// normalize volumes to the quote asset so that they're comparable across
+	// providers in any volume weighted computation
 	if pricesOk {
-		providerPrices[providerName][pair.Base] = tp
+		tp.Volume = providerName.QuoteVolume(tp.Price, tp.Volume)
+		providerPrices[routeName][pair.Base] = tp
 	}
 	if candlesOk {
-		providerCandles[providerName][pair.Base] = cp
+		quoteCandles := make([]types.CandlePrice, len(cp))
+		for i, candle := range cp {
+			candle.Volume = providerName.PerMinuteVolume(
+				providerName.QuoteVolume(candle.Price, candle.Volume),
+			)
+			quoteCandles[i] = candle
+		}
+		providerCandles[routeName][pair.Base] = quoteCandles
 	}
 
 	return pricesOk || candlesOk
@@ -419,11 +862,12 @@ func (o *Oracle) getOrSetProvider(ctx context.Context, providerName provider.Nam
 
 	priceProvider, ok = o.priceProviders[providerName]
 	if !ok {
-		newProvider, err := NewProvider(
+		newProvider, cancel, err := o.providerLimiter.newPriceProvider(
 			ctx,
 			providerName,
 			o.logger,
 			o.endpoints[providerName],
+			o.symbolAliases[providerName],
 			o.providerPairs[providerName]...,
 		)
 		if err != nil {
@@ -432,11 +876,32 @@ func (o *Oracle) getOrSetProvider(ctx context.Context, providerName provider.Nam
 		newProvider.StartConnections()
 		priceProvider = newProvider
 		o.priceProviders[providerName] = newProvider
+		o.providerCancels[providerName] = cancel
 	}
 
 	return priceProvider, nil
 }
 
+// newPriceProvider creates a provider with its own context so that it can be
+// stopped independently of the oracle by calling the returned cancel func.
+// The provider is subscribed to the pairs using its aliased symbols.
+func newPriceProvider(
+	ctx context.Context,
+	providerName provider.Name,
+	logger zerolog.Logger,
+	endpoint provider.Endpoint,
+	aliases provider.SymbolAliases,
+	providerPairs ...types.CurrencyPair,
+) (provider.Provider, context.CancelFunc, error) {
+	providerCtx, cancel := context.WithCancel(ctx)
+	newProvider, err := NewProvider(providerCtx, providerName, logger, endpoint, aliases.Apply(providerPairs...)...)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return provider.NewAliasProvider(newProvider, aliases), cancel, nil
+}
+
 func NewProvider(
 	ctx context.Context,
 	providerName provider.Name,
@@ -460,6 +925,9 @@ func NewProvider(
 	case provider.ProviderOsmosisV2:
 		return provider.NewOsmosisV2Provider(ctx, logger, endpoint, providerPairs...)
 
+	case provider.ProviderOsmosisChain:
+		return provider.NewOsmosisChainProvider(ctx, logger, endpoint, providerPairs...)
+
 	case provider.ProviderHuobi:
 		return provider.NewHuobiProvider(ctx, logger, endpoint, providerPairs...)
 
@@ -481,12 +949,33 @@ func NewProvider(
 	case provider.ProviderCrypto:
 		return provider.NewCryptoProvider(ctx, logger, endpoint, providerPairs...)
 
+	case provider.ProviderDeribit:
+		return provider.NewDeribitProvider(ctx, logger, endpoint, providerPairs...)
+
 	case provider.ProviderPolygon:
 		return provider.NewPolygonProvider(ctx, logger, endpoint, providerPairs...)
 
 	case provider.ProviderFin:
 		return provider.NewFinProvider(endpoint), nil
 
+	case provider.ProviderUniswap:
+		return provider.NewUniswapProvider(ctx, logger, endpoint, providerPairs...)
+
+	case provider.ProviderChainlink:
+		return provider.NewChainlinkProvider(ctx, logger, endpoint, providerPairs...)
+
+	case provider.ProviderPriceFeeder:
+		return provider.NewPriceFeederProvider(endpoint), nil
+
+	case provider.ProviderNumia:
+		return provider.NewNumiaProvider(endpoint), nil
+
+	case provider.ProviderCoinGecko:
+		return provider.NewCoinGeckoProvider(ctx, logger, endpoint, providerPairs...)
+
+	case provider.ProviderRest:
+		return provider.NewRestProvider(ctx, logger, endpoint, providerPairs...)
+
 	case provider.ProviderMock:
 		return provider.NewMockProvider(), nil
 	}
@@ -495,9 +984,10 @@ func NewProvider(
 }
 
 func (o *Oracle) checkAcceptList(params oracletypes.Params) {
+	prices := o.GetPrices()
 	for _, denom := range params.AcceptList {
 		symbol := strings.ToUpper(denom.SymbolDenom)
-		if _, ok := o.prices[symbol]; !ok {
+		if _, ok := prices[symbol]; !ok {
 			o.logger.Warn().Str("denom", symbol).Msg("price missing for required denom")
 		}
 	}
@@ -519,10 +1009,6 @@ func (o *Oracle) tick(ctx context.Context) error {
 		return err
 	}
 
-	if err := o.SetPrices(ctx); err != nil {
-		return err
-	}
-
 	// Get oracle vote period, next block height, current vote period, and index
 	// in the vote period.
 	oracleVotePeriod := int64(oracleParams.VotePeriod)
@@ -568,7 +1054,7 @@ func (o *Oracle) tick(ctx context.Context) error {
 		return err
 	}
 
-	exchangeRatesStr := GenerateExchangeRatesString(o.prices)
+	exchangeRatesStr := GenerateExchangeRatesString(o.GetPrices())
 	hash := oracletypes.GetAggregateVoteHash(salt, exchangeRatesStr, valAddr)
 	preVoteMsg := &oracletypes.MsgAggregateExchangeRatePrevote{
 		Hash:      hash.String(), // hash of prices from the oracle
@@ -578,6 +1064,33 @@ func (o *Oracle) tick(ctx context.Context) error {
 
 	isPrevoteOnlyTx := o.previousPrevote == nil
 	if isPrevoteOnlyTx {
+		// prices are collected separately, so make sure they're still fresh
+		// before committing to them
+		if lastSync := o.GetLastPriceSyncTimestamp(); time.Since(lastSync) > o.priceMaxAge() {
+			return fmt.Errorf("skipping pre-vote, prices were last collected at %s", lastSync.Format(time.RFC3339))
+		}
+		if ready, pending := o.IsReady(); !ready {
+			o.logger.Info().
+				Strs("pending", pending).
+				Msg("abstaining from voting period, waiting for the readiness gate")
+			telemetry.IncrCounter(1, "vote", "abstain")
+			return nil
+		}
+		if abstain, coverage := o.shouldAbstain(); abstain {
+			o.logger.Warn().
+				Str("price_coverage", coverage.String()).
+				Msg("abstaining from voting period, too few assets have a price")
+			telemetry.IncrCounter(1, "vote", "abstain")
+			return nil
+		}
+		if failures := o.strictFailures(); len(failures) > 0 {
+			o.logger.Warn().
+				Strs("failures", failures).
+				Msg("abstaining from voting period, strict mode doesn't vote on degraded prices")
+			telemetry.IncrCounter(1, "vote", "abstain")
+			return nil
+		}
+
 		// This timeout could be as small as oracleVotePeriod-indexInVotePeriod,
 		// but we give it some extra time just in case.
 		//
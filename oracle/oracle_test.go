@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle/client"
 	"github.com/ojo-network/price-feeder/oracle/provider"
 	"github.com/ojo-network/price-feeder/oracle/types"
@@ -22,11 +23,15 @@ type mockProvider struct {
 
 func (m mockProvider) StartConnections() {}
 
-func (m mockProvider) GetTickerPrices(_ ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (m mockProvider) Describe() provider.Description {
+	return provider.Description{}
+}
+
+func (m mockProvider) GetTickerPrices(_ context.Context, _ ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	return m.prices, nil
 }
 
-func (m mockProvider) GetCandlePrices(_ ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (m mockProvider) GetCandlePrices(_ context.Context, _ ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candles := make(map[string][]types.CandlePrice)
 	for pair, price := range m.prices {
 		candles[pair] = []types.CandlePrice{
@@ -42,6 +47,12 @@ func (m mockProvider) GetCandlePrices(_ ...types.CurrencyPair) (map[string][]typ
 
 func (m mockProvider) SubscribeCurrencyPairs(...types.CurrencyPair) {}
 
+func (m mockProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+func (m mockProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return map[string]types.CurrencyPair{}
+}
+
 func (m mockProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	return map[string]struct{}{}, nil
 }
@@ -52,20 +63,64 @@ type failingProvider struct {
 
 func (m failingProvider) StartConnections() {}
 
-func (m failingProvider) GetTickerPrices(_ ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (m failingProvider) Describe() provider.Description {
+	return provider.Description{}
+}
+
+func (m failingProvider) GetTickerPrices(_ context.Context, _ ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	return nil, fmt.Errorf("unable to get ticker prices")
 }
 
-func (m failingProvider) GetCandlePrices(_ ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (m failingProvider) GetCandlePrices(_ context.Context, _ ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	return nil, fmt.Errorf("unable to get candle prices")
 }
 
 func (m failingProvider) SubscribeCurrencyPairs(...types.CurrencyPair) {}
 
+func (m failingProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+func (m failingProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return map[string]types.CurrencyPair{}
+}
+
 func (m failingProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	return map[string]struct{}{}, nil
 }
 
+// slowProvider blocks until the context of the request is done.
+type slowProvider struct {
+	canceled chan struct{}
+}
+
+func (m slowProvider) StartConnections() {}
+
+func (m slowProvider) Describe() provider.Description {
+	return provider.Description{}
+}
+
+func (m slowProvider) GetTickerPrices(ctx context.Context, _ ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	<-ctx.Done()
+	close(m.canceled)
+	return nil, ctx.Err()
+}
+
+func (m slowProvider) GetCandlePrices(ctx context.Context, _ ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (m slowProvider) SubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+func (m slowProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+func (m slowProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return map[string]types.CurrencyPair{}
+}
+
+func (m slowProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	return map[string]struct{}{}, nil
+}
+
 type OracleTestSuite struct {
 	suite.Suite
 
@@ -119,6 +174,33 @@ func TestServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(OracleTestSuite))
 }
 
+func TestSetPricesProviderTimeout(t *testing.T) {
+	oracle := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderOsmosis: {{Base: "OSMO", Quote: "USD"}},
+		},
+		time.Millisecond*50,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+
+	slow := slowProvider{canceled: make(chan struct{})}
+	oracle.priceProviders = map[provider.Name]provider.Provider{
+		provider.ProviderOsmosis: slow,
+	}
+
+	// provider failures are logged and leave the asset without a price
+	require.NoError(t, oracle.SetPrices(context.TODO()))
+	require.Empty(t, oracle.GetPrices())
+	select {
+	case <-slow.canceled:
+	case <-time.After(time.Second):
+		t.Fatal("provider request was not canceled")
+	}
+}
+
 func (ots *OracleTestSuite) TestStop() {
 	ots.Eventually(
 		func() bool {
@@ -135,6 +217,14 @@ func (ots *OracleTestSuite) TestGetLastPriceSyncTimestamp() {
 	ots.Require().Equal(time.Time{}, ots.oracle.GetLastPriceSyncTimestamp())
 }
 
+func (ots *OracleTestSuite) TestPriceMaxAge() {
+	o := &Oracle{priceInterval: tickerSleep}
+	ots.Require().Equal(minPriceMaxAge, o.priceMaxAge())
+
+	o.SetIntervals(time.Minute, time.Second)
+	ots.Require().Equal(3*time.Minute, o.priceMaxAge())
+}
+
 func (ots *OracleTestSuite) TestPrices() {
 	// initial prices should be empty (not set)
 	ots.Require().Empty(ots.oracle.GetPrices())
@@ -236,7 +326,7 @@ func (ots *OracleTestSuite) TestPrices() {
 
 	prices = ots.oracle.GetPrices()
 	ots.Require().Len(prices, 4)
-	ots.Require().Equal(sdk.MustNewDecFromStr("3.710916056220858266"), prices["OJO"])
+	ots.Require().Equal(sdk.MustNewDecFromStr("3.710942777612422485"), prices["OJO"])
 	ots.Require().Equal(sdk.MustNewDecFromStr("3.717"), prices["XBT"])
 	ots.Require().Equal(sdk.MustNewDecFromStr("1"), prices["USDC"])
 	ots.Require().Equal(sdk.MustNewDecFromStr("1"), prices["USDT"])
@@ -429,6 +519,38 @@ func TestSuccessSetProviderTickerPricesAndCandles(t *testing.T) {
 	require.True(t, success, "It should successfully set the prices")
 	require.Equal(t, atomPrice, providerPrices[provider.ProviderGate][pair.Base].Price)
 	require.Equal(t, atomPrice, providerCandles[provider.ProviderGate][pair.Base][0].Price)
+
+	// gate reports base volume which is converted to quote volume
+	quoteVolume := atomVolume.Mul(atomPrice)
+	require.Equal(t, quoteVolume, providerPrices[provider.ProviderGate][pair.Base].Volume)
+	require.Equal(t, quoteVolume, providerCandles[provider.ProviderGate][pair.Base][0].Volume)
+	require.Equal(t, atomVolume, candles[pair.String()][0].Volume, "provider candles must not be modified")
+}
+
+func TestSetProviderTickerPricesAndCandles_PerMinuteVolume(t *testing.T) {
+	providerPrices := make(provider.AggregatedProviderPrices, 1)
+	providerCandles := make(provider.AggregatedProviderCandles, 1)
+	pair := types.CurrencyPair{Base: "OSMO", Quote: "USD"}
+	volume := sdk.MustNewDecFromStr("5000")
+
+	success := SetProviderTickerPricesAndCandles(
+		provider.ProviderOsmosis,
+		providerPrices,
+		providerCandles,
+		map[string]types.TickerPrice{
+			pair.String(): {Price: sdk.OneDec(), Volume: volume},
+		},
+		map[string][]types.CandlePrice{
+			pair.String(): {{Price: sdk.OneDec(), Volume: volume, TimeStamp: provider.PastUnixTime(time.Minute)}},
+		},
+		pair,
+	)
+	require.True(t, success)
+
+	// osmosis sends 5 minute candles whose volume is spread over each minute,
+	// while the ticker volume is left as is
+	require.Equal(t, sdk.MustNewDecFromStr("1000"), providerCandles[provider.ProviderOsmosis]["OSMO"][0].Volume)
+	require.Equal(t, volume, providerPrices[provider.ProviderOsmosis]["OSMO"].Volume)
 }
 
 func TestFailedSetProviderTickerPricesAndCandles(t *testing.T) {
@@ -514,6 +636,36 @@ func (ots *OracleTestSuite) TestSuccessGetComputedPricesTickers() {
 	require.Equal(ots.T(), prices[pair.Base], atomPrice)
 }
 
+func (ots *OracleTestSuite) TestGetComputedPricesZeroTicker() {
+	pair := types.CurrencyPair{
+		Base:  "ATOM",
+		Quote: "USD",
+	}
+
+	providerPrices := provider.AggregatedProviderPrices{
+		provider.ProviderBinance: {
+			pair.Base: {
+				Price:  sdk.ZeroDec(),
+				Volume: sdk.MustNewDecFromStr("894123.00"),
+			},
+		},
+	}
+
+	providerPair := map[provider.Name][]types.CurrencyPair{
+		provider.ProviderBinance: {pair},
+	}
+
+	prices, err := ots.oracle.GetComputedPrices(
+		make(provider.AggregatedProviderCandles, 1),
+		providerPrices,
+		providerPair,
+		make(map[string]sdk.Dec),
+	)
+
+	require.NoError(ots.T(), err)
+	require.NotContains(ots.T(), prices, pair.Base)
+}
+
 func (ots *OracleTestSuite) TestGetComputedPricesCandlesConversion() {
 	btcPair := types.CurrencyPair{
 		Base:  "BTC",
@@ -580,12 +732,13 @@ func (ots *OracleTestSuite) TestGetComputedPricesCandlesConversion() {
 	}
 	providerCandles[provider.ProviderOkx] = okxCandles
 
-	// btc / usd rate
+	// btc / usd rate, with the same usd volume as the converted btc / eth
+	// candles so that both are weighted equally
 	krakenCandles := make(map[string][]types.CandlePrice, 1)
 	krakenCandles[btcUSDPair.Base] = []types.CandlePrice{
 		{
 			Price:     btcUSDPrice,
-			Volume:    volume,
+			Volume:    volume.Mul(ethUsdPrice),
 			TimeStamp: provider.PastUnixTime(1 * time.Minute),
 		},
 	}
@@ -666,11 +819,12 @@ func (ots *OracleTestSuite) TestGetComputedPricesTickersConversion() {
 	}
 	providerPrices[provider.ProviderOkx] = okxTickerPrices
 
-	// btc / usd rate
+	// btc / usd rate, with the same usd volume as the converted btc / eth
+	// tickers so that both are weighted equally
 	krakenTickerPrices := make(map[string]types.TickerPrice, 1)
 	krakenTickerPrices[btcUSDPair.Base] = types.TickerPrice{
 		Price:  btcUSDPrice,
-		Volume: volume,
+		Volume: volume.Mul(ethUsdPrice),
 	}
 	providerPrices[provider.ProviderKraken] = krakenTickerPrices
 
@@ -814,3 +968,113 @@ func (ots *OracleTestSuite) TestGetComputedPricesEmptyTvwap() {
 		})
 	}
 }
+
+func TestSetPricesProviderQuoteBounds(t *testing.T) {
+	cfg := config.Config{
+		CurrencyPairs: []config.CurrencyPair{
+			{
+				Base:           "ATOM",
+				Quote:          "USD",
+				Providers:      []provider.Name{provider.ProviderKraken, provider.ProviderBinance},
+				ProviderQuotes: map[provider.Name]string{provider.ProviderBinance: "USDT"},
+				MaxPrice:       "100",
+			},
+			{Base: "USDT", Quote: "USD", Providers: []provider.Name{provider.ProviderKraken}},
+		},
+	}
+
+	oracle := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderKraken: {
+				{Base: "ATOM", Quote: "USD"},
+				{Base: "USDT", Quote: "USD"},
+			},
+			provider.ProviderBinance: {{Base: "ATOM", Quote: "USDT"}},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	oracle.SetPriceBounds(cfg.PriceBounds())
+	oracle.priceProviders = map[provider.Name]provider.Provider{
+		provider.ProviderKraken: mockProvider{prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.OneDec()},
+			"USDTUSD": {Price: sdk.OneDec(), Volume: sdk.OneDec()},
+		}},
+		provider.ProviderBinance: mockProvider{prices: map[string]types.TickerPrice{
+			"ATOMUSDT": {Price: sdk.MustNewDecFromStr("1000"), Volume: sdk.OneDec()},
+		}},
+	}
+
+	// binance's ATOM/USDT price is above the bounds of the ATOM/USD pair
+	require.NoError(t, oracle.SetPrices(context.TODO()))
+	require.Equal(t, sdk.MustNewDecFromStr("10"), oracle.GetPrices()["ATOM"])
+}
+
+func TestSetPricesFailoverSkipsOutOfBoundsPrimary(t *testing.T) {
+	cfg := config.Config{
+		CurrencyPairs: []config.CurrencyPair{
+			{
+				Base:        "ATOM",
+				Quote:       "USD",
+				Providers:   []provider.Name{provider.ProviderBinance, provider.ProviderKraken},
+				MaxPrice:    "100",
+				Aggregation: config.AggregationFailover,
+			},
+			{
+				Base:        "OJO",
+				Quote:       "USD",
+				Providers:   []provider.Name{provider.ProviderBinance},
+				MaxPrice:    "10",
+				Aggregation: config.AggregationFailover,
+			},
+		},
+	}
+
+	oracle := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance: {
+				{Base: "ATOM", Quote: "USD"},
+				{Base: "OJO", Quote: "USD"},
+			},
+			provider.ProviderKraken: {{Base: "ATOM", Quote: "USD"}},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	oracle.SetPriceBounds(cfg.PriceBounds())
+	oracle.SetAggregations(cfg.Aggregations())
+	oracle.priceProviders = map[provider.Name]provider.Provider{
+		provider.ProviderBinance: mockProvider{prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("1000"), Volume: sdk.OneDec()},
+			"OJOUSD":  {Price: sdk.MustNewDecFromStr("20"), Volume: sdk.OneDec()},
+		}},
+		provider.ProviderKraken: mockProvider{prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.OneDec()},
+		}},
+	}
+
+	// binance's prices are above the bounds, so kraken's ATOM price is used
+	// and OJO, which has no other provider, is skipped
+	require.NoError(t, oracle.SetPrices(context.TODO()))
+	prices := oracle.GetPrices()
+	require.Equal(t, sdk.MustNewDecFromStr("10"), prices["ATOM"])
+	_, ok := prices["OJO"]
+	require.False(t, ok)
+
+	// an asset priced by other providers than its failover providers doesn't
+	// fall back to that price
+	prices, err := oracle.applyAggregations(
+		map[string]sdk.Dec{"OJO": sdk.MustNewDecFromStr("5")},
+		map[provider.Name]map[string]sdk.Dec{
+			provider.ProviderKraken: {"OJO": sdk.MustNewDecFromStr("5")},
+		},
+	)
+	require.NoError(t, err)
+	require.Empty(t, prices)
+}
@@ -0,0 +1,132 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// PreflightFailure classifies why a provider couldn't be created.
+type PreflightFailure string
+
+const (
+	// PreflightMisconfigured means the provider won't be created until its
+	// configuration is fixed, ex. an endpoint host that doesn't exist or which
+	// doesn't answer with the provider's API.
+	PreflightMisconfigured PreflightFailure = "misconfigured"
+	// PreflightUnreachable means the provider's endpoint couldn't be reached,
+	// which may only be temporary.
+	PreflightUnreachable PreflightFailure = "unreachable"
+)
+
+// PreflightResult defines the outcome of creating a single provider.
+type PreflightResult struct {
+	Provider provider.Name
+	Failure  PreflightFailure
+	Err      error
+}
+
+// OK returns true if the provider was created.
+func (r PreflightResult) OK() bool {
+	return r.Err == nil
+}
+
+// Preflight creates each provider with all of its pairs using the same
+// constructors as the oracle, without starting their connections, and stops
+// them right away. It reports the providers which failed to be created, which
+// would otherwise only surface once the oracle first collects prices. The
+// providers are created concurrently and the results are sorted by provider
+// name.
+func Preflight(
+	ctx context.Context,
+	logger zerolog.Logger,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	endpoints map[provider.Name]provider.Endpoint,
+	symbolAliases map[provider.Name]provider.SymbolAliases,
+) []PreflightResult {
+	return PreflightWithConcurrency(ctx, logger, providerPairs, endpoints, symbolAliases, 0)
+}
+
+// PreflightWithConcurrency runs Preflight creating up to concurrency providers
+// at once, or all of them at once if it isn't positive.
+func PreflightWithConcurrency(
+	ctx context.Context,
+	logger zerolog.Logger,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	endpoints map[provider.Name]provider.Endpoint,
+	symbolAliases map[provider.Name]provider.SymbolAliases,
+	concurrency int,
+) []PreflightResult {
+	var (
+		limiter = newProviderLimiter(concurrency)
+		mtx     sync.Mutex
+		wg      sync.WaitGroup
+		results = make([]PreflightResult, 0, len(providerPairs))
+	)
+
+	for providerName, pairs := range providerPairs {
+		wg.Add(1)
+		go func(providerName provider.Name, pairs []types.CurrencyPair) {
+			defer wg.Done()
+
+			result := PreflightResult{Provider: providerName}
+			_, stop, err := limiter.newPriceProvider(ctx, providerName, logger, endpoints[providerName], symbolAliases[providerName], pairs...)
+			if err != nil {
+				result.Failure = classifyPreflightError(err)
+				result.Err = err
+			} else {
+				stop()
+			}
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			results = append(results, result)
+		}(providerName, pairs)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Provider < results[j].Provider
+	})
+	return results
+}
+
+// classifyPreflightError tells apart the errors of creating a provider which
+// are worth retrying, ex. a refused connection or a timeout, from the ones
+// which need the configuration to be fixed. A host which doesn't resolve is
+// considered misconfigured since it's most often a typo.
+func classifyPreflightError(err error) PreflightFailure {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsNotFound {
+			return PreflightMisconfigured
+		}
+		return PreflightUnreachable
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return PreflightUnreachable
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return PreflightUnreachable
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return PreflightUnreachable
+	}
+
+	return PreflightMisconfigured
+}
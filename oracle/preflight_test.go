@@ -0,0 +1,81 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestPreflight(t *testing.T) {
+	atomPair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"symbol":"ATOMUSDT"}]`)) //nolint:errcheck
+	}))
+	defer api.Close()
+
+	website := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html></html>`)) //nolint:errcheck
+	}))
+	defer website.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	endpoint := func(name provider.Name, rest string) provider.Endpoint {
+		return provider.Endpoint{Name: name, Rest: rest, Websocket: "stream.binance.invalid:9443"}
+	}
+
+	results := Preflight(
+		context.Background(),
+		zerolog.Nop(),
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance:   {atomPair},
+			provider.ProviderBinanceUS: {atomPair},
+			provider.ProviderMexc:      {atomPair},
+			"foo":                      {atomPair},
+		},
+		map[provider.Name]provider.Endpoint{
+			provider.ProviderBinance:   endpoint(provider.ProviderBinance, api.URL),
+			provider.ProviderBinanceUS: endpoint(provider.ProviderBinanceUS, website.URL),
+			provider.ProviderMexc:      endpoint(provider.ProviderMexc, closed.URL),
+		},
+		make(map[provider.Name]provider.SymbolAliases),
+	)
+	require.Len(t, results, 4)
+
+	require.Equal(t, provider.ProviderBinance, results[0].Provider)
+	require.True(t, results[0].OK())
+
+	require.Equal(t, provider.ProviderBinanceUS, results[1].Provider)
+	require.Equal(t, PreflightMisconfigured, results[1].Failure)
+
+	require.Equal(t, provider.Name("foo"), results[2].Provider)
+	require.Equal(t, PreflightMisconfigured, results[2].Failure)
+
+	require.Equal(t, provider.ProviderMexc, results[3].Provider)
+	require.Equal(t, PreflightUnreachable, results[3].Failure)
+	require.Error(t, results[3].Err)
+}
+
+func TestClassifyPreflightError(t *testing.T) {
+	require.Equal(t,
+		PreflightMisconfigured,
+		classifyPreflightError(fmt.Errorf("get pairs: %w", &net.DNSError{Name: "api.binance.con", IsNotFound: true})),
+	)
+	require.Equal(t,
+		PreflightUnreachable,
+		classifyPreflightError(fmt.Errorf("get pairs: %w", &net.DNSError{Name: "api.binance.com", IsTimeout: true})),
+	)
+	require.Equal(t, PreflightUnreachable, classifyPreflightError(context.DeadlineExceeded))
+	require.Equal(t, PreflightMisconfigured, classifyPreflightError(fmt.Errorf("provider foo not found")))
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+type (
+	// SymbolAliases maps the symbols used in the config to the symbols a
+	// provider lists the same assets under, ex. when a venue still uses the
+	// ticker an asset had before a rebrand.
+	SymbolAliases map[string]string
+
+	// aliasProvider wraps a provider which only knows the aliased symbols. The
+	// pairs requested from it are translated to the provider's symbols and its
+	// prices are keyed by the requested pairs again. GetAvailablePairs still
+	// returns the provider's own symbols.
+	aliasProvider struct {
+		Provider
+		aliases SymbolAliases
+	}
+)
+
+// NewAliasProvider wraps a provider created with the pairs returned by
+// aliases.Apply so that it can be used with the config's symbols.
+func NewAliasProvider(p Provider, aliases SymbolAliases) Provider {
+	if len(aliases) == 0 {
+		return p
+	}
+	return &aliasProvider{
+		Provider: p,
+		aliases:  aliases,
+	}
+}
+
+// Apply returns the pairs with their base and quote replaced by the provider's
+// symbols.
+func (a SymbolAliases) Apply(pairs ...types.CurrencyPair) []types.CurrencyPair {
+	aliased := make([]types.CurrencyPair, len(pairs))
+	for i, cp := range pairs {
+		aliased[i] = a.apply(cp)
+	}
+	return aliased
+}
+
+func (a SymbolAliases) apply(cp types.CurrencyPair) types.CurrencyPair {
+	if alias, ok := a[cp.Base]; ok {
+		cp.Base = alias
+	}
+	if alias, ok := a[cp.Quote]; ok {
+		cp.Quote = alias
+	}
+	return cp
+}
+
+// revert returns the pair with the provider's symbols replaced by the config's
+// symbols they alias.
+func (a SymbolAliases) revert(cp types.CurrencyPair) types.CurrencyPair {
+	for symbol, alias := range a {
+		if cp.Base == alias {
+			cp.Base = symbol
+		}
+		if cp.Quote == alias {
+			cp.Quote = symbol
+		}
+	}
+	return cp
+}
+
+// GetTickerPrices returns the tickerPrices of the provider keyed by the
+// requested pairs.
+func (p *aliasProvider) GetTickerPrices(
+	ctx context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string]types.TickerPrice, error) {
+	prices, err := p.Provider.GetTickerPrices(ctx, p.aliases.Apply(pairs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	tickerPrices := make(map[string]types.TickerPrice, len(prices))
+	for _, cp := range pairs {
+		if price, ok := prices[p.aliases.apply(cp).String()]; ok {
+			tickerPrices[cp.String()] = price
+		}
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns the candlePrices of the provider keyed by the
+// requested pairs.
+func (p *aliasProvider) GetCandlePrices(
+	ctx context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	prices, err := p.Provider.GetCandlePrices(ctx, p.aliases.Apply(pairs...)...)
+	if err != nil {
+		return nil, err
+	}
+	return p.candlesByPair(prices, pairs), nil
+}
+
+// getCandlePricesWithInterval returns the candlePrices of the provider
+// spanning the given interval keyed by the requested pairs, see
+// GetCandlePricesWithInterval.
+func (p *aliasProvider) getCandlePricesWithInterval(
+	ctx context.Context,
+	providerName Name,
+	interval time.Duration,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	prices, err := GetCandlePricesWithInterval(ctx, p.Provider, providerName, interval, p.aliases.Apply(pairs...)...)
+	if err != nil {
+		return nil, err
+	}
+	return p.candlesByPair(prices, pairs), nil
+}
+
+// candlesByPair keys the provider's candles, keyed by its own symbols, by the
+// requested pairs.
+func (p *aliasProvider) candlesByPair(
+	prices map[string][]types.CandlePrice,
+	pairs []types.CurrencyPair,
+) map[string][]types.CandlePrice {
+	candlePrices := make(map[string][]types.CandlePrice, len(prices))
+	for _, cp := range pairs {
+		if candles, ok := prices[p.aliases.apply(cp).String()]; ok {
+			candlePrices[cp.String()] = candles
+		}
+	}
+	return candlePrices
+}
+
+// SubscribeCurrencyPairs subscribes to the pairs using the provider's symbols.
+func (p *aliasProvider) SubscribeCurrencyPairs(pairs ...types.CurrencyPair) {
+	p.Provider.SubscribeCurrencyPairs(p.aliases.Apply(pairs...)...)
+}
+
+// UnsubscribeCurrencyPairs unsubscribes from the pairs using the provider's
+// symbols.
+func (p *aliasProvider) UnsubscribeCurrencyPairs(pairs ...types.CurrencyPair) {
+	p.Provider.UnsubscribeCurrencyPairs(p.aliases.Apply(pairs...)...)
+}
+
+// GetSubscribedPairs returns the pairs the provider is subscribed to using the
+// config's symbols.
+func (p *aliasProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	subscribed := p.Provider.GetSubscribedPairs()
+	if subscribed == nil {
+		return nil
+	}
+
+	pairs := make(map[string]types.CurrencyPair, len(subscribed))
+	for _, cp := range subscribed {
+		cp = p.aliases.revert(cp)
+		pairs[cp.String()] = cp
+	}
+	return pairs
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// venueProvider returns the prices of the pairs it knows by their symbols and
+// records the pairs it was subscribed to.
+type venueProvider struct {
+	prices     map[string]types.TickerPrice
+	subscribed []types.CurrencyPair
+}
+
+func (p *venueProvider) GetTickerPrices(
+	_ context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string]types.TickerPrice, error) {
+	tickerPrices := make(map[string]types.TickerPrice)
+	for _, cp := range pairs {
+		if price, ok := p.prices[cp.String()]; ok {
+			tickerPrices[cp.String()] = price
+		}
+	}
+	return tickerPrices, nil
+}
+
+func (p *venueProvider) GetCandlePrices(
+	_ context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	candlePrices := make(map[string][]types.CandlePrice)
+	for _, cp := range pairs {
+		if price, ok := p.prices[cp.String()]; ok {
+			candlePrices[cp.String()] = []types.CandlePrice{{Price: price.Price, Volume: price.Volume}}
+		}
+	}
+	return candlePrices, nil
+}
+
+func (p *venueProvider) Describe() Description {
+	return Description{}
+}
+
+func (p *venueProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	return map[string]struct{}{}, nil
+}
+
+func (p *venueProvider) SubscribeCurrencyPairs(pairs ...types.CurrencyPair) {
+	p.subscribed = append(p.subscribed, pairs...)
+}
+
+func (p *venueProvider) UnsubscribeCurrencyPairs(pairs ...types.CurrencyPair) {
+	for _, cp := range pairs {
+		for i, subscribed := range p.subscribed {
+			if subscribed == cp {
+				p.subscribed = append(p.subscribed[:i], p.subscribed[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (p *venueProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	pairs := make(map[string]types.CurrencyPair, len(p.subscribed))
+	for _, cp := range p.subscribed {
+		pairs[cp.String()] = cp
+	}
+	return pairs
+}
+
+func (p *venueProvider) StartConnections() {}
+
+func TestAliasProvider(t *testing.T) {
+	polPrice := types.TickerPrice{Price: sdk.MustNewDecFromStr("0.7"), Volume: sdk.MustNewDecFromStr("1000")}
+	atomPrice := types.TickerPrice{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("500")}
+
+	venue := &venueProvider{
+		prices: map[string]types.TickerPrice{
+			"POLUSD":  polPrice,
+			"ATOMUSD": atomPrice,
+		},
+	}
+	p := NewAliasProvider(venue, SymbolAliases{"MATIC": "POL"})
+
+	matic := types.CurrencyPair{Base: "MATIC", Quote: "USD"}
+	atom := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+
+	t.Run("ticker prices", func(t *testing.T) {
+		prices, err := p.GetTickerPrices(context.TODO(), matic, atom)
+		require.NoError(t, err)
+		require.Equal(t, map[string]types.TickerPrice{
+			"MATICUSD": polPrice,
+			"ATOMUSD":  atomPrice,
+		}, prices)
+	})
+
+	t.Run("candle prices", func(t *testing.T) {
+		prices, err := p.GetCandlePrices(context.TODO(), matic)
+		require.NoError(t, err)
+		require.Len(t, prices, 1)
+		require.Equal(t, polPrice.Price, prices["MATICUSD"][0].Price)
+	})
+
+	t.Run("subscribe", func(t *testing.T) {
+		p.SubscribeCurrencyPairs(matic, atom)
+		require.Equal(t, []types.CurrencyPair{
+			{Base: "POL", Quote: "USD"},
+			atom,
+		}, venue.subscribed)
+	})
+
+	t.Run("subscribed pairs", func(t *testing.T) {
+		require.Equal(t, map[string]types.CurrencyPair{
+			"MATICUSD": matic,
+			"ATOMUSD":  atom,
+		}, p.GetSubscribedPairs())
+	})
+}
+
+func TestNewAliasProvider_NoAliases(t *testing.T) {
+	venue := &venueProvider{}
+	require.Equal(t, Provider(venue), NewAliasProvider(venue, nil))
+}
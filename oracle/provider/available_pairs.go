@@ -13,22 +13,38 @@ import (
 // to, and uses the given provider's GetAvailablePairs method to check that the
 // given pairs can be subscribed to. It will return an updated list of pairs that
 // can be subsribed to, and send a warning log about any pairs passed in that
-// cannot be subsribed to.
+// cannot be subsribed to. If the available pairs can't be retrieved and the
+// endpoint assumes its pairs are available, all of the pairs are returned.
 func ConfirmPairAvailability(
 	p Provider,
-	providerName Name,
+	endpoint Endpoint,
 	logger zerolog.Logger,
 	cps ...types.CurrencyPair,
 ) ([]types.CurrencyPair, error) {
+	providerName := endpoint.Name
 	availablePairs, err := p.GetAvailablePairs()
 	if err != nil {
-		return nil, err
+		if !endpoint.AssumeAvailable {
+			return nil, err
+		}
+		logger.Warn().
+			Err(err).
+			Str("provider", providerName.String()).
+			Msg("failed to get available pairs, subscribing to all pairs")
+		return cps, nil
+	}
+
+	// providers don't all list their pairs in upper case, so both sides are
+	// normalized before comparing them
+	upperPairs := make(map[string]struct{}, len(availablePairs))
+	for symbol := range availablePairs {
+		upperPairs[strings.ToUpper(symbol)] = struct{}{}
 	}
 
 	// confirm pairs can be subscribed to
 	confirmedPairs := []types.CurrencyPair{}
 	for _, cp := range cps {
-		if _, ok := availablePairs[strings.ToUpper(cp.String())]; !ok {
+		if _, ok := upperPairs[strings.ToUpper(cp.String())]; !ok {
 			logger.Warn().Msg(fmt.Sprintf(
 				"%s not an available pair to be subscribed to in %v, %v ignoring pair",
 				cp.String(),
@@ -42,3 +58,52 @@ func ConfirmPairAvailability(
 
 	return confirmedPairs, nil
 }
+
+// marketListing defines a market a provider lists a pair in. Venues may list a
+// symbol more than once, ex. for its spot and margin markets, and the listings
+// are told apart by their market type and trading status.
+type marketListing struct {
+	symbol string
+	spot   bool
+	status string
+}
+
+// availableMarkets returns the symbols of the listings the provider can
+// subscribe to. When a symbol is listed more than once, its spot listing is
+// preferred and the duplicates are logged if their attributes differ. Symbols
+// only listed in other markets are left out, since the providers subscribe to
+// spot markets.
+func availableMarkets(logger zerolog.Logger, listings []marketListing) map[string]struct{} {
+	preferred := make(map[string]marketListing, len(listings))
+	for _, listing := range listings {
+		symbol := strings.ToUpper(listing.symbol)
+		kept, ok := preferred[symbol]
+		if !ok {
+			preferred[symbol] = listing
+			continue
+		}
+
+		if kept != listing {
+			logger.Warn().
+				Str("symbol", symbol).
+				Bool("spot", kept.spot).
+				Str("status", kept.status).
+				Bool("duplicate_spot", listing.spot).
+				Str("duplicate_status", listing.status).
+				Msg("provider listed pair more than once with differing attributes")
+		}
+		if listing.spot && !kept.spot {
+			preferred[symbol] = listing
+		}
+	}
+
+	availablePairs := make(map[string]struct{}, len(preferred))
+	for symbol, listing := range preferred {
+		if !listing.spot {
+			logger.Debug().Str("symbol", symbol).Msg("pair isn't listed in a spot market")
+			continue
+		}
+		availablePairs[symbol] = struct{}{}
+	}
+	return availablePairs
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// discoveryProvider is a venueProvider listing its available pairs, or failing
+// to if err is set.
+type discoveryProvider struct {
+	venueProvider
+	available map[string]struct{}
+	err       error
+}
+
+func (p *discoveryProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	return p.available, p.err
+}
+
+func TestConfirmPairAvailability(t *testing.T) {
+	cps := []types.CurrencyPair{
+		{Base: "ATOM", Quote: "USDT"},
+		{Base: "OJO", Quote: "USDT"},
+	}
+
+	p := &discoveryProvider{available: map[string]struct{}{"ATOMUSDT": {}}}
+	confirmed, err := ConfirmPairAvailability(p, Endpoint{Name: ProviderBinance}, zerolog.Nop(), cps...)
+	require.NoError(t, err)
+	require.Equal(t, cps[:1], confirmed)
+
+	p = &discoveryProvider{err: fmt.Errorf("rest endpoint unreachable")}
+	_, err = ConfirmPairAvailability(p, Endpoint{Name: ProviderBinance}, zerolog.Nop(), cps...)
+	require.Error(t, err)
+
+	// pairs are assumed to be available when they can't be confirmed
+	confirmed, err = ConfirmPairAvailability(
+		p,
+		Endpoint{Name: ProviderBinance, AssumeAvailable: true},
+		zerolog.Nop(),
+		cps...,
+	)
+	require.NoError(t, err)
+	require.Equal(t, cps, confirmed)
+}
+
+func TestConfirmPairAvailability_MixedCase(t *testing.T) {
+	testCases := []struct {
+		name      string
+		available string
+		cp        types.CurrencyPair
+	}{
+		{name: "upper case", available: "ATOMUSDT", cp: types.CurrencyPair{Base: "ATOM", Quote: "USDT"}},
+		{name: "lower case pair", available: "ATOMUSDT", cp: types.CurrencyPair{Base: "atom", Quote: "usdt"}},
+		{name: "mixed case pair", available: "ATOMUSDT", cp: types.CurrencyPair{Base: "Atom", Quote: "USDT"}},
+		{name: "lower case available pair", available: "atomusdt", cp: types.CurrencyPair{Base: "ATOM", Quote: "USDT"}},
+		{name: "mixed case on both sides", available: "AtomUsdt", cp: types.CurrencyPair{Base: "atom", Quote: "USDT"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &discoveryProvider{available: map[string]struct{}{tc.available: {}}}
+			confirmed, err := ConfirmPairAvailability(p, Endpoint{Name: ProviderBinance}, zerolog.Nop(), tc.cp)
+			require.NoError(t, err)
+			require.Equal(t, []types.CurrencyPair{tc.cp}, confirmed)
+		})
+	}
+}
+
+func TestAvailableMarkets(t *testing.T) {
+	available := availableMarkets(zerolog.Nop(), []marketListing{
+		{symbol: "ATOMUSDT", spot: false, status: "margin_only"},
+		{symbol: "ATOMUSDT", spot: true, status: "online"},
+		{symbol: "ojousdt", spot: true, status: "online"},
+		{symbol: "OJOUSDT", spot: true, status: "online"},
+		{symbol: "BTCUSDT", spot: false},
+	})
+
+	// the spot listing is kept among duplicates, and pairs only listed in
+	// other markets are left out
+	require.Equal(t, map[string]struct{}{"ATOMUSDT": {}, "OJOUSDT": {}}, available)
+}
@@ -23,7 +23,19 @@ const (
 	binanceRestPath   = "/api/v3/ticker/price"
 )
 
-var _ Provider = (*BinanceProvider)(nil)
+var (
+	_ Provider = (*BinanceProvider)(nil)
+
+	// binanceSubscription subscribes to the ticker and candle streams of each
+	// pair in separate messages.
+	binanceSubscription = subscriptionFormat{
+		channels:   []string{"ticker", "kline_1m"},
+		perChannel: true,
+		maxPairs:   1,
+		symbol:     currencyPairToBinancePair,
+		message:    newBinanceStreamSubscriptionMsg,
+	}
+)
 
 type (
 	// BinanceProvider defines an Oracle provider implemented by the Binance public
@@ -45,19 +57,28 @@ type (
 	// Unmarshal matches incoming object keys to the keys used by Marshal (either the
 	// struct field name or its tag), preferring an exact match but also accepting a
 	// case-insensitive match. C field which is Statistics close time is not used, but
-	// it avoids to implement specific UnmarshalJSON.
+	// it avoids to implement specific UnmarshalJSON. The same goes for the B and A
+	// quantities of the best bid and ask.
 	BinanceTicker struct {
 		Symbol    string `json:"s"` // Symbol ex.: BTCUSDT
 		LastPrice string `json:"c"` // Last price ex.: 0.0025
 		Volume    string `json:"v"` // Total traded base asset volume ex.: 1000
 		C         uint64 `json:"C"` // Statistics close time
+		BestBid   string `json:"b"` // Best bid price ex.: 0.0024
+		B         string `json:"B"` // Best bid quantity
+		BestAsk   string `json:"a"` // Best ask price ex.: 0.0026
+		A         string `json:"A"` // Best ask quantity
 	}
 
 	// BinanceCandleMetadata candle metadata used to compute tvwap price.
 	BinanceCandleMetadata struct {
-		Close     string `json:"c"` // Price at close
-		TimeStamp int64  `json:"T"` // Close time in unix epoch ex.: 1645756200000
-		Volume    string `json:"v"` // Volume during period
+		Open        string `json:"o"` // Price at open
+		High        string `json:"h"` // Highest price during period
+		Low         string `json:"l"` // Lowest price during period
+		Close       string `json:"c"` // Price at close
+		TimeStamp   int64  `json:"T"` // Close time in unix epoch ex.: 1645756200000
+		Volume      string `json:"v"` // Volume during period
+		LastTradeID int64  `json:"L"` // Last trade ID, keeps "L" from matching Low
 	}
 
 	// BinanceCandle candle binance websocket channel "kline_1m" response.
@@ -93,21 +114,23 @@ func NewBinanceProvider(
 	binanceUS bool,
 	pairs ...types.CurrencyPair,
 ) (*BinanceProvider, error) {
-	if (endpoints.Name) != ProviderBinance {
-		if !binanceUS {
-			endpoints = Endpoint{
-				Name:      ProviderBinance,
-				Rest:      binanceRestHost,
-				Websocket: binanceWSHost,
-			}
-		} else {
-			endpoints = Endpoint{
-				Name:      ProviderBinanceUS,
-				Rest:      binanceRestUSHost,
-				Websocket: binanceUSWSHost,
-			}
+	// binance.us shares the streams and symbols of binance.com, only its
+	// hosts and listed pairs differ
+	defaultEndpoints := Endpoint{
+		Name:      ProviderBinance,
+		Rest:      binanceRestHost,
+		Websocket: binanceWSHost,
+	}
+	if binanceUS {
+		defaultEndpoints = Endpoint{
+			Name:      ProviderBinanceUS,
+			Rest:      binanceRestUSHost,
+			Websocket: binanceUSWSHost,
 		}
 	}
+	if endpoints.Name != defaultEndpoints.Name {
+		endpoints = defaultEndpoints
+	}
 
 	wsURL := url.URL{
 		Scheme: "wss",
@@ -115,7 +138,7 @@ func NewBinanceProvider(
 		Path:   binanceWSPath,
 	}
 
-	binanceLogger := logger.With().Str("provider", string(ProviderBinance)).Logger()
+	binanceLogger := logger.With().Str("provider", string(endpoints.Name)).Logger()
 
 	provider := &BinanceProvider{
 		logger:          binanceLogger,
@@ -127,7 +150,7 @@ func NewBinanceProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -137,11 +160,16 @@ func NewBinanceProvider(
 
 	provider.setSubscribedPairs(confirmedPairs...)
 
+	subscriptionMsgs, err := provider.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		return nil, err
+	}
+
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
-		provider.getSubscriptionMsgs(confirmedPairs...),
+		subscriptionMsgs,
 		provider.messageReceived,
 		disabledPingDuration,
 		websocket.PingMessage,
@@ -155,16 +183,13 @@ func (p *BinanceProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
-func (p *BinanceProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
-	subscriptionMsgs := make([]interface{}, 0, len(p.subscribedPairs)*2)
-	for _, cp := range cps {
-		binanceTickerPair := currencyPairToBinanceTickerPair(cp)
-		subscriptionMsgs = append(subscriptionMsgs, newBinanceSubscriptionMsg(binanceTickerPair))
+// Describe returns the capabilities of the provider.
+func (p *BinanceProvider) Describe() Description {
+	return describe(p.endpoints, true, binanceSubscription.channels...)
+}
 
-		binanceCandlePair := currencyPairToBinanceCandlePair(cp)
-		subscriptionMsgs = append(subscriptionMsgs, newBinanceSubscriptionMsg(binanceCandlePair))
-	}
-	return subscriptionMsgs
+func (p *BinanceProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) ([]interface{}, error) {
+	return binanceSubscription.build(cps...)
 }
 
 // SubscribeCurrencyPairs sends the new subscription messages to the websocket
@@ -182,7 +207,7 @@ func (p *BinanceProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -190,7 +215,11 @@ func (p *BinanceProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 		return
 	}
 
-	newSubscriptionMsgs := p.getSubscriptionMsgs(confirmedPairs...)
+	newSubscriptionMsgs, err := p.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to build subscription messages")
+		return
+	}
 	p.wsc.AddWebsocketConnection(
 		newSubscriptionMsgs,
 		p.messageReceived,
@@ -200,8 +229,12 @@ func (p *BinanceProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *BinanceProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *BinanceProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *BinanceProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
@@ -209,7 +242,7 @@ func (p *BinanceProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[stri
 		key := cp.String()
 		price, err := p.getTickerPrice(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -227,7 +260,7 @@ func (p *BinanceProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[stri
 }
 
 // GetCandlePrices returns the candlePrices based on the provided pairs.
-func (p *BinanceProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *BinanceProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
@@ -235,7 +268,7 @@ func (p *BinanceProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[stri
 		key := cp.String()
 		prices, err := p.getCandlePrices(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -265,7 +298,14 @@ func (p *BinanceProvider) getTickerPrice(key string) (types.TickerPrice, error)
 		)
 	}
 
-	return ticker.toTickerPrice()
+	tp, err := ticker.toTickerPrice()
+	if err != nil {
+		return types.TickerPrice{}, err
+	}
+	if err := p.endpoints.checkSpread(key, tp); err != nil {
+		return types.TickerPrice{}, err
+	}
+	return tp, nil
 }
 
 func (p *BinanceProvider) getCandlePrices(key string) ([]types.CandlePrice, error) {
@@ -305,14 +345,14 @@ func (p *BinanceProvider) messageReceived(_ int, _ *WebsocketConnection, bz []by
 	tickerErr = json.Unmarshal(bz, &tickerResp)
 	if len(tickerResp.LastPrice) != 0 {
 		p.setTickerPair(tickerResp)
-		telemetryWebsocketMessage(ProviderBinance, MessageTypeTicker)
+		telemetryWebsocketMessage(p.endpoints.Name, MessageTypeTicker)
 		return
 	}
 
 	candleErr = json.Unmarshal(bz, &candleResp)
 	if len(candleResp.Metadata.Close) != 0 {
 		p.setCandlePair(candleResp)
-		telemetryWebsocketMessage(ProviderBinance, MessageTypeCandle)
+		telemetryWebsocketMessage(p.endpoints.Name, MessageTypeCandle)
 		return
 	}
 
@@ -321,6 +361,7 @@ func (p *BinanceProvider) messageReceived(_ int, _ *WebsocketConnection, bz []by
 		return
 	}
 
+	telemetryParseError(p.endpoints.Name, parseErrorReason(tickerErr, candleErr, subscribeRespErr))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("ticker", tickerErr).
@@ -343,6 +384,9 @@ func (p *BinanceProvider) setCandlePair(candle BinanceCandle) {
 	candleList = append(candleList, candle)
 
 	for _, c := range p.candles[candle.Symbol] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.Metadata.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -351,12 +395,26 @@ func (p *BinanceProvider) setCandlePair(candle BinanceCandle) {
 }
 
 func (ticker BinanceTicker) toTickerPrice() (types.TickerPrice, error) {
-	return types.NewTickerPrice(string(ProviderBinance), ticker.Symbol, ticker.LastPrice, ticker.Volume)
+	tp, err := types.NewTickerPrice(string(ProviderBinance), ticker.Symbol, ticker.LastPrice, ticker.Volume)
+	if err != nil {
+		return types.TickerPrice{}, err
+	}
+	return tp.WithBidAsk(string(ProviderBinance), ticker.Symbol, ticker.BestBid, ticker.BestAsk)
 }
 
 func (candle BinanceCandle) toCandlePrice() (types.CandlePrice, error) {
-	return types.NewCandlePrice(string(ProviderBinance), candle.Symbol, candle.Metadata.Close, candle.Metadata.Volume,
+	cp, err := types.NewCandlePrice(string(ProviderBinance), candle.Symbol, candle.Metadata.Close, candle.Metadata.Volume,
 		candle.Metadata.TimeStamp)
+	if err != nil {
+		return types.CandlePrice{}, err
+	}
+	return cp.WithOHLC(
+		string(ProviderBinance),
+		candle.Symbol,
+		candle.Metadata.Open,
+		candle.Metadata.High,
+		candle.Metadata.Low,
+	)
 }
 
 // setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
@@ -366,10 +424,18 @@ func (p *BinanceProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *BinanceProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 // ex.: map["ATOMUSDT" => {}, "OJOUSDC" => {}].
 func (p *BinanceProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + binanceRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + binanceRestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -388,16 +454,34 @@ func (p *BinanceProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	return availablePairs, nil
 }
 
+// currencyPairToBinancePair receives a currency pair and return binance
+// symbol atomusdt.
+func currencyPairToBinancePair(cp types.CurrencyPair) string {
+	return strings.ToLower(cp.String())
+}
+
 // currencyPairToBinanceTickerPair receives a currency pair and return binance
 // ticker symbol atomusdt@ticker.
 func currencyPairToBinanceTickerPair(cp types.CurrencyPair) string {
-	return strings.ToLower(cp.String() + "@ticker")
+	return currencyPairToBinancePair(cp) + "@ticker"
 }
 
 // currencyPairToBinanceCandlePair receives a currency pair and return binance
 // candle symbol atomusdt@kline_1m.
 func currencyPairToBinanceCandlePair(cp types.CurrencyPair) string {
-	return strings.ToLower(cp.String() + "@kline_1m")
+	return currencyPairToBinancePair(cp) + "@kline_1m"
+}
+
+// newBinanceStreamSubscriptionMsg returns a new subscription Msg to the
+// streams of every symbol and channel, ex. atomusdt@ticker.
+func newBinanceStreamSubscriptionMsg(channels, symbols []string) interface{} {
+	params := make([]string, 0, len(symbols)*len(channels))
+	for _, symbol := range symbols {
+		for _, channel := range channels {
+			params = append(params, symbol+"@"+channel)
+		}
+	}
+	return newBinanceSubscriptionMsg(params...)
 }
 
 // newBinanceSubscriptionMsg returns a new subscription Msg.
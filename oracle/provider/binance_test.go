@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -34,7 +37,7 @@ func TestBinanceProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr(lastPrice), prices["ATOMUSDT"].Price)
@@ -61,6 +64,7 @@ func TestBinanceProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "LUNA", Quote: "USDT"},
 		)
@@ -73,12 +77,84 @@ func TestBinanceProvider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "binanceus has no ticker data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
 }
 
+func TestBinanceProvider_GetTickerPrices_LogsMissingPair(t *testing.T) {
+	var logs bytes.Buffer
+	p := &BinanceProvider{
+		logger:    zerolog.New(&logs).Level(zerolog.DebugLevel),
+		endpoints: Endpoint{Name: ProviderBinance},
+		tickers: map[string]BinanceTicker{
+			"ATOMUSDT": {Symbol: "ATOMUSDT", LastPrice: "34.69", Volume: "2396974.02"},
+		},
+	}
+
+	prices, err := p.GetTickerPrices(
+		context.TODO(),
+		types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
+		types.CurrencyPair{Base: "FOO", Quote: "BAR"},
+	)
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+
+	// the missing pair is logged with its error at debug level
+	var entry map[string]string
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	require.Equal(t, "debug", entry["level"])
+	require.Equal(t, "FOOBAR", entry["pair"])
+	require.Equal(t, "failed to get ticker price", entry["message"])
+	require.Contains(t, entry["error"], "FOOBAR")
+}
+
+func TestNewBinanceProvider_BinanceUS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, binanceRestPath, r.URL.Path)
+		w.Write([]byte(`[{"symbol":"ATOMUSDT"}]`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	atomPair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	ojoPair := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+
+	// the endpoint configured for binance.us isn't replaced by the defaults
+	endpoint := Endpoint{
+		Name:      ProviderBinanceUS,
+		Rest:      server.URL,
+		Websocket: "stream.binance.invalid:9443",
+	}
+	p, err := NewBinanceProvider(context.TODO(), zerolog.Nop(), endpoint, true, atomPair, ojoPair)
+	require.NoError(t, err)
+	require.Equal(t, endpoint, p.endpoints)
+
+	// only the pairs listed on binance.us are subscribed to
+	require.Equal(t, map[string]types.CurrencyPair{"ATOMUSDT": atomPair}, p.GetSubscribedPairs())
+}
+
+func TestBinanceProvider_SetCandlePair_MaxCandles(t *testing.T) {
+	p := &BinanceProvider{
+		endpoints: Endpoint{Name: ProviderBinance, MaxCandles: 3},
+		candles:   map[string][]BinanceCandle{},
+	}
+
+	start := PastUnixTime(providerCandlePeriod / 2)
+	for i := int64(0); i < 5; i++ {
+		p.setCandlePair(BinanceCandle{
+			Symbol:   "ATOMUSDT",
+			Metadata: BinanceCandleMetadata{TimeStamp: start + i*unixMinute},
+		})
+	}
+
+	// the most recent candles are kept
+	candles := p.candles["ATOMUSDT"]
+	require.Len(t, candles, 3)
+	require.Equal(t, start+4*unixMinute, candles[0].Metadata.TimeStamp)
+	require.Equal(t, start+2*unixMinute, candles[2].Metadata.TimeStamp)
+}
+
 func TestBinanceCurrencyPairToBinancePair(t *testing.T) {
 	cp := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
 	binanceSymbol := currencyPairToBinanceTickerPair(cp)
@@ -93,7 +169,8 @@ func TestBinanceProvider_getSubscriptionMsgs(t *testing.T) {
 		{Base: "ATOM", Quote: "USDT"},
 	}
 
-	subMsgs := provider.getSubscriptionMsgs(cps...)
+	subMsgs, err := provider.getSubscriptionMsgs(cps...)
+	require.NoError(t, err)
 
 	msg, _ := json.Marshal(subMsgs[0])
 	require.Equal(t, "{\"method\":\"SUBSCRIBE\",\"params\":[\"atomusdt@ticker\"],\"id\":1}", string(msg))
@@ -101,3 +178,31 @@ func TestBinanceProvider_getSubscriptionMsgs(t *testing.T) {
 	msg, _ = json.Marshal(subMsgs[1])
 	require.Equal(t, "{\"method\":\"SUBSCRIBE\",\"params\":[\"atomusdt@kline_1m\"],\"id\":1}", string(msg))
 }
+
+func TestBinanceTicker_BidAsk(t *testing.T) {
+	// the quantities "B" and "A" must not overwrite the bid and ask prices
+	msg := `{"e":"24hrTicker","s":"ATOMUSDT","c":"10.01","v":"1000","b":"10.00","B":"31.21","a":"10.02","A":"40.66","C":1499869899040}`
+
+	var ticker BinanceTicker
+	require.NoError(t, json.Unmarshal([]byte(msg), &ticker))
+
+	tp, err := ticker.toTickerPrice()
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("10.00"), tp.Bid)
+	require.Equal(t, sdk.MustNewDecFromStr("10.02"), tp.Ask)
+}
+
+func TestBinanceCandle_OHLC(t *testing.T) {
+	// the last trade id "L" must not be decoded into the low price
+	msg := `{"e":"kline","s":"ATOMUSDT","k":{"t":1499040000000,"T":1499040059999,"s":"ATOMUSDT","i":"1m","f":100,"L":200,"o":"10.00","c":"10.02","h":"10.05","l":"9.98","v":"1000"}}`
+
+	var candle BinanceCandle
+	require.NoError(t, json.Unmarshal([]byte(msg), &candle))
+
+	cp, err := candle.toCandlePrice()
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("10.00"), cp.Open)
+	require.Equal(t, sdk.MustNewDecFromStr("10.05"), cp.High)
+	require.Equal(t, sdk.MustNewDecFromStr("9.98"), cp.Low)
+	require.Equal(t, sdk.MustNewDecFromStr("10.02"), cp.Price)
+}
@@ -25,6 +25,7 @@ const (
 	tickerChannel       = "ticker"
 	candleChannel       = "candle5m"
 	instType            = "SP"
+	bitgetSpotSuffix    = "_SPBL"
 )
 
 var _ Provider = (*BitgetProvider)(nil)
@@ -100,8 +101,10 @@ type (
 		Data     []BitgetPairData `json:"data"`
 	}
 	BitgetPairData struct {
-		Base  string `json:"baseCoin"`
-		Quote string `json:"quoteCoin"`
+		Symbol string `json:"symbol"`
+		Base   string `json:"baseCoin"`
+		Quote  string `json:"quoteCoin"`
+		Status string `json:"status"`
 	}
 )
 
@@ -139,7 +142,7 @@ func NewBitgetProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -151,7 +154,7 @@ func NewBitgetProvider(
 
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
 		provider.getSubscriptionMsgs(confirmedPairs...),
 		provider.messageReceived,
@@ -159,6 +162,7 @@ func NewBitgetProvider(
 		websocket.TextMessage,
 		bitgetLogger,
 	)
+	provider.wsc.SetSubscriptionAcks(bitgetSubscriptionAckKeys)
 	return provider, nil
 }
 
@@ -166,6 +170,11 @@ func (p *BitgetProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
+// Describe returns the capabilities of the provider.
+func (p *BitgetProvider) Describe() Description {
+	return describe(p.endpoints, true, tickerChannel, candleChannel)
+}
+
 func (p *BitgetProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
 	subscriptionMsgs := make([]interface{}, 0, 1)
 	bitgetTickerSubscriptionMsg := newBitgetTickerSubscriptionMsg(cps)
@@ -189,7 +198,7 @@ func (p *BitgetProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -202,20 +211,24 @@ func (p *BitgetProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 		newSubscriptionMsgs,
 		p.messageReceived,
 		defaultPingDuration,
-		websocket.PingMessage,
+		websocket.TextMessage,
 	)
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *BitgetProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *BitgetProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *BitgetProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
 	for _, cp := range pairs {
 		price, err := p.getTickerPrice(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -233,14 +246,14 @@ func (p *BitgetProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[strin
 }
 
 // GetCandlePrices returns the candlePrices based on the provided pairs.
-func (p *BitgetProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *BitgetProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
 	for _, cp := range pairs {
 		prices, err := p.getCandlePrices(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -258,7 +271,7 @@ func (p *BitgetProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[strin
 }
 
 // messageReceived handles the received data from the Bitget websocket.
-func (p *BitgetProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byte) {
+func (p *BitgetProvider) messageReceived(_ int, conn *WebsocketConnection, bz []byte) {
 	var (
 		tickerResp           BitgetTicker
 		tickerErr            error
@@ -285,6 +298,7 @@ func (p *BitgetProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byt
 			Str("Channel", subscriptionResponse.Arg.Channel).
 			Str("InstType", subscriptionResponse.Arg.InstType).
 			Msg("Bitget subscription confirmed")
+		conn.AcknowledgeSubscription(bitgetSubscriptionAckKey(subscriptionResponse.Arg))
 		return
 	}
 
@@ -299,16 +313,19 @@ func (p *BitgetProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byt
 	if candleResp.Arg.Channel == candleChannel {
 		candle, err := candleResp.ToBitgetCandle()
 		if err != nil {
+			telemetryParseError(ProviderBitget, parseErrorReason(err))
 			p.logger.Error().
 				Int("length", len(bz)).
 				AnErr("candle", err).
 				Msg("Unable to parse bitget candle")
+			return
 		}
 		p.setCandlePair(candle)
 		telemetryWebsocketMessage(ProviderBitget, MessageTypeCandle)
 		return
 	}
 
+	telemetryParseError(ProviderBitget, parseErrorReason(tickerErr, candleErr))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("ticker", tickerErr).
@@ -322,7 +339,7 @@ func (p *BitgetProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byt
 // Ref: https://bitgetlimited.github.io/apidoc/en/spot/#candlesticks-channel
 func (bcr BitgetCandleResponse) ToBitgetCandle() (BitgetCandle, error) {
 	if len(bcr.Data) < 1 || len(bcr.Data[0]) < 6 {
-		return BitgetCandle{}, fmt.Errorf("invalid candle response")
+		return BitgetCandle{}, newParseError(ParseErrorMissingField, fmt.Errorf("invalid candle response"))
 	}
 
 	ts, err := strconv.ParseInt(bcr.Data[0][0], 10, 64)
@@ -352,6 +369,9 @@ func (p *BitgetProvider) setCandlePair(candle BitgetCandle) {
 	candleList = append(candleList, candle)
 
 	for _, c := range p.candles[candle.Arg.InstID] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -406,9 +426,17 @@ func (p *BitgetProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *BitgetProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 func (p *BitgetProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + bitgetRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + bitgetRestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -422,16 +450,21 @@ func (p *BitgetProvider) GetAvailablePairs() (map[string]struct{}, error) {
 		return nil, fmt.Errorf("unable to get bitget available pairs")
 	}
 
-	availablePairs := make(map[string]struct{}, len(pairsSummary.Data))
+	listings := make([]marketListing, 0, len(pairsSummary.Data))
 	for _, pair := range pairsSummary.Data {
 		cp := types.CurrencyPair{
 			Base:  pair.Base,
 			Quote: pair.Quote,
 		}
-		availablePairs[strings.ToUpper(cp.String())] = struct{}{}
+		listings = append(listings, marketListing{
+			symbol: cp.String(),
+			// spot symbols are suffixed, ex. BTCUSDT_SPBL
+			spot:   pair.Symbol == "" || strings.HasSuffix(pair.Symbol, bitgetSpotSuffix),
+			status: pair.Status,
+		})
 	}
 
-	return availablePairs, nil
+	return availableMarkets(p.logger, listings), nil
 }
 
 // toTickerPrice converts current BitgetTicker to TickerPrice.
@@ -457,6 +490,26 @@ func (candle BitgetCandle) toCandlePrice() (types.CandlePrice, error) {
 	)
 }
 
+// bitgetSubscriptionAckKeys returns the keys of the subscribe events Bitget
+// acknowledges a subscription message with, one per argument.
+func bitgetSubscriptionAckKeys(msg interface{}) []string {
+	subscriptionMsg, ok := msg.(BitgetSubscriptionMsg)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(subscriptionMsg.Args))
+	for _, arg := range subscriptionMsg.Args {
+		keys = append(keys, bitgetSubscriptionAckKey(arg))
+	}
+	return keys
+}
+
+// bitgetSubscriptionAckKey returns the acknowledgement key of the argument,
+// ex. ticker:ATOMUSDT.
+func bitgetSubscriptionAckKey(arg BitgetSubscriptionArg) string {
+	return arg.Channel + ":" + strings.ToUpper(arg.InstID)
+}
+
 // newBitgetTickerSubscriptionMsg returns a new ticker subscription Msg.
 func newBitgetTickerSubscriptionMsg(cps []types.CurrencyPair) BitgetSubscriptionMsg {
 	args := []BitgetSubscriptionArg{}
@@ -41,7 +41,7 @@ func TestBitgetProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr(lastPrice), prices["ATOMUSDT"].Price)
@@ -84,6 +84,7 @@ func TestBitgetProvider_GetTickerPrices(t *testing.T) {
 		}
 		p.tickers = tickerMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "LUNA", Quote: "USDT"},
 		)
@@ -97,7 +98,7 @@ func TestBitgetProvider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "bitget has no ticker data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
@@ -129,7 +130,7 @@ func TestBitgetProvider_GetCandlePrices(t *testing.T) {
 
 		p.setCandlePair(candle)
 
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr(price), prices["ATOMUSDT"][0].Price)
@@ -138,7 +139,7 @@ func TestBitgetProvider_GetCandlePrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_candle", func(t *testing.T) {
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "bitget has no candle data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
@@ -180,3 +181,13 @@ func TestBitgetProvider_NewSubscriptionMsg(t *testing.T) {
 	require.Equal(t, sub.Args[3].InstID, "FOOBAR")
 	require.Equal(t, sub.Args[3].Channel, "candle5m")
 }
+
+func TestBitgetProvider_SubscriptionAcks(t *testing.T) {
+	msg := newBitgetTickerSubscriptionMsg([]types.CurrencyPair{{Base: "ATOM", Quote: "USDT"}})
+	require.Equal(t, []string{tickerChannel + ":ATOMUSDT", candleChannel + ":ATOMUSDT"}, bitgetSubscriptionAckKeys(msg))
+
+	p := &BitgetProvider{logger: zerolog.Nop()}
+	conn := &WebsocketConnection{pendingAcks: map[string]struct{}{tickerChannel + ":ATOMUSDT": {}}}
+	p.messageReceived(0, conn, []byte(`{"event":"subscribe","arg":{"instType":"sp","channel":"`+tickerChannel+`","instId":"ATOMUSDT"}}`))
+	require.Empty(t, conn.pendingAcks)
+}
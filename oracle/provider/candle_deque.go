@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// candleDeque stores the candles of a pair oldest first. Candles are appended
+// at the back and stale candles are evicted from the front, both in amortized
+// O(1). Candles already stored are never modified in place, so the slice
+// returned by list can be read after the provider's lock is released while
+// newer candles are added.
+type candleDeque struct {
+	candles []types.CandlePrice
+	head    int
+}
+
+// len returns the number of candles in the deque.
+func (d *candleDeque) len() int {
+	return len(d.candles) - d.head
+}
+
+// latest returns the most recent candle of the deque, or false if it's empty.
+func (d *candleDeque) latest() (types.CandlePrice, bool) {
+	if d.len() == 0 {
+		return types.CandlePrice{}, false
+	}
+	return d.candles[len(d.candles)-1], true
+}
+
+// add evicts the candles at or before staleTime from the front of the deque
+// and adds the candles in time order. Candles older than the latest one are
+// inserted at their position, which copies the deque.
+func (d *candleDeque) add(staleTime int64, candles ...types.CandlePrice) {
+	for d.len() > 0 && d.candles[d.head].TimeStamp <= staleTime {
+		d.head++
+	}
+	d.compact()
+
+	for _, candle := range candles {
+		latest, ok := d.latest()
+		if !ok || latest.TimeStamp <= candle.TimeStamp {
+			d.candles = append(d.candles, candle)
+			continue
+		}
+
+		live := d.list()
+		i := sort.Search(len(live), func(i int) bool {
+			return live[i].TimeStamp > candle.TimeStamp
+		})
+		inserted := make([]types.CandlePrice, 0, len(live)+1)
+		inserted = append(inserted, live[:i]...)
+		inserted = append(inserted, candle)
+		inserted = append(inserted, live[i:]...)
+		d.candles = inserted
+		d.head = 0
+	}
+}
+
+// limit evicts the oldest candles beyond max candles from the deque. The
+// deque is unbounded if max isn't positive.
+func (d *candleDeque) limit(max int) {
+	if max <= 0 || d.len() <= max {
+		return
+	}
+	d.head = len(d.candles) - max
+	d.compact()
+}
+
+// compact moves the candles to a new backing array once the evicted candles
+// make up half of it, leaving the previous array untouched for its readers.
+func (d *candleDeque) compact() {
+	if d.head == 0 || d.head*2 < len(d.candles) {
+		return
+	}
+	candles := make([]types.CandlePrice, d.len(), 2*d.len()+1)
+	copy(candles, d.candles[d.head:])
+	d.candles = candles
+	d.head = 0
+}
+
+// list returns the candles of the deque, oldest first, without copying them.
+// The returned slice must not be modified.
+func (d *candleDeque) list() []types.CandlePrice {
+	return d.candles[d.head:len(d.candles):len(d.candles)]
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func testCandle(timeStamp int64) types.CandlePrice {
+	return types.CandlePrice{Price: sdk.OneDec(), Volume: sdk.OneDec(), TimeStamp: timeStamp}
+}
+
+func candleTimeStamps(candles []types.CandlePrice) []int64 {
+	timeStamps := make([]int64, len(candles))
+	for i, c := range candles {
+		timeStamps[i] = c.TimeStamp
+	}
+	return timeStamps
+}
+
+func TestCandleDeque(t *testing.T) {
+	t.Run("evicts stale candles", func(t *testing.T) {
+		d := &candleDeque{}
+		for ts := int64(1); ts <= 10; ts++ {
+			d.add(ts-4, testCandle(ts))
+		}
+		require.Equal(t, []int64{7, 8, 9, 10}, candleTimeStamps(d.list()))
+
+		latest, ok := d.latest()
+		require.True(t, ok)
+		require.Equal(t, int64(10), latest.TimeStamp)
+	})
+
+	t.Run("keeps time order", func(t *testing.T) {
+		d := &candleDeque{}
+		d.add(0, testCandle(1), testCandle(4))
+		d.add(0, testCandle(2), testCandle(3), testCandle(5))
+		require.Equal(t, []int64{1, 2, 3, 4, 5}, candleTimeStamps(d.list()))
+	})
+
+	t.Run("listed candles are not modified", func(t *testing.T) {
+		d := &candleDeque{}
+		d.add(0, testCandle(1), testCandle(2), testCandle(3))
+		listed := d.list()
+
+		d.add(2, testCandle(4))
+		d.add(2, testCandle(0))
+		d.add(3, testCandle(5), testCandle(6))
+		require.Equal(t, []int64{1, 2, 3}, candleTimeStamps(listed))
+		require.Equal(t, []int64{4, 5, 6}, candleTimeStamps(d.list()))
+	})
+
+	t.Run("limits candle count", func(t *testing.T) {
+		d := &candleDeque{}
+		for ts := int64(1); ts <= 10; ts++ {
+			d.add(0, testCandle(ts))
+			d.limit(3)
+		}
+		require.Equal(t, []int64{8, 9, 10}, candleTimeStamps(d.list()))
+
+		d.limit(0)
+		require.Equal(t, 3, d.len())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		d := &candleDeque{}
+		_, ok := d.latest()
+		require.False(t, ok)
+		require.Empty(t, d.list())
+
+		d.add(0, testCandle(1))
+		d.add(10)
+		require.Equal(t, 0, d.len())
+	})
+}
@@ -0,0 +1,472 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	chainlinkRestHost     = "https://cloudflare-eth.com"
+	chainlinkPollInterval = 15 * time.Second
+
+	// defaultChainlinkTickerMaxAge covers the one hour heartbeat of the
+	// default feeds, which are only updated more often when the price moves.
+	defaultChainlinkTickerMaxAge = 75 * time.Minute
+
+	// chainlinkLatestRoundDataSelector is the function selector of
+	// latestRoundData().
+	chainlinkLatestRoundDataSelector = "feaf968c"
+
+	// chainlinkDecimalsSelector is the function selector of decimals().
+	chainlinkDecimalsSelector = "313ce567"
+)
+
+var (
+	_ Provider = (*ChainlinkProvider)(nil)
+
+	ethAddressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+)
+
+// chainlinkFeeds defines the Ethereum mainnet Chainlink price feed proxies
+// the provider reads from by default, indexed by their currency pair symbol.
+// More feeds can be added with the endpoint's Feeds.
+var chainlinkFeeds = map[string]string{
+	"BTCUSD":  "0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c",
+	"ETHUSD":  "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+	"LINKUSD": "0x2c1d072e956AFFC0D435Cb7AC38EF18d24d9127c",
+}
+
+type (
+	// ChainlinkProvider defines an Oracle provider that reads the answers of
+	// Chainlink price feeds through an Ethereum JSON-RPC endpoint. The time of
+	// the latest round is used as the ticker's timestamp so that feeds which
+	// stopped updating are reported as stale.
+	//
+	// It polls the subscribed feeds every chainlinkPollInterval.
+	//
+	// REF: https://docs.chain.link/data-feeds/api-reference#latestrounddata
+	ChainlinkProvider struct {
+		ctx             context.Context
+		logger          zerolog.Logger
+		mtx             sync.RWMutex
+		endpoints       Endpoint
+		client          *http.Client
+		feeds           map[string]string              // Symbol => feed address
+		decimals        map[string]int64               // feed address => decimals
+		tickers         map[string]types.TickerPrice   // Symbol => TickerPrice
+		candles         map[string][]types.CandlePrice // Symbol => CandlePrice
+		subscribedPairs map[string]types.CurrencyPair  // Symbol => types.CurrencyPair
+	}
+
+	// ChainlinkRoundData defines the latest round of a Chainlink feed.
+	ChainlinkRoundData struct {
+		Answer    sdk.Dec // answer scaled by the feed's decimals
+		UpdatedAt int64   // time the round was updated in unix epoch ms
+	}
+)
+
+// NewChainlinkProvider creates a new ChainlinkProvider.
+func NewChainlinkProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*ChainlinkProvider, error) {
+	if endpoints.Name != ProviderChainlink {
+		endpoints = Endpoint{
+			Name: ProviderChainlink,
+			Rest: chainlinkRestHost,
+		}
+	}
+
+	chainlinkLogger := logger.With().Str("provider", string(ProviderChainlink)).Logger()
+
+	provider := &ChainlinkProvider{
+		ctx:             ctx,
+		logger:          chainlinkLogger,
+		endpoints:       endpoints,
+		client:          endpoints.httpClient(newDefaultHTTPClient()),
+		feeds:           endpoints.chainlinkFeeds(),
+		decimals:        map[string]int64{},
+		tickers:         map[string]types.TickerPrice{},
+		candles:         map[string][]types.CandlePrice{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		provider,
+		provider.endpoints,
+		provider.logger,
+		pairs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.setSubscribedPairs(confirmedPairs...)
+
+	return provider, nil
+}
+
+// ValidateChainlinkFeeds returns an error if a feed address isn't a valid
+// Ethereum address.
+func ValidateChainlinkFeeds(feeds map[string]string) error {
+	for symbol, address := range feeds {
+		if !ethAddressRegex.MatchString(address) {
+			return fmt.Errorf("invalid chainlink feed address %q for %s", address, symbol)
+		}
+	}
+	return nil
+}
+
+// chainlinkFeeds returns the default feeds along with the endpoint's feeds,
+// which take precedence.
+func (e Endpoint) chainlinkFeeds() map[string]string {
+	feeds := make(map[string]string, len(chainlinkFeeds)+len(e.Feeds))
+	for symbol, address := range chainlinkFeeds {
+		feeds[symbol] = address
+	}
+	for symbol, address := range e.Feeds {
+		// the config keys are lowercased when parsed
+		feeds[strings.ToUpper(symbol)] = address
+	}
+	return feeds
+}
+
+// StartConnections starts polling the subscribed feeds.
+func (p *ChainlinkProvider) StartConnections() {
+	go p.pollPrices()
+}
+
+// Describe returns the capabilities of the provider.
+func (p *ChainlinkProvider) Describe() Description {
+	return describe(p.endpoints, true)
+}
+
+// SubscribeCurrencyPairs adds the new currency pairs to the feeds polled by
+// the provider.
+func (p *ChainlinkProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		p,
+		p.endpoints,
+		p.logger,
+		cps...,
+	)
+	if err != nil {
+		return
+	}
+
+	p.setSubscribedPairs(confirmedPairs...)
+}
+
+// UnsubscribeCurrencyPairs stops polling the feeds of the currency pairs and
+// removes their prices.
+func (p *ChainlinkProvider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, cp := range cps {
+		delete(p.subscribedPairs, cp.String())
+		delete(p.tickers, cp.String())
+		delete(p.candles, cp.String())
+	}
+}
+
+// GetTickerPrices returns the latest round answer of the provided pairs.
+func (p *ChainlinkProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+
+	tickerErrs := 0
+	for _, cp := range pairs {
+		price, err := p.getTickerPrice(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
+			tickerErrs++
+			continue
+		}
+		tickerPrices[cp.String()] = price
+	}
+
+	if tickerErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoTickers.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns the answers sampled at each poll of the provided
+// pairs.
+func (p *ChainlinkProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
+
+	candleErrs := 0
+	for _, cp := range pairs {
+		prices, err := p.getCandlePrices(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
+			candleErrs++
+			continue
+		}
+		candlePrices[cp.String()] = prices
+	}
+
+	if candleErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoCandles.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return candlePrices, nil
+}
+
+// GetAvailablePairs returns all feeds the provider knows how to read.
+func (p *ChainlinkProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	availablePairs := make(map[string]struct{}, len(p.feeds))
+	for symbol := range p.feeds {
+		availablePairs[symbol] = struct{}{}
+	}
+	return availablePairs, nil
+}
+
+// tickerMaxAge returns the configured maximum ticker age, or one covering
+// the heartbeat of the default feeds.
+func (p *ChainlinkProvider) tickerMaxAge() time.Duration {
+	if p.endpoints.TickerMaxAge <= 0 {
+		return defaultChainlinkTickerMaxAge
+	}
+	return p.endpoints.TickerMaxAge
+}
+
+func (p *ChainlinkProvider) getTickerPrice(key string) (types.TickerPrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	ticker, ok := p.tickers[key]
+	if !ok {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+	if isStale(ticker.TimeStamp, p.tickerMaxAge()) {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerStale.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	return ticker, nil
+}
+
+func (p *ChainlinkProvider) getCandlePrices(key string) ([]types.CandlePrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	candles, ok := p.candles[key]
+	if !ok {
+		return []types.CandlePrice{}, fmt.Errorf(
+			types.ErrCandleNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	candleList := []types.CandlePrice{}
+	candleList = append(candleList, candles...)
+
+	return candleList, nil
+}
+
+// pollPrices updates the prices of all subscribed feeds every
+// chainlinkPollInterval until the provider's context is done.
+func (p *ChainlinkProvider) pollPrices() {
+	pollTicker := time.NewTicker(chainlinkPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		p.updatePrices()
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-pollTicker.C:
+			continue
+		}
+	}
+}
+
+// updatePrices reads the latest round of every subscribed feed and stores
+// its answer as the pair's ticker and latest candle.
+func (p *ChainlinkProvider) updatePrices() {
+	p.mtx.RLock()
+	pairs := types.MapPairsToSlice(p.subscribedPairs)
+	p.mtx.RUnlock()
+
+	for _, cp := range pairs {
+		symbol := cp.String()
+		feed, ok := p.feeds[symbol]
+		if !ok {
+			continue
+		}
+
+		round, err := p.readFeed(feed)
+		if err != nil {
+			TelemetryFailure(ProviderChainlink, MessageTypeTicker)
+			p.logger.Error().Err(err).Str("pair", symbol).Msg("failed to read chainlink feed")
+			continue
+		}
+
+		p.setPrice(symbol, round, time.Now().UnixMilli())
+		telemetryRestPoll(ProviderChainlink, MessageTypeTicker)
+		telemetryRestPoll(ProviderChainlink, MessageTypeCandle)
+	}
+}
+
+// readFeed calls latestRoundData on the feed and scales its answer by the
+// feed's decimals, which are read once and cached.
+func (p *ChainlinkProvider) readFeed(feed string) (ChainlinkRoundData, error) {
+	decimals, err := p.feedDecimals(feed)
+	if err != nil {
+		return ChainlinkRoundData{}, err
+	}
+
+	selector, err := hex.DecodeString(chainlinkLatestRoundDataSelector)
+	if err != nil {
+		return ChainlinkRoundData{}, err
+	}
+	result, err := ethCall(p.client, p.endpoints.Rest, feed, selector)
+	if err != nil {
+		return ChainlinkRoundData{}, err
+	}
+
+	return decodeChainlinkRoundData(result, decimals)
+}
+
+// feedDecimals returns the decimals of the feed's answers.
+func (p *ChainlinkProvider) feedDecimals(feed string) (int64, error) {
+	p.mtx.RLock()
+	decimals, ok := p.decimals[feed]
+	p.mtx.RUnlock()
+	if ok {
+		return decimals, nil
+	}
+
+	selector, err := hex.DecodeString(chainlinkDecimalsSelector)
+	if err != nil {
+		return 0, err
+	}
+	result, err := ethCall(p.client, p.endpoints.Rest, feed, selector)
+	if err != nil {
+		return 0, err
+	}
+	word, err := ethWord(result, 0)
+	if err != nil {
+		return 0, err
+	}
+	decimals = ethUint(word).Int64()
+	if decimals > sdk.Precision {
+		return 0, fmt.Errorf("unsupported chainlink feed decimals: %d", decimals)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.decimals[feed] = decimals
+	return decimals, nil
+}
+
+// decodeChainlinkRoundData decodes the (uint80 roundId, int256 answer,
+// uint256 startedAt, uint256 updatedAt, uint80 answeredInRound) returned by
+// a latestRoundData call.
+func decodeChainlinkRoundData(result []byte, decimals int64) (ChainlinkRoundData, error) {
+	answerWord, err := ethWord(result, 1)
+	if err != nil {
+		return ChainlinkRoundData{}, err
+	}
+	updatedAtWord, err := ethWord(result, 3)
+	if err != nil {
+		return ChainlinkRoundData{}, err
+	}
+
+	answer := ethInt(answerWord)
+	if answer.Sign() <= 0 {
+		return ChainlinkRoundData{}, fmt.Errorf("invalid chainlink answer: %s", answer)
+	}
+	updatedAt := ethUint(updatedAtWord)
+	if !updatedAt.IsInt64() || updatedAt.Sign() == 0 {
+		return ChainlinkRoundData{}, fmt.Errorf("invalid chainlink round time: %s", updatedAt)
+	}
+
+	return ChainlinkRoundData{
+		Answer:    sdk.NewDecFromBigIntWithPrec(answer, decimals),
+		UpdatedAt: time.Unix(updatedAt.Int64(), 0).UnixMilli(),
+	}, nil
+}
+
+// setPrice stores the round's answer as the pair's ticker, timestamped with
+// the round's time, and appends it as a candle at the time it was polled
+// unless the round is stale, dropping candles older than providerCandlePeriod.
+func (p *ChainlinkProvider) setPrice(symbol string, round ChainlinkRoundData, timeStamp int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// feed answers carry no traded volume
+	p.tickers[symbol] = types.TickerPrice{
+		Price:     round.Answer,
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: round.UpdatedAt,
+	}
+
+	staleTime := PastUnixTime(providerCandlePeriod)
+	candleList := []types.CandlePrice{}
+	if !isStale(round.UpdatedAt, p.tickerMaxAge()) {
+		candleList = append(candleList, types.CandlePrice{
+			Price:     round.Answer,
+			Volume:    sdk.ZeroDec(),
+			TimeStamp: timeStamp,
+		})
+	}
+	for _, candle := range p.candles[symbol] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
+		if staleTime < candle.TimeStamp {
+			candleList = append(candleList, candle)
+		}
+	}
+	p.candles[symbol] = candleList
+}
+
+// setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
+func (p *ChainlinkProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
+	for _, cp := range cps {
+		p.subscribedPairs[cp.String()] = cp
+	}
+}
+
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *ChainlinkProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/oracle/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// chainlinkRoundDataResult ABI encodes the return values of a latestRoundData
+// call.
+func chainlinkRoundDataResult(answer, updatedAt uint64) string {
+	data := ethEncodeUint(10)
+	data = append(data, ethEncodeUint(answer)...)
+	data = append(data, ethEncodeUint(updatedAt)...)
+	data = append(data, ethEncodeUint(updatedAt)...)
+	data = append(data, ethEncodeUint(10)...)
+	return "0x" + hex.EncodeToString(data)
+}
+
+func TestChainlinkProvider_GetTickerPrices(t *testing.T) {
+	p, err := NewChainlinkProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{},
+		types.CurrencyPair{Base: "ETH", Quote: "USD"},
+	)
+	require.NoError(t, err)
+
+	t.Run("valid_request_single_ticker", func(t *testing.T) {
+		price := sdk.MustNewDecFromStr("1850.12")
+
+		p.tickers = map[string]types.TickerPrice{
+			"ETHUSD": {
+				Price:     price,
+				Volume:    sdk.ZeroDec(),
+				TimeStamp: time.Now().Add(-time.Hour).UnixMilli(),
+			},
+		}
+
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ETH", Quote: "USD"})
+		require.NoError(t, err)
+		require.Len(t, prices, 1)
+		require.Equal(t, price, prices["ETHUSD"].Price)
+	})
+
+	t.Run("invalid_request_stale_ticker", func(t *testing.T) {
+		p.tickers = map[string]types.TickerPrice{
+			"ETHUSD": {
+				Price:     sdk.MustNewDecFromStr("1850.12"),
+				Volume:    sdk.ZeroDec(),
+				TimeStamp: time.Now().Add(-2 * time.Hour).UnixMilli(),
+			},
+		}
+
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ETH", Quote: "USD"})
+		require.Error(t, err)
+		require.Nil(t, prices)
+	})
+
+	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		require.Error(t, err)
+		require.Equal(t, "chainlink has no ticker data for requested pairs: [FOOBAR]", err.Error())
+		require.Nil(t, prices)
+	})
+}
+
+func TestChainlinkProvider_ReadFeed(t *testing.T) {
+	updatedAt := time.Now().Add(-10 * time.Minute).Unix()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req EthRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "eth_call", req.Method)
+
+		params := req.Params[0].(map[string]interface{})
+		var result string
+		switch params["data"].(string) {
+		case "0x" + chainlinkDecimalsSelector:
+			result = "0x" + hex.EncodeToString(ethEncodeUint(8))
+		case "0x" + chainlinkLatestRoundDataSelector:
+			result = chainlinkRoundDataResult(185012000000, uint64(updatedAt))
+		default:
+			t.Fatalf("unexpected call data %s", params["data"])
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(EthRPCResponse{Result: result}))
+	}))
+	defer server.Close()
+
+	p := ChainlinkProvider{
+		logger:    zerolog.Nop(),
+		endpoints: Endpoint{Name: ProviderChainlink, Rest: server.URL},
+		client:    server.Client(),
+		decimals:  map[string]int64{},
+		tickers:   map[string]types.TickerPrice{},
+		candles:   map[string][]types.CandlePrice{},
+	}
+
+	round, err := p.readFeed(chainlinkFeeds["ETHUSD"])
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("1850.12"), round.Answer)
+	require.Equal(t, updatedAt*1000, round.UpdatedAt)
+	require.Equal(t, int64(8), p.decimals[chainlinkFeeds["ETHUSD"]])
+
+	now := time.Now().UnixMilli()
+	p.setPrice("ETHUSD", round, now)
+	require.Equal(t, round.UpdatedAt, p.tickers["ETHUSD"].TimeStamp)
+	require.Len(t, p.candles["ETHUSD"], 1)
+	require.Equal(t, now, p.candles["ETHUSD"][0].TimeStamp)
+
+	// stale rounds update the ticker but add no candle
+	round.UpdatedAt = time.Now().Add(-2 * time.Hour).UnixMilli()
+	p.setPrice("ETHUSD", round, now)
+	require.Len(t, p.candles["ETHUSD"], 1)
+}
+
+func TestDecodeChainlinkRoundData(t *testing.T) {
+	result, err := hex.DecodeString(chainlinkRoundDataResult(0, 1)[2:])
+	require.NoError(t, err)
+	_, err = decodeChainlinkRoundData(result, 8)
+	require.Error(t, err)
+
+	_, err = decodeChainlinkRoundData(result[:ethWordSize], 8)
+	require.Error(t, err)
+}
+
+func TestChainlinkProvider_GetAvailablePairs(t *testing.T) {
+	p, err := NewChainlinkProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{
+			Name:  ProviderChainlink,
+			Rest:  chainlinkRestHost,
+			Feeds: map[string]string{"atomusd": "0xDC4BDB458C6361093069Ca2aD30D74cc152EdC75"},
+		},
+	)
+	require.NoError(t, err)
+
+	pairs, err := p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Contains(t, pairs, "ETHUSD")
+	require.Contains(t, pairs, "ATOMUSD")
+}
+
+func TestValidateChainlinkFeeds(t *testing.T) {
+	require.NoError(t, ValidateChainlinkFeeds(chainlinkFeeds))
+	require.Error(t, ValidateChainlinkFeeds(map[string]string{"ATOMUSD": "0x1234"}))
+}
+
+func TestChainlinkProvider_GetSubscribedPairs(t *testing.T) {
+	eth := types.CurrencyPair{Base: "ETH", Quote: "USD"}
+	p := ChainlinkProvider{subscribedPairs: map[string]types.CurrencyPair{}}
+	p.setSubscribedPairs(eth)
+
+	pairs := p.GetSubscribedPairs()
+	require.Equal(t, map[string]types.CurrencyPair{"ETHUSD": eth}, pairs)
+
+	// the returned pairs are a copy of the subscribed pairs
+	delete(pairs, "ETHUSD")
+	require.Contains(t, p.GetSubscribedPairs(), "ETHUSD")
+}
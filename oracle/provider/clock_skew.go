@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// defaultMaxClockSkew is the offset between a venue's clock and the local
+	// clock beyond which the clocks are considered skewed.
+	defaultMaxClockSkew = 5 * time.Second
+
+	// clockSkewSamples is the number of recent timestamps the skew of a venue
+	// is estimated from.
+	clockSkewSamples = 32
+
+	// clockSkewMinSamples is the number of timestamps needed before a skew is
+	// reported, so that a few delayed messages aren't mistaken for one.
+	clockSkewMinSamples = 8
+)
+
+// clockSkew estimates the offset of a venue's clock from the local clock from
+// the timestamps of the venue's messages which are sent as the event happens,
+// ex. trades. The offset is the median of the differences between the recent
+// timestamps and the times they were received at, so that it isn't thrown off
+// by a message delayed on the network or replayed on reconnect. The network
+// latency makes the venue's clock appear slightly behind the local one.
+//
+// A nil clockSkew never detects a skew and computes stale times from the local
+// clock.
+type clockSkew struct {
+	mtx        sync.Mutex
+	provider   Name
+	logger     zerolog.Logger
+	maxSkew    time.Duration
+	compensate bool
+	samples    []int64 // venue - local milliseconds, a ring of clockSkewSamples
+	next       int
+	offset     int64
+	skewed     bool
+}
+
+// newClockSkew returns the clockSkew of the endpoint's provider.
+func newClockSkew(logger zerolog.Logger, endpoint Endpoint) *clockSkew {
+	maxSkew := endpoint.MaxClockSkew
+	if maxSkew <= 0 {
+		maxSkew = defaultMaxClockSkew
+	}
+	return &clockSkew{
+		provider:   endpoint.Name,
+		logger:     logger,
+		maxSkew:    maxSkew,
+		compensate: endpoint.CompensateClockSkew,
+		samples:    make([]int64, 0, clockSkewSamples),
+	}
+}
+
+// observe records a millisecond timestamp of the venue received at the local
+// time received, updating the estimated offset. A warning is logged once the
+// offset exceeds the maximum skew, and again once the clocks agree.
+func (s *clockSkew) observe(venueTime, received int64) {
+	if s == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	sample := venueTime - received
+	if len(s.samples) < clockSkewSamples {
+		s.samples = append(s.samples, sample)
+	} else {
+		s.samples[s.next] = sample
+	}
+	s.next = (s.next + 1) % clockSkewSamples
+	if len(s.samples) < clockSkewMinSamples {
+		return
+	}
+
+	sorted := make([]int64, len(s.samples))
+	copy(sorted, s.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	s.offset = sorted[len(sorted)/2]
+	telemetryClockSkew(s.provider, s.offset)
+
+	skewed := s.offset > s.maxSkew.Milliseconds() || -s.offset > s.maxSkew.Milliseconds()
+	switch {
+	case skewed && !s.skewed:
+		s.logger.Warn().
+			Dur("skew", time.Duration(s.offset)*time.Millisecond).
+			Bool("compensated", s.compensate).
+			Msg("venue clock is skewed from the local clock, check both clocks are synchronized")
+	case !skewed && s.skewed:
+		s.logger.Info().
+			Dur("skew", time.Duration(s.offset)*time.Millisecond).
+			Msg("venue clock is no longer skewed from the local clock")
+	}
+	s.skewed = skewed
+}
+
+// pastUnixTime returns the venue's millisecond timestamp of the local unix
+// time minus t. It's the local PastUnixTime unless the skew is compensated
+// and the clocks are skewed, in which case the time is moved by their offset
+// so that the venue's timestamps are compared to the same instant.
+func (s *clockSkew) pastUnixTime(t time.Duration) int64 {
+	if s == nil {
+		return PastUnixTime(t)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.compensate || !s.skewed {
+		return PastUnixTime(t)
+	}
+	return PastUnixTime(t) + s.offset
+}
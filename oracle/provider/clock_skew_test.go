@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkew(t *testing.T) {
+	now := time.Now().UnixMilli()
+	skew := (10 * time.Second).Milliseconds()
+
+	t.Run("detects skew", func(t *testing.T) {
+		s := newClockSkew(zerolog.Nop(), Endpoint{Name: ProviderCoinbase})
+		for i := 0; i < clockSkewMinSamples-1; i++ {
+			s.observe(now+skew, now)
+		}
+		// too few timestamps to tell
+		require.False(t, s.skewed)
+
+		s.observe(now+skew, now)
+		require.True(t, s.skewed)
+		require.Equal(t, skew, s.offset)
+
+		// the skew isn't compensated by default
+		require.InDelta(t, PastUnixTime(time.Minute), s.pastUnixTime(time.Minute), 1000)
+	})
+
+	t.Run("ignores outliers", func(t *testing.T) {
+		s := newClockSkew(zerolog.Nop(), Endpoint{Name: ProviderCoinbase})
+		for i := 0; i < clockSkewSamples; i++ {
+			venueTime := now - 50
+			if i%4 == 0 {
+				// ex. a message replayed on reconnect
+				venueTime = now - skew
+			}
+			s.observe(venueTime, now)
+		}
+		require.False(t, s.skewed)
+		require.Equal(t, int64(-50), s.offset)
+	})
+
+	t.Run("compensates skew", func(t *testing.T) {
+		s := newClockSkew(zerolog.Nop(), Endpoint{
+			Name:                ProviderCoinbase,
+			MaxClockSkew:        20 * time.Second,
+			CompensateClockSkew: true,
+		})
+		for i := 0; i < clockSkewSamples; i++ {
+			s.observe(now-skew, now)
+		}
+		// within the maximum skew
+		require.False(t, s.skewed)
+		require.InDelta(t, PastUnixTime(time.Minute), s.pastUnixTime(time.Minute), 1000)
+
+		for i := 0; i < clockSkewSamples; i++ {
+			s.observe(now-3*skew, now)
+		}
+		require.True(t, s.skewed)
+		require.InDelta(t, PastUnixTime(time.Minute)-3*skew, s.pastUnixTime(time.Minute), 1000)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var s *clockSkew
+		s.observe(now+skew, now)
+		require.InDelta(t, PastUnixTime(time.Minute), s.pastUnixTime(time.Minute), 1000)
+	})
+}
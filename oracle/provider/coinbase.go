@@ -19,15 +19,48 @@ import (
 )
 
 const (
-	coinbaseWSHost    = "ws-feed.exchange.coinbase.com"
-	coinbasePingCheck = time.Second * 28 // should be < 30
-	coinbaseRestHost  = "https://api.exchange.coinbase.com"
-	coinbaseRestPath  = "/products"
-	coinbaseTimeFmt   = "2006-01-02T15:04:05.000000Z"
-	unixMinute        = 60000
+	coinbaseWSHost          = "ws-feed.exchange.coinbase.com"
+	coinbasePingCheck       = time.Second * 28 // should be < 30
+	coinbaseRestHost        = "https://api.exchange.coinbase.com"
+	coinbaseSandboxWSHost   = "ws-feed-public.sandbox.exchange.coinbase.com"
+	coinbaseSandboxRestHost = "https://api-public.sandbox.exchange.coinbase.com"
+	coinbaseRestPath        = "/products"
+	coinbaseTradesPath      = "/products/%s/trades?after=%d&limit=%d"
+	coinbaseTimeFmt         = "2006-01-02T15:04:05.000000Z"
+	unixMinute              = 60000
+
+	// coinbaseMaxResyncTrades is the maximum number of trades returned by a
+	// single request of the rest trades endpoint.
+	coinbaseMaxResyncTrades = 1000
+
+	// coinbaseSubscriptionBatchSize is the default maximum amount of
+	// product ids of a subscribe message. The product ids beyond it are
+	// subscribed to coinbaseSubscriptionBatchDelay apart, since larger
+	// messages are rejected as a whole.
+	coinbaseSubscriptionBatchSize  = 100
+	coinbaseSubscriptionBatchDelay = 250 * time.Millisecond
+
+	coinbaseMatchesChannel = "matches"
+	coinbaseTickerChannel  = "ticker"
 )
 
-var _ Provider = (*CoinbaseProvider)(nil)
+var (
+	_ Provider = (*CoinbaseProvider)(nil)
+
+	// coinbaseChannels are the channels subscribed to by default, the trades
+	// building the candles and the tickers.
+	coinbaseChannels = []string{coinbaseMatchesChannel, coinbaseTickerChannel}
+
+	// coinbaseSubscription subscribes to the channels of all pairs over a
+	// single connection, in batches of coinbaseSubscriptionBatchSize pairs.
+	coinbaseSubscription = subscriptionFormat{
+		channels:   coinbaseChannels,
+		batchSize:  coinbaseSubscriptionBatchSize,
+		batchDelay: coinbaseSubscriptionBatchDelay,
+		symbol:     currencyPairToCoinbasePair,
+		message:    newCoinbaseSubscription,
+	}
+)
 
 type (
 	// CoinbaseProvider defines an Oracle provider implemented by the Coinbase public
@@ -35,14 +68,18 @@ type (
 	//
 	// REF: https://www.coinbase.io/docs/websocket/index.html
 	CoinbaseProvider struct {
+		ctx             context.Context
 		wsc             *WebsocketController
 		logger          zerolog.Logger
 		reconnectTimer  *time.Ticker
 		mtx             sync.RWMutex
 		endpoints       Endpoint
-		trades          map[string][]CoinbaseTrade    // Symbol => []CoinbaseTrade
-		tickers         map[string]CoinbaseTicker     // Symbol => CoinbaseTicker
-		subscribedPairs map[string]types.CurrencyPair // Symbol => types.CurrencyPair
+		trades          map[string]*coinbaseTradeBuffer // Symbol => trades in time order
+		tickers         map[string]CoinbaseTicker       // Symbol => CoinbaseTicker
+		subscribedPairs map[string]types.CurrencyPair   // Symbol => types.CurrencyPair
+		unsubscribed    map[string]struct{}             // Symbol => unsubscribed product
+		clockCandles    map[string]*candleDeque         // Symbol => completed minute candles
+		clockSkew       *clockSkew
 	}
 
 	// CoinbaseSubscriptionMsg Msg to subscribe to all channels.
@@ -52,21 +89,68 @@ type (
 		Channels   []string `json:"channels"`    // channels to subscribe to ex.: "ticker"
 	}
 
+	// CoinbaseSubscriptionsResponse defines the response listing the channels
+	// and products of the connection once a subscription was processed.
+	CoinbaseSubscriptionsResponse struct {
+		Channels []CoinbaseSubscribedChannel `json:"channels"`
+	}
+
+	// CoinbaseSubscribedChannel defines a channel and its subscribed products.
+	CoinbaseSubscribedChannel struct {
+		Name       string   `json:"name"`        // ex.: ticker
+		ProductIDs []string `json:"product_ids"` // ex.: ["ATOM-USDT", ...]
+	}
+
 	// CoinbaseMatchResponse defines the response body for coinbase trades.
 	CoinbaseTradeResponse struct {
 		Type      string `json:"type"`       // "last_match" or "match"
+		TradeID   int64  `json:"trade_id"`   // ex.: 10
 		ProductID string `json:"product_id"` // ex.: ATOM-USDT
 		Time      string `json:"time"`       // Time in format 2006-01-02T15:04:05.000000Z
 		Size      string `json:"size"`       // Size of the trade ex.: 10.41
 		Price     string `json:"price"`      // ex.: 14.02
 	}
 
+	// CoinbaseRestTrade defines the response body of a trade of the rest
+	// trades endpoint.
+	CoinbaseRestTrade struct {
+		TradeID int64  `json:"trade_id"` // ex.: 10
+		Time    string `json:"time"`     // Time in format 2006-01-02T15:04:05.000Z
+		Size    string `json:"size"`     // Size of the trade ex.: 10.41
+		Price   string `json:"price"`    // ex.: 14.02
+	}
+
 	// CoinbaseTrade defines the trade info we'd like to save.
 	CoinbaseTrade struct {
+		TradeID   int64  // ex.: 10
 		ProductID string // ex.: ATOM-USDT
 		Time      int64  // Time in unix epoch ex.: 164732388700
 		Size      string // Size of the trade ex.: 10.41
 		Price     string // ex.: 14.02
+		AfterGap  bool   // Trades right before this one were missed
+	}
+
+	// coinbaseTradeKey identifies a trade, by its trade id when Coinbase sent
+	// one and by its time, size and price otherwise.
+	coinbaseTradeKey struct {
+		tradeID int64
+		time    int64
+		size    string
+		price   string
+	}
+
+	// coinbaseTradeBuffer is a ring buffer of the trades of a product in time
+	// order. Trades are appended at the back and stale trades are evicted
+	// from the front, both in amortized O(1), and the
+	// recorded trades are indexed so that duplicates can be dropped.
+	// Coinbase numbers the trades of a product consecutively, so the latest
+	// trade id is kept to detect the trades missed during a websocket gap.
+	coinbaseTradeBuffer struct {
+		trades      []CoinbaseTrade
+		head        int
+		size        int
+		keys        map[coinbaseTradeKey]struct{}
+		lastTradeID int64
 	}
 
 	// CoinbaseTicker defines the ticker info we'd like to save.
@@ -74,6 +158,12 @@ type (
 		ProductID string `json:"product_id"` // ex.: ATOM-USDT
 		Price     string `json:"price"`      // ex.: 523.0
 		Volume    string `json:"volume_24h"` // 24-hour volume
+		BestBid   string `json:"best_bid"`   // ex.: 522.9
+		BestAsk   string `json:"best_ask"`   // ex.: 523.1
+		Open24h   string `json:"open_24h"`   // price 24 hours ago
+		High24h   string `json:"high_24h"`   // 24-hour high
+		Low24h    string `json:"low_24h"`    // 24-hour low
+		Time      int64  `json:"-"`          // Time received in unix epoch ex.: 164732388700
 	}
 
 	// CoinbaseErrResponse defines the response body for errors.
@@ -84,8 +174,9 @@ type (
 
 	// CoinbasePairSummary defines the response structure for a Coinbase pair summary.
 	CoinbasePairSummary struct {
-		Base  string `json:"base_currency"`
-		Quote string `json:"quote_currency"`
+		Base   string `json:"base_currency"`
+		Quote  string `json:"quote_currency"`
+		Status string `json:"status"`
 	}
 )
 
@@ -103,6 +194,8 @@ func NewCoinbaseProvider(
 			Websocket: coinbaseWSHost,
 		}
 	}
+	endpoints = endpoints.withEnvironment()
+
 	wsURL := url.URL{
 		Scheme: "wss",
 		Host:   endpoints.Websocket,
@@ -111,17 +204,21 @@ func NewCoinbaseProvider(
 	coinbaseLogger := logger.With().Str("provider", string(ProviderCoinbase)).Logger()
 
 	provider := &CoinbaseProvider{
+		ctx:             ctx,
 		logger:          coinbaseLogger,
 		reconnectTimer:  time.NewTicker(coinbasePingCheck),
 		endpoints:       endpoints,
-		trades:          map[string][]CoinbaseTrade{},
+		trades:          map[string]*coinbaseTradeBuffer{},
 		tickers:         map[string]CoinbaseTicker{},
 		subscribedPairs: map[string]types.CurrencyPair{},
+		unsubscribed:    map[string]struct{}{},
+		clockCandles:    map[string]*candleDeque{},
+		clockSkew:       newClockSkew(coinbaseLogger, endpoints),
 	}
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -131,37 +228,74 @@ func NewCoinbaseProvider(
 
 	provider.setSubscribedPairs(confirmedPairs...)
 
+	subscriptionMsgs, err := provider.getSubscriptionMsgs(pairs...)
+	if err != nil {
+		return nil, err
+	}
+
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
-		provider.getSubscriptionMsgs(pairs...),
+		subscriptionMsgs,
 		provider.messageReceived,
 		defaultPingDuration,
 		websocket.PingMessage,
 		coinbaseLogger,
 	)
+	provider.wsc.SetSubscriptionAcks(coinbaseSubscriptionAckKeys)
 
 	return provider, nil
 }
 
 func (p *CoinbaseProvider) StartConnections() {
 	p.wsc.StartConnections()
+	if p.endpoints.ClockCandles {
+		go p.runCandleClock()
+	}
 }
 
-func (p *CoinbaseProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
-	subscriptionMsgs := make([]interface{}, 0, 1)
-
-	topics := make([]string, len(cps))
-	index := 0
+// Describe returns the capabilities of the provider. Its candles are built
+// from the matches channel, which the pairs can leave out.
+func (p *CoinbaseProvider) Describe() Description {
+	channels := p.endpoints.coinbaseChannels(types.CurrencyPair{})
+	candles := false
+	for _, channel := range channels {
+		if channel == coinbaseMatchesChannel {
+			candles = true
+		}
+	}
+	return describe(p.endpoints, candles, channels...)
+}
 
+// getSubscriptionMsgs returns the subscription messages of the pairs, a
+// message per set of channels the pairs subscribe to.
+func (p *CoinbaseProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) ([]interface{}, error) {
+	channelSets := [][]string{}
+	channelPairs := map[string][]types.CurrencyPair{}
 	for _, cp := range cps {
-		topics[index] = currencyPairToCoinbasePair(cp)
-		index++
+		channels := p.endpoints.coinbaseChannels(cp)
+		key := strings.Join(channels, ",")
+		if _, ok := channelPairs[key]; !ok {
+			channelSets = append(channelSets, channels)
+		}
+		channelPairs[key] = append(channelPairs[key], cp)
 	}
-	msg := newCoinbaseSubscription(topics...)
-	subscriptionMsgs = append(subscriptionMsgs, msg)
-	return subscriptionMsgs
+
+	msgs := []interface{}{}
+	for _, channels := range channelSets {
+		format := coinbaseSubscription
+		format.channels = channels
+		if p.endpoints.SubscriptionBatchSize > 0 {
+			format.batchSize = p.endpoints.SubscriptionBatchSize
+		}
+		channelMsgs, err := format.build(channelPairs[strings.Join(channels, ",")]...)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, channelMsgs...)
+	}
+	return msgs, nil
 }
 
 // SubscribeCurrencyPairs sends the new subscription messages to the websocket
@@ -179,7 +313,7 @@ func (p *CoinbaseProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -187,25 +321,73 @@ func (p *CoinbaseProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 		return
 	}
 
-	newSubscriptionMsgs := p.getSubscriptionMsgs(confirmedPairs...)
+	newSubscriptionMsgs, err := p.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to build subscription messages")
+		return
+	}
 	p.wsc.AddWebsocketConnection(
 		newSubscriptionMsgs,
 		p.messageReceived,
 		defaultPingDuration,
 		websocket.PingMessage,
 	)
+	for _, cp := range confirmedPairs {
+		delete(p.unsubscribed, currencyPairToCoinbasePair(cp))
+	}
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs sends the unsubscription messages of the pairs to
+// the websocket, a message per set of channels the pairs subscribed to, and
+// removes their tickers and trades. The messages of the pairs still in flight
+// are dropped.
+func (p *CoinbaseProvider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	channelSets := [][]string{}
+	channelProducts := map[string][]string{}
+	for _, cp := range cps {
+		if _, ok := p.subscribedPairs[cp.String()]; !ok {
+			continue
+		}
+		channels := p.endpoints.coinbaseChannels(cp)
+		key := strings.Join(channels, ",")
+		if _, ok := channelProducts[key]; !ok {
+			channelSets = append(channelSets, channels)
+		}
+		productID := currencyPairToCoinbasePair(cp)
+		channelProducts[key] = append(channelProducts[key], productID)
+
+		delete(p.subscribedPairs, cp.String())
+		delete(p.tickers, productID)
+		delete(p.trades, productID)
+		delete(p.clockCandles, productID)
+		p.unsubscribed[productID] = struct{}{}
+	}
+
+	for _, channels := range channelSets {
+		msg := CoinbaseSubscriptionMsg{
+			Type:       "unsubscribe",
+			ProductIDs: channelProducts[strings.Join(channels, ",")],
+			Channels:   channels,
+		}
+		if err := p.wsc.SendJSON(msg); err != nil {
+			p.logger.Warn().Err(err).Strs("products", msg.ProductIDs).Msg("failed to unsubscribe")
+		}
+	}
+}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *CoinbaseProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *CoinbaseProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
 	for _, cp := range pairs {
 		price, err := p.getTickerPrice(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -223,22 +405,50 @@ func (p *CoinbaseProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[str
 }
 
 // GetCandlePrices returns candles based off of the saved trades map.
-// Candles need to be cut up into one-minute intervals.
-func (p *CoinbaseProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
-	tradeMap := make(map[string][]CoinbaseTrade, len(pairs))
+// Candles need to be cut up into one-minute intervals. With ClockCandles, the
+// minute candles completed on the wall clock are returned instead.
+func (p *CoinbaseProvider) GetCandlePrices(ctx context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	return p.GetCandlePricesWithInterval(ctx, time.Minute, pairs...)
+}
 
-	tradeErrs := 0
+// GetCandlePricesWithInterval returns candles of the given interval based off
+// of the saved trades map, bucketing the same trades as GetCandlePrices.
+func (p *CoinbaseProvider) GetCandlePricesWithInterval(
+	_ context.Context,
+	interval time.Duration,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid candle interval: %s", interval)
+	}
+
+	tradeMap := make(map[types.CurrencyPair][]CoinbaseTrade, len(pairs))
+
+	// pairs not subscribed to the matches channel have no candles
+	candlePairs := make([]types.CurrencyPair, 0, len(pairs))
 	for _, cp := range pairs {
-		key := currencyPairToCoinbasePair(cp)
-		tradeSet, err := p.getTradePrices(key)
+		if p.endpoints.hasCoinbaseChannel(cp, coinbaseMatchesChannel) {
+			candlePairs = append(candlePairs, cp)
+		}
+	}
+	if len(candlePairs) == 0 && len(pairs) > 0 {
+		return map[string][]types.CandlePrice{}, nil
+	}
+	if p.endpoints.ClockCandles && interval == time.Minute {
+		return p.getClockCandles(candlePairs), nil
+	}
+
+	tradeErrs := 0
+	for _, cp := range candlePairs {
+		tradeSet, err := p.getTradePrices(currencyPairToCoinbasePair(cp))
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get trade prices")
 			tradeErrs++
 			continue
 		}
-		tradeMap[key] = tradeSet
+		tradeMap[cp] = tradeSet
 	}
-	if tradeErrs == len(pairs) {
+	if tradeErrs == len(candlePairs) {
 		return nil, fmt.Errorf(
 			types.ErrNoTickers.Error(),
 			p.endpoints.Name,
@@ -248,60 +458,170 @@ func (p *CoinbaseProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[str
 
 	candles := make(map[string][]types.CandlePrice)
 
-	for cp := range tradeMap {
-		trades := tradeMap[cp]
-		// sort oldest -> newest
-		sort.Slice(trades, func(i, j int) bool {
-			return time.Unix(trades[i].Time, 0).Before(time.Unix(trades[j].Time, 0))
-		})
+	for cp, trades := range tradeMap {
+		candleSlice, err := coinbaseTradesToCandles(trades, interval)
+		if err != nil {
+			return nil, err
+		}
+		// a pair without trades is left out rather than reported with an
+		// empty candle, so that its ticker is used instead
+		if len(candleSlice) > 0 {
+			candles[cp.String()] = candleSlice
+		}
+	}
 
-		candleSlice := []types.CandlePrice{
-			{
-				Price:  sdk.ZeroDec(),
-				Volume: sdk.ZeroDec(),
-			},
-		}
-		startTime := trades[0].Time
-		index := 0
-
-		// divide into chunks by minute
-		for _, trade := range trades {
-			// every minute, reset the time period
-			if trade.Time-startTime > unixMinute {
-				index++
-				startTime = trade.Time
-				candleSlice = append(candleSlice, types.CandlePrice{
-					Price:  sdk.ZeroDec(),
-					Volume: sdk.ZeroDec(),
-				})
-			}
+	return candles, nil
+}
 
-			size, err := sdk.NewDecFromStr(trade.Size)
-			if err != nil {
-				return nil, err
-			}
-			price, err := sdk.NewDecFromStr(trade.Price)
-			if err != nil {
-				return nil, err
-			}
+// runCandleClock finalizes the candles of the minute ending at each minute
+// boundary of the wall clock until the provider's context is done. A timer is
+// set for each boundary so that the candles don't drift from the clock.
+func (p *CoinbaseProvider) runCandleClock() {
+	for {
+		boundary := time.Now().Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(time.Until(boundary))
+		select {
+		case <-p.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			p.finalizeCandles(boundary)
+		}
+	}
+}
 
-			volume := candleSlice[index].Volume.Add(size)
-			candleSlice[index] = types.CandlePrice{
-				Volume:    volume,     // aggregate size
-				Price:     price,      // most recent price
-				TimeStamp: trade.Time, // most recent timestamp
+// finalizeCandles builds the candle of each product from its trades made in
+// the minute ending at the boundary, timestamped at the boundary. Once added,
+// a candle is never updated: trades of its minute received after the boundary
+// are left out of it. Products without trades in the minute get no candle.
+func (p *CoinbaseProvider) finalizeCandles(boundary time.Time) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	end := boundary.UnixMilli()
+	start := boundary.Add(-time.Minute).UnixMilli()
+	staleTime := PastUnixTime(providerCandlePeriod)
+	for productID, buffer := range p.trades {
+		minuteTrades := []CoinbaseTrade{}
+		for _, trade := range buffer.list() {
+			if trade.Time >= start && trade.Time < end {
+				minuteTrades = append(minuteTrades, trade)
 			}
 		}
 
-		candles[coinbasePairToCurrencyPair(cp)] = candleSlice
+		candles, err := coinbaseTradesToCandles(minuteTrades, time.Minute)
+		if err != nil {
+			p.logger.Warn().Err(err).Str("product", productID).Msg("failed to finalize candle")
+			continue
+		}
+		if len(candles) == 0 {
+			continue
+		}
+		candle := candles[0]
+		candle.TimeStamp = end
+
+		deque, ok := p.clockCandles[productID]
+		if !ok {
+			deque = &candleDeque{}
+			p.clockCandles[productID] = deque
+		}
+		deque.add(staleTime, candle)
+		deque.limit(p.endpoints.MaxCandles)
+	}
+}
+
+// getClockCandles returns the completed minute candles of the pairs, leaving
+// out the pairs without any so that their tickers are used instead.
+func (p *CoinbaseProvider) getClockCandles(pairs []types.CurrencyPair) map[string][]types.CandlePrice {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	staleTime := PastUnixTime(providerCandlePeriod)
+	candles := make(map[string][]types.CandlePrice, len(pairs))
+	for _, cp := range pairs {
+		deque, ok := p.clockCandles[currencyPairToCoinbasePair(cp)]
+		if !ok {
+			continue
+		}
+		fresh := []types.CandlePrice{}
+		for _, candle := range deque.list() {
+			if candle.TimeStamp > staleTime {
+				fresh = append(fresh, candle)
+			}
+		}
+		if len(fresh) > 0 {
+			candles[cp.String()] = fresh
+		}
 	}
+	return candles
+}
+
+// coinbaseTradesToCandles buckets the trades into candles spanning the
+// interval, each starting at the first trade following the previous candle.
+// A trade made exactly one interval after the start of a candle starts the
+// next one. Trades without a positive price are skipped so that no candle is
+// ever reported at a zero price.
+func coinbaseTradesToCandles(trades []CoinbaseTrade, interval time.Duration) ([]types.CandlePrice, error) {
+	// sort oldest -> newest
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Time < trades[j].Time
+	})
+
+	candles := []types.CandlePrice{}
+	startTime := int64(0)
+	for _, trade := range trades {
+		size, err := sdk.NewDecFromStr(trade.Size)
+		if err != nil {
+			return nil, err
+		}
+		price, err := sdk.NewDecFromStr(trade.Price)
+		if err != nil {
+			return nil, err
+		}
+		if !price.IsPositive() {
+			continue
+		}
+
+		// every interval, reset the time period
+		if len(candles) == 0 || trade.Time-startTime >= interval.Milliseconds() {
+			startTime = trade.Time
+			candles = append(candles, types.CandlePrice{
+				Price:  sdk.ZeroDec(),
+				Volume: sdk.ZeroDec(),
+			})
+		}
 
+		last := len(candles) - 1
+		candles[last] = addCoinbaseTradeToCandle(candles[last], price, size, trade.Time)
+		if trade.AfterGap {
+			// the missed trades' volume isn't part of the candle
+			candles[last].VolumeUnreliable = true
+		}
+	}
 	return candles, nil
 }
 
+// addCoinbaseTradeToCandle returns the candle updated with a trade more recent
+// than its previous trades, whose OHLC is computed from the candle's trades.
+func addCoinbaseTradeToCandle(candle types.CandlePrice, price, size sdk.Dec, timeStamp int64) types.CandlePrice {
+	open, high, low := candle.Open, candle.High, candle.Low
+	if open.IsNil() {
+		open, high, low = price, price, price
+	}
+	return types.CandlePrice{
+		Volume:           candle.Volume.Add(size), // aggregate size
+		Price:            price,                   // most recent price
+		TimeStamp:        timeStamp,               // most recent timestamp
+		Open:             open,                    // first price
+		High:             sdk.MaxDec(high, price),
+		Low:              sdk.MinDec(low, price),
+		VolumeUnreliable: candle.VolumeUnreliable,
+	}
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 func (p *CoinbaseProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + coinbaseRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + coinbaseRestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -312,16 +632,16 @@ func (p *CoinbaseProvider) GetAvailablePairs() (map[string]struct{}, error) {
 		return nil, err
 	}
 
-	availablePairs := make(map[string]struct{}, len(pairsSummary))
+	listings := make([]marketListing, 0, len(pairsSummary))
 	for _, pair := range pairsSummary {
 		cp := types.CurrencyPair{
 			Base:  pair.Base,
 			Quote: pair.Quote,
 		}
-		availablePairs[strings.ToUpper(cp.String())] = struct{}{}
+		listings = append(listings, marketListing{symbol: cp.String(), spot: true, status: pair.Status})
 	}
 
-	return availablePairs, nil
+	return availableMarkets(p.logger, listings), nil
 }
 
 func (p *CoinbaseProvider) getTickerPrice(cp types.CurrencyPair) (types.TickerPrice, error) {
@@ -330,7 +650,21 @@ func (p *CoinbaseProvider) getTickerPrice(cp types.CurrencyPair) (types.TickerPr
 
 	gp := currencyPairToCoinbasePair(cp)
 	if tickerPair, ok := p.tickers[gp]; ok {
-		return tickerPair.toTickerPrice()
+		if isStale(tickerPair.Time, p.endpoints.tickerMaxAge()) {
+			return types.TickerPrice{}, fmt.Errorf(
+				types.ErrTickerStale.Error(),
+				p.endpoints.Name,
+				gp,
+			)
+		}
+		tp, err := tickerPair.toTickerPrice(cp)
+		if err != nil {
+			return types.TickerPrice{}, err
+		}
+		if err := p.endpoints.checkSpread(gp, tp); err != nil {
+			return types.TickerPrice{}, err
+		}
+		return tp, nil
 	}
 
 	return types.TickerPrice{}, fmt.Errorf(
@@ -349,12 +683,13 @@ func (p *CoinbaseProvider) getTradePrices(key string) ([]CoinbaseTrade, error) {
 		return []CoinbaseTrade{}, fmt.Errorf("failed to get trades for %s", key)
 	}
 
-	return trades, nil
+	return trades.list(), nil
 }
 
-func (p *CoinbaseProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byte) {
+func (p *CoinbaseProvider) messageReceived(_ int, conn *WebsocketConnection, bz []byte) {
 	var coinbaseTrade CoinbaseTradeResponse
 	if err := json.Unmarshal(bz, &coinbaseTrade); err != nil {
+		telemetryParseError(ProviderCoinbase, ParseErrorUnmarshal)
 		p.logger.Error().Err(err).Msg("unable to unmarshal response")
 		return
 	}
@@ -369,21 +704,48 @@ func (p *CoinbaseProvider) messageReceived(_ int, _ *WebsocketConnection, bz []b
 	}
 
 	if coinbaseTrade.Type == "subscriptions" { // successful subscription message
+		var subscriptions CoinbaseSubscriptionsResponse
+		if err := json.Unmarshal(bz, &subscriptions); err != nil {
+			p.logger.Debug().Err(err).Msg("unable to unmarshal subscriptions response")
+			return
+		}
+		for _, channel := range subscriptions.Channels {
+			for _, productID := range channel.ProductIDs {
+				conn.AcknowledgeSubscription(channel.Name + ":" + productID)
+			}
+		}
 		return
 	}
 
 	if coinbaseTrade.Type == "ticker" {
 		var coinbaseTicker CoinbaseTicker
 		if err := json.Unmarshal(bz, &coinbaseTicker); err != nil {
+			telemetryParseError(ProviderCoinbase, ParseErrorUnmarshal)
 			p.logger.Error().Err(err).Msg("unable to unmarshal response")
 			return
 		}
+		if len(coinbaseTicker.ProductID) == 0 || len(coinbaseTicker.Price) == 0 {
+			telemetryParseError(ProviderCoinbase, ParseErrorMissingField)
+			p.logger.Error().Int("length", len(bz)).Msg("coinbase ticker is missing its product or price")
+			return
+		}
 
 		p.setTickerPair(coinbaseTicker)
 		telemetryWebsocketMessage(ProviderCoinbase, MessageTypeTicker)
 		return
 	}
 
+	if coinbaseTrade.Type != "match" && coinbaseTrade.Type != "last_match" {
+		telemetryParseError(ProviderCoinbase, ParseErrorUnknownType)
+		p.logger.Error().Str("type", coinbaseTrade.Type).Msg("received an unknown coinbase message type")
+		return
+	}
+	if len(coinbaseTrade.ProductID) == 0 || len(coinbaseTrade.Price) == 0 || len(coinbaseTrade.Size) == 0 {
+		telemetryParseError(ProviderCoinbase, ParseErrorMissingField)
+		p.logger.Error().Int("length", len(bz)).Msg("coinbase trade is missing its product, price or size")
+		return
+	}
+
 	telemetryWebsocketMessage(ProviderCoinbase, MessageTypeTrade)
 	p.setTradePair(coinbaseTrade)
 }
@@ -399,6 +761,7 @@ func (tr CoinbaseTradeResponse) timeToUnix() int64 {
 
 func (tr CoinbaseTradeResponse) toTrade() CoinbaseTrade {
 	return CoinbaseTrade{
+		TradeID:   tr.TradeID,
 		Time:      tr.timeToUnix(),
 		Price:     tr.Price,
 		ProductID: tr.ProductID,
@@ -406,30 +769,238 @@ func (tr CoinbaseTradeResponse) toTrade() CoinbaseTrade {
 	}
 }
 
+// toTrade converts the rest trade of the product to a CoinbaseTrade.
+func (t CoinbaseRestTrade) toTrade(productID string) CoinbaseTrade {
+	var timeStamp int64
+	if tradeTime, err := time.Parse(time.RFC3339Nano, t.Time); err == nil {
+		timeStamp = tradeTime.UnixMilli()
+	}
+	return CoinbaseTrade{
+		TradeID:   t.TradeID,
+		Time:      timeStamp,
+		Price:     t.Price,
+		ProductID: productID,
+		Size:      t.Size,
+	}
+}
+
 func (p *CoinbaseProvider) setTickerPair(ticker CoinbaseTicker) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
+	if _, ok := p.unsubscribed[ticker.ProductID]; ok {
+		return
+	}
+	ticker.Time = time.Now().UnixMilli()
 	p.tickers[ticker.ProductID] = ticker
 }
 
 // setTradePair takes a CoinbaseTradeResponse, converts its date into unix epoch,
-// and then will add it to a copy of the trade slice. Then it filters out any
-// "stale" trades, and sets the trade slice in memory to the copy.
+// and appends it to the trades of its product, evicting the "stale" trades.
+// Trades that were already recorded, ex. the last_match sent again after a
+// reconnect, are ignored so that their volume isn't counted twice. A trade
+// following missed trades is flagged so that the volume of its candle is
+// reported as unreliable.
 func (p *CoinbaseProvider) setTradePair(tradeResponse CoinbaseTradeResponse) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
-	staleTime := PastUnixTime(providerCandlePeriod)
-	tradeList := []CoinbaseTrade{
-		tradeResponse.toTrade(),
+
+	if _, ok := p.unsubscribed[tradeResponse.ProductID]; ok {
+		return
+	}
+	trades, ok := p.trades[tradeResponse.ProductID]
+	if !ok {
+		trades = newCoinbaseTradeBuffer()
+		p.trades[tradeResponse.ProductID] = trades
+	}
+
+	trade := tradeResponse.toTrade()
+	if tradeResponse.Type == "match" {
+		// unlike the last_match, matches are sent as they happen
+		p.clockSkew.observe(trade.Time, time.Now().UnixMilli())
+	}
+	if missed := trades.missedTrades(trade); missed > 0 {
+		trade.AfterGap = true
+		telemetryTradeGap(ProviderCoinbase)
+		p.logger.Warn().
+			Str("product", trade.ProductID).
+			Int64("missed_trades", missed).
+			Msg("coinbase trade sequence gap, candle volume is unreliable")
+		if p.endpoints.ResyncTrades {
+			go p.resyncTrades(trade, missed)
+		}
+	}
+	trades.add(trade, p.clockSkew.pastUnixTime(providerCandlePeriod))
+}
+
+// resyncTrades fetches the trades missed right before the trade from the rest
+// api and adds them to the trades of its product. The trade ids are used to
+// find the missed trades rather than the sequence numbers of the messages,
+// which are shared with the other channels of the product and so aren't
+// consecutive on the matches channel. Once all of the missed trades are
+// recovered, the volume of the trade's candle is reliable again.
+func (p *CoinbaseProvider) resyncTrades(trade CoinbaseTrade, missed int64) {
+	limit := missed
+	if limit > coinbaseMaxResyncTrades {
+		limit = coinbaseMaxResyncTrades
+	}
+
+	// the rest api returns the trades preceding the after trade id, most
+	// recent first
+	path := p.endpoints.Rest + fmt.Sprintf(coinbaseTradesPath, trade.ProductID, trade.TradeID, limit)
+	resp, err := p.endpoints.httpClient(newDefaultHTTPClient()).Get(path)
+	if err != nil {
+		p.logger.Warn().Err(err).Str("product", trade.ProductID).Msg("failed to resync coinbase trades")
+		return
+	}
+	defer resp.Body.Close()
+	if err := checkHTTPStatus(resp); err != nil {
+		p.logger.Warn().Err(err).Str("product", trade.ProductID).Msg("failed to resync coinbase trades")
+		return
+	}
+
+	var restTrades []CoinbaseRestTrade
+	if err := json.NewDecoder(resp.Body).Decode(&restTrades); err != nil {
+		p.logger.Warn().Err(err).Str("product", trade.ProductID).Msg("failed to resync coinbase trades")
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// the product may have been unsubscribed while the trades were fetched
+	if _, ok := p.unsubscribed[trade.ProductID]; ok {
+		return
+	}
+	trades, ok := p.trades[trade.ProductID]
+	if !ok {
+		return
+	}
+	staleTime := p.clockSkew.pastUnixTime(providerCandlePeriod)
+	recovered := int64(0)
+	for _, restTrade := range restTrades {
+		if restTrade.TradeID >= trade.TradeID || restTrade.TradeID < trade.TradeID-missed {
+			continue
+		}
+		recovered++
+
+		missedTrade := restTrade.toTrade(trade.ProductID)
+		if missedTrade.Time > staleTime {
+			trades.add(missedTrade, staleTime)
+		}
+	}
+
+	if recovered == missed {
+		trades.clearGap(trade.TradeID)
+	}
+	p.logger.Info().
+		Str("product", trade.ProductID).
+		Int64("missed_trades", missed).
+		Int64("recovered_trades", recovered).
+		Msg("resynced coinbase trades")
+}
+
+// key returns the key identifying the trade.
+func (t CoinbaseTrade) key() coinbaseTradeKey {
+	if t.TradeID != 0 {
+		return coinbaseTradeKey{tradeID: t.TradeID}
+	}
+	return coinbaseTradeKey{time: t.Time, size: t.Size, price: t.Price}
+}
+
+func newCoinbaseTradeBuffer() *coinbaseTradeBuffer {
+	return &coinbaseTradeBuffer{
+		keys: map[coinbaseTradeKey]struct{}{},
+	}
+}
+
+// len returns the number of trades in the buffer.
+func (b *coinbaseTradeBuffer) len() int {
+	return b.size
+}
+
+// at returns the i-th oldest trade of the buffer.
+func (b *coinbaseTradeBuffer) at(i int) CoinbaseTrade {
+	return b.trades[(b.head+i)%len(b.trades)]
+}
+
+// missedTrades returns the number of trades missed between the most recent
+// trade of the buffer and the trade according to their trade ids. Trades
+// without an id and trades older than the most recent one missed none.
+func (b *coinbaseTradeBuffer) missedTrades(trade CoinbaseTrade) int64 {
+	if trade.TradeID == 0 || b.lastTradeID == 0 || trade.TradeID <= b.lastTradeID+1 {
+		return 0
+	}
+	return trade.TradeID - b.lastTradeID - 1
+}
+
+// add evicts the trades at or before staleTime from the front of the buffer
+// and inserts the trade unless it was already recorded. Trades are kept in
+// time order: a trade older than the most recent ones, ex. a resynced trade
+// missed during a gap, is moved back to its place. It returns false if the
+// trade was a duplicate.
+func (b *coinbaseTradeBuffer) add(trade CoinbaseTrade, staleTime int64) bool {
+	key := trade.key()
+	if _, ok := b.keys[key]; ok {
+		return false
+	}
+	if trade.TradeID > b.lastTradeID {
+		b.lastTradeID = trade.TradeID
 	}
 
-	for _, t := range p.trades[tradeResponse.ProductID] {
-		if staleTime < t.Time {
-			tradeList = append(tradeList, t)
+	for b.size > 0 && b.trades[b.head].Time <= staleTime {
+		delete(b.keys, b.trades[b.head].key())
+		b.trades[b.head] = CoinbaseTrade{}
+		b.head = (b.head + 1) % len(b.trades)
+		b.size--
+	}
+
+	if b.size == len(b.trades) {
+		b.grow()
+	}
+	i := b.size
+	for ; i > 0 && b.at(i-1).Time > trade.Time; i-- {
+		b.trades[(b.head+i)%len(b.trades)] = b.at(i - 1)
+	}
+	b.trades[(b.head+i)%len(b.trades)] = trade
+	b.size++
+	b.keys[key] = struct{}{}
+	return true
+}
+
+// clearGap unflags the trade following missed trades once they were recovered.
+func (b *coinbaseTradeBuffer) clearGap(tradeID int64) {
+	for i := 0; i < b.size; i++ {
+		index := (b.head + i) % len(b.trades)
+		if b.trades[index].TradeID == tradeID {
+			b.trades[index].AfterGap = false
+			return
 		}
 	}
-	p.trades[tradeResponse.ProductID] = tradeList
+}
+
+// grow doubles the capacity of the buffer, moving its trades to the front of
+// the new storage.
+func (b *coinbaseTradeBuffer) grow() {
+	capacity := 2 * len(b.trades)
+	if capacity == 0 {
+		capacity = 16
+	}
+	trades := make([]CoinbaseTrade, capacity)
+	for i := 0; i < b.size; i++ {
+		trades[i] = b.at(i)
+	}
+	b.trades = trades
+	b.head = 0
+}
+
+// list returns a copy of the trades of the buffer, oldest first.
+func (b *coinbaseTradeBuffer) list() []CoinbaseTrade {
+	trades := make([]CoinbaseTrade, b.size)
+	for i := range trades {
+		trades[i] = b.at(i)
+	}
+	return trades
 }
 
 // setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
@@ -439,13 +1010,82 @@ func (p *CoinbaseProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
-func (ticker CoinbaseTicker) toTickerPrice() (types.TickerPrice, error) {
-	return types.NewTickerPrice(
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *CoinbaseProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
+// toTickerPrice converts the ticker of the currency pair to a TickerPrice.
+func (ticker CoinbaseTicker) toTickerPrice(cp types.CurrencyPair) (types.TickerPrice, error) {
+	tp, err := types.NewTickerPrice(
 		string(ProviderCoinbase),
-		coinbasePairToCurrencyPair(ticker.ProductID),
+		cp.String(),
 		ticker.Price,
 		ticker.Volume,
 	)
+	if err != nil {
+		return types.TickerPrice{}, err
+	}
+	tp.TimeStamp = ticker.Time
+	return tp.WithBidAsk(
+		string(ProviderCoinbase),
+		cp.String(),
+		ticker.BestBid,
+		ticker.BestAsk,
+	)
+}
+
+// ValidateCoinbaseChannels returns an error if a channel isn't one of the
+// coinbase channels the provider reads or a pair has no channels.
+func ValidateCoinbaseChannels(channels []string, pairChannels map[string][]string) error {
+	supported := func(channels []string) error {
+		for _, channel := range channels {
+			if channel != coinbaseMatchesChannel && channel != coinbaseTickerChannel {
+				return fmt.Errorf("unsupported coinbase channel %q", channel)
+			}
+		}
+		return nil
+	}
+	if err := supported(channels); err != nil {
+		return err
+	}
+	for symbol, channels := range pairChannels {
+		if len(channels) == 0 {
+			return fmt.Errorf("no coinbase channels for %s", symbol)
+		}
+		if err := supported(channels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// coinbaseChannels returns the channels subscribed to for the pair, its pair
+// channels if any, else the endpoint's channels or the default channels.
+func (e Endpoint) coinbaseChannels(cp types.CurrencyPair) []string {
+	for symbol, channels := range e.PairChannels {
+		// the config keys are lowercased when parsed
+		if strings.EqualFold(symbol, cp.String()) {
+			return channels
+		}
+	}
+	if len(e.Channels) > 0 {
+		return e.Channels
+	}
+	return coinbaseChannels
+}
+
+// hasCoinbaseChannel returns whether the pair is subscribed to the channel.
+func (e Endpoint) hasCoinbaseChannel(cp types.CurrencyPair, channel string) bool {
+	for _, c := range e.coinbaseChannels(cp) {
+		if c == channel {
+			return true
+		}
+	}
+	return false
 }
 
 // currencyPairToCoinbasePair returns the expected pair for Coinbase
@@ -454,17 +1094,28 @@ func currencyPairToCoinbasePair(pair types.CurrencyPair) string {
 	return pair.Base + "-" + pair.Quote
 }
 
-// coinbasePairToCurrencyPair returns the currency pair string
-// ex.: "ATOMUSDT".
-func coinbasePairToCurrencyPair(coinbasePair string) string {
-	return strings.ReplaceAll(coinbasePair, "-", "")
+// coinbaseSubscriptionAckKeys returns the keys of the channels and products
+// of a subscription message, which Coinbase acknowledges by listing all of
+// the subscribed channels and products of the connection.
+func coinbaseSubscriptionAckKeys(msg interface{}) []string {
+	subscriptionMsg, ok := msg.(CoinbaseSubscriptionMsg)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(subscriptionMsg.Channels)*len(subscriptionMsg.ProductIDs))
+	for _, channel := range subscriptionMsg.Channels {
+		for _, productID := range subscriptionMsg.ProductIDs {
+			keys = append(keys, channel+":"+productID)
+		}
+	}
+	return keys
 }
 
-// newCoinbaseSubscription returns a new subscription topic for matches/tickers.
-func newCoinbaseSubscription(cp ...string) CoinbaseSubscriptionMsg {
+// newCoinbaseSubscription returns a new subscription topic for the channels.
+func newCoinbaseSubscription(channels, cp []string) interface{} {
 	return CoinbaseSubscriptionMsg{
 		Type:       "subscribe",
 		ProductIDs: cp,
-		Channels:   []string{"matches", "ticker"},
+		Channels:   channels,
 	}
 }
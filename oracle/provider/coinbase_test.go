@@ -3,9 +3,15 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/websocket"
 	"github.com/ojo-network/price-feeder/oracle/types"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
@@ -28,17 +34,35 @@ func TestCoinbaseProvider_GetTickerPrices(t *testing.T) {
 		tickerMap["ATOM-USDT"] = CoinbaseTicker{
 			Price:  lastPrice,
 			Volume: volume,
+			Time:   time.Now().UnixMilli(),
 		}
 
 		p.tickers = tickerMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr(lastPrice), prices["ATOMUSDT"].Price)
 		require.Equal(t, sdk.MustNewDecFromStr(volume), prices["ATOMUSDT"].Volume)
 	})
 
+	t.Run("wide_spread_ticker_rejected", func(t *testing.T) {
+		p.tickers = map[string]CoinbaseTicker{
+			"ATOM-USDT": {
+				Price:   "34.69000000",
+				Volume:  "2396974.02000000",
+				BestBid: "33.00000000",
+				BestAsk: "36.00000000",
+				Time:    time.Now().UnixMilli(),
+			},
+		}
+		p.endpoints.MaxSpread = "1"
+		defer func() { p.endpoints.MaxSpread = "" }()
+
+		_, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		require.Error(t, err)
+	})
+
 	t.Run("valid_request_multi_ticker", func(t *testing.T) {
 		lastPriceAtom := "34.69000000"
 		lastPriceOjo := "41.35000000"
@@ -48,15 +72,18 @@ func TestCoinbaseProvider_GetTickerPrices(t *testing.T) {
 		tickerMap["ATOM-USDT"] = CoinbaseTicker{
 			Price:  lastPriceAtom,
 			Volume: volume,
+			Time:   time.Now().UnixMilli(),
 		}
 
 		tickerMap["OJO-USDT"] = CoinbaseTicker{
 			Price:  lastPriceOjo,
 			Volume: volume,
+			Time:   time.Now().UnixMilli(),
 		}
 
 		p.tickers = tickerMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "OJO", Quote: "USDT"},
 		)
@@ -69,16 +96,55 @@ func TestCoinbaseProvider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "coinbase has no ticker data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
+
+	t.Run("invalid_request_stale_ticker", func(t *testing.T) {
+		tickerMap := map[string]CoinbaseTicker{}
+		tickerMap["ATOM-USDT"] = CoinbaseTicker{
+			Price:  "34.69000000",
+			Volume: "2396974.02000000",
+			Time:   time.Now().Add(-defaultTickerMaxAge - time.Minute).UnixMilli(),
+		}
+
+		p.tickers = tickerMap
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		require.EqualError(t, err, "coinbase has no ticker data for requested pairs: [ATOMUSDT]")
+		require.Nil(t, prices)
+	})
 }
 
-func TestCoinbasePairToCurrencyPair(t *testing.T) {
-	cp := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
-	currencyPairSymbol := coinbasePairToCurrencyPair("ATOM-USDT")
-	require.Equal(t, cp.String(), currencyPairSymbol)
+func TestCoinbaseProvider_DashedSymbol(t *testing.T) {
+	cp := types.CurrencyPair{Base: "FOO-BAR", Quote: "USD"}
+	now := time.Now().UTC()
+
+	p := &CoinbaseProvider{
+		logger: zerolog.Nop(),
+		tickers: map[string]CoinbaseTicker{
+			"FOO-BAR-USD": {Price: "1.5", Volume: "100", Time: now.UnixMilli()},
+		},
+		trades: map[string]*coinbaseTradeBuffer{},
+	}
+	p.setTradePair(CoinbaseTradeResponse{
+		Type:      "match",
+		TradeID:   1,
+		ProductID: "FOO-BAR-USD",
+		Time:      now.Format(coinbaseTimeFmt),
+		Size:      "2",
+		Price:     "1.5",
+	})
+
+	// the pair is reported under its symbol without the dash of the base
+	// being stripped
+	prices, err := p.GetTickerPrices(context.TODO(), cp)
+	require.NoError(t, err)
+	require.Contains(t, prices, "FOO-BARUSD")
+
+	candles, err := p.GetCandlePrices(context.TODO(), cp)
+	require.NoError(t, err)
+	require.Contains(t, candles, "FOO-BARUSD")
 }
 
 func TestCurrencyPairToCoinbasePair(t *testing.T) {
@@ -94,8 +160,568 @@ func TestCoinbaseProvider_getSubscriptionMsgs(t *testing.T) {
 	cps := []types.CurrencyPair{
 		{Base: "ATOM", Quote: "USDT"},
 	}
-	subMsgs := provider.getSubscriptionMsgs(cps...)
+	subMsgs, err := provider.getSubscriptionMsgs(cps...)
+	require.NoError(t, err)
 
 	msg, _ := json.Marshal(subMsgs[0])
 	require.Equal(t, "{\"type\":\"subscribe\",\"product_ids\":[\"ATOM-USDT\"],\"channels\":[\"matches\",\"ticker\"]}", string(msg))
 }
+
+func TestCoinbaseProvider_getSubscriptionMsgs_Batched(t *testing.T) {
+	provider := &CoinbaseProvider{
+		endpoints:       Endpoint{SubscriptionBatchSize: 2},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+	subMsgs, err := provider.getSubscriptionMsgs(
+		types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
+		types.CurrencyPair{Base: "BTC", Quote: "USDT"},
+		types.CurrencyPair{Base: "OJO", Quote: "USDT"},
+	)
+	require.NoError(t, err)
+
+	// the pairs are subscribed to over a single connection
+	require.Len(t, subMsgs, 1)
+	batch, ok := subMsgs[0].(subscriptionBatch)
+	require.True(t, ok)
+	require.Equal(t, coinbaseSubscriptionBatchDelay, batch.delay)
+	require.Len(t, batch.msgs, 2)
+
+	msg, _ := json.Marshal(batch.msgs[1])
+	require.Equal(t, "{\"type\":\"subscribe\",\"product_ids\":[\"OJO-USDT\"],\"channels\":[\"matches\",\"ticker\"]}", string(msg))
+}
+
+func TestCoinbaseProvider_Channels(t *testing.T) {
+	provider := &CoinbaseProvider{
+		endpoints: Endpoint{
+			Channels:     []string{"ticker"},
+			PairChannels: map[string][]string{"btcusdt": {"matches", "ticker"}},
+		},
+		subscribedPairs: map[string]types.CurrencyPair{},
+		trades:          map[string]*coinbaseTradeBuffer{},
+	}
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	btcUSDT := types.CurrencyPair{Base: "BTC", Quote: "USDT"}
+	ojoUSDT := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+
+	// pairs are grouped by their channels
+	subMsgs, err := provider.getSubscriptionMsgs(atomUSDT, btcUSDT, ojoUSDT)
+	require.NoError(t, err)
+	require.Len(t, subMsgs, 2)
+
+	msg, _ := json.Marshal(subMsgs[0])
+	require.Equal(t, "{\"type\":\"subscribe\",\"product_ids\":[\"ATOM-USDT\",\"OJO-USDT\"],\"channels\":[\"ticker\"]}", string(msg))
+	msg, _ = json.Marshal(subMsgs[1])
+	require.Equal(t, "{\"type\":\"subscribe\",\"product_ids\":[\"BTC-USDT\"],\"channels\":[\"matches\",\"ticker\"]}", string(msg))
+
+	// ticker-only pairs have no candles
+	candles, err := provider.GetCandlePrices(context.TODO(), atomUSDT, ojoUSDT)
+	require.NoError(t, err)
+	require.Empty(t, candles)
+
+	_, err = provider.GetCandlePrices(context.TODO(), atomUSDT, btcUSDT)
+	require.Error(t, err)
+
+	require.NoError(t, ValidateCoinbaseChannels([]string{"ticker"}, map[string][]string{"atomusdt": {"matches"}}))
+	require.Error(t, ValidateCoinbaseChannels([]string{"level2"}, nil))
+	require.Error(t, ValidateCoinbaseChannels(nil, map[string][]string{"atomusdt": {}}))
+}
+
+func TestCoinbaseProvider_SetTradePair(t *testing.T) {
+	now := time.Now().UTC()
+	lastMatch := CoinbaseTradeResponse{
+		Type:      "last_match",
+		TradeID:   10,
+		ProductID: "ATOM-USDT",
+		Time:      now.Format(coinbaseTimeFmt),
+		Size:      "1.5",
+		Price:     "10.1",
+	}
+
+	t.Run("trade_id", func(t *testing.T) {
+		p := &CoinbaseProvider{trades: map[string]*coinbaseTradeBuffer{}}
+		p.setTradePair(lastMatch)
+
+		// the last_match is sent again on reconnect
+		p.setTradePair(lastMatch)
+		require.Equal(t, 1, p.trades["ATOM-USDT"].len())
+
+		// a different trade with the same time, size and price is kept
+		match := lastMatch
+		match.Type = "match"
+		match.TradeID = 11
+		p.setTradePair(match)
+		require.Equal(t, 2, p.trades["ATOM-USDT"].len())
+
+		candles, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		require.NoError(t, err)
+		require.Equal(t, sdk.MustNewDecFromStr("3"), candles["ATOMUSDT"][0].Volume)
+	})
+
+	t.Run("no_trade_id", func(t *testing.T) {
+		p := &CoinbaseProvider{trades: map[string]*coinbaseTradeBuffer{}}
+		trade := lastMatch
+		trade.TradeID = 0
+		p.setTradePair(trade)
+		p.setTradePair(trade)
+		require.Equal(t, 1, p.trades["ATOM-USDT"].len())
+
+		trade.Size = "2"
+		p.setTradePair(trade)
+		require.Equal(t, 2, p.trades["ATOM-USDT"].len())
+	})
+
+	t.Run("trade_gap", func(t *testing.T) {
+		p := &CoinbaseProvider{logger: zerolog.Nop(), trades: map[string]*coinbaseTradeBuffer{}}
+		p.setTradePair(lastMatch)
+
+		match := lastMatch
+		match.Type = "match"
+		match.TradeID = 11
+		p.setTradePair(match)
+
+		candles, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		require.NoError(t, err)
+		require.False(t, candles["ATOMUSDT"][0].VolumeUnreliable)
+
+		// trades 12 to 14 were missed
+		match.TradeID = 15
+		p.setTradePair(match)
+
+		candles, err = p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		require.NoError(t, err)
+		require.True(t, candles["ATOMUSDT"][0].VolumeUnreliable)
+		require.Equal(t, sdk.MustNewDecFromStr("4.5"), candles["ATOMUSDT"][0].Volume)
+	})
+
+	t.Run("trade_resync", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/products/ATOM-USDT/trades", r.URL.Path)
+			require.Equal(t, "15", r.URL.Query().Get("after"))
+			require.Equal(t, "3", r.URL.Query().Get("limit"))
+
+			restTime := now.Format(time.RFC3339Nano)
+			fmt.Fprintf(w, `[
+				{"trade_id":14,"time":%q,"size":"1","price":"10.2"},
+				{"trade_id":13,"time":%q,"size":"1","price":"10.2"},
+				{"trade_id":12,"time":%q,"size":"1","price":"10.2"}
+			]`, restTime, restTime, restTime)
+		}))
+		defer server.Close()
+
+		p := &CoinbaseProvider{
+			logger:    zerolog.Nop(),
+			endpoints: Endpoint{Name: ProviderCoinbase, Rest: server.URL, ResyncTrades: true},
+			trades:    map[string]*coinbaseTradeBuffer{},
+		}
+		p.setTradePair(lastMatch)
+
+		match := lastMatch
+		match.Type = "match"
+		match.TradeID = 11
+		p.setTradePair(match)
+
+		// trades 12 to 14 were missed and are fetched from the rest api
+		match.TradeID = 15
+		p.setTradePair(match)
+
+		require.Eventually(t, func() bool {
+			candles, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+			require.NoError(t, err)
+			return !candles["ATOMUSDT"][0].VolumeUnreliable
+		}, time.Second, 10*time.Millisecond)
+
+		candles, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		require.NoError(t, err)
+		require.Equal(t, sdk.MustNewDecFromStr("7.5"), candles["ATOMUSDT"][0].Volume)
+		require.Equal(t, 6, p.trades["ATOM-USDT"].len())
+	})
+
+	t.Run("unsubscribe_during_resync", func(t *testing.T) {
+		requested := make(chan struct{})
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(requested)
+			<-release
+
+			restTime := now.Format(time.RFC3339Nano)
+			fmt.Fprintf(w, `[{"trade_id":12,"time":%q,"size":"1","price":"10.2"}]`, restTime)
+		}))
+		defer server.Close()
+
+		atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+		p := &CoinbaseProvider{
+			logger:          zerolog.Nop(),
+			endpoints:       Endpoint{Name: ProviderCoinbase, Rest: server.URL, ResyncTrades: true},
+			trades:          map[string]*coinbaseTradeBuffer{},
+			tickers:         map[string]CoinbaseTicker{},
+			subscribedPairs: map[string]types.CurrencyPair{},
+			unsubscribed:    map[string]struct{}{},
+		}
+		p.wsc = NewWebsocketController(
+			context.Background(),
+			Endpoint{Name: ProviderCoinbase},
+			url.URL{},
+			nil,
+			p.messageReceived,
+			disabledPingDuration,
+			websocket.PingMessage,
+			zerolog.Nop(),
+		)
+		p.setSubscribedPairs(atomUSDT)
+		p.setTradePair(lastMatch)
+
+		// trade 12 was missed, and the pair is unsubscribed while it's fetched
+		match := lastMatch
+		match.Type = "match"
+		match.TradeID = 13
+		p.setTradePair(match)
+
+		select {
+		case <-requested:
+		case <-time.After(5 * time.Second):
+			t.Fatal("missed trades not requested")
+		}
+		p.UnsubscribeCurrencyPairs(atomUSDT)
+		close(release)
+
+		require.Never(t, func() bool {
+			p.mtx.RLock()
+			defer p.mtx.RUnlock()
+			return len(p.trades) > 0
+		}, 200*time.Millisecond, 10*time.Millisecond)
+	})
+}
+
+func TestCoinbaseProvider_SubscriptionAcks(t *testing.T) {
+	msg := newCoinbaseSubscription([]string{"ticker", "matches"}, []string{"ATOM-USDT"})
+	require.Equal(t, []string{"ticker:ATOM-USDT", "matches:ATOM-USDT"}, coinbaseSubscriptionAckKeys(msg))
+
+	// the subscriptions message lists all of the channels of the connection
+	p := &CoinbaseProvider{logger: zerolog.Nop()}
+	conn := &WebsocketConnection{pendingAcks: map[string]struct{}{"ticker:ATOM-USDT": {}, "matches:OJO-USDT": {}}}
+	p.messageReceived(0, conn, []byte(`{"type":"subscriptions","channels":[{"name":"ticker","product_ids":["ATOM-USDT","BTC-USD"]}]}`))
+	require.Equal(t, map[string]struct{}{"matches:OJO-USDT": {}}, conn.pendingAcks)
+}
+
+func TestCoinbaseTradeBuffer(t *testing.T) {
+	b := newCoinbaseTradeBuffer()
+	for i := int64(1); i <= 40; i++ {
+		require.True(t, b.add(CoinbaseTrade{TradeID: i, Time: i}, 0))
+	}
+	require.Equal(t, 40, b.len())
+	require.False(t, b.add(CoinbaseTrade{TradeID: 40, Time: 40}, 0))
+
+	// stale trades are evicted from the front when a trade is added
+	require.True(t, b.add(CoinbaseTrade{TradeID: 41, Time: 41}, 30))
+	trades := b.list()
+	require.Len(t, trades, 11)
+	for i, trade := range trades {
+		require.Equal(t, int64(31+i), trade.Time)
+	}
+
+	require.Equal(t, int64(0), b.missedTrades(CoinbaseTrade{TradeID: 42}))
+	require.Equal(t, int64(3), b.missedTrades(CoinbaseTrade{TradeID: 45}))
+	require.Equal(t, int64(0), b.missedTrades(CoinbaseTrade{TradeID: 20}))
+	require.Equal(t, int64(0), b.missedTrades(CoinbaseTrade{Time: 45}))
+
+	// evicted trades are no longer duplicates
+	require.True(t, b.add(CoinbaseTrade{TradeID: 1, Time: 42}, 30))
+	require.Equal(t, 12, b.len())
+}
+
+func TestCoinbaseTradeBuffer_OutOfOrder(t *testing.T) {
+	b := newCoinbaseTradeBuffer()
+	for _, id := range []int64{1, 2, 6} {
+		require.True(t, b.add(CoinbaseTrade{TradeID: id, Time: id * 10}, 0))
+	}
+
+	// the trades missed before trade 6 are resynced most recent first
+	for _, id := range []int64{5, 4, 3} {
+		require.True(t, b.add(CoinbaseTrade{TradeID: id, Time: id * 10}, 0))
+	}
+	trades := b.list()
+	require.Len(t, trades, 6)
+	for i, trade := range trades {
+		require.Equal(t, int64(i+1), trade.TradeID)
+	}
+	require.Equal(t, int64(0), b.missedTrades(CoinbaseTrade{TradeID: 7}))
+
+	// the oldest trades are still evicted first
+	require.True(t, b.add(CoinbaseTrade{TradeID: 7, Time: 70}, 30))
+	trades = b.list()
+	require.Len(t, trades, 4)
+	require.Equal(t, int64(4), trades[0].TradeID)
+}
+
+// coinbaseTradeSliceRebuild is the previous trade storage, rebuilding the
+// whole slice of trades on every trade, used as a benchmark baseline.
+func coinbaseTradeSliceRebuild(trades []CoinbaseTrade, trade CoinbaseTrade, staleTime int64) []CoinbaseTrade {
+	tradeList := []CoinbaseTrade{trade}
+	for _, t := range trades {
+		if t.key() == trade.key() {
+			return trades
+		}
+		if staleTime < t.Time {
+			tradeList = append(tradeList, t)
+		}
+	}
+	return tradeList
+}
+
+// benchmarkCoinbaseTrades is the amount of trades in the candle period of a
+// high-volume pair.
+const benchmarkCoinbaseTrades = 5000
+
+func BenchmarkCoinbaseTradeBuffer_Add(b *testing.B) {
+	buffer := newCoinbaseTradeBuffer()
+	for i := int64(0); i < benchmarkCoinbaseTrades; i++ {
+		buffer.add(CoinbaseTrade{TradeID: i + 1, ProductID: "BTC-USD", Time: i}, -1)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := int64(benchmarkCoinbaseTrades); i < int64(benchmarkCoinbaseTrades+b.N); i++ {
+		buffer.add(CoinbaseTrade{TradeID: i + 1, ProductID: "BTC-USD", Time: i}, i-benchmarkCoinbaseTrades)
+	}
+}
+
+func BenchmarkCoinbaseTradeSliceRebuild(b *testing.B) {
+	trades := []CoinbaseTrade{}
+	for i := int64(0); i < benchmarkCoinbaseTrades; i++ {
+		trades = coinbaseTradeSliceRebuild(trades, CoinbaseTrade{TradeID: i + 1, ProductID: "BTC-USD", Time: i}, -1)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := int64(benchmarkCoinbaseTrades); i < int64(benchmarkCoinbaseTrades+b.N); i++ {
+		trades = coinbaseTradeSliceRebuild(
+			trades,
+			CoinbaseTrade{TradeID: i + 1, ProductID: "BTC-USD", Time: i},
+			i-benchmarkCoinbaseTrades,
+		)
+	}
+}
+
+func TestAddCoinbaseTradeToCandle(t *testing.T) {
+	candle := types.CandlePrice{Price: sdk.ZeroDec(), Volume: sdk.ZeroDec()}
+	for i, price := range []string{"10.1", "10.4", "9.9", "10.2"} {
+		candle = addCoinbaseTradeToCandle(candle, sdk.MustNewDecFromStr(price), sdk.OneDec(), int64(i))
+	}
+
+	require.Equal(t, sdk.MustNewDecFromStr("10.1"), candle.Open)
+	require.Equal(t, sdk.MustNewDecFromStr("10.4"), candle.High)
+	require.Equal(t, sdk.MustNewDecFromStr("9.9"), candle.Low)
+	require.Equal(t, sdk.MustNewDecFromStr("10.2"), candle.Price)
+	require.Equal(t, sdk.NewDec(4), candle.Volume)
+	require.Equal(t, int64(3), candle.TimeStamp)
+}
+
+func TestCoinbaseTradesToCandles(t *testing.T) {
+	trade := func(timeMs int64, price string) CoinbaseTrade {
+		return CoinbaseTrade{Time: timeMs, Size: "1", Price: price}
+	}
+	minute := time.Minute.Milliseconds()
+
+	t.Run("no trades", func(t *testing.T) {
+		candles, err := coinbaseTradesToCandles([]CoinbaseTrade{}, time.Minute)
+		require.NoError(t, err)
+		require.Empty(t, candles)
+	})
+
+	t.Run("single trade", func(t *testing.T) {
+		candles, err := coinbaseTradesToCandles([]CoinbaseTrade{trade(minute, "10")}, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, candles, 1)
+		require.Equal(t, sdk.MustNewDecFromStr("10"), candles[0].Price)
+		require.Equal(t, sdk.OneDec(), candles[0].Volume)
+		require.Equal(t, minute, candles[0].TimeStamp)
+	})
+
+	t.Run("minute boundary", func(t *testing.T) {
+		// a trade exactly one interval after the first starts a new candle,
+		// one just before it doesn't
+		candles, err := coinbaseTradesToCandles([]CoinbaseTrade{
+			trade(2*minute, "12"),
+			trade(0, "10"),
+			trade(minute-1, "11"),
+		}, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, candles, 2)
+		require.Equal(t, sdk.MustNewDecFromStr("11"), candles[0].Price)
+		require.Equal(t, sdk.NewDec(2), candles[0].Volume)
+		require.Equal(t, sdk.MustNewDecFromStr("12"), candles[1].Price)
+		require.Equal(t, sdk.OneDec(), candles[1].Volume)
+	})
+
+	t.Run("zero price trades", func(t *testing.T) {
+		candles, err := coinbaseTradesToCandles([]CoinbaseTrade{
+			trade(0, "0"),
+			trade(minute, "10"),
+		}, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, candles, 1)
+		require.Equal(t, sdk.MustNewDecFromStr("10"), candles[0].Price)
+
+		candles, err = coinbaseTradesToCandles([]CoinbaseTrade{trade(0, "0")}, time.Minute)
+		require.NoError(t, err)
+		require.Empty(t, candles)
+	})
+
+	t.Run("invalid price", func(t *testing.T) {
+		_, err := coinbaseTradesToCandles([]CoinbaseTrade{trade(0, "bad")}, time.Minute)
+		require.Error(t, err)
+	})
+}
+
+func TestCoinbaseProvider_GetCandlePrices_NoTrades(t *testing.T) {
+	p := &CoinbaseProvider{
+		logger: zerolog.Nop(),
+		trades: map[string]*coinbaseTradeBuffer{"ATOM-USDT": {}},
+	}
+
+	// the pair is left out rather than reported with a zero price candle
+	candles, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+	require.NoError(t, err)
+	require.Empty(t, candles)
+}
+
+func TestCoinbaseProvider_UnsubscribeCurrencyPairs(t *testing.T) {
+	received := make(chan CoinbaseSubscriptionMsg, 2)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var msg CoinbaseSubscriptionMsg
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			received <- msg
+		}
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	ojoUSDT := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+	p := &CoinbaseProvider{
+		logger:          zerolog.Nop(),
+		trades:          map[string]*coinbaseTradeBuffer{},
+		tickers:         map[string]CoinbaseTicker{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+		unsubscribed:    map[string]struct{}{},
+	}
+	p.setSubscribedPairs(atomUSDT, ojoUSDT)
+	subscriptionMsgs, err := p.getSubscriptionMsgs(atomUSDT, ojoUSDT)
+	require.NoError(t, err)
+	p.wsc = NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderCoinbase},
+		*wsURL,
+		subscriptionMsgs,
+		p.messageReceived,
+		disabledPingDuration,
+		websocket.PingMessage,
+		zerolog.Nop(),
+	)
+	p.wsc.StartConnections()
+
+	select {
+	case msg := <-received:
+		require.Equal(t, "subscribe", msg.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription message not received")
+	}
+
+	p.setTickerPair(CoinbaseTicker{ProductID: "ATOM-USDT", Price: "10", Volume: "100"})
+	p.setTradePair(CoinbaseTradeResponse{
+		Type:      "match",
+		TradeID:   1,
+		ProductID: "ATOM-USDT",
+		Time:      time.Now().UTC().Format(coinbaseTimeFmt),
+		Size:      "1",
+		Price:     "10",
+	})
+	p.UnsubscribeCurrencyPairs(atomUSDT)
+
+	select {
+	case msg := <-received:
+		require.Equal(t, CoinbaseSubscriptionMsg{
+			Type:       "unsubscribe",
+			ProductIDs: []string{"ATOM-USDT"},
+			Channels:   coinbaseChannels,
+		}, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("unsubscription message not received")
+	}
+	require.Equal(t, map[string]types.CurrencyPair{"OJOUSDT": ojoUSDT}, p.GetSubscribedPairs())
+	require.NotContains(t, p.tickers, "ATOM-USDT")
+	require.NotContains(t, p.trades, "ATOM-USDT")
+
+	// the messages still in flight are dropped
+	p.setTickerPair(CoinbaseTicker{ProductID: "ATOM-USDT", Price: "10", Volume: "100"})
+	require.NotContains(t, p.tickers, "ATOM-USDT")
+}
+
+func TestCoinbaseProvider_ClockCandles(t *testing.T) {
+	boundary := time.Now().UTC().Truncate(time.Minute)
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	p := &CoinbaseProvider{
+		logger:       zerolog.Nop(),
+		endpoints:    Endpoint{Name: ProviderCoinbase, ClockCandles: true},
+		trades:       map[string]*coinbaseTradeBuffer{},
+		clockCandles: map[string]*candleDeque{},
+	}
+	trade := func(id int64, at time.Time, price string) {
+		p.setTradePair(CoinbaseTradeResponse{
+			Type:      "match",
+			TradeID:   id,
+			ProductID: "ATOM-USDT",
+			Time:      at.Format(coinbaseTimeFmt),
+			Size:      "1",
+			Price:     price,
+		})
+	}
+	trade(1, boundary.Add(-50*time.Second), "10")
+	trade(2, boundary.Add(-10*time.Second), "11")
+
+	// no candle is reported before its minute is finalized
+	candles, err := p.GetCandlePrices(context.TODO(), atomUSDT)
+	require.NoError(t, err)
+	require.Empty(t, candles)
+
+	p.finalizeCandles(boundary)
+	trade(3, boundary.Add(5*time.Second), "12")
+
+	expected := []types.CandlePrice{{
+		Price:     sdk.MustNewDecFromStr("11"),
+		Volume:    sdk.MustNewDecFromStr("2"),
+		TimeStamp: boundary.UnixMilli(),
+		Open:      sdk.MustNewDecFromStr("10"),
+		High:      sdk.MustNewDecFromStr("11"),
+		Low:       sdk.MustNewDecFromStr("10"),
+	}}
+	candles, err = p.GetCandlePrices(context.TODO(), atomUSDT)
+	require.NoError(t, err)
+	require.Equal(t, expected, candles["ATOMUSDT"])
+
+	// a late trade of the finalized minute leaves its candle untouched
+	trade(4, boundary.Add(-time.Second), "20")
+	candles, err = p.GetCandlePrices(context.TODO(), atomUSDT)
+	require.NoError(t, err)
+	require.Equal(t, expected, candles["ATOMUSDT"])
+
+	// other intervals still bucket the trades on demand
+	candles, err = p.GetCandlePricesWithInterval(context.TODO(), 5*time.Minute, atomUSDT)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("4"), candles["ATOMUSDT"][0].Volume)
+}
@@ -0,0 +1,424 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/ojo/util/decmath"
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	coinGeckoRestURL          = "https://api.coingecko.com/api/v3"
+	coinGeckoProRestURL       = "https://pro-api.coingecko.com/api/v3"
+	coinGeckoPricePath        = "/simple/price?ids=%s&vs_currencies=%s&include_last_updated_at=true"
+	coinGeckoVsCurrenciesPath = "/simple/supported_vs_currencies"
+	coinGeckoAPIKeyHeader     = "x-cg-pro-api-key"
+	coinGeckoLastUpdatedKey   = "last_updated_at"
+
+	// coinGeckoPollInterval keeps the provider at two requests per minute,
+	// well within the rate limit of CoinGecko's public API.
+	coinGeckoPollInterval = 30 * time.Second
+
+	// coinGeckoRateLimitBackoff is how long the provider stops polling after
+	// being rate limited without a Retry-After header.
+	coinGeckoRateLimitBackoff = time.Minute
+)
+
+var _ Provider = (*CoinGeckoProvider)(nil)
+
+// coinGeckoCoinIDs defines the CoinGecko ids of the coins the provider reads
+// by default, indexed by their base symbol. More coins can be added with the
+// endpoint's CoinIDs.
+var coinGeckoCoinIDs = map[string]string{
+	"ATOM": "cosmos",
+	"BTC":  "bitcoin",
+	"DAI":  "dai",
+	"ETH":  "ethereum",
+	"OSMO": "osmosis",
+	"USDC": "usd-coin",
+	"USDT": "tether",
+}
+
+type (
+	// CoinGeckoProvider defines an Oracle provider reading the spot prices
+	// CoinGecko aggregates over many exchanges. It's meant as a broad
+	// reference to cross-check the other providers against rather than as a
+	// primary source: its tickers carry no volume and it reports no candles.
+	//
+	// It polls the prices of the subscribed pairs every coinGeckoPollInterval
+	// with a single request, and stops polling for the Retry-After duration
+	// when it's rate limited.
+	//
+	// REF: https://www.coingecko.com/api/documentation
+	CoinGeckoProvider struct {
+		ctx             context.Context
+		logger          zerolog.Logger
+		mtx             sync.RWMutex
+		endpoints       Endpoint
+		client          *http.Client
+		coinIDs         map[string]string             // Base => coin id
+		tickers         map[string]types.TickerPrice  // Symbol => TickerPrice
+		subscribedPairs map[string]types.CurrencyPair // Symbol => types.CurrencyPair
+		retryAt         time.Time
+	}
+
+	// CoinGeckoPriceResponse defines the response of the simple price
+	// endpoint, the prices of each coin id keyed by their lower case quote
+	// along with the time they were last updated at in seconds.
+	CoinGeckoPriceResponse map[string]map[string]float64
+)
+
+// NewCoinGeckoProvider creates a new CoinGeckoProvider. The CoinGecko pro API
+// is used when an API key is configured without a rest endpoint.
+func NewCoinGeckoProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*CoinGeckoProvider, error) {
+	if endpoints.Name != ProviderCoinGecko {
+		endpoints = Endpoint{Name: ProviderCoinGecko}
+	}
+	if len(endpoints.Rest) == 0 {
+		endpoints.Rest = coinGeckoRestURL
+		if len(endpoints.APIKey) > 0 {
+			endpoints.Rest = coinGeckoProRestURL
+		}
+	}
+
+	coinGeckoLogger := logger.With().Str("provider", string(ProviderCoinGecko)).Logger()
+
+	provider := &CoinGeckoProvider{
+		ctx:             ctx,
+		logger:          coinGeckoLogger,
+		endpoints:       endpoints,
+		client:          endpoints.httpClient(newDefaultHTTPClient()),
+		coinIDs:         endpoints.coinGeckoCoinIDs(),
+		tickers:         map[string]types.TickerPrice{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		provider,
+		provider.endpoints,
+		provider.logger,
+		pairs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.setSubscribedPairs(confirmedPairs...)
+
+	return provider, nil
+}
+
+// coinGeckoCoinIDs returns the default coin ids along with the endpoint's
+// coin ids, which take precedence.
+func (e Endpoint) coinGeckoCoinIDs() map[string]string {
+	coinIDs := make(map[string]string, len(coinGeckoCoinIDs)+len(e.CoinIDs))
+	for base, id := range coinGeckoCoinIDs {
+		coinIDs[base] = id
+	}
+	for base, id := range e.CoinIDs {
+		// the config keys are lowercased when parsed
+		coinIDs[strings.ToUpper(base)] = id
+	}
+	return coinIDs
+}
+
+// StartConnections starts polling the prices of the subscribed pairs.
+func (p *CoinGeckoProvider) StartConnections() {
+	go p.pollPrices()
+}
+
+// Describe returns the capabilities of the provider.
+func (p *CoinGeckoProvider) Describe() Description {
+	return describe(p.endpoints, false)
+}
+
+// SubscribeCurrencyPairs adds the new currency pairs to the pairs polled by
+// the provider.
+func (p *CoinGeckoProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	// the availability is confirmed without holding the lock, which a rate
+	// limited request takes to set the time to retry at
+	confirmedPairs, err := ConfirmPairAvailability(
+		p,
+		p.endpoints,
+		p.logger,
+		cps...,
+	)
+	if err != nil {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.setSubscribedPairs(confirmedPairs...)
+}
+
+// UnsubscribeCurrencyPairs stops polling the prices of the currency pairs and
+// removes them.
+func (p *CoinGeckoProvider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, cp := range cps {
+		delete(p.subscribedPairs, cp.String())
+		delete(p.tickers, cp.String())
+	}
+}
+
+// GetTickerPrices returns the latest polled prices of the provided pairs.
+func (p *CoinGeckoProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+
+	tickerErrs := 0
+	for _, cp := range pairs {
+		price, err := p.getTickerPrice(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
+			tickerErrs++
+			continue
+		}
+		tickerPrices[cp.String()] = price
+	}
+
+	if tickerErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoTickers.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns no candles since CoinGecko only reports spot
+// prices, leaving the provider's pairs to be priced from its tickers.
+func (p *CoinGeckoProvider) GetCandlePrices(context.Context, ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	return map[string][]types.CandlePrice{}, nil
+}
+
+// GetAvailablePairs returns the pairs of the coins the provider has an id
+// for quoted in each of the currencies supported by CoinGecko.
+func (p *CoinGeckoProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	var vsCurrencies []string
+	if err := p.get(context.Background(), coinGeckoVsCurrenciesPath, &vsCurrencies); err != nil {
+		return nil, err
+	}
+
+	availablePairs := make(map[string]struct{}, len(p.coinIDs)*len(vsCurrencies))
+	for base := range p.coinIDs {
+		for _, quote := range vsCurrencies {
+			cp := types.CurrencyPair{Base: base, Quote: strings.ToUpper(quote)}
+			availablePairs[cp.String()] = struct{}{}
+		}
+	}
+	return availablePairs, nil
+}
+
+func (p *CoinGeckoProvider) getTickerPrice(key string) (types.TickerPrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	ticker, ok := p.tickers[key]
+	if !ok {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+	if isStale(ticker.TimeStamp, p.endpoints.tickerMaxAge()) {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerStale.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	return ticker, nil
+}
+
+// pollPrices updates the prices of the subscribed pairs every
+// coinGeckoPollInterval until the provider's context is done.
+func (p *CoinGeckoProvider) pollPrices() {
+	pollTicker := time.NewTicker(coinGeckoPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		p.updatePrices()
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-pollTicker.C:
+			continue
+		}
+	}
+}
+
+// updatePrices requests the prices of all subscribed pairs at once and
+// stores them as the pairs' tickers, unless the provider is waiting out a
+// rate limit.
+func (p *CoinGeckoProvider) updatePrices() {
+	p.mtx.RLock()
+	pairs := types.MapPairsToSlice(p.subscribedPairs)
+	retryAt := p.retryAt
+	p.mtx.RUnlock()
+
+	if len(pairs) == 0 {
+		return
+	}
+	if time.Now().Before(retryAt) {
+		p.logger.Debug().Time("retry_at", retryAt).Msg("skipping rate limited poll")
+		return
+	}
+
+	ids, vsCurrencies := p.priceQuery(pairs)
+	var prices CoinGeckoPriceResponse
+	path := fmt.Sprintf(coinGeckoPricePath, strings.Join(ids, ","), strings.Join(vsCurrencies, ","))
+	if err := p.get(p.ctx, path, &prices); err != nil {
+		TelemetryFailure(ProviderCoinGecko, MessageTypeTicker)
+		p.logger.Error().Err(err).Msg("failed to get coingecko prices")
+		return
+	}
+
+	for _, cp := range pairs {
+		if err := p.setTickerPrice(cp, prices); err != nil {
+			p.logger.Warn().Err(err).Str("pair", cp.String()).Msg("failed to read coingecko price")
+			continue
+		}
+		telemetryRestPoll(ProviderCoinGecko, MessageTypeTicker)
+	}
+}
+
+// priceQuery returns the sorted coin ids and lower case quotes of the pairs.
+func (p *CoinGeckoProvider) priceQuery(pairs []types.CurrencyPair) (ids, vsCurrencies []string) {
+	idSet := map[string]struct{}{}
+	vsSet := map[string]struct{}{}
+	for _, cp := range pairs {
+		if id, ok := p.coinIDs[strings.ToUpper(cp.Base)]; ok {
+			idSet[id] = struct{}{}
+		}
+		vsSet[strings.ToLower(cp.Quote)] = struct{}{}
+	}
+
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	for vs := range vsSet {
+		vsCurrencies = append(vsCurrencies, vs)
+	}
+	sort.Strings(ids)
+	sort.Strings(vsCurrencies)
+	return ids, vsCurrencies
+}
+
+// setTickerPrice stores the price of the pair in the response as its ticker,
+// timestamped with the time CoinGecko last updated it.
+func (p *CoinGeckoProvider) setTickerPrice(cp types.CurrencyPair, prices CoinGeckoPriceResponse) error {
+	quotes, ok := prices[p.coinIDs[strings.ToUpper(cp.Base)]]
+	if !ok {
+		return fmt.Errorf(types.ErrMissingExchangeRate.Error(), cp.String())
+	}
+	price, ok := quotes[strings.ToLower(cp.Quote)]
+	if !ok {
+		return fmt.Errorf(types.ErrMissingExchangeRate.Error(), cp.String())
+	}
+
+	dec, err := decmath.NewDecFromFloat(price)
+	if err != nil || !dec.IsPositive() {
+		telemetryParseError(ProviderCoinGecko, ParseErrorBadDecimal)
+		return fmt.Errorf("invalid coingecko price (%f) for %s", price, cp.String())
+	}
+
+	timeStamp := time.Now().UnixMilli()
+	if updatedAt, ok := quotes[coinGeckoLastUpdatedKey]; ok {
+		timeStamp = SecondsToMilli(int64(updatedAt))
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// aggregated prices carry no traded volume
+	p.tickers[cp.String()] = types.TickerPrice{
+		Price:     dec,
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: timeStamp,
+	}
+	return nil
+}
+
+// get requests the path from the CoinGecko API, authenticated with the
+// endpoint's API key if any, and decodes its response into v. Being rate
+// limited stops the polling until the time CoinGecko asks to retry at.
+func (p *CoinGeckoProvider) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoints.Rest+path, nil)
+	if err != nil {
+		return err
+	}
+	if len(p.endpoints.APIKey) > 0 {
+		req.Header.Set(coinGeckoAPIKeyHeader, p.endpoints.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make CoinGecko request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAt := time.Now().Add(coinGeckoRetryAfter(resp))
+		p.mtx.Lock()
+		p.retryAt = retryAt
+		p.mtx.Unlock()
+		return fmt.Errorf("rate limited by CoinGecko until %s", retryAt.Format(time.RFC3339))
+	}
+	if err := checkHTTPStatus(resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to unmarshal CoinGecko response body: %w", err)
+	}
+	return nil
+}
+
+// coinGeckoRetryAfter returns the seconds of the response's Retry-After
+// header, or coinGeckoRateLimitBackoff if it has none.
+func coinGeckoRetryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return coinGeckoRateLimitBackoff
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
+func (p *CoinGeckoProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
+	for _, cp := range cps {
+		p.subscribedPairs[cp.String()] = cp
+	}
+}
+
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *CoinGeckoProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func newCoinGeckoTestServer(t *testing.T, priceRequests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "cg-key", r.Header.Get(coinGeckoAPIKeyHeader))
+		switch r.URL.Path {
+		case coinGeckoVsCurrenciesPath:
+			fmt.Fprint(w, `["usd","eur"]`)
+		case "/simple/price":
+			if atomic.AddInt32(priceRequests, 1) > 1 {
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			fmt.Fprintf(w, `{"cosmos":{"usd":10.5,"eur":9.75,"last_updated_at":%d},"juno-network":{"usd":0.3}}`,
+				time.Now().Unix())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCoinGeckoProvider_GetTickerPrices(t *testing.T) {
+	var priceRequests int32
+	server := newCoinGeckoTestServer(t, &priceRequests)
+	defer server.Close()
+
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	atomEUR := types.CurrencyPair{Base: "ATOM", Quote: "EUR"}
+	junoUSD := types.CurrencyPair{Base: "JUNO", Quote: "USD"}
+	p, err := NewCoinGeckoProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{
+			Name:    ProviderCoinGecko,
+			Rest:    server.URL,
+			APIKey:  "cg-key",
+			CoinIDs: map[string]string{"juno": "juno-network"},
+		},
+		atomUSD, atomEUR, junoUSD,
+		// coins without an id aren't available
+		types.CurrencyPair{Base: "FOO", Quote: "USD"},
+	)
+	require.NoError(t, err)
+	require.Len(t, p.GetSubscribedPairs(), 3)
+
+	// all pairs are requested at once
+	ids, vsCurrencies := p.priceQuery(types.MapPairsToSlice(p.GetSubscribedPairs()))
+	require.Equal(t, []string{"cosmos", "juno-network"}, ids)
+	require.Equal(t, []string{"eur", "usd"}, vsCurrencies)
+
+	p.updatePrices()
+	prices, err := p.GetTickerPrices(context.TODO(), atomUSD, atomEUR, junoUSD)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("10.5"), prices["ATOMUSD"].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("9.75"), prices["ATOMEUR"].Price)
+	require.True(t, prices["ATOMUSD"].Volume.IsZero())
+	require.Equal(t, sdk.MustNewDecFromStr("0.3"), prices["JUNOUSD"].Price)
+
+	candles, err := p.GetCandlePrices(context.TODO(), atomUSD)
+	require.NoError(t, err)
+	require.Empty(t, candles)
+}
+
+func TestCoinGeckoProvider_RateLimit(t *testing.T) {
+	var priceRequests int32
+	server := newCoinGeckoTestServer(t, &priceRequests)
+	defer server.Close()
+
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	p, err := NewCoinGeckoProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{Name: ProviderCoinGecko, Rest: server.URL, APIKey: "cg-key"},
+		atomUSD,
+	)
+	require.NoError(t, err)
+
+	// the first poll is served, the second rate limited
+	p.updatePrices()
+	p.updatePrices()
+	require.Equal(t, int32(2), atomic.LoadInt32(&priceRequests))
+	require.WithinDuration(t, time.Now().Add(time.Minute), p.retryAt, 5*time.Second)
+
+	// the following polls wait for the Retry-After duration
+	p.updatePrices()
+	require.Equal(t, int32(2), atomic.LoadInt32(&priceRequests))
+
+	// the prices of the served poll are still reported
+	prices, err := p.GetTickerPrices(context.TODO(), atomUSD)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("10.5"), prices["ATOMUSD"].Price)
+}
@@ -133,7 +133,7 @@ func NewCryptoProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -145,7 +145,7 @@ func NewCryptoProvider(
 
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
 		provider.getSubscriptionMsgs(confirmedPairs...),
 		provider.messageReceived,
@@ -161,6 +161,11 @@ func (p *CryptoProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
+// Describe returns the capabilities of the provider.
+func (p *CryptoProvider) Describe() Description {
+	return describe(p.endpoints, true, cryptoTickerChannel, cryptoCandleChannel)
+}
+
 func (p *CryptoProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
 	subscriptionMsgs := make([]interface{}, 0, len(cps)*2)
 	for _, cp := range cps {
@@ -192,7 +197,7 @@ func (p *CryptoProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -210,8 +215,12 @@ func (p *CryptoProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *CryptoProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *CryptoProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *CryptoProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
@@ -219,7 +228,7 @@ func (p *CryptoProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[strin
 		key := currencyPairToCryptoPair(cp)
 		price, err := p.getTickerPrice(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -237,7 +246,7 @@ func (p *CryptoProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[strin
 }
 
 // GetCandlePrices returns the candlePrices based on the provided pairs.
-func (p *CryptoProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *CryptoProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
@@ -245,7 +254,7 @@ func (p *CryptoProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[strin
 		key := currencyPairToCryptoPair(cp)
 		prices, err := p.getCandlePrices(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -342,6 +351,7 @@ func (p *CryptoProvider) messageReceived(messageType int, conn *WebsocketConnect
 		return
 	}
 
+	telemetryParseError(ProviderCrypto, parseErrorReason(heartbeatErr, tickerErr, candleErr))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("heartbeat", heartbeatErr).
@@ -406,6 +416,9 @@ func (p *CryptoProvider) setCandlePair(symbol string, candlePair CryptoCandle) {
 	candleList = append(candleList, candle)
 
 	for _, c := range p.candles[symbol] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -421,10 +434,18 @@ func (p *CryptoProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *CryptoProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 // ex.: map["ATOMUSDT" => {}, "OJOUSDC" => {}].
 func (p *CryptoProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + cryptoRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + cryptoRestPath)
 	if err != nil {
 		return nil, err
 	}
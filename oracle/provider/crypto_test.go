@@ -31,7 +31,7 @@ func TestCryptoProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, lastPrice, prices["ATOMUSDT"].Price)
@@ -56,6 +56,7 @@ func TestCryptoProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "LUNA", Quote: "USDT"},
 		)
@@ -68,7 +69,7 @@ func TestCryptoProvider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.Error(t, err)
 		require.Equal(t, "crypto has no ticker data for requested pairs: [FOOBAR]", err.Error())
 		require.Nil(t, prices)
@@ -97,7 +98,7 @@ func TestCryptoProvider_GetCandlePrices(t *testing.T) {
 
 		p.setCandlePair("ATOM_USDT", candle)
 
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		priceDec, _ := sdk.NewDecFromStr(price)
@@ -109,7 +110,7 @@ func TestCryptoProvider_GetCandlePrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_candle", func(t *testing.T) {
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "crypto has no candle data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
@@ -0,0 +1,505 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	deribitWSHost             = "www.deribit.com"
+	deribitWSPath             = "/ws/api/v2"
+	deribitRestHost           = "https://www.deribit.com"
+	deribitRestPath           = "/api/v2/public/get_index_price_names"
+	deribitIndexChannelPrefix = "deribit_price_index."
+	deribitSubscriptionMethod = "subscription"
+	deribitHeartbeatMethod    = "heartbeat"
+	deribitTestRequest        = "test_request"
+
+	// deribitHeartbeatInterval is the interval, in seconds, of the test
+	// requests the server sends and expects an answer to, the minimum being 10.
+	deribitHeartbeatInterval = 30
+)
+
+var (
+	_ Provider = (*DeribitProvider)(nil)
+
+	// deribitSubscription subscribes to the index price channels of all pairs
+	// in a single message.
+	deribitSubscription = subscriptionFormat{
+		symbol:  currencyPairToDeribitIndex,
+		message: newDeribitSubscriptionMsg,
+	}
+)
+
+type (
+	// DeribitProvider defines an Oracle provider implemented by the Deribit
+	// public API. It reports the index prices Deribit computes from several
+	// spot exchanges, which carry no volume, and builds one minute candles
+	// from them.
+	//
+	// REF: https://docs.deribit.com/#deribit_price_index-index_name
+	DeribitProvider struct {
+		wsc             *WebsocketController
+		logger          zerolog.Logger
+		mtx             sync.RWMutex
+		endpoints       Endpoint
+		tickers         map[string]types.TickerPrice   // Index name => TickerPrice
+		candles         map[string][]types.CandlePrice // Index name => CandlePrice
+		subscribedPairs map[string]types.CurrencyPair  // Symbol => types.CurrencyPair
+	}
+
+	// DeribitRequest defines a JSON-RPC request to the Deribit API.
+	DeribitRequest struct {
+		JSONRPC string      `json:"jsonrpc"` // 2.0
+		ID      int64       `json:"id"`
+		Method  string      `json:"method"` // ex.: public/subscribe
+		Params  interface{} `json:"params"`
+	}
+	DeribitSubscriptionParams struct {
+		Channels []string `json:"channels"` // ex.: deribit_price_index.btc_usd
+	}
+	DeribitHeartbeatParams struct {
+		Interval int `json:"interval"` // seconds between the server's test requests
+	}
+
+	// DeribitMessage defines the envelope of the messages sent by Deribit,
+	// either a response to a request, holding its id, or a notification,
+	// holding its method.
+	DeribitMessage struct {
+		ID     *int64               `json:"id"`
+		Method string               `json:"method"` // ex.: subscription, heartbeat
+		Params DeribitMessageParams `json:"params"`
+		Error  *DeribitError        `json:"error"`
+	}
+	DeribitMessageParams struct {
+		Channel string            `json:"channel"` // ex.: deribit_price_index.btc_usd
+		Data    DeribitIndexPrice `json:"data"`
+		Type    string            `json:"type"` // heartbeat type, ex.: test_request
+	}
+	DeribitIndexPrice struct {
+		IndexName string      `json:"index_name"` // ex.: btc_usd
+		Price     json.Number `json:"price"`      // ex.: 3937.89
+		Timestamp int64       `json:"timestamp"`  // unix milliseconds
+	}
+	DeribitError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	// DeribitIndexNamesResponse defines the response of the rest endpoint
+	// listing the index names.
+	DeribitIndexNamesResponse struct {
+		Result []string `json:"result"` // ex.: ["btc_usd", "eth_usd"]
+	}
+)
+
+// NewDeribitProvider creates a new DeribitProvider.
+func NewDeribitProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*DeribitProvider, error) {
+	if endpoints.Name != ProviderDeribit {
+		endpoints = Endpoint{
+			Name:      ProviderDeribit,
+			Rest:      deribitRestHost,
+			Websocket: deribitWSHost,
+		}
+	}
+
+	wsURL := url.URL{
+		Scheme: "wss",
+		Host:   endpoints.Websocket,
+		Path:   deribitWSPath,
+	}
+
+	deribitLogger := logger.With().Str("provider", string(ProviderDeribit)).Logger()
+
+	provider := &DeribitProvider{
+		logger:          deribitLogger,
+		endpoints:       endpoints,
+		tickers:         map[string]types.TickerPrice{},
+		candles:         map[string][]types.CandlePrice{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		provider,
+		provider.endpoints,
+		provider.logger,
+		pairs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.setSubscribedPairs(confirmedPairs...)
+
+	subscriptionMsgs, err := provider.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.wsc = NewWebsocketController(
+		ctx,
+		endpoints,
+		wsURL,
+		subscriptionMsgs,
+		provider.messageReceived,
+		disabledPingDuration,
+		websocket.PingMessage,
+		deribitLogger,
+	)
+
+	return provider, nil
+}
+
+func (p *DeribitProvider) StartConnections() {
+	p.wsc.StartConnections()
+}
+
+// Describe returns the capabilities of the provider.
+func (p *DeribitProvider) Describe() Description {
+	return describe(p.endpoints, true, strings.TrimSuffix(deribitIndexChannelPrefix, "."))
+}
+
+// getSubscriptionMsgs returns the message enabling the heartbeat of the
+// connection followed by the subscription messages of the pairs.
+func (p *DeribitProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) ([]interface{}, error) {
+	msgs, err := deribitSubscription.build(cps...)
+	if err != nil {
+		return nil, err
+	}
+	heartbeatMsg := DeribitRequest{
+		JSONRPC: "2.0",
+		ID:      2,
+		Method:  "public/set_heartbeat",
+		Params:  DeribitHeartbeatParams{Interval: deribitHeartbeatInterval},
+	}
+	return append([]interface{}{heartbeatMsg}, msgs...), nil
+}
+
+// SubscribeCurrencyPairs sends the new subscription messages to the websocket
+// and adds them to the providers subscribedPairs array
+func (p *DeribitProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	newPairs := []types.CurrencyPair{}
+	for _, cp := range cps {
+		if _, ok := p.subscribedPairs[cp.String()]; !ok {
+			newPairs = append(newPairs, cp)
+		}
+	}
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		p,
+		p.endpoints,
+		p.logger,
+		newPairs...,
+	)
+	if err != nil {
+		return
+	}
+
+	newSubscriptionMsgs, err := p.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to build subscription messages")
+		return
+	}
+	p.wsc.AddWebsocketConnection(
+		newSubscriptionMsgs,
+		p.messageReceived,
+		disabledPingDuration,
+		websocket.PingMessage,
+	)
+	p.setSubscribedPairs(confirmedPairs...)
+}
+
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *DeribitProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// GetTickerPrices returns the tickerPrices based on the provided pairs.
+func (p *DeribitProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+
+	tickerErrs := 0
+	for _, cp := range pairs {
+		price, err := p.getTickerPrice(currencyPairToDeribitIndex(cp))
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
+			tickerErrs++
+			continue
+		}
+		tickerPrices[cp.String()] = price
+	}
+
+	if tickerErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoTickers.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns the candlePrices based on the provided pairs.
+func (p *DeribitProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
+
+	candleErrs := 0
+	for _, cp := range pairs {
+		prices, err := p.getCandlePrices(currencyPairToDeribitIndex(cp))
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
+			candleErrs++
+			continue
+		}
+		candlePrices[cp.String()] = prices
+	}
+
+	if candleErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoCandles.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return candlePrices, nil
+}
+
+func (p *DeribitProvider) getTickerPrice(key string) (types.TickerPrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	ticker, ok := p.tickers[key]
+	if !ok {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+	if isStale(ticker.TimeStamp, p.endpoints.tickerMaxAge()) {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerStale.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	return ticker, nil
+}
+
+func (p *DeribitProvider) getCandlePrices(key string) ([]types.CandlePrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	candles, ok := p.candles[key]
+	if !ok {
+		return []types.CandlePrice{}, fmt.Errorf(
+			types.ErrCandleNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	candleList := []types.CandlePrice{}
+	candleList = append(candleList, candles...)
+
+	return candleList, nil
+}
+
+func (p *DeribitProvider) messageReceived(messageType int, conn *WebsocketConnection, bz []byte) {
+	if messageType != websocket.TextMessage {
+		return
+	}
+
+	var msg DeribitMessage
+	if err := json.Unmarshal(bz, &msg); err != nil {
+		telemetryParseError(ProviderDeribit, parseErrorReason(err))
+		p.logger.Error().
+			Int("length", len(bz)).
+			Err(err).
+			Msg("Error on receive message")
+		return
+	}
+
+	switch {
+	case msg.Error != nil:
+		p.logger.Error().
+			Int("code", msg.Error.Code).
+			Str("message", msg.Error.Message).
+			Msg("deribit request failed")
+
+	case msg.ID != nil:
+		// responses to the subscription and heartbeat requests
+
+	case msg.Method == deribitHeartbeatMethod:
+		if msg.Params.Type == deribitTestRequest {
+			p.test(conn)
+		}
+
+	case msg.Method == deribitSubscriptionMethod &&
+		strings.HasPrefix(msg.Params.Channel, deribitIndexChannelPrefix):
+		if err := p.setIndexPrice(msg.Params.Data); err != nil {
+			telemetryParseError(ProviderDeribit, parseErrorReason(err))
+			p.logger.Warn().Err(err).Msg("failed to parse index price")
+			return
+		}
+		telemetryWebsocketMessage(ProviderDeribit, MessageTypeTicker)
+
+	default:
+		telemetryParseError(ProviderDeribit, ParseErrorUnknownType)
+		p.logger.Error().
+			Int("length", len(bz)).
+			Str("method", msg.Method).
+			Msg("Error on receive message")
+	}
+}
+
+// test answers a test request of the server, which closes the connection
+// when its test requests go unanswered.
+func (p *DeribitProvider) test(conn *WebsocketConnection) {
+	testReq := DeribitRequest{
+		JSONRPC: "2.0",
+		ID:      3,
+		Method:  "public/test",
+		Params:  struct{}{},
+	}
+	if err := conn.SendJSON(testReq); err != nil {
+		p.logger.Err(err).Msg("could not answer test request")
+	}
+}
+
+// setIndexPrice stores the index price as the ticker of its index and adds
+// it to the candle of its minute, dropping candles older than
+// providerCandlePeriod.
+func (p *DeribitProvider) setIndexPrice(index DeribitIndexPrice) error {
+	if len(index.IndexName) == 0 || index.Timestamp == 0 {
+		return newParseError(ParseErrorMissingField, fmt.Errorf("index price missing name or timestamp"))
+	}
+	price, err := sdk.NewDecFromStr(index.Price.String())
+	if err != nil {
+		return newParseError(ParseErrorBadDecimal, fmt.Errorf(
+			"failed to parse %s price (%s) for %s: %w", ProviderDeribit, index.Price, index.IndexName, err,
+		))
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// index prices carry no traded volume
+	p.tickers[index.IndexName] = types.TickerPrice{
+		Price:     price,
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: index.Timestamp,
+	}
+
+	candle := types.CandlePrice{
+		Price:     price,
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: index.Timestamp,
+	}
+	candles := p.candles[index.IndexName]
+	// the newest candle is updated until the index price of the next minute
+	if len(candles) > 0 && candles[0].TimeStamp/unixMinute == index.Timestamp/unixMinute {
+		candles = candles[1:]
+	}
+
+	staleTime := PastUnixTime(providerCandlePeriod)
+	candleList := []types.CandlePrice{candle}
+	for _, c := range candles {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
+		if staleTime < c.TimeStamp {
+			candleList = append(candleList, c)
+		}
+	}
+	p.candles[index.IndexName] = candleList
+	return nil
+}
+
+// setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
+func (p *DeribitProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
+	for _, cp := range cps {
+		p.subscribedPairs[cp.String()] = cp
+	}
+}
+
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *DeribitProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
+// GetAvailablePairs returns all pairs to which the provider can subscribe,
+// one for each index name.
+// ex.: map["BTCUSD" => {}, "ETHUSDC" => {}].
+func (p *DeribitProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + deribitRestPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var indexNames DeribitIndexNamesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&indexNames); err != nil {
+		return nil, err
+	}
+
+	availablePairs := make(map[string]struct{}, len(indexNames.Result))
+	for _, indexName := range indexNames.Result {
+		split := strings.Split(indexName, "_")
+		if len(split) != 2 {
+			continue
+		}
+
+		cp := types.CurrencyPair{
+			Base:  split[0],
+			Quote: split[1],
+		}
+
+		availablePairs[strings.ToUpper(cp.String())] = struct{}{}
+	}
+
+	return availablePairs, nil
+}
+
+// currencyPairToDeribitIndex returns the Deribit index name of a pair,
+// ex.: "btc_usd".
+func currencyPairToDeribitIndex(cp types.CurrencyPair) string {
+	return strings.ToLower(cp.Base + "_" + cp.Quote)
+}
+
+// newDeribitSubscriptionMsg returns a new subscription Msg to the index price
+// channels of the index names.
+func newDeribitSubscriptionMsg(_, indexNames []string) interface{} {
+	channels := make([]string, len(indexNames))
+	for i, indexName := range indexNames {
+		channels[i] = deribitIndexChannelPrefix + indexName
+	}
+	return DeribitRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "public/subscribe",
+		Params:  DeribitSubscriptionParams{Channels: channels},
+	}
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func deribitIndexPriceMsg(indexName, price string, timestamp int64) []byte {
+	return []byte(fmt.Sprintf(
+		`{"jsonrpc":"2.0","method":"subscription","params":{"channel":"deribit_price_index.%s",`+
+			`"data":{"timestamp":%d,"price":%s,"index_name":"%s"}}}`,
+		indexName, timestamp, price, indexName,
+	))
+}
+
+func TestDeribitProvider_MessageReceived(t *testing.T) {
+	p := &DeribitProvider{
+		logger:    zerolog.Nop(),
+		endpoints: Endpoint{Name: ProviderDeribit},
+		tickers:   map[string]types.TickerPrice{},
+		candles:   map[string][]types.CandlePrice{},
+	}
+	btcUSD := types.CurrencyPair{Base: "BTC", Quote: "USD"}
+
+	// the response to the subscription is ignored
+	p.messageReceived(websocket.TextMessage, nil, []byte(`{"jsonrpc":"2.0","id":1,"result":["deribit_price_index.btc_usd"]}`))
+	require.Empty(t, p.tickers)
+
+	minute := time.Now().Truncate(time.Minute)
+	p.messageReceived(websocket.TextMessage, nil, deribitIndexPriceMsg("btc_usd", "3937.89", minute.UnixMilli()))
+	p.messageReceived(websocket.TextMessage, nil, deribitIndexPriceMsg("btc_usd", "3940.5", minute.Add(30*time.Second).UnixMilli()))
+
+	prices, err := p.GetTickerPrices(context.TODO(), btcUSD)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("3940.5"), prices["BTCUSD"].Price)
+	require.True(t, prices["BTCUSD"].Volume.IsZero())
+
+	// index prices of the same minute update its candle
+	candles, err := p.GetCandlePrices(context.TODO(), btcUSD)
+	require.NoError(t, err)
+	require.Len(t, candles["BTCUSD"], 1)
+	require.Equal(t, sdk.MustNewDecFromStr("3940.5"), candles["BTCUSD"][0].Price)
+
+	p.messageReceived(websocket.TextMessage, nil, deribitIndexPriceMsg("btc_usd", "3950", minute.Add(time.Minute).UnixMilli()))
+	candles, err = p.GetCandlePrices(context.TODO(), btcUSD)
+	require.NoError(t, err)
+	require.Len(t, candles["BTCUSD"], 2)
+	require.Equal(t, sdk.MustNewDecFromStr("3950"), candles["BTCUSD"][0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("3940.5"), candles["BTCUSD"][1].Price)
+
+	_, err = p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ETH", Quote: "USD"})
+	require.EqualError(t, err, "deribit has no ticker data for requested pairs: [ETHUSD]")
+}
+
+func TestDeribitProvider_getSubscriptionMsgs(t *testing.T) {
+	p := &DeribitProvider{}
+	msgs, err := p.getSubscriptionMsgs(
+		types.CurrencyPair{Base: "BTC", Quote: "USD"},
+		types.CurrencyPair{Base: "ETH", Quote: "USDC"},
+	)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	msg, _ := json.Marshal(msgs[0])
+	require.Equal(t, `{"jsonrpc":"2.0","id":2,"method":"public/set_heartbeat","params":{"interval":30}}`, string(msg))
+	msg, _ = json.Marshal(msgs[1])
+	require.Equal(t,
+		`{"jsonrpc":"2.0","id":1,"method":"public/subscribe",`+
+			`"params":{"channels":["deribit_price_index.btc_usd","deribit_price_index.eth_usdc"]}}`,
+		string(msg),
+	)
+}
+
+func TestDeribitProvider_GetAvailablePairs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, deribitRestPath, r.URL.Path)
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":["btc_usd","eth_usdc","btcdvol_usdc_extra"]}`)
+	}))
+	defer server.Close()
+
+	p := &DeribitProvider{endpoints: Endpoint{Name: ProviderDeribit, Rest: server.URL}}
+	pairs, err := p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"BTCUSD": {}, "ETHUSDC": {}}, pairs)
+}
+
+func TestCurrencyPairToDeribitIndex(t *testing.T) {
+	cp := types.CurrencyPair{Base: "BTC", Quote: "USD"}
+	require.Equal(t, "btc_usd", currencyPairToDeribitIndex(cp))
+}
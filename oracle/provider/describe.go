@@ -0,0 +1,55 @@
+package provider
+
+import "time"
+
+// apiKeyProviders defines the providers which can't be used without an API
+// key configured on their endpoint.
+var apiKeyProviders = map[Name]struct{}{
+	ProviderPolygon: {},
+	ProviderNumia:   {},
+}
+
+// Description defines the capabilities of a provider, ex. to render them in
+// diagnostics without knowing about each provider.
+type Description struct {
+	Name Name `json:"name"`
+
+	// Rest and Websocket are the hosts the provider connects to, Websocket
+	// being empty for the providers polling their REST API.
+	Rest      string `json:"rest,omitempty"`
+	Websocket string `json:"websocket,omitempty"`
+
+	// Channels are the websocket channels subscribed to for every pair.
+	Channels []string `json:"channels,omitempty"`
+
+	// Candles is true if the provider reports candles, CandleInterval being
+	// their period.
+	Candles        bool          `json:"candles"`
+	CandleInterval time.Duration `json:"candle_interval,omitempty"`
+
+	APIKeyRequired bool `json:"api_key_required"`
+}
+
+// RequiresAPIKey returns true if the provider can't be used without an API
+// key.
+func (n Name) RequiresAPIKey() bool {
+	_, ok := apiKeyProviders[n]
+	return ok
+}
+
+// describe returns the description of a provider connecting to the endpoint
+// and subscribing to the channels.
+func describe(endpoint Endpoint, candles bool, channels ...string) Description {
+	d := Description{
+		Name:           endpoint.Name,
+		Rest:           endpoint.Rest,
+		Websocket:      endpoint.Websocket,
+		Channels:       channels,
+		Candles:        candles,
+		APIKeyRequired: endpoint.Name.RequiresAPIKey(),
+	}
+	if candles {
+		d.CandleInterval = endpoint.Name.CandleInterval()
+	}
+	return d
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	binance := &BinanceProvider{endpoints: Endpoint{
+		Name:      ProviderBinance,
+		Rest:      "https://api1.binance.com",
+		Websocket: "stream.binance.com:9443",
+	}}
+	require.Equal(t, Description{
+		Name:           ProviderBinance,
+		Rest:           "https://api1.binance.com",
+		Websocket:      "stream.binance.com:9443",
+		Channels:       []string{"ticker", "kline_1m"},
+		Candles:        true,
+		CandleInterval: time.Minute,
+	}, binance.Describe())
+
+	numia := NewNumiaProvider(Endpoint{Name: ProviderNumia, APIKey: "key"})
+	d := numia.Describe()
+	require.True(t, d.APIKeyRequired)
+	require.Empty(t, d.Websocket)
+	require.Equal(t, 5*time.Minute, d.CandleInterval)
+
+	// coinbase only builds candles from the matches channel
+	coinbase := &CoinbaseProvider{endpoints: Endpoint{Name: ProviderCoinbase}}
+	require.True(t, coinbase.Describe().Candles)
+	coinbase.endpoints.Channels = []string{coinbaseTickerChannel}
+	d = coinbase.Describe()
+	require.False(t, d.Candles)
+	require.Zero(t, d.CandleInterval)
+	require.Equal(t, []string{"ticker"}, d.Channels)
+}
@@ -0,0 +1,53 @@
+package provider
+
+// Environment names a set of known hosts of a provider, ex. the sandbox
+// deployment of an exchange used for integration testing.
+type Environment string
+
+const (
+	EnvironmentProduction Environment = "production"
+	EnvironmentSandbox    Environment = "sandbox"
+)
+
+// environmentEndpoints defines the known hosts of the providers which offer
+// more than one environment.
+var environmentEndpoints = map[Name]map[Environment]Endpoint{
+	ProviderCoinbase: {
+		EnvironmentProduction: {
+			Rest:      coinbaseRestHost,
+			Websocket: coinbaseWSHost,
+		},
+		EnvironmentSandbox: {
+			Rest:      coinbaseSandboxRestHost,
+			Websocket: coinbaseSandboxWSHost,
+		},
+	},
+}
+
+// HasEnvironment returns true if the provider has known hosts for the given
+// environment.
+func (n Name) HasEnvironment(env Environment) bool {
+	_, ok := environmentEndpoints[n][env]
+	return ok
+}
+
+// withEnvironment fills the rest and websocket hosts which are not set on the
+// endpoint with the known hosts of its environment. Hosts set explicitly take
+// precedence over the environment.
+func (e Endpoint) withEnvironment() Endpoint {
+	if len(e.Environment) == 0 {
+		return e
+	}
+
+	known, ok := environmentEndpoints[e.Name][e.Environment]
+	if !ok {
+		return e
+	}
+	if len(e.Rest) == 0 {
+		e.Rest = known.Rest
+	}
+	if len(e.Websocket) == 0 {
+		e.Websocket = known.Websocket
+	}
+	return e
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoint_WithEnvironment(t *testing.T) {
+	testCases := []struct {
+		name     string
+		endpoint Endpoint
+		expected Endpoint
+	}{
+		{
+			name:     "no environment",
+			endpoint: Endpoint{Name: ProviderCoinbase, Rest: "rest", Websocket: "ws"},
+			expected: Endpoint{Name: ProviderCoinbase, Rest: "rest", Websocket: "ws"},
+		},
+		{
+			name:     "sandbox",
+			endpoint: Endpoint{Name: ProviderCoinbase, Environment: EnvironmentSandbox},
+			expected: Endpoint{
+				Name:        ProviderCoinbase,
+				Rest:        coinbaseSandboxRestHost,
+				Websocket:   coinbaseSandboxWSHost,
+				Environment: EnvironmentSandbox,
+			},
+		},
+		{
+			name:     "explicit hosts take precedence",
+			endpoint: Endpoint{Name: ProviderCoinbase, Rest: "rest", Environment: EnvironmentSandbox},
+			expected: Endpoint{
+				Name:        ProviderCoinbase,
+				Rest:        "rest",
+				Websocket:   coinbaseSandboxWSHost,
+				Environment: EnvironmentSandbox,
+			},
+		},
+		{
+			name:     "unknown environment",
+			endpoint: Endpoint{Name: ProviderBinance, Environment: EnvironmentSandbox},
+			expected: Endpoint{Name: ProviderBinance, Environment: EnvironmentSandbox},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.endpoint.withEnvironment())
+		})
+	}
+}
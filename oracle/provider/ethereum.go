@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+const (
+	ethWordSize = 32
+)
+
+type (
+	// EthRPCRequest defines a JSON-RPC request sent to an Ethereum node.
+	EthRPCRequest struct {
+		JSONRPC string        `json:"jsonrpc"` // always "2.0"
+		ID      uint64        `json:"id"`      // identify messages going back and forth
+		Method  string        `json:"method"`  // ex.: eth_call
+		Params  []interface{} `json:"params"`  // method params
+	}
+
+	// EthCallParams defines the call object of an eth_call request.
+	EthCallParams struct {
+		To   string `json:"to"`   // contract address ex.: 0x88e6...5640
+		Data string `json:"data"` // hex encoded call data
+	}
+
+	// EthRPCResponse defines the response of an Ethereum JSON-RPC request.
+	EthRPCResponse struct {
+		Result string       `json:"result"`
+		Error  *EthRPCError `json:"error"`
+	}
+
+	// EthRPCError defines the error object of an Ethereum JSON-RPC response.
+	EthRPCError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+// ethCall performs an eth_call against the latest block and returns the
+// decoded return data.
+func ethCall(client *http.Client, rpcURL, to string, data []byte) ([]byte, error) {
+	bz, err := json.Marshal(EthRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_call",
+		Params: []interface{}{
+			EthCallParams{
+				To:   to,
+				Data: "0x" + hex.EncodeToString(data),
+			},
+			"latest",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Post(rpcURL, "application/json", bytes.NewReader(bz))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := checkHTTPStatus(resp); err != nil {
+		return nil, err
+	}
+
+	var rpcResp EthRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("eth_call to %s failed: %s", to, rpcResp.Error.Message)
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(rpcResp.Result, "0x"))
+}
+
+// ethWord returns the i-th 32 byte word of ABI encoded data.
+func ethWord(data []byte, i int) ([]byte, error) {
+	start := i * ethWordSize
+	if start < 0 || start+ethWordSize > len(data) {
+		return nil, fmt.Errorf("abi data too short: want word %d, have %d bytes", i, len(data))
+	}
+	return data[start : start+ethWordSize], nil
+}
+
+// ethUint decodes an ABI encoded unsigned integer word.
+func ethUint(word []byte) *big.Int {
+	return new(big.Int).SetBytes(word)
+}
+
+// ethInt decodes an ABI encoded two's complement signed integer word.
+func ethInt(word []byte) *big.Int {
+	i := new(big.Int).SetBytes(word)
+	if len(word) > 0 && word[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(len(word)*8)))
+	}
+	return i
+}
+
+// ethEncodeUint ABI encodes an unsigned integer into a single word.
+func ethEncodeUint(v uint64) []byte {
+	word := make([]byte, ethWordSize)
+	new(big.Int).SetUint64(v).FillBytes(word)
+	return word
+}
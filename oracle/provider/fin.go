@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -69,7 +70,7 @@ func NewFinProvider(endpoint Endpoint) *FinProvider {
 	if endpoint.Name == ProviderFin {
 		return &FinProvider{
 			baseURL: endpoint.Rest,
-			client:  newDefaultHTTPClient(),
+			client:  endpoint.httpClient(newDefaultHTTPClient()),
 		}
 	}
 	return &FinProvider{
@@ -80,11 +81,11 @@ func NewFinProvider(endpoint Endpoint) *FinProvider {
 
 // GetTickerPrices queries the FIN json API and returns with a
 // map of string => types.TickerPrice.
-func (p FinProvider) GetTickerPrices(pairs ...types.CurrencyPair) (
+func (p FinProvider) GetTickerPrices(ctx context.Context, pairs ...types.CurrencyPair) (
 	map[string]types.TickerPrice, error,
 ) {
 	path := fmt.Sprintf("%s%s", p.baseURL, finTickersEndpoint)
-	tickerResponse, err := p.client.Get(path)
+	tickerResponse, err := httpGetWithContext(ctx, p.client, path)
 	if err != nil {
 		return nil, fmt.Errorf("FIN tickers request failed: %w", err)
 	}
@@ -153,10 +154,10 @@ func (ft FinTickers) toTickerPrices(pairs []types.CurrencyPair) (
 // GetCandlePrices queries the FIN json API for each pair,
 // gets each set of candles, and returns with a map
 // of string => []types.CandlePrice.
-func (p FinProvider) GetCandlePrices(pairs ...types.CurrencyPair) (
+func (p FinProvider) GetCandlePrices(ctx context.Context, pairs ...types.CurrencyPair) (
 	map[string][]types.CandlePrice, error,
 ) {
-	pairAddresses, err := p.getFinPairAddresses()
+	pairAddresses, err := p.getFinPairAddresses(ctx)
 	if err != nil {
 		return nil,
 			fmt.Errorf("FIN pair addresses lookup failed: %w", err)
@@ -181,7 +182,7 @@ func (p FinProvider) GetCandlePrices(pairs ...types.CurrencyPair) (
 			windowStartTime.Format(time.RFC3339),
 			windowEndTime.Format(time.RFC3339),
 		)
-		candlesResponse, err := p.client.Get(path)
+		candlesResponse, err := httpGetWithContext(ctx, p.client, path)
 		if err != nil {
 			return nil, fmt.Errorf("FIN candles request failed: %w", err)
 		}
@@ -236,7 +237,7 @@ func (fc FinCandles) ToCandlePrice() ([]types.CandlePrice, error) {
 // GetAvailablePairs queries fin's pairs and returns a map of
 // pair => empty struct.
 func (p FinProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	finPairs, err := p.getFinPairs()
+	finPairs, err := p.getFinPairs(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -253,9 +254,9 @@ func (p FinProvider) GetAvailablePairs() (map[string]struct{}, error) {
 
 // getFinPairs queries the fin json API for available pairs,
 // parses it, and returns it.
-func (p FinProvider) getFinPairs() (FinPairs, error) {
+func (p FinProvider) getFinPairs(ctx context.Context) (FinPairs, error) {
 	path := fmt.Sprintf("%s%s", p.baseURL, finPairsEndpoint)
-	pairsResponse, err := p.client.Get(path)
+	pairsResponse, err := httpGetWithContext(ctx, p.client, path)
 	if err != nil {
 		return FinPairs{}, err
 	}
@@ -270,8 +271,8 @@ func (p FinProvider) getFinPairs() (FinPairs, error) {
 
 // getFinPairAddresses queries the fin API for token pairs,
 // and returns a map of [base+quote] => pool id address.
-func (p FinProvider) getFinPairAddresses() (map[string]string, error) {
-	finPairs, err := p.getFinPairs()
+func (p FinProvider) getFinPairAddresses(ctx context.Context) (map[string]string, error) {
+	finPairs, err := p.getFinPairs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -285,6 +286,15 @@ func (p FinProvider) getFinPairAddresses() (map[string]string, error) {
 // SubscribeCurrencyPairs performs a no-op since fin does not use websockets
 func (p FinProvider) SubscribeCurrencyPairs(_ ...types.CurrencyPair) {}
 
+// UnsubscribeCurrencyPairs performs a no-op since fin does not use websockets.
+func (p FinProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// GetSubscribedPairs returns nil since fin fetches the prices of the
+// requested pairs on demand.
+func (p FinProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return nil
+}
+
 // binToTimeStamp takes a bin time expressed in a string
 // and converts it into a unix timestamp.
 func binToTimeStamp(bin string) (int64, error) {
@@ -311,3 +321,8 @@ func strToDec(str string) (sdk.Dec, error) {
 // StartConnections performs a no-op since fin is not a websocket
 // provider.
 func (p FinProvider) StartConnections() {}
+
+// Describe returns the capabilities of the provider.
+func (p FinProvider) Describe() Description {
+	return describe(Endpoint{Name: ProviderFin, Rest: p.baseURL}, true)
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -38,7 +39,7 @@ func TestFinProvider_GetTickerPrices(t *testing.T) {
 		defer server.Close()
 		p.client = server.Client()
 		p.baseURL = server.URL
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "KUJI", Quote: "AXLUSDC"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "KUJI", Quote: "AXLUSDC"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr("0.9640001379"), prices["KUJIAXLUSDC"].Price)
@@ -81,6 +82,7 @@ func TestFinProvider_GetTickerPrices(t *testing.T) {
 		p.client = server.Client()
 		p.baseURL = server.URL
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "KUJI", Quote: "AXLUSDC"},
 			types.CurrencyPair{Base: "EVMOS", Quote: "AXLUSDC"},
 		)
@@ -100,7 +102,7 @@ func TestFinProvider_GetTickerPrices(t *testing.T) {
 		defer server.Close()
 		p.client = server.Client()
 		p.baseURL = server.URL
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.Error(t, err)
 		require.Nil(t, prices)
 	})
@@ -128,7 +130,7 @@ func TestFinProvider_GetTickerPrices(t *testing.T) {
 		defer server.Close()
 		p.client = server.Client()
 		p.baseURL = server.URL
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.Error(t, err)
 		require.Nil(t, prices)
 	})
@@ -141,7 +143,7 @@ func TestFinProvider_GetTickerPrices(t *testing.T) {
 		server.Client().CheckRedirect = preventRedirect
 		p.client = server.Client()
 		p.baseURL = server.URL
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.Error(t, err)
 		require.Nil(t, prices)
 	})
@@ -175,7 +177,7 @@ func TestFinProvider_GetCandlePrices(t *testing.T) {
 		defer server.Close()
 		p.client = server.Client()
 		p.baseURL = server.URL
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "KUJI", Quote: "AXLUSDC"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "KUJI", Quote: "AXLUSDC"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Len(t, prices["KUJIAXLUSDC"], 3)
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"sync"
 	"time"
 
@@ -24,7 +23,19 @@ const (
 	gateRestPath  = "/api/v4/spot/currency_pairs"
 )
 
-var _ Provider = (*GateProvider)(nil)
+var (
+	_ Provider = (*GateProvider)(nil)
+
+	// gateSubscription subscribes to the ticker and candles of each pair in
+	// separate messages, as a candle subscription only takes a single pair.
+	gateSubscription = subscriptionFormat{
+		channels:   []string{"ticker", "kline"},
+		perChannel: true,
+		maxPairs:   1,
+		symbol:     currencyPairToGatePair,
+		message:    newGateSubscription,
+	}
+)
 
 type (
 	// GateProvider defines an Oracle provider implemented by the Gate public
@@ -96,8 +107,9 @@ type (
 
 	// GatePairSummary defines the response structure for a Gate pair summary.
 	GatePairSummary struct {
-		Base  string `json:"base"`
-		Quote string `json:"quote"`
+		Base        string `json:"base"`
+		Quote       string `json:"quote"`
+		TradeStatus string `json:"trade_status"`
 	}
 )
 
@@ -135,7 +147,7 @@ func NewGateProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -145,11 +157,16 @@ func NewGateProvider(
 
 	provider.setSubscribedPairs(confirmedPairs...)
 
+	subscriptionMsgs, err := provider.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		return nil, err
+	}
+
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
-		provider.getSubscriptionMsgs(confirmedPairs...),
+		subscriptionMsgs,
 		provider.messageReceived,
 		defaultPingDuration,
 		websocket.PingMessage,
@@ -163,14 +180,13 @@ func (p *GateProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
-func (p *GateProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
-	subscriptionMsgs := make([]interface{}, 0, len(cps)*2)
-	for _, cp := range cps {
-		gatePair := currencyPairToGatePair(cp)
-		subscriptionMsgs = append(subscriptionMsgs, newGateTickerSubscription(gatePair))
-		subscriptionMsgs = append(subscriptionMsgs, newGateCandleSubscription(gatePair))
-	}
-	return subscriptionMsgs
+// Describe returns the capabilities of the provider.
+func (p *GateProvider) Describe() Description {
+	return describe(p.endpoints, true, gateSubscription.channels...)
+}
+
+func (p *GateProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) ([]interface{}, error) {
+	return gateSubscription.build(cps...)
 }
 
 // SubscribeCurrencyPairs sends the new subscription messages to the websocket
@@ -188,7 +204,7 @@ func (p *GateProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -196,7 +212,11 @@ func (p *GateProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 		return
 	}
 
-	newSubscriptionMsgs := p.getSubscriptionMsgs(confirmedPairs...)
+	newSubscriptionMsgs, err := p.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to build subscription messages")
+		return
+	}
 	p.wsc.AddWebsocketConnection(
 		newSubscriptionMsgs,
 		p.messageReceived,
@@ -206,15 +226,19 @@ func (p *GateProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *GateProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *GateProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *GateProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
 	for _, cp := range pairs {
 		price, err := p.getTickerPrice(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -232,14 +256,14 @@ func (p *GateProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]
 }
 
 // GetCandlePrices returns the candlePrices based on the provided pairs.
-func (p *GateProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *GateProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
 	for _, cp := range pairs {
 		prices, err := p.getCandlePrices(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -328,6 +352,7 @@ func (p *GateProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byte)
 		return
 	}
 
+	telemetryParseError(ProviderGate, parseErrorReason(tickerErr, candleErr))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("ticker", tickerErr).
@@ -347,7 +372,7 @@ func (p *GateProvider) messageReceivedTickerPrice(bz []byte) error {
 	}
 
 	if tickerMessage.Method != "ticker.update" {
-		return fmt.Errorf("message is not a ticker update")
+		return newParseError(ParseErrorUnknownType, fmt.Errorf("message is not a ticker update"))
 	}
 
 	tickerBz, err := json.Marshal(tickerMessage.Params[1])
@@ -364,7 +389,7 @@ func (p *GateProvider) messageReceivedTickerPrice(bz []byte) error {
 
 	symbol, ok := tickerMessage.Params[0].(string)
 	if !ok {
-		return fmt.Errorf("symbol should be a string")
+		return newParseError(ParseErrorMissingField, fmt.Errorf("symbol should be a string"))
 	}
 	gateTicker.Symbol = symbol
 
@@ -426,12 +451,12 @@ func (p *GateProvider) messageReceivedCandle(bz []byte) error {
 	}
 
 	if candleMessage.Method != "kline.update" {
-		return fmt.Errorf("message is not a kline update")
+		return newParseError(ParseErrorUnknownType, fmt.Errorf("message is not a kline update"))
 	}
 
 	var gateCandle GateCandle
 	if err := gateCandle.UnmarshalParams(candleMessage.Params); err != nil {
-		return err
+		return newParseError(ParseErrorMissingField, err)
 	}
 
 	p.setCandlePair(gateCandle)
@@ -455,6 +480,9 @@ func (p *GateProvider) setCandlePair(candle GateCandle) {
 
 	candleList = append(candleList, candle)
 	for _, c := range p.candles[candle.Symbol] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -469,9 +497,17 @@ func (p *GateProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *GateProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 func (p *GateProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + gateRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + gateRestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -482,16 +518,16 @@ func (p *GateProvider) GetAvailablePairs() (map[string]struct{}, error) {
 		return nil, err
 	}
 
-	availablePairs := make(map[string]struct{}, len(pairsSummary))
+	listings := make([]marketListing, 0, len(pairsSummary))
 	for _, pair := range pairsSummary {
 		cp := types.CurrencyPair{
 			Base:  pair.Base,
 			Quote: pair.Quote,
 		}
-		availablePairs[strings.ToUpper(cp.String())] = struct{}{}
+		listings = append(listings, marketListing{symbol: cp.String(), spot: true, status: pair.TradeStatus})
 	}
 
-	return availablePairs, nil
+	return availableMarkets(p.logger, listings), nil
 }
 
 func (ticker GateTicker) toTickerPrice() (types.TickerPrice, error) {
@@ -523,6 +559,14 @@ func newGateTickerSubscription(cp ...string) GateTickerSubscriptionMsg {
 	}
 }
 
+// newGateSubscription returns a new subscription topic for the channel.
+func newGateSubscription(channels, cp []string) interface{} {
+	if channels[0] == "kline" {
+		return newGateCandleSubscription(cp[0])
+	}
+	return newGateTickerSubscription(cp...)
+}
+
 // newGateCandleSubscription returns a new subscription topic for candles.
 func newGateCandleSubscription(gatePair string) GateCandleSubscriptionMsg {
 	params := []interface{}{
@@ -128,7 +128,7 @@ func NewHuobiProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -140,7 +140,7 @@ func NewHuobiProvider(
 
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
 		provider.getSubscriptionMsgs(confirmedPairs...),
 		provider.messageReceived,
@@ -156,6 +156,11 @@ func (p *HuobiProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
+// Describe returns the capabilities of the provider.
+func (p *HuobiProvider) Describe() Description {
+	return describe(p.endpoints, true, "ticker", "kline.1min")
+}
+
 func (p *HuobiProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
 	subscriptionMsgs := make([]interface{}, 0, len(cps)*2)
 	for _, cp := range cps {
@@ -180,7 +185,7 @@ func (p *HuobiProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -198,15 +203,19 @@ func (p *HuobiProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *HuobiProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *HuobiProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *HuobiProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
 	for _, cp := range pairs {
 		price, err := p.getTickerPrice(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -224,14 +233,14 @@ func (p *HuobiProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string
 }
 
 // GetCandlePrices returns the candlePrices based on the provided pairs.
-func (p *HuobiProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *HuobiProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
 	for _, cp := range pairs {
 		prices, err := p.getCandlePrices(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -258,6 +267,7 @@ func (p *HuobiProvider) messageReceived(messageType int, conn *WebsocketConnecti
 
 	bz, err := decompressGzip(bz)
 	if err != nil {
+		telemetryParseError(ProviderHuobi, ParseErrorUnmarshal)
 		p.logger.Err(err).Msg("failed to decompress gziped message")
 		return
 	}
@@ -295,6 +305,7 @@ func (p *HuobiProvider) messageReceived(messageType int, conn *WebsocketConnecti
 		return
 	}
 
+	telemetryParseError(ProviderHuobi, parseErrorReason(tickerErr, candleErr, err))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("ticker", tickerErr).
@@ -342,6 +353,9 @@ func (p *HuobiProvider) setCandlePair(candle HuobiCandle) {
 	candleList = append(candleList, candle)
 
 	for _, c := range p.candles[candle.CH] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.Tick.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -396,9 +410,17 @@ func (p *HuobiProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *HuobiProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 func (p *HuobiProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + huobiRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + huobiRestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+var _ IntervalCandleProvider = (*CoinbaseProvider)(nil)
+
+// IntervalCandleProvider defines a provider able to build its candles at any
+// interval, ex. from its trades, rather than only at the interval it retains
+// them at.
+type IntervalCandleProvider interface {
+	// GetCandlePricesWithInterval returns the candlePrices of the provided
+	// pairs spanning the given interval.
+	GetCandlePricesWithInterval(context.Context, time.Duration, ...types.CurrencyPair) (map[string][]types.CandlePrice, error)
+}
+
+// GetCandlePricesWithInterval returns the candles of the provided pairs of a
+// provider spanning the given interval, ex. five minute candles next to the
+// one minute candles returned by GetCandlePrices. Providers implementing
+// IntervalCandleProvider build them directly, the candles of the others are
+// resampled from their own interval, which must not be longer than interval.
+func GetCandlePricesWithInterval(
+	ctx context.Context,
+	p Provider,
+	providerName Name,
+	interval time.Duration,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	// a provider wrapped for its symbol aliases keeps its own candles
+	if ap, ok := p.(*aliasProvider); ok {
+		return ap.getCandlePricesWithInterval(ctx, providerName, interval, pairs...)
+	}
+	if ip, ok := p.(IntervalCandleProvider); ok {
+		return ip.GetCandlePricesWithInterval(ctx, interval, pairs...)
+	}
+	if interval < providerName.CandleInterval() {
+		return nil, fmt.Errorf(
+			"%s candles can't be resampled to %s, their interval is %s",
+			providerName, interval, providerName.CandleInterval(),
+		)
+	}
+
+	candles, err := p.GetCandlePrices(ctx, pairs...)
+	if err != nil {
+		return nil, err
+	}
+	if interval == providerName.CandleInterval() {
+		return candles, nil
+	}
+
+	resampled := make(map[string][]types.CandlePrice, len(candles))
+	for symbol, cp := range candles {
+		resampled[symbol] = ResampleCandles(cp, interval)
+	}
+	return resampled, nil
+}
+
+// ResampleCandles merges the candles falling in the same interval, aligned on
+// the unix epoch, into a single candle and returns them sorted newest first.
+// A merged candle has the price and timestamp of its latest candle and the
+// summed volume of its candles. Its OHLC is only set if all of its candles
+// have one.
+func ResampleCandles(candles []types.CandlePrice, interval time.Duration) []types.CandlePrice {
+	sorted := make([]types.CandlePrice, len(candles))
+	copy(sorted, candles)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].TimeStamp < sorted[j].TimeStamp
+	})
+
+	resampled := []types.CandlePrice{}
+	bucket := int64(0)
+	for i, candle := range sorted {
+		candleBucket := candle.TimeStamp / interval.Milliseconds()
+		if i == 0 || candleBucket != bucket {
+			bucket = candleBucket
+			resampled = append(resampled, candle)
+			continue
+		}
+		last := len(resampled) - 1
+		resampled[last] = mergeCandles(resampled[last], candle)
+	}
+
+	// newest first
+	for i, j := 0, len(resampled)-1; i < j; i, j = i+1, j-1 {
+		resampled[i], resampled[j] = resampled[j], resampled[i]
+	}
+	return resampled
+}
+
+// mergeCandles returns the candle spanning both the older and the newer
+// candle.
+func mergeCandles(older, newer types.CandlePrice) types.CandlePrice {
+	merged := types.CandlePrice{
+		Price:            newer.Price,
+		Volume:           older.Volume.Add(newer.Volume),
+		TimeStamp:        newer.TimeStamp,
+		VolumeUnreliable: older.VolumeUnreliable || newer.VolumeUnreliable,
+	}
+	if older.HasOHLC() && newer.HasOHLC() {
+		merged.Open = older.Open
+		merged.High = sdk.MaxDec(older.High, newer.High)
+		merged.Low = sdk.MinDec(older.Low, newer.Low)
+	}
+	return merged
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// candleProvider is a venueProvider returning the given candles.
+type candleProvider struct {
+	venueProvider
+	candles map[string][]types.CandlePrice
+}
+
+func (p *candleProvider) GetCandlePrices(context.Context, ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	return p.candles, nil
+}
+
+func minuteCandle(minute int64, price, volume string) types.CandlePrice {
+	return types.CandlePrice{
+		Price:     sdk.MustNewDecFromStr(price),
+		Volume:    sdk.MustNewDecFromStr(volume),
+		TimeStamp: minute * unixMinute,
+		Open:      sdk.MustNewDecFromStr(price),
+		High:      sdk.MustNewDecFromStr(price),
+		Low:       sdk.MustNewDecFromStr(price),
+	}
+}
+
+func TestResampleCandles(t *testing.T) {
+	// newest first, as retained by the providers
+	candles := []types.CandlePrice{
+		minuteCandle(6, "12", "1"),
+		minuteCandle(5, "9", "2"),
+		minuteCandle(3, "11", "3"),
+		minuteCandle(2, "8", "1"),
+		minuteCandle(1, "10", "4"),
+	}
+
+	resampled := ResampleCandles(candles, 5*time.Minute)
+	require.Len(t, resampled, 2)
+
+	require.Equal(t, sdk.MustNewDecFromStr("12"), resampled[0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("3"), resampled[0].Volume)
+	require.Equal(t, int64(6*unixMinute), resampled[0].TimeStamp)
+	require.Equal(t, sdk.MustNewDecFromStr("9"), resampled[0].Open)
+
+	require.Equal(t, sdk.MustNewDecFromStr("11"), resampled[1].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("8"), resampled[1].Volume)
+	require.Equal(t, int64(3*unixMinute), resampled[1].TimeStamp)
+	require.Equal(t, sdk.MustNewDecFromStr("10"), resampled[1].Open)
+	require.Equal(t, sdk.MustNewDecFromStr("11"), resampled[1].High)
+	require.Equal(t, sdk.MustNewDecFromStr("8"), resampled[1].Low)
+
+	// the provided candles are left untouched
+	require.Equal(t, int64(6*unixMinute), candles[0].TimeStamp)
+
+	// a candle without OHLC leaves its merged candle without one
+	candles[1].Open = sdk.Dec{}
+	resampled = ResampleCandles(candles, 5*time.Minute)
+	require.False(t, resampled[0].HasOHLC())
+	require.True(t, resampled[1].HasOHLC())
+}
+
+func TestGetCandlePricesWithInterval(t *testing.T) {
+	p := &candleProvider{candles: map[string][]types.CandlePrice{
+		"ATOMUSDT": {minuteCandle(7, "12", "1"), minuteCandle(6, "11", "1"), minuteCandle(4, "10", "1")},
+	}}
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	candles, err := GetCandlePricesWithInterval(context.TODO(), p, ProviderBinance, time.Minute, atomUSDT)
+	require.NoError(t, err)
+	require.Len(t, candles["ATOMUSDT"], 3)
+
+	candles, err = GetCandlePricesWithInterval(context.TODO(), p, ProviderBinance, 5*time.Minute, atomUSDT)
+	require.NoError(t, err)
+	require.Len(t, candles["ATOMUSDT"], 2)
+	require.Equal(t, sdk.MustNewDecFromStr("2"), candles["ATOMUSDT"][0].Volume)
+
+	// candles can't be resampled to a shorter interval
+	_, err = GetCandlePricesWithInterval(context.TODO(), p, ProviderOsmosis, time.Minute, atomUSDT)
+	require.Error(t, err)
+}
+
+func TestCoinbaseProvider_GetCandlePricesWithInterval(t *testing.T) {
+	p := &CoinbaseProvider{logger: zerolog.Nop(), trades: map[string]*coinbaseTradeBuffer{}}
+	now := time.Now().UTC()
+	for i, age := range []time.Duration{4 * time.Minute, 2 * time.Minute, 0} {
+		p.setTradePair(CoinbaseTradeResponse{
+			Type:      "match",
+			TradeID:   int64(i + 1),
+			ProductID: "ATOM-USDT",
+			Time:      now.Add(-age).Format(coinbaseTimeFmt),
+			Size:      "1",
+			Price:     "10",
+		})
+	}
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	candles, err := p.GetCandlePrices(context.TODO(), atomUSDT)
+	require.NoError(t, err)
+	require.Len(t, candles["ATOMUSDT"], 3)
+
+	// the same trades are bucketed in longer candles
+	candles, err = GetCandlePricesWithInterval(context.TODO(), p, ProviderCoinbase, 5*time.Minute, atomUSDT)
+	require.NoError(t, err)
+	require.Len(t, candles["ATOMUSDT"], 1)
+	require.Equal(t, sdk.MustNewDecFromStr("3"), candles["ATOMUSDT"][0].Volume)
+
+	_, err = p.GetCandlePricesWithInterval(context.TODO(), 0, atomUSDT)
+	require.Error(t, err)
+}
+
+func TestGetCandlePricesWithInterval_AliasProvider(t *testing.T) {
+	p := &CoinbaseProvider{logger: zerolog.Nop(), trades: map[string]*coinbaseTradeBuffer{}}
+	now := time.Now().UTC()
+	for i, age := range []time.Duration{4 * time.Minute, 2 * time.Minute, 0} {
+		p.setTradePair(CoinbaseTradeResponse{
+			Type:      "match",
+			TradeID:   int64(i + 1),
+			ProductID: "POL-USD",
+			Time:      now.Add(-age).Format(coinbaseTimeFmt),
+			Size:      "1",
+			Price:     "0.7",
+		})
+	}
+	aliased := NewAliasProvider(p, SymbolAliases{"MATIC": "POL"})
+	maticUSD := types.CurrencyPair{Base: "MATIC", Quote: "USD"}
+
+	// the candles are still built from the trades, at an interval shorter
+	// than the one they'd otherwise be resampled from
+	candles, err := GetCandlePricesWithInterval(context.TODO(), aliased, ProviderCoinbase, 30*time.Second, maticUSD)
+	require.NoError(t, err)
+	require.Len(t, candles["MATICUSD"], 3)
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// jsonPathMember selects the member of an object.
+	jsonPathMember jsonPathStepKind = iota
+	// jsonPathIndex selects the element of an array at an index.
+	jsonPathIndex
+	// jsonPathFilter selects the first element of an array of objects whose
+	// member equals a value, ignoring case.
+	jsonPathFilter
+)
+
+type (
+	jsonPathStepKind uint8
+
+	// jsonPathStep defines a step of a JSON path.
+	jsonPathStep struct {
+		kind  jsonPathStepKind
+		key   string
+		index int
+		value string
+	}
+
+	// JSONPath defines the path of a value in a JSON document, in dot and
+	// bracket notation: members are separated by dots and array elements are
+	// selected by their index, ex. "data[0].last", or by the value of one of
+	// their members, ex. "tickers[symbol=ATOMUSDT].last". A path may start
+	// with a bracket when the document is an array, ex. "[0].price".
+	JSONPath struct {
+		path  string
+		steps []jsonPathStep
+	}
+)
+
+// ParseJSONPath parses a JSON path in dot and bracket notation.
+func ParseJSONPath(path string) (JSONPath, error) {
+	p := JSONPath{path: path}
+	if len(path) == 0 {
+		return p, fmt.Errorf("empty json path")
+	}
+
+	rest := path
+	for len(rest) > 0 {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return p, fmt.Errorf("unclosed bracket in json path %q", path)
+			}
+			step, err := parseJSONPathSelector(rest[1:end])
+			if err != nil {
+				return p, fmt.Errorf("invalid json path %q: %w", path, err)
+			}
+			p.steps = append(p.steps, step)
+			rest = rest[end+1:]
+
+		case rest[0] == '.' && len(p.steps) > 0:
+			rest = rest[1:]
+			fallthrough
+
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return p, fmt.Errorf("empty member in json path %q", path)
+			}
+			p.steps = append(p.steps, jsonPathStep{kind: jsonPathMember, key: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	return p, nil
+}
+
+// parseJSONPathSelector parses the content of a bracket, either an array
+// index or a member=value filter.
+func parseJSONPathSelector(selector string) (jsonPathStep, error) {
+	if key, value, ok := strings.Cut(selector, "="); ok {
+		if len(key) == 0 {
+			return jsonPathStep{}, fmt.Errorf("empty filter member in [%s]", selector)
+		}
+		return jsonPathStep{kind: jsonPathFilter, key: key, value: value}, nil
+	}
+
+	index, err := strconv.Atoi(selector)
+	if err != nil || index < 0 {
+		return jsonPathStep{}, fmt.Errorf("invalid array index [%s]", selector)
+	}
+	return jsonPathStep{kind: jsonPathIndex, index: index}, nil
+}
+
+// String returns the path in dot and bracket notation.
+func (p JSONPath) String() string {
+	return p.path
+}
+
+// Lookup returns the value at the path in the document decoded with
+// json.Decoder.UseNumber, or false if the document has no value there.
+func (p JSONPath) Lookup(doc interface{}) (interface{}, bool) {
+	value := doc
+	for _, step := range p.steps {
+		switch step.kind {
+		case jsonPathMember:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[step.key]; !ok {
+				return nil, false
+			}
+
+		case jsonPathIndex:
+			array, ok := value.([]interface{})
+			if !ok || step.index >= len(array) {
+				return nil, false
+			}
+			value = array[step.index]
+
+		case jsonPathFilter:
+			array, ok := value.([]interface{})
+			if !ok {
+				return nil, false
+			}
+			found := false
+			for _, element := range array {
+				object, ok := element.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if member, ok := object[step.key]; ok && strings.EqualFold(fmt.Sprint(member), step.value) {
+					value, found = element, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath_Lookup(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{
+		"data": {"ticker": {"last": "10.5", "vol": 1200}},
+		"tickers": [
+			{"symbol": "OJO-USDT", "last": "0.05"},
+			{"symbol": "ATOM-USDT", "last": "10.4"}
+		],
+		"list": [[1700000000, "10.3"]]
+	}`))
+	decoder.UseNumber()
+	var doc interface{}
+	require.NoError(t, decoder.Decode(&doc))
+
+	testCases := []struct {
+		path     string
+		expected interface{}
+		found    bool
+	}{
+		{path: "data.ticker.last", expected: "10.5", found: true},
+		{path: "data.ticker.vol", expected: json.Number("1200"), found: true},
+		{path: "tickers[1].last", expected: "10.4", found: true},
+		{path: "tickers[symbol=atom-usdt].last", expected: "10.4", found: true},
+		{path: "list[0][1]", expected: "10.3", found: true},
+		{path: "tickers[2].last"},
+		{path: "tickers[symbol=JUNO-USDT].last"},
+		{path: "data.ticker.bid"},
+		{path: "data[0]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			path, err := ParseJSONPath(tc.path)
+			require.NoError(t, err)
+
+			value, found := path.Lookup(doc)
+			require.Equal(t, tc.found, found)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	// documents which are arrays are indexed from the start of the path
+	path, err := ParseJSONPath("[0].price")
+	require.NoError(t, err)
+	value, ok := path.Lookup([]interface{}{map[string]interface{}{"price": "1.5"}})
+	require.True(t, ok)
+	require.Equal(t, "1.5", value)
+
+	for _, invalid := range []string{"", ".price", "data..last", "data.", "tickers[0", "tickers[-1]", "tickers[x]", "tickers[=ATOM]"} {
+		_, err := ParseJSONPath(invalid)
+		require.Error(t, err, invalid)
+	}
+}
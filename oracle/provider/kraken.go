@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,9 +23,22 @@ const (
 	KrakenRestPath                = "/0/public/AssetPairs"
 	krakenEventSystemStatus       = "systemStatus"
 	krakenEventSubscriptionStatus = "subscriptionStatus"
+	krakenAlreadySubscribed       = "Already subscribed"
 )
 
-var _ Provider = (*KrakenProvider)(nil)
+var (
+	_ Provider = (*KrakenProvider)(nil)
+
+	// krakenSubscription subscribes to the ticker and ohlc channels of each
+	// pair in separate messages.
+	krakenSubscription = subscriptionFormat{
+		channels:   []string{"ticker", "ohlc"},
+		perChannel: true,
+		maxPairs:   1,
+		symbol:     currencyPairToKrakenPair,
+		message:    newKrakenSubscriptionMsg,
+	}
+)
 
 type (
 	// KrakenProvider defines an Oracle provider implemented by the Kraken public
@@ -39,6 +53,7 @@ type (
 		tickers         map[string]types.TickerPrice  // Symbol => TickerPrice
 		candles         map[string][]KrakenCandle     // Symbol => KrakenCandle
 		subscribedPairs map[string]types.CurrencyPair // Symbol => types.CurrencyPair
+		clockSkew       *clockSkew
 	}
 
 	// KrakenTicker ticker price response from Kraken ticker channel.
@@ -46,11 +61,16 @@ type (
 	KrakenTicker struct {
 		C []string `json:"c"` // Close with Price in the first position
 		V []string `json:"v"` // Volume with the value over last 24 hours in the second position
+		A []string `json:"a"` // Best ask with the price in the first position
+		B []string `json:"b"` // Best bid with the price in the first position
 	}
 
 	// KrakenCandle candle response from Kraken candle channel.
 	// REF: https://docs.kraken.com/websockets/#message-ohlc
 	KrakenCandle struct {
+		Open      string // Open price during this period
+		High      string // Highest price during this period
+		Low       string // Lowest price during this period
 		Close     string // Close price during this period
 		TimeStamp int64  // Linux epoch timestamp
 		Volume    string // Volume during this period
@@ -76,9 +96,10 @@ type (
 
 	// KrakenEventSubscriptionStatus parse the subscriptionStatus event message.
 	KrakenEventSubscriptionStatus struct {
-		Status       string `json:"status"`       // subscribed|unsubscribed|error
-		Pair         string `json:"pair"`         // Pair symbol base/quote ex.: "XBT/USD"
-		ErrorMessage string `json:"errorMessage"` // error description
+		Status       string                    `json:"status"`       // subscribed|unsubscribed|error
+		Pair         string                    `json:"pair"`         // Pair symbol base/quote ex.: "XBT/USD"
+		ErrorMessage string                    `json:"errorMessage"` // error description
+		Subscription KrakenSubscriptionChannel `json:"subscription"` // subscribed channel
 	}
 
 	// KrakenPairsSummary defines the response structure for an Kraken pairs summary.
@@ -88,7 +109,9 @@ type (
 
 	// KrakenPairData defines the data response structure for an Kraken pair.
 	KrakenPairData struct {
-		WsName string `json:"wsname"`
+		WsName  string `json:"wsname"`
+		AltName string `json:"altname"`
+		Status  string `json:"status"`
 	}
 )
 
@@ -120,11 +143,12 @@ func NewKrakenProvider(
 		tickers:         map[string]types.TickerPrice{},
 		candles:         map[string][]KrakenCandle{},
 		subscribedPairs: map[string]types.CurrencyPair{},
+		clockSkew:       newClockSkew(krakenLogger, endpoints),
 	}
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -134,16 +158,22 @@ func NewKrakenProvider(
 
 	provider.setSubscribedPairs(confirmedPairs...)
 
+	subscriptionMsgs, err := provider.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		return nil, err
+	}
+
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
-		provider.getSubscriptionMsgs(confirmedPairs...),
+		subscriptionMsgs,
 		provider.messageReceived,
 		time.Duration(0),
 		websocket.PingMessage,
 		krakenLogger,
 	)
+	provider.wsc.SetSubscriptionAcks(krakenSubscriptionAckKeys)
 
 	return provider, nil
 }
@@ -152,14 +182,13 @@ func (p *KrakenProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
-func (p *KrakenProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
-	subscriptionMsgs := make([]interface{}, 0, len(cps)*2)
-	for _, cp := range cps {
-		krakenPair := currencyPairToKrakenPair(cp)
-		subscriptionMsgs = append(subscriptionMsgs, newKrakenTickerSubscriptionMsg(krakenPair))
-		subscriptionMsgs = append(subscriptionMsgs, newKrakenCandleSubscriptionMsg(krakenPair))
-	}
-	return subscriptionMsgs
+// Describe returns the capabilities of the provider.
+func (p *KrakenProvider) Describe() Description {
+	return describe(p.endpoints, true, krakenSubscription.channels...)
+}
+
+func (p *KrakenProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) ([]interface{}, error) {
+	return krakenSubscription.build(cps...)
 }
 
 // SubscribeCurrencyPairs sends the new subscription messages to the websocket
@@ -177,7 +206,7 @@ func (p *KrakenProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -185,7 +214,11 @@ func (p *KrakenProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 		return
 	}
 
-	newSubscriptionMsgs := p.getSubscriptionMsgs(confirmedPairs...)
+	newSubscriptionMsgs, err := p.getSubscriptionMsgs(confirmedPairs...)
+	if err != nil {
+		p.logger.Error().Err(err).Msg("failed to build subscription messages")
+		return
+	}
 	p.wsc.AddWebsocketConnection(
 		newSubscriptionMsgs,
 		p.messageReceived,
@@ -195,8 +228,12 @@ func (p *KrakenProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *KrakenProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *KrakenProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *KrakenProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
@@ -212,6 +249,11 @@ func (p *KrakenProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[strin
 			tickerErrs++
 			continue
 		}
+		if err := p.endpoints.checkSpread(key, price); err != nil {
+			p.logger.Warn().Err(err).Msg("rejecting ticker")
+			tickerErrs++
+			continue
+		}
 		tickerPrices[key] = price
 	}
 
@@ -226,7 +268,7 @@ func (p *KrakenProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[strin
 }
 
 // GetCandlePrices returns the candlePrices based on the provided pairs.
-func (p *KrakenProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *KrakenProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
@@ -234,7 +276,7 @@ func (p *KrakenProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[strin
 		key := cp.String()
 		prices, err := p.getCandlePrices(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -252,13 +294,17 @@ func (p *KrakenProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[strin
 }
 
 func (candle KrakenCandle) toCandlePrice() (types.CandlePrice, error) {
-	return types.NewCandlePrice(
+	cp, err := types.NewCandlePrice(
 		string(ProviderKraken),
 		candle.Symbol,
 		candle.Close,
 		candle.Volume,
 		candle.TimeStamp,
 	)
+	if err != nil {
+		return types.CandlePrice{}, err
+	}
+	return cp.WithOHLC(string(ProviderKraken), candle.Symbol, candle.Open, candle.High, candle.Low)
 }
 
 func (p *KrakenProvider) getCandlePrices(key string) ([]types.CandlePrice, error) {
@@ -286,7 +332,7 @@ func (p *KrakenProvider) getCandlePrices(key string) ([]types.CandlePrice, error
 }
 
 // messageReceived handles any message sent by the provider.
-func (p *KrakenProvider) messageReceived(messageType int, _ *WebsocketConnection, bz []byte) {
+func (p *KrakenProvider) messageReceived(messageType int, conn *WebsocketConnection, bz []byte) {
 	if messageType != websocket.TextMessage {
 		return
 	}
@@ -304,7 +350,7 @@ func (p *KrakenProvider) messageReceived(messageType int, _ *WebsocketConnection
 		case krakenEventSystemStatus:
 			return
 		case krakenEventSubscriptionStatus:
-			p.messageReceivedSubscriptionStatus(bz)
+			p.messageReceivedSubscriptionStatus(conn, bz)
 			return
 		}
 		return
@@ -320,6 +366,7 @@ func (p *KrakenProvider) messageReceived(messageType int, _ *WebsocketConnection
 		return
 	}
 
+	telemetryParseError(ProviderKraken, parseErrorReason(tickerErr, candleErr))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("ticker", tickerErr).
@@ -338,12 +385,12 @@ func (p *KrakenProvider) messageReceivedTickerPrice(bz []byte) error {
 	}
 
 	if len(tickerMessage) != 4 {
-		return fmt.Errorf("received an unexpected structure")
+		return newParseError(ParseErrorUnknownType, fmt.Errorf("received an unexpected structure"))
 	}
 
 	channelName, ok := tickerMessage[2].(string)
 	if !ok || channelName != "ticker" {
-		return fmt.Errorf("received an unexpected channel name")
+		return newParseError(ParseErrorUnknownType, fmt.Errorf("received an unexpected channel name"))
 	}
 
 	tickerBz, err := json.Marshal(tickerMessage[1])
@@ -361,19 +408,21 @@ func (p *KrakenProvider) messageReceivedTickerPrice(bz []byte) error {
 	krakenPair, ok := tickerMessage[3].(string)
 	if !ok {
 		p.logger.Debug().Msg("received an unexpected pair")
-		return err
+		return newParseError(ParseErrorMissingField, fmt.Errorf("received an unexpected pair"))
 	}
 
-	krakenPair = normalizeKrakenBTCPair(krakenPair)
-	currencyPairSymbol := krakenPairToCurrencyPairSymbol(krakenPair)
+	cp, err := krakenPairToCurrencyPair(krakenPair)
+	if err != nil {
+		return newParseError(ParseErrorMissingField, err)
+	}
 
-	tickerPrice, err := krakenTicker.toTickerPrice(currencyPairSymbol)
+	tickerPrice, err := krakenTicker.toTickerPrice(cp.String())
 	if err != nil {
 		p.logger.Err(err).Msg("could not parse kraken ticker to ticker price")
-		return err
+		return newParseError(ParseErrorBadDecimal, err)
 	}
 
-	p.setTickerPair(currencyPairSymbol, tickerPrice)
+	p.setTickerPair(cp.String(), tickerPrice)
 	telemetryWebsocketMessage(ProviderKraken, MessageTypeTicker)
 	return nil
 }
@@ -394,10 +443,28 @@ func (candle *KrakenCandle) UnmarshalJSON(buf []byte) error {
 	}
 	timeFloat, err := strconv.ParseFloat(time, 64)
 	if err != nil {
-		return fmt.Errorf("unable to convert time to float")
+		return newParseError(ParseErrorBadDecimal, fmt.Errorf("unable to convert time to float"))
 	}
 	candle.TimeStamp = int64(timeFloat)
 
+	open, ok := tmp[2].(string)
+	if !ok {
+		return fmt.Errorf("open field must be a string")
+	}
+	candle.Open = open
+
+	high, ok := tmp[3].(string)
+	if !ok {
+		return fmt.Errorf("high field must be a string")
+	}
+	candle.High = high
+
+	low, ok := tmp[4].(string)
+	if !ok {
+		return fmt.Errorf("low field must be a string")
+	}
+	candle.Low = low
+
 	close, ok := tmp[5].(string)
 	if !ok {
 		return fmt.Errorf("close field must be a string")
@@ -423,12 +490,12 @@ func (p *KrakenProvider) messageReceivedCandle(bz []byte) error {
 	}
 
 	if len(candleMessage) != 4 {
-		return fmt.Errorf("received something different than candle")
+		return newParseError(ParseErrorUnknownType, fmt.Errorf("received something different than candle"))
 	}
 
 	channelName, ok := candleMessage[2].(string)
 	if !ok || channelName != "ohlc-1" {
-		return fmt.Errorf("received an unexpected channel name")
+		return newParseError(ParseErrorUnknownType, fmt.Errorf("received an unexpected channel name"))
 	}
 
 	tickerBz, err := json.Marshal(candleMessage[1])
@@ -438,17 +505,19 @@ func (p *KrakenProvider) messageReceivedCandle(bz []byte) error {
 
 	var krakenCandle KrakenCandle
 	if err := krakenCandle.UnmarshalJSON(tickerBz); err != nil {
-		return err
+		return newParseError(ParseErrorMissingField, err)
 	}
 
 	krakenPair, ok := candleMessage[3].(string)
 	if !ok {
-		return fmt.Errorf("received an unexpected pair")
+		return newParseError(ParseErrorMissingField, fmt.Errorf("received an unexpected pair"))
 	}
 
-	krakenPair = normalizeKrakenBTCPair(krakenPair)
-	currencyPairSymbol := krakenPairToCurrencyPairSymbol(krakenPair)
-	krakenCandle.Symbol = currencyPairSymbol
+	cp, err := krakenPairToCurrencyPair(krakenPair)
+	if err != nil {
+		return newParseError(ParseErrorMissingField, err)
+	}
+	krakenCandle.Symbol = cp.String()
 
 	telemetryWebsocketMessage(ProviderKraken, MessageTypeCandle)
 	p.setCandlePair(krakenCandle)
@@ -456,24 +525,40 @@ func (p *KrakenProvider) messageReceivedCandle(bz []byte) error {
 }
 
 // messageReceivedSubscriptionStatus handle the subscription status message
-// sent by the provider.
-func (p *KrakenProvider) messageReceivedSubscriptionStatus(bz []byte) {
+// sent by the provider. Any status answers the subscription of the channel
+// and pair, so it's acknowledged: a rejected pair is removed rather than
+// subscribed to again.
+func (p *KrakenProvider) messageReceivedSubscriptionStatus(conn *WebsocketConnection, bz []byte) {
 	var subscriptionStatus KrakenEventSubscriptionStatus
 	if err := json.Unmarshal(bz, &subscriptionStatus); err != nil {
+		telemetryParseError(ProviderKraken, ParseErrorUnmarshal)
 		p.logger.Err(err).Msg("provider could not unmarshal KrakenEventSubscriptionStatus")
 		return
 	}
+	if key, ok := krakenSubscriptionAckKey(subscriptionStatus.Subscription.Name, subscriptionStatus.Pair); ok {
+		conn.AcknowledgeSubscription(key)
+	}
 
 	switch subscriptionStatus.Status {
 	case "error":
+		// a retried subscription the first acknowledgement of which was
+		// missed is answered with an error, but the pair is subscribed
+		if strings.EqualFold(subscriptionStatus.ErrorMessage, krakenAlreadySubscribed) {
+			return
+		}
 		p.logger.Error().Msg(subscriptionStatus.ErrorMessage)
-		p.removeSubscribedTickers(krakenPairToCurrencyPairSymbol(subscriptionStatus.Pair))
-		return
 	case "unsubscribed":
 		p.logger.Debug().Msgf("ticker %s was unsubscribed", subscriptionStatus.Pair)
-		p.removeSubscribedTickers(krakenPairToCurrencyPairSymbol(subscriptionStatus.Pair))
+	default:
+		return
+	}
+
+	cp, err := krakenPairToCurrencyPair(subscriptionStatus.Pair)
+	if err != nil {
+		p.logger.Err(err).Msg("could not remove subscribed pair")
 		return
 	}
+	p.removeSubscribedPairs(cp)
 }
 
 // setTickerPair sets an ticker to the map thread safe by the mutex.
@@ -488,11 +573,16 @@ func (p *KrakenProvider) setCandlePair(candle KrakenCandle) {
 	defer p.mtx.Unlock()
 	// convert kraken timestamp seconds -> milliseconds
 	candle.TimeStamp = SecondsToMilli(candle.TimeStamp)
-	staleTime := PastUnixTime(providerCandlePeriod)
+	// the candle's time is the time of its latest trade, sent as it happens
+	p.clockSkew.observe(candle.TimeStamp, time.Now().UnixMilli())
+	staleTime := p.clockSkew.pastUnixTime(providerCandlePeriod)
 	candleList := []KrakenCandle{}
 
 	candleList = append(candleList, candle)
 	for _, c := range p.candles[candle.Symbol] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -507,19 +597,27 @@ func (p *KrakenProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
-// removeSubscribedTickers delete N pairs from the subscribed map.
-func (p *KrakenProvider) removeSubscribedTickers(tickerSymbols ...string) {
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *KrakenProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
+// removeSubscribedPairs delete N pairs from the subscribed map.
+func (p *KrakenProvider) removeSubscribedPairs(cps ...types.CurrencyPair) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	for _, tickerSymbol := range tickerSymbols {
-		delete(p.subscribedPairs, tickerSymbol)
+	for _, cp := range cps {
+		delete(p.subscribedPairs, cp.String())
 	}
 }
 
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 func (p *KrakenProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + KrakenRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + KrakenRestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -530,8 +628,16 @@ func (p *KrakenProvider) GetAvailablePairs() (map[string]struct{}, error) {
 		return nil, err
 	}
 
-	availablePairs := make(map[string]struct{}, len(pairsSummary.Result))
-	for _, pair := range pairsSummary.Result {
+	// sort the pairs so that the same listing is kept among duplicates
+	names := make([]string, 0, len(pairsSummary.Result))
+	for name := range pairsSummary.Result {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	listings := make([]marketListing, 0, len(names))
+	for _, name := range names {
+		pair := pairsSummary.Result[name]
 		splitPair := strings.Split(pair.WsName, "/")
 		if len(splitPair) != 2 {
 			continue
@@ -541,48 +647,83 @@ func (p *KrakenProvider) GetAvailablePairs() (map[string]struct{}, error) {
 			Base:  splitPair[0],
 			Quote: splitPair[1],
 		}
-		availablePairs[strings.ToUpper(cp.String())] = struct{}{}
+		listings = append(listings, marketListing{
+			symbol: cp.String(),
+			// dark pool pairs are suffixed with .d
+			spot:   !strings.HasSuffix(pair.AltName, ".d"),
+			status: pair.Status,
+		})
 	}
 
-	return availablePairs, nil
+	return availableMarkets(p.logger, listings), nil
 }
 
 // toTickerPrice return a TickerPrice based on the KrakenTicker.
 func (ticker KrakenTicker) toTickerPrice(symbol string) (types.TickerPrice, error) {
 	if len(ticker.C) != 2 || len(ticker.V) != 2 {
-		return types.TickerPrice{}, fmt.Errorf("error converting KrakenTicker to TickerPrice")
+		return types.TickerPrice{}, newParseError(
+			ParseErrorMissingField,
+			fmt.Errorf("error converting KrakenTicker to TickerPrice"),
+		)
 	}
 	// ticker.C has the Price in the first position.
 	// ticker.V has the totla	Value over last 24 hours in the second position.
-	return types.NewTickerPrice(string(ProviderKraken), symbol, ticker.C[0], ticker.V[1])
+	tp, err := types.NewTickerPrice(string(ProviderKraken), symbol, ticker.C[0], ticker.V[1])
+	if err != nil || len(ticker.B) == 0 || len(ticker.A) == 0 {
+		return tp, err
+	}
+	return tp.WithBidAsk(string(ProviderKraken), symbol, ticker.B[0], ticker.A[0])
 }
 
-// newKrakenTickerSubscriptionMsg returns a new subscription Msg.
-func newKrakenTickerSubscriptionMsg(pairs ...string) KrakenSubscriptionMsg {
-	return KrakenSubscriptionMsg{
-		Event: "subscribe",
-		Pair:  pairs,
-		Subscription: KrakenSubscriptionChannel{
-			Name: "ticker",
-		},
+// krakenSubscriptionAckKeys returns the keys of the subscription status
+// messages Kraken acknowledges a subscription message with, one per pair of
+// its channel.
+func krakenSubscriptionAckKeys(msg interface{}) []string {
+	subscriptionMsg, ok := msg.(KrakenSubscriptionMsg)
+	if !ok {
+		return nil
 	}
+	keys := make([]string, 0, len(subscriptionMsg.Pair))
+	for _, pair := range subscriptionMsg.Pair {
+		if key, ok := krakenSubscriptionAckKey(subscriptionMsg.Subscription.Name, pair); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// krakenSubscriptionAckKey returns the acknowledgement key of the channel and
+// pair, ex. ticker:BTCUSD. The pair is normalized since Kraken answers with
+// XBT for BTC.
+func krakenSubscriptionAckKey(channel, krakenPair string) (string, bool) {
+	cp, err := krakenPairToCurrencyPair(krakenPair)
+	if err != nil || len(channel) == 0 {
+		return "", false
+	}
+	return channel + ":" + cp.String(), true
 }
 
-// newKrakenSubscriptionMsg returns a new subscription Msg.
-func newKrakenCandleSubscriptionMsg(pairs ...string) KrakenSubscriptionMsg {
+// newKrakenSubscriptionMsg returns a new subscription Msg to the channel.
+func newKrakenSubscriptionMsg(channels, pairs []string) interface{} {
 	return KrakenSubscriptionMsg{
 		Event: "subscribe",
 		Pair:  pairs,
 		Subscription: KrakenSubscriptionChannel{
-			Name: "ohlc",
+			Name: channels[0],
 		},
 	}
 }
 
-// krakenPairToCurrencyPairSymbol receives a kraken pair formated
-// ex.: ATOM/USDT and return currencyPair Symbol ATOMUSDT.
-func krakenPairToCurrencyPairSymbol(krakenPair string) string {
-	return strings.ReplaceAll(krakenPair, "/", "")
+// krakenPairToCurrencyPair receives a kraken pair formated
+// ex.: ATOM/USDT and returns its currency pair, with XBT normalized to BTC.
+// The base and quote are split on the separator only, so symbols containing
+// other characters such as a dash are kept as they are.
+func krakenPairToCurrencyPair(krakenPair string) (types.CurrencyPair, error) {
+	base, quote, ok := strings.Cut(krakenPair, "/")
+	if !ok || len(base) == 0 || len(quote) == 0 || strings.Contains(quote, "/") {
+		return types.CurrencyPair{}, fmt.Errorf("invalid kraken pair %s", krakenPair)
+	}
+	return normalizeKrakenBTCPair(types.CurrencyPair{Base: base, Quote: quote}), nil
 }
 
 // currencyPairToKrakenPair receives a currency pair
@@ -593,6 +734,12 @@ func currencyPairToKrakenPair(cp types.CurrencyPair) string {
 
 // normalizeKrakenBTCPair changes XBT pairs to BTC,
 // since other providers list bitcoin as BTC.
-func normalizeKrakenBTCPair(ticker string) string {
-	return strings.Replace(ticker, "XBT", "BTC", 1)
+func normalizeKrakenBTCPair(cp types.CurrencyPair) types.CurrencyPair {
+	if cp.Base == "XBT" {
+		cp.Base = "BTC"
+	}
+	if cp.Quote == "XBT" {
+		cp.Quote = "BTC"
+	}
+	return cp
 }
@@ -32,7 +32,7 @@ func TestKrakenProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, lastPrice, prices["ATOMUSDT"].Price)
@@ -57,6 +57,7 @@ func TestKrakenProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "LUNA", Quote: "USDT"},
 		)
@@ -69,16 +70,29 @@ func TestKrakenProvider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "kraken has no ticker data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
 }
 
-func TestKrakenPairToCurrencyPairSymbol(t *testing.T) {
-	cp := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
-	currencyPairSymbol := krakenPairToCurrencyPairSymbol("ATOM/USDT")
-	require.Equal(t, cp.String(), currencyPairSymbol)
+func TestKrakenPairToCurrencyPair(t *testing.T) {
+	cp, err := krakenPairToCurrencyPair("ATOM/USDT")
+	require.NoError(t, err)
+	require.Equal(t, types.CurrencyPair{Base: "ATOM", Quote: "USDT"}, cp)
+
+	cp, err = krakenPairToCurrencyPair("XBT/USD")
+	require.NoError(t, err)
+	require.Equal(t, types.CurrencyPair{Base: "BTC", Quote: "USD"}, cp)
+
+	// symbols containing a dash are kept as they are
+	cp, err = krakenPairToCurrencyPair("FOO-BAR/USD")
+	require.NoError(t, err)
+	require.Equal(t, types.CurrencyPair{Base: "FOO-BAR", Quote: "USD"}, cp)
+	require.Equal(t, "FOO-BAR/USD", currencyPairToKrakenPair(cp))
+
+	_, err = krakenPairToCurrencyPair("ATOMUSDT")
+	require.Error(t, err)
 }
 
 func TestKrakenCurrencyPairToKrakenPair(t *testing.T) {
@@ -88,11 +102,15 @@ func TestKrakenCurrencyPairToKrakenPair(t *testing.T) {
 }
 
 func TestNormalizeKrakenBTCPair(t *testing.T) {
-	btcSymbol := normalizeKrakenBTCPair("XBT/USDT")
-	require.Equal(t, btcSymbol, "BTC/USDT")
+	btcPair := normalizeKrakenBTCPair(types.CurrencyPair{Base: "XBT", Quote: "USDT"})
+	require.Equal(t, btcPair, types.CurrencyPair{Base: "BTC", Quote: "USDT"})
 
-	atomSymbol := normalizeKrakenBTCPair("ATOM/USDT")
-	require.Equal(t, atomSymbol, "ATOM/USDT")
+	atomPair := normalizeKrakenBTCPair(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+	require.Equal(t, atomPair, types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+
+	// only whole XBT symbols are normalized
+	xbtxPair := normalizeKrakenBTCPair(types.CurrencyPair{Base: "XBTX", Quote: "USD"})
+	require.Equal(t, xbtxPair, types.CurrencyPair{Base: "XBTX", Quote: "USD"})
 }
 
 func TestKrakenProvider_getSubscriptionMsgs(t *testing.T) {
@@ -102,7 +120,8 @@ func TestKrakenProvider_getSubscriptionMsgs(t *testing.T) {
 	cps := []types.CurrencyPair{
 		{Base: "ATOM", Quote: "USDT"},
 	}
-	subMsgs := provider.getSubscriptionMsgs(cps...)
+	subMsgs, err := provider.getSubscriptionMsgs(cps...)
+	require.NoError(t, err)
 
 	msg, _ := json.Marshal(subMsgs[0])
 	require.Equal(t, "{\"event\":\"subscribe\",\"pair\":[\"ATOM/USDT\"],\"subscription\":{\"name\":\"ticker\"}}", string(msg))
@@ -110,3 +129,17 @@ func TestKrakenProvider_getSubscriptionMsgs(t *testing.T) {
 	msg, _ = json.Marshal(subMsgs[1])
 	require.Equal(t, "{\"event\":\"subscribe\",\"pair\":[\"ATOM/USDT\"],\"subscription\":{\"name\":\"ohlc\"}}", string(msg))
 }
+
+func TestKrakenSubscriptionAckKeys(t *testing.T) {
+	msg := newKrakenSubscriptionMsg([]string{"ticker"}, []string{"BTC/USD", "ATOM/USDT"})
+	require.Equal(t, []string{"ticker:BTCUSD", "ticker:ATOMUSDT"}, krakenSubscriptionAckKeys(msg))
+
+	// kraken acknowledges BTC pairs with XBT
+	key, ok := krakenSubscriptionAckKey("ticker", "XBT/USD")
+	require.True(t, ok)
+	require.Equal(t, "ticker:BTCUSD", key)
+
+	_, ok = krakenSubscriptionAckKey("", "XBT/USD")
+	require.False(t, ok)
+	require.Empty(t, krakenSubscriptionAckKeys("ping"))
+}
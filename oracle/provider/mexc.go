@@ -118,7 +118,7 @@ func NewMexcProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -130,7 +130,7 @@ func NewMexcProvider(
 
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
 		provider.getSubscriptionMsgs(confirmedPairs...),
 		provider.messageReceived,
@@ -146,6 +146,11 @@ func (p *MexcProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
+// Describe returns the capabilities of the provider.
+func (p *MexcProvider) Describe() Description {
+	return describe(p.endpoints, true, "sub.overview", "sub.kline")
+}
+
 func (p *MexcProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
 	subscriptionMsgs := make([]interface{}, 0, len(cps)+1)
 	for _, cp := range cps {
@@ -171,7 +176,7 @@ func (p *MexcProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -189,8 +194,12 @@ func (p *MexcProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *MexcProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the provided pairs.
-func (p *MexcProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *MexcProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
@@ -198,7 +207,7 @@ func (p *MexcProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]
 		key := currencyPairToMexcPair(cp)
 		price, err := p.getTickerPrice(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -216,7 +225,7 @@ func (p *MexcProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]
 }
 
 // GetCandlePrices returns the candlePrices based on the provided pairs.
-func (p *MexcProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *MexcProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
@@ -224,7 +233,7 @@ func (p *MexcProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string]
 		key := currencyPairToMexcPair(cp)
 		prices, err := p.getCandlePrices(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -285,7 +294,7 @@ func (p *MexcProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byte)
 	)
 
 	tickerErr = json.Unmarshal(bz, &tickerResp)
-	for _, cp := range p.subscribedPairs {
+	for _, cp := range p.GetSubscribedPairs() {
 		mexcPair := currencyPairToMexcPair(cp)
 		if tickerResp.Symbol[mexcPair].LastPrice != 0 {
 			p.setTickerPair(
@@ -305,6 +314,7 @@ func (p *MexcProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byte)
 	}
 
 	if tickerErr != nil || candleErr != nil {
+		telemetryParseError(ProviderMexc, parseErrorReason(tickerErr, candleErr))
 		p.logger.Error().
 			Int("length", len(bz)).
 			AnErr("ticker", tickerErr).
@@ -319,10 +329,12 @@ func (p *MexcProvider) setTickerPair(symbol string, ticker MexcTicker) {
 
 	price, err := decmath.NewDecFromFloat(ticker.LastPrice)
 	if err != nil {
+		telemetryParseError(ProviderMexc, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("mexc: failed to parse ticker price")
 	}
 	volume, err := decmath.NewDecFromFloat(ticker.Volume)
 	if err != nil {
+		telemetryParseError(ProviderMexc, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("mexc: failed to parse ticker volume")
 	}
 
@@ -338,10 +350,12 @@ func (p *MexcProvider) setCandlePair(candleResp MexcCandleResponse) {
 
 	close, err := decmath.NewDecFromFloat(candleResp.Metadata.Close)
 	if err != nil {
+		telemetryParseError(ProviderMexc, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("mexc: failed to parse candle close")
 	}
 	volume, err := decmath.NewDecFromFloat(candleResp.Metadata.Volume)
 	if err != nil {
+		telemetryParseError(ProviderMexc, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("mexc: failed to parse candle volume")
 	}
 	candle := types.CandlePrice{
@@ -356,6 +370,9 @@ func (p *MexcProvider) setCandlePair(candleResp MexcCandleResponse) {
 	candleList = append(candleList, candle)
 
 	for _, c := range p.candles[candleResp.Symbol] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -371,10 +388,18 @@ func (p *MexcProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *MexcProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 // ex.: map["ATOMUSDT" => {}, "OJOUSDC" => {}].
 func (p *MexcProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + mexcRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + mexcRestPath)
 	if err != nil {
 		return nil, err
 	}
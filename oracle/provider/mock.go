@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"net/http"
@@ -44,13 +45,27 @@ func (p *MockProvider) StartConnections() {
 	// no-op mock does not use websockets
 }
 
+// Describe returns the capabilities of the provider.
+func (p *MockProvider) Describe() Description {
+	return describe(Endpoint{Name: ProviderMock, Rest: p.baseURL}, true)
+}
+
 // SubscribeCurrencyPairs performs a no-op since mock does not use websockets
 func (p MockProvider) SubscribeCurrencyPairs(...types.CurrencyPair) {}
 
-func (p MockProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+// UnsubscribeCurrencyPairs performs a no-op since mock does not use websockets.
+func (p MockProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// GetSubscribedPairs returns nil since mock fetches the prices of the
+// requested pairs on demand.
+func (p MockProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return nil
+}
+
+func (p MockProvider) GetTickerPrices(ctx context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
-	resp, err := p.client.Get(p.baseURL)
+	resp, err := httpGetWithContext(ctx, p.client, p.baseURL)
 	if err != nil {
 		return nil, err
 	}
@@ -103,8 +118,8 @@ func (p MockProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]t
 	return tickerPrices, nil
 }
 
-func (p MockProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
-	price, err := p.GetTickerPrices(pairs...)
+func (p MockProvider) GetCandlePrices(ctx context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	price, err := p.GetTickerPrices(ctx, pairs...)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -27,7 +28,7 @@ ATOM,USDC,21.84,1827884.77
 		mp.client = server.Client()
 		mp.baseURL = server.URL
 
-		prices, err := mp.GetTickerPrices(types.CurrencyPair{Base: "OJO", Quote: "USDT"})
+		prices, err := mp.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "OJO", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr("3.04"), prices["OJOUSDT"].Price)
@@ -49,6 +50,7 @@ ATOM,USDC,21.84,1827884.77
 		mp.baseURL = server.URL
 
 		prices, err := mp.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "OJO", Quote: "USDT"},
 			types.CurrencyPair{Base: "ATOM", Quote: "USDC"},
 		)
@@ -70,7 +72,7 @@ ATOM,USDC,21.84,1827884.77
 		mp.client = server.Client()
 		mp.baseURL = server.URL
 
-		prices, err := mp.GetTickerPrices(types.CurrencyPair{Base: "OJO", Quote: "USDT"})
+		prices, err := mp.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "OJO", Quote: "USDT"})
 		require.Error(t, err)
 		require.Nil(t, prices)
 	})
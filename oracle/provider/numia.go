@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/ojo/util/decmath"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	numiaRestURL        = "https://osmosis.numia.xyz"
+	numiaTokensPath     = "/tokens/v2/all"
+	numiaChartPath      = "/tokens/v2/historical/%s/chart?tf=5"
+	numiaQuoteSymbol    = "USD"
+	numiaCandleInterval = 5 // minutes
+)
+
+var _ Provider = (*NumiaProvider)(nil)
+
+type (
+	// NumiaProvider defines an Oracle provider reading the Osmosis token
+	// prices indexed by Numia, an Osmosis data path independent of the
+	// osmosis and osmosisv2 providers. Its prices are denominated in USD and
+	// its API requires an API key.
+	//
+	// REF: https://docs.numia.xyz/
+	NumiaProvider struct {
+		endpoints Endpoint
+		client    *http.Client
+	}
+
+	// NumiaTokenResponse defines the response structure of a token of the
+	// Numia tokens endpoint. Its volume is the 24h volume in USD.
+	NumiaTokenResponse struct {
+		Symbol    string  `json:"symbol"`
+		Price     float64 `json:"price"`
+		Volume    float64 `json:"volume_24h"`
+		Liquidity float64 `json:"liquidity"`
+	}
+
+	// NumiaCandleResponse defines the response structure of a candle of the
+	// Numia historical chart endpoint, timestamped in seconds.
+	NumiaCandleResponse struct {
+		Time   int64   `json:"time"`
+		Open   float64 `json:"open"`
+		High   float64 `json:"high"`
+		Low    float64 `json:"low"`
+		Close  float64 `json:"close"`
+		Volume float64 `json:"volume"`
+	}
+)
+
+// NewNumiaProvider creates a new NumiaProvider.
+func NewNumiaProvider(endpoint Endpoint) *NumiaProvider {
+	if endpoint.Name != ProviderNumia {
+		endpoint = Endpoint{Name: ProviderNumia}
+	}
+	if len(endpoint.Rest) == 0 {
+		endpoint.Rest = numiaRestURL
+	}
+	return &NumiaProvider{
+		endpoints: endpoint,
+		client:    endpoint.httpClient(newDefaultHTTPClient()),
+	}
+}
+
+// StartConnections performs a no-op since numia does not use websockets.
+func (p *NumiaProvider) StartConnections() {}
+
+// Describe returns the capabilities of the provider.
+func (p *NumiaProvider) Describe() Description {
+	return describe(p.endpoints, true)
+}
+
+// SubscribeCurrencyPairs performs a no-op since numia does not use websockets.
+func (p *NumiaProvider) SubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// UnsubscribeCurrencyPairs performs a no-op since numia does not use websockets.
+func (p *NumiaProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// GetSubscribedPairs returns nil since numia fetches the prices of the
+// requested pairs on demand.
+func (p *NumiaProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return nil
+}
+
+// GetTickerPrices returns the tickerPrices based on the provided pairs, which
+// must be quoted in USD.
+func (p *NumiaProvider) GetTickerPrices(
+	ctx context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string]types.TickerPrice, error) {
+	tokens, err := p.getTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+	for _, cp := range pairs {
+		token, ok := tokens[strings.ToUpper(cp.Base)]
+		if !ok || !strings.EqualFold(cp.Quote, numiaQuoteSymbol) {
+			return nil, fmt.Errorf(types.ErrMissingExchangeRate.Error(), cp.String())
+		}
+
+		price, err := decmath.NewDecFromFloat(token.Price)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Numia price (%f) for %s", token.Price, cp.Base)
+		}
+		volume, err := decmath.NewDecFromFloat(token.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Numia volume (%f) for %s", token.Volume, cp.Base)
+		}
+		tickerPrices[cp.String()] = types.TickerPrice{Price: price, Volume: volume}
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns the 5 minute candles of the provided pairs within
+// the candle period.
+func (p *NumiaProvider) GetCandlePrices(
+	ctx context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	candles := make(map[string][]types.CandlePrice, len(pairs))
+	for _, cp := range pairs {
+		if !strings.EqualFold(cp.Quote, numiaQuoteSymbol) {
+			return nil, fmt.Errorf(types.ErrMissingExchangeRate.Error(), cp.String())
+		}
+
+		var candlesResp []NumiaCandleResponse
+		if err := p.get(ctx, fmt.Sprintf(numiaChartPath, cp.Base), &candlesResp); err != nil {
+			return nil, err
+		}
+
+		staleTime := PastUnixTime(providerCandlePeriod)
+		candlePrices := []types.CandlePrice{}
+		for _, c := range candlesResp {
+			timestamp := SecondsToMilli(c.Time)
+			if staleTime >= timestamp {
+				continue
+			}
+
+			ohlcv, err := numiaDecs(c.Open, c.High, c.Low, c.Close, c.Volume)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read Numia candle of %s: %w", cp.Base, err)
+			}
+			candlePrices = append(candlePrices, types.CandlePrice{
+				Open:      ohlcv[0],
+				High:      ohlcv[1],
+				Low:       ohlcv[2],
+				Price:     ohlcv[3],
+				Volume:    ohlcv[4],
+				TimeStamp: timestamp,
+			})
+		}
+		candles[cp.String()] = candlePrices
+	}
+	return candles, nil
+}
+
+// GetAvailablePairs returns the USD pairs of the tokens indexed by Numia.
+func (p *NumiaProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	tokens, err := p.getTokens(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	availablePairs := make(map[string]struct{}, len(tokens))
+	for symbol := range tokens {
+		cp := types.CurrencyPair{Base: symbol, Quote: numiaQuoteSymbol}
+		availablePairs[cp.String()] = struct{}{}
+	}
+	return availablePairs, nil
+}
+
+// getTokens returns the tokens indexed by Numia keyed by their upper case
+// symbol, returning an error if a symbol is listed twice.
+func (p *NumiaProvider) getTokens(ctx context.Context) (map[string]NumiaTokenResponse, error) {
+	var tokensResp []NumiaTokenResponse
+	if err := p.get(ctx, numiaTokensPath, &tokensResp); err != nil {
+		return nil, err
+	}
+
+	tokens := make(map[string]NumiaTokenResponse, len(tokensResp))
+	for _, token := range tokensResp {
+		symbol := strings.ToUpper(token.Symbol)
+		if _, ok := tokens[symbol]; ok {
+			return nil, fmt.Errorf("duplicate token found in Numia response: %s", symbol)
+		}
+		tokens[symbol] = token
+	}
+	return tokens, nil
+}
+
+// get requests the path from the Numia API, authenticated with the endpoint's
+// API key, and decodes its response into v.
+func (p *NumiaProvider) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoints.Rest+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.endpoints.APIKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make Numia request: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkHTTPStatus(resp); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to unmarshal Numia response body: %w", err)
+	}
+	return nil
+}
+
+// numiaDecs converts the floats of a Numia response to decimals.
+func numiaDecs(values ...float64) ([]sdk.Dec, error) {
+	decs := make([]sdk.Dec, len(values))
+	for i, v := range values {
+		d, err := decmath.NewDecFromFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		decs[i] = d
+	}
+	return decs, nil
+}
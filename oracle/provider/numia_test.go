@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func newNumiaTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer numia-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case numiaTokensPath:
+			fmt.Fprint(w, `[{"symbol":"OSMO","price":0.75,"volume_24h":1200000,"liquidity":1e8},`+
+				`{"symbol":"atom","price":10.5,"volume_24h":300000,"liquidity":5e7}]`)
+		case "/tokens/v2/historical/OSMO/chart":
+			now := time.Now().Unix()
+			fmt.Fprintf(w, `[{"time":%d,"open":0.7,"high":0.8,"low":0.65,"close":0.75,"volume":1000},`+
+				`{"time":%d,"open":0.7,"high":0.7,"low":0.7,"close":0.7,"volume":10}]`, now-60, now-3600)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestNumiaProvider_GetTickerPrices(t *testing.T) {
+	server := newNumiaTestServer()
+	defer server.Close()
+
+	p := NewNumiaProvider(Endpoint{Name: ProviderNumia, Rest: server.URL, APIKey: "numia-key"})
+	osmoUSD := types.CurrencyPair{Base: "OSMO", Quote: "USD"}
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+
+	prices, err := p.GetTickerPrices(context.TODO(), osmoUSD, atomUSD)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("0.75"), prices["OSMOUSD"].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("1200000"), prices["OSMOUSD"].Volume)
+	require.Equal(t, sdk.MustNewDecFromStr("10.5"), prices["ATOMUSD"].Price)
+
+	// the token prices are denominated in USD
+	_, err = p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "OSMO", Quote: "USDC"})
+	require.EqualError(t, err, "missing exchange rate for OSMOUSDC")
+
+	_, err = p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "USD"})
+	require.EqualError(t, err, "missing exchange rate for FOOUSD")
+}
+
+func TestNumiaProvider_GetCandlePrices(t *testing.T) {
+	server := newNumiaTestServer()
+	defer server.Close()
+
+	p := NewNumiaProvider(Endpoint{Name: ProviderNumia, Rest: server.URL, APIKey: "numia-key"})
+	candles, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "OSMO", Quote: "USD"})
+	require.NoError(t, err)
+
+	// the candle older than the candle period is left out
+	require.Len(t, candles["OSMOUSD"], 1)
+	candle := candles["OSMOUSD"][0]
+	require.Equal(t, sdk.MustNewDecFromStr("0.75"), candle.Price)
+	require.Equal(t, sdk.MustNewDecFromStr("0.8"), candle.High)
+	require.Equal(t, sdk.MustNewDecFromStr("1000"), candle.Volume)
+	require.True(t, candle.HasOHLC())
+}
+
+func TestNumiaProvider_GetAvailablePairs(t *testing.T) {
+	server := newNumiaTestServer()
+	defer server.Close()
+
+	p := NewNumiaProvider(Endpoint{Name: ProviderNumia, Rest: server.URL, APIKey: "numia-key"})
+	pairs, err := p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"OSMOUSD": {}, "ATOMUSD": {}}, pairs)
+
+	// requests are authenticated with the API key
+	p = NewNumiaProvider(Endpoint{Name: ProviderNumia, Rest: server.URL, APIKey: "wrong-key"})
+	_, err = p.GetAvailablePairs()
+	require.EqualError(t, err, "unexpected status: 401 Unauthorized")
+}
@@ -90,6 +90,13 @@ type (
 		Args []OkxSubscriptionTopic `json:"args"`
 	}
 
+	// OkxSubscriptionResponse defines the response acknowledging the
+	// subscription to a topic.
+	OkxSubscriptionResponse struct {
+		Event string               `json:"event"` // ex.: subscribe
+		Arg   OkxSubscriptionTopic `json:"arg"`   // subscribed topic
+	}
+
 	// OkxPairsSummary defines the response structure for an Okx pairs summary.
 	OkxPairsSummary struct {
 		Data []OkxInstID `json:"data"`
@@ -129,7 +136,7 @@ func NewOkxProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -141,7 +148,7 @@ func NewOkxProvider(
 
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
 		provider.getSubscriptionMsgs(confirmedPairs...),
 		provider.messageReceived,
@@ -149,6 +156,7 @@ func NewOkxProvider(
 		websocket.PingMessage,
 		okxLogger,
 	)
+	provider.wsc.SetSubscriptionAcks(okxSubscriptionAckKeys)
 
 	return provider, nil
 }
@@ -157,6 +165,11 @@ func (p *OkxProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
+// Describe returns the capabilities of the provider.
+func (p *OkxProvider) Describe() Description {
+	return describe(p.endpoints, true, "tickers", "candle1m")
+}
+
 func (p *OkxProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
 	subscriptionMsgs := make([]interface{}, 0, len(cps)*2)
 	for _, cp := range cps {
@@ -185,7 +198,7 @@ func (p *OkxProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -203,15 +216,19 @@ func (p *OkxProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *OkxProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the saved map.
-func (p *OkxProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *OkxProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
 	for _, cp := range pairs {
 		price, err := p.getTickerPrice(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -229,14 +246,14 @@ func (p *OkxProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]t
 }
 
 // GetCandlePrices returns the candlePrices based on the saved map
-func (p *OkxProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *OkxProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
 	for _, cp := range pairs {
 		prices, err := p.getCandlePrices(cp)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -295,14 +312,20 @@ func (p *OkxProvider) getCandlePrices(cp types.CurrencyPair) ([]types.CandlePric
 	return candleList, nil
 }
 
-func (p *OkxProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byte) {
+func (p *OkxProvider) messageReceived(_ int, conn *WebsocketConnection, bz []byte) {
 	var (
-		tickerResp OkxTickerResponse
-		tickerErr  error
-		candleResp OkxCandleResponse
-		candleErr  error
+		subscriptionResp OkxSubscriptionResponse
+		tickerResp       OkxTickerResponse
+		tickerErr        error
+		candleResp       OkxCandleResponse
+		candleErr        error
 	)
 
+	if err := json.Unmarshal(bz, &subscriptionResp); err == nil && subscriptionResp.Event == "subscribe" {
+		conn.AcknowledgeSubscription(okxSubscriptionAckKey(subscriptionResp.Arg))
+		return
+	}
+
 	// sometimes the message received is not a ticker or a candle response.
 	tickerErr = json.Unmarshal(bz, &tickerResp)
 	if tickerResp.ID.Channel == "tickers" {
@@ -322,6 +345,7 @@ func (p *OkxProvider) messageReceived(_ int, _ *WebsocketConnection, bz []byte)
 		return
 	}
 
+	telemetryParseError(ProviderOkx, parseErrorReason(tickerErr, candleErr))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("ticker", tickerErr).
@@ -341,6 +365,7 @@ func (p *OkxProvider) setCandlePair(pairData []string, instID string) {
 
 	ts, err := strconv.ParseInt(pairData[0], 10, 64)
 	if err != nil {
+		telemetryParseError(ProviderOkx, ParseErrorBadDecimal)
 		return
 	}
 	// the candlesticks channel uses an array of strings.
@@ -355,6 +380,9 @@ func (p *OkxProvider) setCandlePair(pairData []string, instID string) {
 
 	candleList = append(candleList, candle)
 	for _, c := range p.candles[instID] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -369,9 +397,17 @@ func (p *OkxProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *OkxProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs return all available pairs symbol to subscribe.
 func (p *OkxProvider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + okxRestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + okxRestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -432,6 +468,26 @@ func newOkxCandleSubscriptionTopic(instID string) OkxSubscriptionTopic {
 	}
 }
 
+// okxSubscriptionAckKeys returns the keys of the subscribe events OKX
+// acknowledges a subscription message with, one per topic.
+func okxSubscriptionAckKeys(msg interface{}) []string {
+	subscriptionMsg, ok := msg.(OkxSubscriptionMsg)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(subscriptionMsg.Args))
+	for _, topic := range subscriptionMsg.Args {
+		keys = append(keys, okxSubscriptionAckKey(topic))
+	}
+	return keys
+}
+
+// okxSubscriptionAckKey returns the acknowledgement key of the topic, ex.
+// tickers:ATOM-USDT.
+func okxSubscriptionAckKey(topic OkxSubscriptionTopic) string {
+	return topic.Channel + ":" + strings.ToUpper(topic.InstID)
+}
+
 // newOkxSubscriptionMsg returns a new subscription Msg for Okx.
 func newOkxSubscriptionMsg(args ...OkxSubscriptionTopic) OkxSubscriptionMsg {
 	return OkxSubscriptionMsg{
@@ -35,7 +35,7 @@ func TestOkxProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = syncMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr(lastPrice), prices["ATOMUSDT"].Price)
@@ -66,6 +66,7 @@ func TestOkxProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = syncMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "LUNA", Quote: "USDT"},
 		)
@@ -78,7 +79,7 @@ func TestOkxProvider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "okx has no ticker data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
@@ -105,3 +106,14 @@ func TestOkxProvider_getSubscriptionMsgs(t *testing.T) {
 	msg, _ = json.Marshal(subMsgs[1])
 	require.Equal(t, "{\"op\":\"subscribe\",\"args\":[{\"channel\":\"tickers\",\"instId\":\"ATOM-USDT\"}]}", string(msg))
 }
+
+func TestOkxProvider_SubscriptionAcks(t *testing.T) {
+	msgs := (&OkxProvider{}).getSubscriptionMsgs(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+	require.Equal(t, []string{"candle1m:ATOM-USDT"}, okxSubscriptionAckKeys(msgs[0]))
+	require.Equal(t, []string{"tickers:ATOM-USDT"}, okxSubscriptionAckKeys(msgs[1]))
+
+	p := &OkxProvider{logger: zerolog.Nop()}
+	conn := &WebsocketConnection{pendingAcks: map[string]struct{}{"tickers:ATOM-USDT": {}}}
+	p.messageReceived(0, conn, []byte(`{"event":"subscribe","arg":{"channel":"tickers","instId":"ATOM-USDT"}}`))
+	require.Empty(t, conn.pendingAcks)
+}
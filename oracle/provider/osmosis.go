@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -63,7 +64,7 @@ func NewOsmosisProvider(endpoint Endpoint) *OsmosisProvider {
 	if endpoint.Name == ProviderOsmosis {
 		return &OsmosisProvider{
 			baseURL: endpoint.Rest,
-			client:  newDefaultHTTPClient(),
+			client:  endpoint.httpClient(newDefaultHTTPClient()),
 		}
 	}
 	return &OsmosisProvider{
@@ -76,13 +77,27 @@ func (p *OsmosisProvider) StartConnections() {
 	// no-op osmosis v1 does not use websockets
 }
 
+// Describe returns the capabilities of the provider.
+func (p *OsmosisProvider) Describe() Description {
+	return describe(Endpoint{Name: ProviderOsmosis, Rest: p.baseURL}, true)
+}
+
 // SubscribeCurrencyPairs performs a no-op since osmosis does not use websockets
 func (p OsmosisProvider) SubscribeCurrencyPairs(...types.CurrencyPair) {}
 
-func (p OsmosisProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+// UnsubscribeCurrencyPairs performs a no-op since osmosis does not use websockets.
+func (p OsmosisProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// GetSubscribedPairs returns nil since osmosis fetches the prices of the
+// requested pairs on demand.
+func (p OsmosisProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return nil
+}
+
+func (p OsmosisProvider) GetTickerPrices(ctx context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	path := fmt.Sprintf("%s%s/all", p.baseURL, osmosisTokenEndpoint)
 
-	resp, err := p.client.Get(path)
+	resp, err := httpGetWithContext(ctx, p.client, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make Osmosis request: %w", err)
 	}
@@ -142,7 +157,7 @@ func (p OsmosisProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[strin
 	return tickerPrices, nil
 }
 
-func (p OsmosisProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p OsmosisProvider) GetCandlePrices(ctx context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candles := make(map[string][]types.CandlePrice)
 	for _, pair := range pairs {
 		if _, ok := candles[pair.Base]; !ok {
@@ -151,7 +166,7 @@ func (p OsmosisProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[strin
 
 		path := fmt.Sprintf("%s%s/%s/chart?tf=5", p.baseURL, osmosisCandleEndpoint, pair.Base)
 
-		resp, err := p.client.Get(path)
+		resp, err := httpGetWithContext(ctx, p.client, path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to make Osmosis request: %w", err)
 		}
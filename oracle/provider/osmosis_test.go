@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -42,7 +43,7 @@ func TestOsmosisProvider_GetTickerPrices(t *testing.T) {
 		p.client = server.Client()
 		p.baseURL = server.URL
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr("28.52"), prices["ATOMUSDT"].Price)
@@ -79,6 +80,7 @@ func TestOsmosisProvider_GetTickerPrices(t *testing.T) {
 		p.baseURL = server.URL
 
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "LUNA", Quote: "USDT"},
 		)
@@ -100,7 +102,7 @@ func TestOsmosisProvider_GetTickerPrices(t *testing.T) {
 		p.client = server.Client()
 		p.baseURL = server.URL
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.Error(t, err)
 		require.Nil(t, prices)
 	})
@@ -134,7 +136,7 @@ func TestOsmosisProvider_GetTickerPrices(t *testing.T) {
 		p.client = server.Client()
 		p.baseURL = server.URL
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.Error(t, err)
 		require.Nil(t, prices)
 	})
@@ -149,7 +151,7 @@ func TestOsmosisProvider_GetTickerPrices(t *testing.T) {
 		p.client = server.Client()
 		p.baseURL = server.URL
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
 		require.Error(t, err)
 		require.Nil(t, prices)
 	})
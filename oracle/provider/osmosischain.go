@@ -0,0 +1,489 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	osmosisChainRestHost     = "https://lcd.osmosis.zone"
+	osmosisChainPoolEndpoint = "/osmosis/gamm/v1beta1/pools"
+	osmosisChainPollInterval = 15 * time.Second
+)
+
+var _ Provider = (*OsmosisChainProvider)(nil)
+
+// osmosisChainPools defines the default Osmosis balancer pools the provider
+// reads reserves from, indexed by their currency pair symbol. More pools can
+// be added with the endpoint's Pools.
+var osmosisChainPools = map[string]OsmosisChainPool{
+	"ATOMOSMO": {
+		ID:            1,
+		BaseDenom:     "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+		QuoteDenom:    "uosmo",
+		BaseDecimals:  6,
+		QuoteDecimals: 6,
+	},
+	"JUNOOSMO": {
+		ID:            497,
+		BaseDenom:     "ibc/46B44899322F3CD854D2D46DEEF881958467CDD4B3B10086DA49296BBED94BED",
+		QuoteDenom:    "uosmo",
+		BaseDecimals:  6,
+		QuoteDecimals: 6,
+	},
+	"OSMOUSDC": {
+		ID:            678,
+		BaseDenom:     "uosmo",
+		QuoteDenom:    "ibc/D189335C6E4A68B513C10AB227BF1C1D38C746766278BA3EEB4FB14124F1D858",
+		BaseDecimals:  6,
+		QuoteDecimals: 6,
+	},
+}
+
+type (
+	// OsmosisChainProvider defines an Oracle provider that computes spot
+	// prices from Osmosis pool reserves queried directly from a chain LCD
+	// endpoint, without going through a hosted price API.
+	//
+	// It polls the configured pools every osmosisChainPollInterval.
+	//
+	// REF: https://docs.osmosis.zone/osmosis-core/modules/gamm
+	OsmosisChainProvider struct {
+		ctx             context.Context
+		logger          zerolog.Logger
+		mtx             sync.RWMutex
+		endpoints       Endpoint
+		client          *http.Client
+		pools           map[string]OsmosisChainPool    // Symbol => OsmosisChainPool
+		tickers         map[string]types.TickerPrice   // Symbol => TickerPrice
+		candles         map[string][]types.CandlePrice // Symbol => CandlePrice
+		subscribedPairs map[string]types.CurrencyPair  // Symbol => types.CurrencyPair
+	}
+
+	// OsmosisChainPool defines an Osmosis pool and the denoms of the pair
+	// priced from its reserves.
+	OsmosisChainPool struct {
+		ID            uint64 `toml:"id" mapstructure:"id"`                         // pool id ex.: 1
+		BaseDenom     string `toml:"base_denom" mapstructure:"base_denom"`         // on chain denom of the base asset
+		QuoteDenom    string `toml:"quote_denom" mapstructure:"quote_denom"`       // on chain denom of the quote asset
+		BaseDecimals  int64  `toml:"base_decimals" mapstructure:"base_decimals"`   // decimals of the base denom
+		QuoteDecimals int64  `toml:"quote_decimals" mapstructure:"quote_decimals"` // decimals of the quote denom
+	}
+
+	// OsmosisChainPoolResponse defines the response of a gamm pool query.
+	OsmosisChainPoolResponse struct {
+		Pool OsmosisChainPoolData `json:"pool"`
+	}
+
+	// OsmosisChainPoolData defines the reserves of a balancer pool.
+	OsmosisChainPoolData struct {
+		ID         string                  `json:"id"`
+		PoolAssets []OsmosisChainPoolAsset `json:"pool_assets"`
+	}
+
+	// OsmosisChainPoolAsset defines a single reserve of a balancer pool.
+	OsmosisChainPoolAsset struct {
+		Token struct {
+			Denom  string `json:"denom"`  // ex.: uosmo
+			Amount string `json:"amount"` // ex.: 1234567890
+		} `json:"token"`
+		Weight string `json:"weight"` // ex.: 536870912000000
+	}
+)
+
+// NewOsmosisChainProvider creates a new OsmosisChainProvider.
+func NewOsmosisChainProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*OsmosisChainProvider, error) {
+	if endpoints.Name != ProviderOsmosisChain {
+		endpoints = Endpoint{
+			Name: ProviderOsmosisChain,
+			Rest: osmosisChainRestHost,
+		}
+	}
+
+	osmosisChainLogger := logger.With().Str("provider", string(ProviderOsmosisChain)).Logger()
+
+	provider := &OsmosisChainProvider{
+		ctx:             ctx,
+		logger:          osmosisChainLogger,
+		endpoints:       endpoints,
+		client:          endpoints.httpClient(newDefaultHTTPClient()),
+		pools:           endpoints.osmosisChainPools(),
+		tickers:         map[string]types.TickerPrice{},
+		candles:         map[string][]types.CandlePrice{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		provider,
+		provider.endpoints,
+		provider.logger,
+		pairs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.setSubscribedPairs(confirmedPairs...)
+
+	return provider, nil
+}
+
+// ValidateOsmosisChainPools returns an error if a pool has no id or denoms,
+// or decimals out of the range of sdk.Dec.
+func ValidateOsmosisChainPools(pools map[string]OsmosisChainPool) error {
+	for symbol, pool := range pools {
+		if pool.ID == 0 {
+			return fmt.Errorf("missing osmosis pool id for %s", symbol)
+		}
+		if len(pool.BaseDenom) == 0 || len(pool.QuoteDenom) == 0 {
+			return fmt.Errorf("missing osmosis pool %d denoms for %s", pool.ID, symbol)
+		}
+		if pool.BaseDecimals < 0 || pool.BaseDecimals > sdk.Precision ||
+			pool.QuoteDecimals < 0 || pool.QuoteDecimals > sdk.Precision {
+			return fmt.Errorf("invalid osmosis pool %d decimals for %s", pool.ID, symbol)
+		}
+	}
+	return nil
+}
+
+// osmosisChainPools returns the default pools along with the endpoint's
+// pools, which take precedence.
+func (e Endpoint) osmosisChainPools() map[string]OsmosisChainPool {
+	pools := make(map[string]OsmosisChainPool, len(osmosisChainPools)+len(e.Pools))
+	for symbol, pool := range osmosisChainPools {
+		pools[symbol] = pool
+	}
+	for symbol, pool := range e.Pools {
+		// the config keys are lowercased when parsed
+		pools[strings.ToUpper(symbol)] = pool
+	}
+	return pools
+}
+
+// StartConnections starts polling the subscribed pools.
+func (p *OsmosisChainProvider) StartConnections() {
+	go p.pollPrices()
+}
+
+// Describe returns the capabilities of the provider.
+func (p *OsmosisChainProvider) Describe() Description {
+	return describe(p.endpoints, true)
+}
+
+// SubscribeCurrencyPairs adds the new currency pairs to the pools polled by
+// the provider.
+func (p *OsmosisChainProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		p,
+		p.endpoints,
+		p.logger,
+		cps...,
+	)
+	if err != nil {
+		return
+	}
+
+	p.setSubscribedPairs(confirmedPairs...)
+}
+
+// UnsubscribeCurrencyPairs stops polling the pools of the currency pairs and
+// removes their prices.
+func (p *OsmosisChainProvider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, cp := range cps {
+		delete(p.subscribedPairs, cp.String())
+		delete(p.tickers, cp.String())
+		delete(p.candles, cp.String())
+	}
+}
+
+// GetTickerPrices returns the latest polled spot price of the provided pairs.
+func (p *OsmosisChainProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+
+	tickerErrs := 0
+	for _, cp := range pairs {
+		price, err := p.getTickerPrice(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
+			tickerErrs++
+			continue
+		}
+		tickerPrices[cp.String()] = price
+	}
+
+	if tickerErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoTickers.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns the spot prices polled within providerCandlePeriod
+// of the provided pairs.
+func (p *OsmosisChainProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
+
+	candleErrs := 0
+	for _, cp := range pairs {
+		prices, err := p.getCandlePrices(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
+			candleErrs++
+			continue
+		}
+		candlePrices[cp.String()] = prices
+	}
+
+	if candleErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoCandles.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return candlePrices, nil
+}
+
+// GetAvailablePairs returns the pairs of all configured pool ids.
+func (p *OsmosisChainProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	availablePairs := make(map[string]struct{}, len(p.pools))
+	for symbol := range p.pools {
+		availablePairs[strings.ToUpper(symbol)] = struct{}{}
+	}
+	return availablePairs, nil
+}
+
+func (p *OsmosisChainProvider) getTickerPrice(key string) (types.TickerPrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	ticker, ok := p.tickers[key]
+	if !ok {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+	if isStale(ticker.TimeStamp, p.endpoints.tickerMaxAge()) {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerStale.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	return ticker, nil
+}
+
+func (p *OsmosisChainProvider) getCandlePrices(key string) ([]types.CandlePrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	candles, ok := p.candles[key]
+	if !ok {
+		return []types.CandlePrice{}, fmt.Errorf(
+			types.ErrCandleNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	candleList := []types.CandlePrice{}
+	candleList = append(candleList, candles...)
+
+	return candleList, nil
+}
+
+// pollPrices updates the prices of all subscribed pools every
+// osmosisChainPollInterval until the provider's context is done.
+func (p *OsmosisChainProvider) pollPrices() {
+	pollTicker := time.NewTicker(osmosisChainPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		p.updatePrices()
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-pollTicker.C:
+			continue
+		}
+	}
+}
+
+// updatePrices queries the reserves of every subscribed pool and stores the
+// resulting spot price as the ticker and latest candle.
+func (p *OsmosisChainProvider) updatePrices() {
+	p.mtx.RLock()
+	pairs := types.MapPairsToSlice(p.subscribedPairs)
+	p.mtx.RUnlock()
+
+	for _, cp := range pairs {
+		symbol := cp.String()
+		pool, ok := p.pools[symbol]
+		if !ok {
+			continue
+		}
+
+		price, err := p.getPoolPrice(pool)
+		if err != nil {
+			TelemetryFailure(ProviderOsmosisChain, MessageTypeTicker)
+			p.logger.Error().Err(err).Str("pair", symbol).Msg("failed to query osmosis pool")
+			continue
+		}
+
+		p.setPrice(symbol, price, time.Now().UnixMilli())
+		telemetryRestPoll(ProviderOsmosisChain, MessageTypeTicker)
+	}
+}
+
+// getPoolPrice queries the pool reserves and returns the spot price of the
+// pool's base denom in its quote denom.
+func (p *OsmosisChainProvider) getPoolPrice(pool OsmosisChainPool) (sdk.Dec, error) {
+	path := fmt.Sprintf("%s%s/%d", p.endpoints.Rest, osmosisChainPoolEndpoint, pool.ID)
+
+	resp, err := p.client.Get(path)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("failed to make Osmosis pool request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkHTTPStatus(resp); err != nil {
+		return sdk.Dec{}, err
+	}
+
+	var poolResp OsmosisChainPoolResponse
+	if err := json.NewDecoder(resp.Body).Decode(&poolResp); err != nil {
+		return sdk.Dec{}, fmt.Errorf("failed to unmarshal Osmosis pool response: %w", err)
+	}
+
+	return pool.spotPrice(poolResp.Pool)
+}
+
+// spotPrice computes the balancer spot price of the base denom from the pool
+// reserves: (quoteAmount / quoteWeight) / (baseAmount / baseWeight), adjusted
+// for the denoms' decimals.
+func (pool OsmosisChainPool) spotPrice(data OsmosisChainPoolData) (sdk.Dec, error) {
+	var base, quote *OsmosisChainPoolAsset
+	for i := range data.PoolAssets {
+		switch data.PoolAssets[i].Token.Denom {
+		case pool.BaseDenom:
+			base = &data.PoolAssets[i]
+		case pool.QuoteDenom:
+			quote = &data.PoolAssets[i]
+		}
+	}
+	if base == nil || quote == nil {
+		return sdk.Dec{}, fmt.Errorf("osmosis pool %d has no %s/%s reserves", pool.ID, pool.BaseDenom, pool.QuoteDenom)
+	}
+
+	baseAmount, baseWeight, err := base.reserve()
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	quoteAmount, quoteWeight, err := quote.reserve()
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	if !baseAmount.IsPositive() {
+		return sdk.Dec{}, fmt.Errorf("osmosis pool %d has no %s liquidity", pool.ID, pool.BaseDenom)
+	}
+
+	// multiply before dividing to avoid losing precision to the large weights
+	price := quoteAmount.Mul(baseWeight).Quo(baseAmount.Mul(quoteWeight))
+	decimals := pool.BaseDecimals - pool.QuoteDecimals
+	if decimals > 0 {
+		price = price.Mul(sdk.NewDec(10).Power(uint64(decimals)))
+	} else if decimals < 0 {
+		price = price.Quo(sdk.NewDec(10).Power(uint64(-decimals)))
+	}
+
+	return price, nil
+}
+
+// reserve returns the asset's reserve amount and weight.
+func (a OsmosisChainPoolAsset) reserve() (amount, weight sdk.Dec, err error) {
+	amount, err = sdk.NewDecFromStr(a.Token.Amount)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, fmt.Errorf("failed to parse %s reserve: %w", a.Token.Denom, err)
+	}
+	weight, err = sdk.NewDecFromStr(a.Weight)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, fmt.Errorf("failed to parse %s weight: %w", a.Token.Denom, err)
+	}
+	if !weight.IsPositive() {
+		return sdk.Dec{}, sdk.Dec{}, fmt.Errorf("invalid %s weight: %s", a.Token.Denom, a.Weight)
+	}
+	return amount, weight, nil
+}
+
+// setPrice stores the polled price as the pair's ticker and appends it as a
+// candle, dropping candles older than providerCandlePeriod.
+func (p *OsmosisChainProvider) setPrice(symbol string, price sdk.Dec, timeStamp int64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// pool reserves carry no traded volume
+	p.tickers[symbol] = types.TickerPrice{
+		Price:     price,
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: timeStamp,
+	}
+
+	staleTime := PastUnixTime(providerCandlePeriod)
+	candleList := []types.CandlePrice{{
+		Price:     price,
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: timeStamp,
+	}}
+	for _, candle := range p.candles[symbol] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
+		if staleTime < candle.TimeStamp {
+			candleList = append(candleList, candle)
+		}
+	}
+	p.candles[symbol] = candleList
+}
+
+// setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
+func (p *OsmosisChainProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
+	for _, cp := range cps {
+		p.subscribedPairs[cp.String()] = cp
+	}
+}
+
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *OsmosisChainProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/oracle/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOsmosisChainProvider_GetTickerPrices(t *testing.T) {
+	p, err := NewOsmosisChainProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{},
+		types.CurrencyPair{Base: "ATOM", Quote: "OSMO"},
+	)
+	require.NoError(t, err)
+
+	t.Run("valid_request_single_ticker", func(t *testing.T) {
+		price := sdk.MustNewDecFromStr("14.25")
+		p.setPrice("ATOMOSMO", price, time.Now().UnixMilli())
+
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "OSMO"})
+		require.NoError(t, err)
+		require.Len(t, prices, 1)
+		require.Equal(t, price, prices["ATOMOSMO"].Price)
+		require.True(t, prices["ATOMOSMO"].Volume.IsZero())
+	})
+
+	t.Run("invalid_request_stale_ticker", func(t *testing.T) {
+		p.setPrice("ATOMOSMO", sdk.MustNewDecFromStr("14.25"), time.Now().Add(-time.Hour).UnixMilli())
+
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "OSMO"})
+		require.Error(t, err)
+		require.Nil(t, prices)
+	})
+
+	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		require.Error(t, err)
+		require.Equal(t, "osmosischain has no ticker data for requested pairs: [FOOBAR]", err.Error())
+		require.Nil(t, prices)
+	})
+}
+
+func TestOsmosisChainProvider_GetCandlePrices(t *testing.T) {
+	p := OsmosisChainProvider{
+		endpoints: Endpoint{Name: ProviderOsmosisChain},
+		tickers:   map[string]types.TickerPrice{},
+		candles:   map[string][]types.CandlePrice{},
+	}
+
+	p.setPrice("ATOMOSMO", sdk.MustNewDecFromStr("14"), time.Now().Add(-time.Hour).UnixMilli())
+	p.setPrice("ATOMOSMO", sdk.MustNewDecFromStr("15"), time.Now().Add(-time.Minute).UnixMilli())
+	p.setPrice("ATOMOSMO", sdk.MustNewDecFromStr("16"), time.Now().UnixMilli())
+
+	candles, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "OSMO"})
+	require.NoError(t, err)
+	require.Len(t, candles["ATOMOSMO"], 2)
+	require.Equal(t, sdk.MustNewDecFromStr("16"), candles["ATOMOSMO"][0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("15"), candles["ATOMOSMO"][1].Price)
+}
+
+func TestOsmosisChainProvider_GetPoolPrice(t *testing.T) {
+	pool := osmosisChainPools["ATOMOSMO"]
+
+	t.Run("valid_request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "/osmosis/gamm/v1beta1/pools/1", req.URL.String())
+			resp := `{
+				"pool": {
+					"@type": "/osmosis.gamm.v1beta1.Pool",
+					"id": "1",
+					"pool_assets": [
+						{
+							"token": {
+								"denom": "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+								"amount": "2000000000"
+							},
+							"weight": "536870912000000"
+						},
+						{
+							"token": {
+								"denom": "uosmo",
+								"amount": "30000000000"
+							},
+							"weight": "536870912000000"
+						}
+					]
+				}
+			}`
+			rw.Write([]byte(resp))
+		}))
+		defer server.Close()
+
+		p := OsmosisChainProvider{
+			endpoints: Endpoint{Name: ProviderOsmosisChain, Rest: server.URL},
+			client:    server.Client(),
+		}
+
+		price, err := p.getPoolPrice(pool)
+		require.NoError(t, err)
+		require.Equal(t, sdk.MustNewDecFromStr("15"), price)
+	})
+
+	t.Run("weighted_pool", func(t *testing.T) {
+		data := OsmosisChainPoolData{PoolAssets: make([]OsmosisChainPoolAsset, 2)}
+		data.PoolAssets[0].Token.Denom = pool.BaseDenom
+		data.PoolAssets[0].Token.Amount = "1000"
+		data.PoolAssets[0].Weight = "3"
+		data.PoolAssets[1].Token.Denom = pool.QuoteDenom
+		data.PoolAssets[1].Token.Amount = "1000"
+		data.PoolAssets[1].Weight = "1"
+
+		price, err := pool.spotPrice(data)
+		require.NoError(t, err)
+		require.Equal(t, sdk.MustNewDecFromStr("3"), price)
+	})
+
+	t.Run("invalid_request_missing_denom", func(t *testing.T) {
+		_, err := pool.spotPrice(OsmosisChainPoolData{})
+		require.Error(t, err)
+	})
+}
+
+func TestOsmosisChainProvider_GetAvailablePairs(t *testing.T) {
+	p, err := NewOsmosisChainProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{
+			Name: ProviderOsmosisChain,
+			Pools: map[string]OsmosisChainPool{
+				"stosmoosmo": {ID: 833, BaseDenom: "ibc/stosmo", QuoteDenom: "uosmo", BaseDecimals: 6, QuoteDecimals: 6},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	pairs, err := p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Contains(t, pairs, "ATOMOSMO")
+	require.Contains(t, pairs, "OSMOUSDC")
+	require.Contains(t, pairs, "STOSMOOSMO")
+	require.Equal(t, uint64(833), p.pools["STOSMOOSMO"].ID)
+}
+
+func TestValidateOsmosisChainPools(t *testing.T) {
+	require.NoError(t, ValidateOsmosisChainPools(osmosisChainPools))
+	require.Error(t, ValidateOsmosisChainPools(map[string]OsmosisChainPool{
+		"ATOMOSMO": {BaseDenom: "uatom", QuoteDenom: "uosmo"},
+	}))
+	require.Error(t, ValidateOsmosisChainPools(map[string]OsmosisChainPool{
+		"ATOMOSMO": {ID: 1, BaseDenom: "uatom"},
+	}))
+	require.Error(t, ValidateOsmosisChainPools(map[string]OsmosisChainPool{
+		"ATOMOSMO": {ID: 1, BaseDenom: "uatom", QuoteDenom: "uosmo", BaseDecimals: 19},
+	}))
+}
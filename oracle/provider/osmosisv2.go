@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/gorilla/websocket"
@@ -20,9 +21,21 @@ const (
 	osmosisV2WSPath   = "ws"
 	osmosisV2RestHost = "https://api.osmo-api.prod.network.umee.cc"
 	osmosisV2RestPath = "/assetpairs"
+
+	// osmosisV2CandleInterval is the period of the candles sent by the
+	// Osmosis API.
+	osmosisV2CandleInterval = time.Minute
 )
 
-var _ Provider = (*OsmosisV2Provider)(nil)
+var (
+	_ Provider = (*OsmosisV2Provider)(nil)
+
+	// osmosisV2Subscription streams all pairs over a single connection, which
+	// only needs an empty message to start.
+	osmosisV2Subscription = subscriptionFormat{
+		message: func([]string, []string) interface{} { return "" },
+	}
+)
 
 type (
 	// OsmosisV2Provider defines an Oracle provider implemented by OJO's
@@ -35,9 +48,9 @@ type (
 		logger          zerolog.Logger
 		mtx             sync.RWMutex
 		endpoints       Endpoint
-		tickers         map[string]types.TickerPrice   // Symbol => TickerPrice
-		candles         map[string][]types.CandlePrice // Symbol => CandlePrice
-		subscribedPairs map[string]types.CurrencyPair  // Symbol => types.CurrencyPair
+		tickers         map[string]types.TickerPrice  // Symbol => TickerPrice
+		candles         map[string]*candleDeque       // Symbol => candles in time order
+		subscribedPairs map[string]types.CurrencyPair // Symbol => types.CurrencyPair
 	}
 
 	OsmosisV2Ticker struct {
@@ -46,6 +59,9 @@ type (
 	}
 
 	OsmosisV2Candle struct {
+		Open    string `json:"Open"`
+		High    string `json:"High"`
+		Low     string `json:"Low"`
 		Close   string `json:"Close"`
 		Volume  string `json:"Volume"`
 		EndTime int64  `json:"EndTime"`
@@ -91,13 +107,13 @@ func NewOsmosisV2Provider(
 		logger:          osmosisV2Logger,
 		endpoints:       endpoints,
 		tickers:         map[string]types.TickerPrice{},
-		candles:         map[string][]types.CandlePrice{},
+		candles:         map[string]*candleDeque{},
 		subscribedPairs: map[string]types.CurrencyPair{},
 	}
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -107,11 +123,16 @@ func NewOsmosisV2Provider(
 
 	provider.setSubscribedPairs(confirmedPairs...)
 
+	subscriptionMsgs, err := osmosisV2Subscription.build(confirmedPairs...)
+	if err != nil {
+		return nil, err
+	}
+
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
-		[]interface{}{""},
+		subscriptionMsgs,
 		provider.messageReceived,
 		defaultPingDuration,
 		websocket.PingMessage,
@@ -126,6 +147,11 @@ func (p *OsmosisV2Provider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
+// Describe returns the capabilities of the provider.
+func (p *OsmosisV2Provider) Describe() Description {
+	return describe(p.endpoints, true)
+}
+
 // SubscribeCurrencyPairs sends the new subscription messages to the websocket
 // and adds them to the providers subscribedPairs array
 func (p *OsmosisV2Provider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
@@ -134,7 +160,7 @@ func (p *OsmosisV2Provider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		cps...,
 	)
@@ -145,8 +171,24 @@ func (p *OsmosisV2Provider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs removes the pairs from the providers subscribedPairs
+// array along with their tickers and candles. The osmosis api streams every
+// pair on its websocket so no message is sent, the messages of the pairs are
+// ignored once they aren't subscribed to.
+func (p *OsmosisV2Provider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, cp := range cps {
+		key := currencyPairToOsmosisV2Pair(cp)
+		delete(p.subscribedPairs, cp.String())
+		delete(p.tickers, key)
+		delete(p.candles, key)
+	}
+}
+
 // GetTickerPrices returns the tickerPrices based on the saved map.
-func (p *OsmosisV2Provider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *OsmosisV2Provider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
@@ -154,7 +196,7 @@ func (p *OsmosisV2Provider) GetTickerPrices(pairs ...types.CurrencyPair) (map[st
 		key := currencyPairToOsmosisV2Pair(cp)
 		price, err := p.getTickerPrice(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -172,7 +214,7 @@ func (p *OsmosisV2Provider) GetTickerPrices(pairs ...types.CurrencyPair) (map[st
 }
 
 // GetCandlePrices returns the candlePrices based on the saved map
-func (p *OsmosisV2Provider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *OsmosisV2Provider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
@@ -180,7 +222,7 @@ func (p *OsmosisV2Provider) GetCandlePrices(pairs ...types.CurrencyPair) (map[st
 		key := currencyPairToOsmosisV2Pair(cp)
 		prices, err := p.getCandlePrices(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -209,6 +251,13 @@ func (p *OsmosisV2Provider) getTickerPrice(key string) (types.TickerPrice, error
 			key,
 		)
 	}
+	if isStale(ticker.TimeStamp, p.endpoints.tickerMaxAge()) {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerStale.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
 
 	return ticker, nil
 }
@@ -226,10 +275,7 @@ func (p *OsmosisV2Provider) getCandlePrices(key string) ([]types.CandlePrice, er
 		)
 	}
 
-	candleList := []types.CandlePrice{}
-	candleList = append(candleList, candles...)
-
-	return candleList, nil
+	return candles.list(), nil
 }
 
 func (p *OsmosisV2Provider) messageReceived(_ int, _ *WebsocketConnection, bz []byte) {
@@ -249,6 +295,7 @@ func (p *OsmosisV2Provider) messageReceived(_ int, _ *WebsocketConnection, bz []
 
 	messageErr = json.Unmarshal(bz, &messageResp)
 	if messageErr != nil {
+		telemetryParseError(ProviderOsmosisV2, ParseErrorUnmarshal)
 		p.logger.Error().
 			Int("length", len(bz)).
 			AnErr("message", messageErr).
@@ -257,7 +304,7 @@ func (p *OsmosisV2Provider) messageReceived(_ int, _ *WebsocketConnection, bz []
 
 	// Check the response for currency pairs that the provider is subscribed
 	// to and determine whether it is a ticker or candle.
-	for _, pair := range p.subscribedPairs {
+	for _, pair := range p.GetSubscribedPairs() {
 		osmosisV2Pair := currencyPairToOsmosisV2Pair(pair)
 		if msg, ok := messageResp[osmosisV2Pair]; ok {
 			switch v := msg.(type) {
@@ -266,6 +313,7 @@ func (p *OsmosisV2Provider) messageReceived(_ int, _ *WebsocketConnection, bz []
 				tickerString, _ := json.Marshal(v)
 				tickerErr = json.Unmarshal(tickerString, &tickerResp)
 				if tickerErr != nil {
+					telemetryParseError(ProviderOsmosisV2, ParseErrorUnmarshal)
 					p.logger.Error().
 						Int("length", len(bz)).
 						AnErr("ticker", tickerErr).
@@ -288,6 +336,7 @@ func (p *OsmosisV2Provider) messageReceived(_ int, _ *WebsocketConnection, bz []
 				candleString, _ := json.Marshal(v)
 				candleErr = json.Unmarshal(candleString, &candleResp)
 				if candleErr != nil {
+					telemetryParseError(ProviderOsmosisV2, ParseErrorUnmarshal)
 					p.logger.Error().
 						Int("length", len(bz)).
 						AnErr("candle", candleErr).
@@ -313,18 +362,21 @@ func (p *OsmosisV2Provider) setTickerPair(symbol string, tickerPair OsmosisV2Tic
 
 	price, err := sdk.NewDecFromStr(tickerPair.Price)
 	if err != nil {
+		telemetryParseError(ProviderOsmosisV2, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("osmosisv2: failed to parse ticker price")
 		return
 	}
 	volume, err := sdk.NewDecFromStr(tickerPair.Volume)
 	if err != nil {
+		telemetryParseError(ProviderOsmosisV2, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("osmosisv2: failed to parse ticker volume")
 		return
 	}
 
 	p.tickers[symbol] = types.TickerPrice{
-		Price:  price,
-		Volume: volume,
+		Price:     price,
+		Volume:    volume,
+		TimeStamp: time.Now().UnixMilli(),
 	}
 }
 
@@ -334,30 +386,97 @@ func (p *OsmosisV2Provider) setCandlePair(symbol string, candlePair OsmosisV2Can
 
 	close, err := sdk.NewDecFromStr(candlePair.Close)
 	if err != nil {
+		telemetryParseError(ProviderOsmosisV2, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("osmosisv2: failed to parse candle close")
 		return
 	}
 	volume, err := sdk.NewDecFromStr(candlePair.Volume)
 	if err != nil {
+		telemetryParseError(ProviderOsmosisV2, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("osmosisv2: failed to parse candle volume")
 		return
 	}
-	candle := types.CandlePrice{
+	candle, err := types.CandlePrice{
 		Price:     close,
 		Volume:    volume,
 		TimeStamp: candlePair.EndTime,
+	}.WithOHLC(string(ProviderOsmosisV2), symbol, candlePair.Open, candlePair.High, candlePair.Low)
+	if err != nil {
+		telemetryParseError(ProviderOsmosisV2, ParseErrorBadDecimal)
+		p.logger.Warn().Err(err).Msg("osmosisv2: failed to parse candle ohlc")
+		return
 	}
 
 	staleTime := PastUnixTime(providerCandlePeriod)
-	candleList := []types.CandlePrice{}
-	candleList = append(candleList, candle)
-	for _, c := range p.candles[symbol] {
-		if staleTime < c.TimeStamp {
-			candleList = append(candleList, c)
+	candles, ok := p.candles[symbol]
+	if !ok {
+		candles = &candleDeque{}
+		p.candles[symbol] = candles
+	}
+
+	newCandles := []types.CandlePrice{}
+	if latest, ok := candles.latest(); ok {
+		missing := missingCandles(latest.TimeStamp, candle.TimeStamp, osmosisV2CandleInterval)
+		if missing > 0 {
+			p.logger.Warn().
+				Str("symbol", symbol).
+				Int64("missing_candles", missing).
+				Int64("previous_timestamp", latest.TimeStamp).
+				Int64("timestamp", candle.TimeStamp).
+				Msg("osmosisv2: gap in candles")
+			telemetryCandleGap(ProviderOsmosisV2)
+
+			if p.endpoints.FillCandleGaps {
+				newCandles = append(newCandles, fillCandleGap(latest, missing, osmosisV2CandleInterval, staleTime)...)
+			}
 		}
 	}
+	newCandles = append(newCandles, candle)
+
+	candles.add(staleTime, newCandles...)
+	candles.limit(p.endpoints.MaxCandles)
+}
+
+// missingCandles returns the amount of candles of the given interval missing
+// between the previous and the next candle timestamps, in milliseconds. The
+// timestamps are rounded to the interval so that small delays aren't gaps.
+func missingCandles(previous, next int64, interval time.Duration) int64 {
+	intervalMs := interval.Milliseconds()
+	elapsed := next - previous
+	if elapsed <= intervalMs {
+		return 0
+	}
+	return (elapsed+intervalMs/2)/intervalMs - 1
+}
+
+// fillCandleGap returns the missing candles following the previous candle,
+// which carry its close with no volume. Candles older than staleTime are left
+// out.
+func fillCandleGap(previous types.CandlePrice, missing int64, interval time.Duration, staleTime int64) []types.CandlePrice {
+	intervalMs := interval.Milliseconds()
+
+	// skip the missing candles which would already be stale
+	first := int64(1)
+	if skipped := (staleTime - previous.TimeStamp) / intervalMs; skipped >= first {
+		first = skipped + 1
+	}
 
-	p.candles[symbol] = candleList
+	filled := []types.CandlePrice{}
+	for i := first; i <= missing; i++ {
+		timeStamp := previous.TimeStamp + i*intervalMs
+		if timeStamp <= staleTime {
+			continue
+		}
+		filled = append(filled, types.CandlePrice{
+			Price:     previous.Price,
+			Volume:    sdk.ZeroDec(),
+			TimeStamp: timeStamp,
+			Open:      previous.Price,
+			High:      previous.Price,
+			Low:       previous.Price,
+		})
+	}
+	return filled
 }
 
 // setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
@@ -367,10 +486,18 @@ func (p *OsmosisV2Provider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *OsmosisV2Provider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // GetAvailablePairs returns all pairs to which the provider can subscribe.
 // ex.: map["ATOMUSDT" => {}, "OJOUSDC" => {}].
 func (p *OsmosisV2Provider) GetAvailablePairs() (map[string]struct{}, error) {
-	resp, err := http.Get(p.endpoints.Rest + osmosisV2RestPath)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + osmosisV2RestPath)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ojo-network/price-feeder/oracle/types"
@@ -25,13 +26,14 @@ func TestOsmosisV2Provider_GetTickerPrices(t *testing.T) {
 
 		tickerMap := map[string]types.TickerPrice{}
 		tickerMap["OSMO/ATOM"] = types.TickerPrice{
-			Price:  lastPrice,
-			Volume: volume,
+			Price:     lastPrice,
+			Volume:    volume,
+			TimeStamp: time.Now().UnixMilli(),
 		}
 
 		p.tickers = tickerMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "OSMO", Quote: "ATOM"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "OSMO", Quote: "ATOM"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, lastPrice, prices["OSMOATOM"].Price)
@@ -45,17 +47,20 @@ func TestOsmosisV2Provider_GetTickerPrices(t *testing.T) {
 
 		tickerMap := map[string]types.TickerPrice{}
 		tickerMap["ATOM/USDT"] = types.TickerPrice{
-			Price:  lastPriceAtom,
-			Volume: volume,
+			Price:     lastPriceAtom,
+			Volume:    volume,
+			TimeStamp: time.Now().UnixMilli(),
 		}
 
 		tickerMap["LUNA/USDT"] = types.TickerPrice{
-			Price:  lastPriceLuna,
-			Volume: volume,
+			Price:     lastPriceLuna,
+			Volume:    volume,
+			TimeStamp: time.Now().UnixMilli(),
 		}
 
 		p.tickers = tickerMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "ATOM", Quote: "USDT"},
 			types.CurrencyPair{Base: "LUNA", Quote: "USDT"},
 		)
@@ -68,11 +73,25 @@ func TestOsmosisV2Provider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.Error(t, err)
 		require.Equal(t, "osmosisv2 has no ticker data for requested pairs: [FOOBAR]", err.Error())
 		require.Nil(t, prices)
 	})
+
+	t.Run("invalid_request_stale_ticker", func(t *testing.T) {
+		tickerMap := map[string]types.TickerPrice{}
+		tickerMap["OSMO/ATOM"] = types.TickerPrice{
+			Price:     sdk.MustNewDecFromStr("34.69000000"),
+			Volume:    sdk.MustNewDecFromStr("2396974.02000000"),
+			TimeStamp: time.Now().Add(-defaultTickerMaxAge - time.Minute).UnixMilli(),
+		}
+
+		p.tickers = tickerMap
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "OSMO", Quote: "ATOM"})
+		require.EqualError(t, err, "osmosisv2 has no ticker data for requested pairs: [OSMOATOM]")
+		require.Nil(t, prices)
+	})
 }
 
 func TestOsmosisV2Provider_GetCandlePrices(t *testing.T) {
@@ -97,7 +116,7 @@ func TestOsmosisV2Provider_GetCandlePrices(t *testing.T) {
 
 		p.setCandlePair("OSMO/ATOM", candle)
 
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "OSMO", Quote: "ATOM"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "OSMO", Quote: "ATOM"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, sdk.MustNewDecFromStr(price), prices["OSMOATOM"][0].Price)
@@ -106,7 +125,7 @@ func TestOsmosisV2Provider_GetCandlePrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_candle", func(t *testing.T) {
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "osmosisv2 has no candle data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
@@ -117,3 +136,124 @@ func TestOsmosisV2CurrencyPairToOsmosisV2Pair(t *testing.T) {
 	osmosisv2Symbol := currencyPairToOsmosisV2Pair(cp)
 	require.Equal(t, osmosisv2Symbol, "ATOM/USDT")
 }
+
+func TestOsmosisV2Provider_UnsubscribeCurrencyPairs(t *testing.T) {
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	osmoATOM := types.CurrencyPair{Base: "OSMO", Quote: "ATOM"}
+	p := &OsmosisV2Provider{
+		logger:          zerolog.Nop(),
+		endpoints:       Endpoint{Name: ProviderOsmosisV2},
+		tickers:         map[string]types.TickerPrice{},
+		candles:         map[string]*candleDeque{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+	p.setSubscribedPairs(atomUSDT, osmoATOM)
+	p.setTickerPair("ATOM/USDT", OsmosisV2Ticker{Price: "10", Volume: "100"})
+	p.setCandlePair("ATOM/USDT", OsmosisV2Candle{Close: "10", Volume: "100", EndTime: time.Now().UnixMilli()})
+
+	p.UnsubscribeCurrencyPairs(atomUSDT)
+	require.Equal(t, map[string]types.CurrencyPair{"OSMOATOM": osmoATOM}, p.GetSubscribedPairs())
+	require.NotContains(t, p.tickers, "ATOM/USDT")
+	require.NotContains(t, p.candles, "ATOM/USDT")
+
+	// the streamed messages of the pair are ignored
+	p.messageReceived(0, nil, []byte(`{"ATOM/USDT":{"Price":"10","Volume":"100"}}`))
+	require.NotContains(t, p.tickers, "ATOM/USDT")
+}
+
+func TestOsmosisV2Provider_CandleGaps(t *testing.T) {
+	minute := osmosisV2CandleInterval.Milliseconds()
+	start := time.Now().Add(-5 * time.Minute).UnixMilli()
+
+	newProvider := func(fillGaps bool) *OsmosisV2Provider {
+		return &OsmosisV2Provider{
+			logger:    zerolog.Nop(),
+			endpoints: Endpoint{Name: ProviderOsmosisV2, FillCandleGaps: fillGaps},
+			candles:   map[string]*candleDeque{},
+		}
+	}
+	candle := func(timeStamp int64) OsmosisV2Candle {
+		return OsmosisV2Candle{Close: "10", Volume: "100", EndTime: timeStamp}
+	}
+
+	t.Run("no gap", func(t *testing.T) {
+		p := newProvider(true)
+		p.setCandlePair("OSMO/ATOM", candle(start))
+		p.setCandlePair("OSMO/ATOM", candle(start+minute+500))
+		require.Equal(t, 2, p.candles["OSMO/ATOM"].len())
+	})
+
+	t.Run("gap without filling", func(t *testing.T) {
+		p := newProvider(false)
+		p.setCandlePair("OSMO/ATOM", candle(start))
+		p.setCandlePair("OSMO/ATOM", candle(start+3*minute))
+		require.Equal(t, 2, p.candles["OSMO/ATOM"].len())
+	})
+
+	t.Run("gap with filling", func(t *testing.T) {
+		p := newProvider(true)
+		p.setCandlePair("OSMO/ATOM", candle(start))
+		p.setCandlePair("OSMO/ATOM", candle(start+3*minute))
+
+		candles := p.candles["OSMO/ATOM"].list()
+		require.Len(t, candles, 4)
+
+		filled := map[int64]types.CandlePrice{}
+		for _, c := range candles {
+			filled[c.TimeStamp] = c
+		}
+		for _, timeStamp := range []int64{start + minute, start + 2*minute} {
+			require.Contains(t, filled, timeStamp)
+			require.Equal(t, sdk.MustNewDecFromStr("10"), filled[timeStamp].Price)
+			require.True(t, filled[timeStamp].Volume.IsZero())
+		}
+	})
+}
+
+func TestMissingCandles(t *testing.T) {
+	minute := time.Minute.Milliseconds()
+
+	require.Equal(t, int64(0), missingCandles(0, minute, time.Minute))
+	require.Equal(t, int64(0), missingCandles(0, minute+10_000, time.Minute))
+	require.Equal(t, int64(1), missingCandles(0, 2*minute, time.Minute))
+	require.Equal(t, int64(4), missingCandles(0, 5*minute-5_000, time.Minute))
+	require.Equal(t, int64(0), missingCandles(minute, 0, time.Minute))
+}
+
+func BenchmarkOsmosisV2Provider_setCandlePair(b *testing.B) {
+	p := &OsmosisV2Provider{
+		logger:    zerolog.Nop(),
+		endpoints: Endpoint{Name: ProviderOsmosisV2},
+		candles:   map[string]*candleDeque{},
+	}
+
+	// one candle per second keeps providerCandlePeriod worth of candles
+	start := time.Now().Add(-providerCandlePeriod).UnixMilli()
+	for i := 0; i < b.N; i++ {
+		p.setCandlePair("OSMO/ATOM", OsmosisV2Candle{
+			Close:   "10",
+			Volume:  "100",
+			EndTime: start + int64(i)*time.Second.Milliseconds(),
+		})
+	}
+}
+
+func BenchmarkOsmosisV2Provider_getCandlePrices(b *testing.B) {
+	p := &OsmosisV2Provider{
+		logger:    zerolog.Nop(),
+		endpoints: Endpoint{Name: ProviderOsmosisV2},
+		candles:   map[string]*candleDeque{},
+	}
+
+	now := time.Now().UnixMilli()
+	for ts := now - providerCandlePeriod.Milliseconds(); ts <= now; ts += time.Second.Milliseconds() {
+		p.setCandlePair("OSMO/ATOM", OsmosisV2Candle{Close: "10", Volume: "100", EndTime: ts})
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.getCandlePrices("OSMO/ATOM"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
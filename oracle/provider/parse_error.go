@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"errors"
+	"strconv"
+)
+
+const (
+	// ParseErrorUnmarshal is a message that isn't valid JSON or doesn't match
+	// the structure of the provider's messages.
+	ParseErrorUnmarshal = ParseErrorReason("unmarshal")
+	// ParseErrorMissingField is a message of a known type missing a field or
+	// holding a field of the wrong type.
+	ParseErrorMissingField = ParseErrorReason("missing_field")
+	// ParseErrorBadDecimal is a message holding a number that can't be parsed.
+	ParseErrorBadDecimal = ParseErrorReason("bad_decimal")
+	// ParseErrorUnknownType is a valid message of a type the provider doesn't
+	// handle.
+	ParseErrorUnknownType = ParseErrorReason("unknown_type")
+)
+
+// parseErrorPrecedence ranks the reasons from the least to the most specific.
+var parseErrorPrecedence = map[ParseErrorReason]int{
+	ParseErrorUnknownType:  0,
+	ParseErrorUnmarshal:    1,
+	ParseErrorMissingField: 2,
+	ParseErrorBadDecimal:   3,
+}
+
+type (
+	// ParseErrorReason categorizes why a provider message couldn't be parsed.
+	ParseErrorReason string
+
+	// parseError is an error parsing a provider message along with its
+	// reason.
+	parseError struct {
+		reason ParseErrorReason
+		err    error
+	}
+)
+
+// String cast provider ParseErrorReason to string.
+func (r ParseErrorReason) String() string {
+	return string(r)
+}
+
+// newParseError returns err categorized by reason. Errors that were already
+// categorized keep their reason.
+func newParseError(reason ParseErrorReason, err error) error {
+	var pErr *parseError
+	if errors.As(err, &pErr) {
+		return err
+	}
+	return &parseError{reason: reason, err: err}
+}
+
+func (e *parseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *parseError) Unwrap() error {
+	return e.err
+}
+
+// parseErrorReason returns the most specific reason of the errors met while
+// trying to parse a message as each of the provider's message types. Errors
+// that weren't categorized with newParseError are unmarshal errors, unless
+// they come from parsing a number. A message that was parsed without errors
+// but matched none of the types is of an unknown type.
+func parseErrorReason(errs ...error) ParseErrorReason {
+	reason := ParseErrorUnknownType
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		errReason := ParseErrorUnmarshal
+		var pErr *parseError
+		var numErr *strconv.NumError
+		switch {
+		case errors.As(err, &pErr):
+			errReason = pErr.reason
+		case errors.As(err, &numErr):
+			errReason = ParseErrorBadDecimal
+		}
+
+		if parseErrorPrecedence[errReason] > parseErrorPrecedence[reason] {
+			reason = errReason
+		}
+	}
+	return reason
+}
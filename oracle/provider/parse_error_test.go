@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorReason(t *testing.T) {
+	var v struct{}
+	unmarshalErr := json.Unmarshal([]byte("{"), &v)
+	require.Error(t, unmarshalErr)
+	_, numErr := strconv.ParseInt("1.5", 10, 64)
+	require.Error(t, numErr)
+	unknownTypeErr := newParseError(ParseErrorUnknownType, fmt.Errorf("unknown type"))
+	missingFieldErr := newParseError(ParseErrorMissingField, fmt.Errorf("missing field"))
+
+	require.Equal(t, ParseErrorUnknownType, parseErrorReason())
+	require.Equal(t, ParseErrorUnknownType, parseErrorReason(nil, nil))
+	require.Equal(t, ParseErrorUnknownType, parseErrorReason(unknownTypeErr, nil))
+	require.Equal(t, ParseErrorUnmarshal, parseErrorReason(unknownTypeErr, unmarshalErr))
+	require.Equal(t, ParseErrorMissingField, parseErrorReason(unmarshalErr, missingFieldErr))
+	require.Equal(t, ParseErrorBadDecimal, parseErrorReason(missingFieldErr, numErr))
+	require.Equal(t, ParseErrorBadDecimal, parseErrorReason(fmt.Errorf("wrapped: %w", numErr)))
+
+	// categorized errors keep their reason when wrapped again
+	badDecimalErr := newParseError(ParseErrorBadDecimal, fmt.Errorf("bad decimal"))
+	require.Equal(t, ParseErrorBadDecimal, parseErrorReason(newParseError(ParseErrorMissingField, badDecimalErr)))
+	require.Equal(t, "bad decimal", badDecimalErr.Error())
+}
+
+func TestKrakenProvider_ParseErrorReason(t *testing.T) {
+	p := &KrakenProvider{logger: zerolog.Nop()}
+
+	testCases := []struct {
+		name     string
+		msg      string
+		expected ParseErrorReason
+	}{
+		{
+			name:     "invalid json",
+			msg:      `[0,{"c":`,
+			expected: ParseErrorUnmarshal,
+		},
+		{
+			name:     "unknown channel",
+			msg:      `[0,{},"spread","XBT/USD"]`,
+			expected: ParseErrorUnknownType,
+		},
+		{
+			name:     "missing pair",
+			msg:      `[0,{"c":["1","1"],"v":["1","1"]},"ticker",1]`,
+			expected: ParseErrorMissingField,
+		},
+		{
+			name:     "bad decimal",
+			msg:      `[0,{"c":["one","1"],"v":["1","1"]},"ticker","XBT/USD"]`,
+			expected: ParseErrorBadDecimal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tickerErr := p.messageReceivedTickerPrice([]byte(tc.msg))
+			require.Error(t, tickerErr)
+			candleErr := p.messageReceivedCandle([]byte(tc.msg))
+			require.Error(t, candleErr)
+			require.Equal(t, tc.expected, parseErrorReason(tickerErr, candleErr))
+		})
+	}
+}
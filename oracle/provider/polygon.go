@@ -106,7 +106,7 @@ func NewPolygonProvider(
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		provider,
-		provider.endpoints.Name,
+		provider.endpoints,
 		provider.logger,
 		pairs...,
 	)
@@ -118,7 +118,7 @@ func NewPolygonProvider(
 
 	provider.wsc = NewWebsocketController(
 		ctx,
-		endpoints.Name,
+		endpoints,
 		wsURL,
 		provider.getSubscriptionMsgs(confirmedPairs...),
 		provider.messageReceived,
@@ -134,6 +134,11 @@ func (p *PolygonProvider) StartConnections() {
 	p.wsc.StartConnections()
 }
 
+// Describe returns the capabilities of the provider.
+func (p *PolygonProvider) Describe() Description {
+	return describe(p.endpoints, true, polygonAggregatesEvent)
+}
+
 func (p *PolygonProvider) getSubscriptionMsgs(cps ...types.CurrencyPair) []interface{} {
 	subscriptionMsgs := make([]interface{}, 0, len(cps)*2+1)
 
@@ -167,7 +172,7 @@ func (p *PolygonProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 
 	confirmedPairs, err := ConfirmPairAvailability(
 		p,
-		p.endpoints.Name,
+		p.endpoints,
 		p.logger,
 		newPairs...,
 	)
@@ -185,8 +190,12 @@ func (p *PolygonProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
 	p.setSubscribedPairs(confirmedPairs...)
 }
 
+// UnsubscribeCurrencyPairs performs a no-op, the provider keeps receiving the
+// pairs it subscribed to until it's restarted.
+func (p *PolygonProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
 // GetTickerPrices returns the tickerPrices based on the saved map.
-func (p *PolygonProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+func (p *PolygonProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
 	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
 
 	tickerErrs := 0
@@ -194,7 +203,7 @@ func (p *PolygonProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[stri
 		key := currencyPairToPolygonPair(cp)
 		price, err := p.getTickerPrice(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
 			tickerErrs++
 			continue
 		}
@@ -212,7 +221,7 @@ func (p *PolygonProvider) GetTickerPrices(pairs ...types.CurrencyPair) (map[stri
 }
 
 // GetCandlePrices returns the candlePrices based on the saved map
-func (p *PolygonProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+func (p *PolygonProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
 	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
 
 	candleErrs := 0
@@ -220,7 +229,7 @@ func (p *PolygonProvider) GetCandlePrices(pairs ...types.CurrencyPair) (map[stri
 		key := currencyPairToPolygonPair(cp)
 		prices, err := p.getCandlePrices(key)
 		if err != nil {
-			p.logger.Warn().Err(err)
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
 			candleErrs++
 			continue
 		}
@@ -275,7 +284,7 @@ func (p *PolygonProvider) getCandlePrices(key string) ([]types.CandlePrice, erro
 // GetAvailablePairs return all available pairs symbol to susbscribe.
 func (p *PolygonProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	// request for first 1000 tickers (request limit)
-	resp, err := http.Get(p.endpoints.Rest + polygonRestPath + p.endpoints.APIKey + polygonOrderOne + polygonLimitOne)
+	resp, err := p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + polygonRestPath + p.endpoints.APIKey + polygonOrderOne + polygonLimitOne)
 	if err != nil {
 		return nil, err
 	}
@@ -286,7 +295,7 @@ func (p *PolygonProvider) GetAvailablePairs() (map[string]struct{}, error) {
 	defer resp.Body.Close()
 
 	// request for rest of the tickers
-	resp, err = http.Get(p.endpoints.Rest + polygonRestPath + p.endpoints.APIKey + polygonOrderTwo + polygonLimitTwo)
+	resp, err = p.endpoints.httpClient(http.DefaultClient).Get(p.endpoints.Rest + polygonRestPath + p.endpoints.APIKey + polygonOrderTwo + polygonLimitTwo)
 	if err != nil {
 		return nil, err
 	}
@@ -340,6 +349,7 @@ func (p *PolygonProvider) messageReceived(messageType int, _ *WebsocketConnectio
 		return
 	}
 
+	telemetryParseError(ProviderPolygon, parseErrorReason(statusErr, aggregatesErr))
 	p.logger.Error().
 		Int("length", len(bz)).
 		AnErr("status", statusErr).
@@ -358,6 +368,7 @@ func (p *PolygonProvider) setTickerPair(data PolygonAggregatesResponse) {
 		fmt.Sprintf("%f", data.Volume),
 	)
 	if err != nil {
+		telemetryParseError(ProviderPolygon, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("failed to parse ticker")
 		return
 	}
@@ -377,6 +388,7 @@ func (p *PolygonProvider) setCandlePair(data PolygonAggregatesResponse) {
 		data.Timestamp,
 	)
 	if err != nil {
+		telemetryParseError(ProviderPolygon, ParseErrorBadDecimal)
 		p.logger.Warn().Err(err).Msg("failed to parse candle")
 		return
 	}
@@ -386,6 +398,9 @@ func (p *PolygonProvider) setCandlePair(data PolygonAggregatesResponse) {
 	candleList = append(candleList, candle)
 
 	for _, c := range p.candles[data.Pair] {
+		if p.endpoints.candlesFull(len(candleList)) {
+			break
+		}
 		if staleTime < c.TimeStamp {
 			candleList = append(candleList, c)
 		}
@@ -401,6 +416,14 @@ func (p *PolygonProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
 	}
 }
 
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *PolygonProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
+
 // currencyPairToPolygonPair receives a currency pair and returns a polygon
 // ticker symbol i.e: EUR/USD
 func currencyPairToPolygonPair(cp types.CurrencyPair) string {
@@ -32,7 +32,7 @@ func TestPolygonProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "EUR", Quote: "USD"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "EUR", Quote: "USD"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		require.Equal(t, lastPrice, prices["EURUSD"].Price)
@@ -57,6 +57,7 @@ func TestPolygonProvider_GetTickerPrices(t *testing.T) {
 
 		p.tickers = tickerMap
 		prices, err := p.GetTickerPrices(
+			context.TODO(),
 			types.CurrencyPair{Base: "EUR", Quote: "USD"},
 			types.CurrencyPair{Base: "JPY", Quote: "USD"},
 		)
@@ -69,7 +70,7 @@ func TestPolygonProvider_GetTickerPrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
-		prices, err := p.GetTickerPrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.Error(t, err)
 		require.Equal(t, "polygon has no ticker data for requested pairs: [FOOBAR]", err.Error())
 		require.Nil(t, prices)
@@ -100,7 +101,7 @@ func TestPolygonProvider_GetCandlePrices(t *testing.T) {
 
 		p.setCandlePair(data)
 
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "EUR", Quote: "USD"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "EUR", Quote: "USD"})
 		require.NoError(t, err)
 		require.Len(t, prices, 1)
 		priceDec, _ := sdk.NewDecFromStr(fmt.Sprintf("%f", price))
@@ -112,7 +113,7 @@ func TestPolygonProvider_GetCandlePrices(t *testing.T) {
 	})
 
 	t.Run("invalid_request_invalid_candle", func(t *testing.T) {
-		prices, err := p.GetCandlePrices(types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		prices, err := p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
 		require.EqualError(t, err, "polygon has no candle data for requested pairs: [FOOBAR]")
 		require.Nil(t, prices)
 	})
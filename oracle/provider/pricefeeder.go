@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	priceFeederRestURL     = "http://localhost:7171"
+	priceFeederPricesPath  = "/api/v1/prices"
+	priceFeederQuoteSymbol = "USD"
+)
+
+var (
+	_ Provider = (*PriceFeederProvider)(nil)
+
+	// derivedProviders defines the providers reporting prices aggregated by
+	// another price feeder rather than the prices of a market.
+	derivedProviders = map[Name]struct{}{
+		ProviderPriceFeeder: {},
+	}
+)
+
+type (
+	// PriceFeederProvider defines an Oracle provider reading the aggregated
+	// prices of another price-feeder instance from its prices endpoint, so that
+	// a feeder can fall back on an upstream feeder. The upstream prices are
+	// denominated in USD and carry no volume.
+	//
+	// Its prices are derived from other providers, so they're only used for the
+	// assets also priced by a provider of the feeder itself.
+	PriceFeederProvider struct {
+		endpoints Endpoint
+		client    *http.Client
+	}
+
+	// PriceFeederPricesResponse defines the response structure of the prices
+	// endpoint of a price-feeder.
+	PriceFeederPricesResponse struct {
+		Prices    map[string]sdk.Dec `json:"prices"`
+		Timestamp time.Time          `json:"timestamp"`
+	}
+)
+
+// NewPriceFeederProvider creates a new PriceFeederProvider.
+func NewPriceFeederProvider(endpoint Endpoint) *PriceFeederProvider {
+	if endpoint.Name != ProviderPriceFeeder {
+		endpoint = Endpoint{
+			Name: ProviderPriceFeeder,
+			Rest: priceFeederRestURL,
+		}
+	}
+	return &PriceFeederProvider{
+		endpoints: endpoint,
+		client:    endpoint.httpClient(newDefaultHTTPClient()),
+	}
+}
+
+// IsDerived returns true if the provider reports prices derived from other
+// providers, which are never used as the only source of an asset's price.
+func (n Name) IsDerived() bool {
+	_, ok := derivedProviders[n]
+	return ok
+}
+
+// StartConnections performs a no-op since the provider does not use websockets.
+func (p *PriceFeederProvider) StartConnections() {}
+
+// Describe returns the capabilities of the provider.
+func (p *PriceFeederProvider) Describe() Description {
+	return describe(p.endpoints, true)
+}
+
+// SubscribeCurrencyPairs performs a no-op since the provider does not use
+// websockets.
+func (p *PriceFeederProvider) SubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// UnsubscribeCurrencyPairs performs a no-op since the provider does not use
+// websockets.
+func (p *PriceFeederProvider) UnsubscribeCurrencyPairs(...types.CurrencyPair) {}
+
+// GetSubscribedPairs returns nil since the provider fetches the prices of the
+// requested pairs on demand.
+func (p *PriceFeederProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	return nil
+}
+
+// GetTickerPrices returns the upstream prices of the requested pairs, with a
+// zero volume and the time the upstream last computed its prices.
+func (p *PriceFeederProvider) GetTickerPrices(
+	ctx context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string]types.TickerPrice, error) {
+	prices, err := p.getPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+	for _, cp := range pairs {
+		price, ok := prices.price(cp)
+		if !ok {
+			return nil, fmt.Errorf(types.ErrMissingExchangeRate.Error(), cp.String())
+		}
+		tickerPrices[cp.String()] = types.TickerPrice{
+			Price:     price,
+			Volume:    sdk.ZeroDec(),
+			TimeStamp: prices.Timestamp.UnixMilli(),
+		}
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns a single candle of the upstream price of each
+// requested pair, timestamped at the time the upstream last computed its
+// prices.
+func (p *PriceFeederProvider) GetCandlePrices(
+	ctx context.Context,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	prices, err := p.getPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make(map[string][]types.CandlePrice, len(pairs))
+	for _, cp := range pairs {
+		price, ok := prices.price(cp)
+		if !ok {
+			continue
+		}
+		candles[cp.String()] = []types.CandlePrice{{
+			Price:     price,
+			Volume:    sdk.ZeroDec(),
+			TimeStamp: prices.Timestamp.UnixMilli(),
+		}}
+	}
+	return candles, nil
+}
+
+// GetAvailablePairs returns the USD pairs of the assets priced by the
+// upstream.
+func (p *PriceFeederProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	prices, err := p.getPrices(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	availablePairs := make(map[string]struct{}, len(prices.Prices))
+	for base := range prices.Prices {
+		cp := types.CurrencyPair{Base: base, Quote: priceFeederQuoteSymbol}
+		availablePairs[strings.ToUpper(cp.String())] = struct{}{}
+	}
+	return availablePairs, nil
+}
+
+// getPrices fetches the prices of the upstream, returning an error if they
+// are older than the endpoint's ticker max age.
+func (p *PriceFeederProvider) getPrices(ctx context.Context) (PriceFeederPricesResponse, error) {
+	var pricesResp PriceFeederPricesResponse
+
+	resp, err := httpGetWithContext(ctx, p.client, p.endpoints.Rest+priceFeederPricesPath)
+	if err != nil {
+		return pricesResp, fmt.Errorf("failed to make price feeder request: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkHTTPStatus(resp); err != nil {
+		return pricesResp, err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&pricesResp); err != nil {
+		return pricesResp, fmt.Errorf("failed to unmarshal price feeder response body: %w", err)
+	}
+	if isStale(pricesResp.Timestamp.UnixMilli(), p.endpoints.tickerMaxAge()) {
+		return pricesResp, fmt.Errorf(
+			"price feeder prices are stale, last computed at %s",
+			pricesResp.Timestamp.Format(time.RFC3339),
+		)
+	}
+	return pricesResp, nil
+}
+
+// price returns the upstream price of the pair, which must be quoted in USD.
+func (r PriceFeederPricesResponse) price(cp types.CurrencyPair) (sdk.Dec, bool) {
+	if !strings.EqualFold(cp.Quote, priceFeederQuoteSymbol) {
+		return sdk.Dec{}, false
+	}
+	price, ok := r.Prices[strings.ToUpper(cp.Base)]
+	if !ok || price.IsNil() || !price.IsPositive() {
+		return sdk.Dec{}, false
+	}
+	return price, true
+}
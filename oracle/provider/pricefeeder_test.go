@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func newPriceFeederTestServer(t *testing.T, timestamp time.Time) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, priceFeederPricesPath, r.URL.Path)
+		fmt.Fprintf(w, `{"prices":{"ATOM":"10.5","OJO":"0.25"},"timestamp":%q}`, timestamp.Format(time.RFC3339Nano))
+	}))
+}
+
+func TestPriceFeederProvider_GetTickerPrices(t *testing.T) {
+	timestamp := time.Now().Add(-10 * time.Second)
+	server := newPriceFeederTestServer(t, timestamp)
+	defer server.Close()
+
+	p := NewPriceFeederProvider(Endpoint{Name: ProviderPriceFeeder, Rest: server.URL})
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+
+	prices, err := p.GetTickerPrices(context.TODO(), atomUSD)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("10.5"), prices["ATOMUSD"].Price)
+	require.True(t, prices["ATOMUSD"].Volume.IsZero())
+	require.Equal(t, timestamp.UnixMilli(), prices["ATOMUSD"].TimeStamp)
+
+	candles, err := p.GetCandlePrices(context.TODO(), atomUSD)
+	require.NoError(t, err)
+	require.Len(t, candles["ATOMUSD"], 1)
+	require.Equal(t, sdk.MustNewDecFromStr("10.5"), candles["ATOMUSD"][0].Price)
+
+	// the upstream prices are denominated in USD
+	_, err = p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+	require.EqualError(t, err, "missing exchange rate for ATOMUSDT")
+}
+
+func TestPriceFeederProvider_Stale(t *testing.T) {
+	server := newPriceFeederTestServer(t, time.Now().Add(-time.Minute))
+	defer server.Close()
+
+	p := NewPriceFeederProvider(Endpoint{Name: ProviderPriceFeeder, Rest: server.URL, TickerMaxAge: 30 * time.Second})
+	_, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USD"})
+	require.ErrorContains(t, err, "price feeder prices are stale")
+
+	// upstreams not reporting the time of their prices are stale
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"prices":{"ATOM":"10.5"}}`)
+	}))
+	defer server.Close()
+
+	p = NewPriceFeederProvider(Endpoint{Name: ProviderPriceFeeder, Rest: server.URL})
+	_, err = p.GetCandlePrices(context.TODO(), types.CurrencyPair{Base: "ATOM", Quote: "USD"})
+	require.ErrorContains(t, err, "price feeder prices are stale")
+}
+
+func TestPriceFeederProvider_GetAvailablePairs(t *testing.T) {
+	server := newPriceFeederTestServer(t, time.Now())
+	defer server.Close()
+
+	p := NewPriceFeederProvider(Endpoint{Name: ProviderPriceFeeder, Rest: server.URL})
+	pairs, err := p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"ATOMUSD": {}, "OJOUSD": {}}, pairs)
+
+	require.True(t, ProviderPriceFeeder.IsDerived())
+	require.False(t, ProviderBinance.IsDerived())
+}
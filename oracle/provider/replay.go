@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+var _ AsOfCandleSource = (*CandleReplay)(nil)
+
+type (
+	// AsOfCandleSource defines a source of the candles a provider held at a
+	// past time, ex. to backtest an aggregation change against historical
+	// data. Live providers only hold their latest candles and don't implement
+	// it.
+	AsOfCandleSource interface {
+		// GetCandlePricesAsOf returns the candles of the pairs as they were
+		// at the given time, keyed by currency pair symbol and sorted oldest
+		// first.
+		GetCandlePricesAsOf(context.Context, time.Time, ...types.CurrencyPair) (map[string][]types.CandlePrice, error)
+	}
+
+	// CandleReplay replays recorded candles, keyed by currency pair symbol.
+	// The candles as of a given time are the recorded candles a live provider
+	// would have retained at that time: those of the providerCandlePeriod up
+	// to it. Queries are deterministic, since they don't depend on the clock.
+	CandleReplay struct {
+		candles map[string][]types.CandlePrice
+	}
+
+	// RecordedCandle defines a candle recorded from a provider, as loaded by
+	// LoadCandleReplays.
+	RecordedCandle struct {
+		Provider  Name    `json:"provider"`
+		Symbol    string  `json:"symbol"`
+		Price     sdk.Dec `json:"price"`
+		Volume    sdk.Dec `json:"volume"`
+		TimeStamp int64   `json:"timestamp"`
+	}
+)
+
+// NewCandleReplay creates a CandleReplay of the recorded candles, keyed by
+// currency pair symbol, ex. ATOMUSDT.
+func NewCandleReplay(candles map[string][]types.CandlePrice) *CandleReplay {
+	sorted := make(map[string][]types.CandlePrice, len(candles))
+	for symbol, pairCandles := range candles {
+		sorted[symbol] = append([]types.CandlePrice{}, pairCandles...)
+		sort.SliceStable(sorted[symbol], func(i, j int) bool {
+			return sorted[symbol][i].TimeStamp < sorted[symbol][j].TimeStamp
+		})
+	}
+	return &CandleReplay{candles: sorted}
+}
+
+// GetCandlePricesAsOf returns the recorded candles of the pairs timestamped
+// within the providerCandlePeriod up to the given time, oldest first. It
+// errors if a pair has no candles at that time.
+func (r *CandleReplay) GetCandlePricesAsOf(
+	ctx context.Context,
+	asOf time.Time,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	end := asOf.UnixMilli()
+	start := asOf.Add(-providerCandlePeriod).UnixMilli()
+
+	candles := make(map[string][]types.CandlePrice, len(pairs))
+	for _, cp := range pairs {
+		recorded := r.candles[cp.String()]
+
+		// the first candle after the start, and the first after the end
+		from := sort.Search(len(recorded), func(i int) bool { return recorded[i].TimeStamp > start })
+		to := sort.Search(len(recorded), func(i int) bool { return recorded[i].TimeStamp > end })
+		if from == to {
+			return nil, fmt.Errorf("no recorded candles of %s as of %s", cp, asOf.UTC().Format(time.RFC3339))
+		}
+		candles[cp.String()] = append([]types.CandlePrice{}, recorded[from:to]...)
+	}
+	return candles, nil
+}
+
+// GetCandlePricesAsOf returns the candles of the pairs as they were at the
+// given time from the source, which errors with types.ErrAsOfUnsupported
+// unless it's an AsOfCandleSource, like a CandleReplay. Live providers only
+// hold their latest candles, so they always error.
+func GetCandlePricesAsOf(
+	ctx context.Context,
+	source interface{},
+	asOf time.Time,
+	pairs ...types.CurrencyPair,
+) (map[string][]types.CandlePrice, error) {
+	asOfSource, ok := source.(AsOfCandleSource)
+	if !ok {
+		if p, ok := source.(Provider); ok {
+			return nil, types.ErrAsOfUnsupported.Wrapf("provider %s", p.Describe().Name)
+		}
+		return nil, types.ErrAsOfUnsupported
+	}
+	return asOfSource.GetCandlePricesAsOf(ctx, asOf, pairs...)
+}
+
+// LoadCandleReplays reads a stream of recorded candles, one JSON object per
+// line, ex.
+//
+//	{"provider":"binance","symbol":"ATOMUSDT","price":"10.1","volume":"250","timestamp":1709647380000}
+//
+// and returns a CandleReplay of the candles of each provider.
+func LoadCandleReplays(r io.Reader) (map[Name]AsOfCandleSource, error) {
+	recorded := make(map[Name]map[string][]types.CandlePrice)
+
+	decoder := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var candle RecordedCandle
+		if err := decoder.Decode(&candle); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid recorded candle %d: %w", line, err)
+		}
+		if len(candle.Provider) == 0 || len(candle.Symbol) == 0 || candle.Price.IsNil() || candle.Volume.IsNil() {
+			return nil, fmt.Errorf("invalid recorded candle %d: provider, symbol, price and volume are required", line)
+		}
+
+		if _, ok := recorded[candle.Provider]; !ok {
+			recorded[candle.Provider] = make(map[string][]types.CandlePrice)
+		}
+		recorded[candle.Provider][candle.Symbol] = append(recorded[candle.Provider][candle.Symbol], types.CandlePrice{
+			Price:     candle.Price,
+			Volume:    candle.Volume,
+			TimeStamp: candle.TimeStamp,
+		})
+	}
+
+	replays := make(map[Name]AsOfCandleSource, len(recorded))
+	for providerName, candles := range recorded {
+		replays[providerName] = NewCandleReplay(candles)
+	}
+	return replays, nil
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestCandleReplay_GetCandlePricesAsOf(t *testing.T) {
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	start := time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC)
+
+	candle := func(minutes int, price string) types.CandlePrice {
+		return types.CandlePrice{
+			Price:     sdk.MustNewDecFromStr(price),
+			Volume:    sdk.OneDec(),
+			TimeStamp: start.Add(time.Duration(minutes) * time.Minute).UnixMilli(),
+		}
+	}
+
+	// the recorded candles don't need to be sorted
+	replay := NewCandleReplay(map[string][]types.CandlePrice{
+		"ATOMUSDT": {candle(12, "10.3"), candle(0, "10.0"), candle(3, "10.1"), candle(15, "10.4")},
+	})
+
+	candles, err := replay.GetCandlePricesAsOf(context.Background(), start.Add(12*time.Minute), atomUSDT)
+	require.NoError(t, err)
+	require.Equal(t, []types.CandlePrice{candle(3, "10.1"), candle(12, "10.3")}, candles["ATOMUSDT"])
+
+	// the candle timestamped at the queried time is included
+	candles, err = replay.GetCandlePricesAsOf(context.Background(), start, atomUSDT)
+	require.NoError(t, err)
+	require.Equal(t, []types.CandlePrice{candle(0, "10.0")}, candles["ATOMUSDT"])
+
+	_, err = replay.GetCandlePricesAsOf(context.Background(), start.Add(-time.Minute), atomUSDT)
+	require.Error(t, err)
+
+	_, err = replay.GetCandlePricesAsOf(context.Background(), start, types.CurrencyPair{Base: "OJO", Quote: "USDT"})
+	require.Error(t, err)
+}
+
+func TestGetCandlePricesAsOf(t *testing.T) {
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	asOf := time.Date(2024, 3, 5, 14, 3, 0, 0, time.UTC)
+
+	replay := NewCandleReplay(map[string][]types.CandlePrice{
+		"ATOMUSDT": {{Price: sdk.OneDec(), Volume: sdk.OneDec(), TimeStamp: asOf.UnixMilli()}},
+	})
+	candles, err := GetCandlePricesAsOf(context.Background(), replay, asOf, atomUSDT)
+	require.NoError(t, err)
+	require.Len(t, candles["ATOMUSDT"], 1)
+
+	// live providers only hold their latest candles
+	_, err = GetCandlePricesAsOf(context.Background(), NewMockProvider(), asOf, atomUSDT)
+	require.True(t, errors.Is(err, types.ErrAsOfUnsupported))
+}
+
+func TestLoadCandleReplays(t *testing.T) {
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	asOf := time.Date(2024, 3, 5, 14, 3, 0, 0, time.UTC)
+
+	replays, err := LoadCandleReplays(strings.NewReader(
+		`{"provider":"binance","symbol":"ATOMUSDT","price":"10.1","volume":"250","timestamp":1709647380000}
+{"provider":"binance","symbol":"ATOMUSDT","price":"10.0","volume":"100","timestamp":1709647320000}
+{"provider":"kraken","symbol":"ATOMUSDT","price":"10.2","volume":"50","timestamp":1709647380000}
+`))
+	require.NoError(t, err)
+	require.Len(t, replays, 2)
+
+	candles, err := replays[ProviderBinance].GetCandlePricesAsOf(context.Background(), asOf, atomUSDT)
+	require.NoError(t, err)
+	require.Equal(t, []types.CandlePrice{
+		{Price: sdk.MustNewDecFromStr("10.0"), Volume: sdk.NewDec(100), TimeStamp: 1709647320000},
+		{Price: sdk.MustNewDecFromStr("10.1"), Volume: sdk.NewDec(250), TimeStamp: 1709647380000},
+	}, candles["ATOMUSDT"])
+
+	_, err = LoadCandleReplays(strings.NewReader(`{"provider":"binance","symbol":"ATOMUSDT"}`))
+	require.Error(t, err)
+
+	_, err = LoadCandleReplays(strings.NewReader(`{"provider":`))
+	require.Error(t, err)
+}
@@ -0,0 +1,446 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/ojo/util/decmath"
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	// restPollInterval is how often the rest provider polls the tickers of
+	// its pairs.
+	restPollInterval = 15 * time.Second
+
+	// restMaxSecondsTimestamp is the largest numeric timestamp read as
+	// seconds rather than milliseconds: as milliseconds, it's in 2001.
+	restMaxSecondsTimestamp = 1e12
+)
+
+var _ Provider = (*RestProvider)(nil)
+
+type (
+	// RestProvider defines a generic Oracle provider polling the tickers of a
+	// rest API, so that a long-tail venue can be added from the config
+	// without a dedicated provider. The endpoint's TickerPath is requested for
+	// each pair, or once for the pairs sharing it, and the price, volume and
+	// timestamp of the pair's ticker are read from the JSON fields at the
+	// endpoint's PriceField, VolumeField and TimestampField paths. It reports
+	// no candles.
+	RestProvider struct {
+		ctx             context.Context
+		logger          zerolog.Logger
+		mtx             sync.RWMutex
+		endpoints       Endpoint
+		client          *http.Client
+		tickers         map[string]types.TickerPrice  // Symbol => TickerPrice
+		subscribedPairs map[string]types.CurrencyPair // Symbol => types.CurrencyPair
+	}
+
+	// restTickerFields defines the JSON paths of the fields of a pair's
+	// ticker. The volume and timestamp paths are optional.
+	restTickerFields struct {
+		price     JSONPath
+		volume    *JSONPath
+		timestamp *JSONPath
+	}
+)
+
+// NewRestProvider creates a new RestProvider. Since a generic rest API has no
+// known listing of its pairs, the pairs are subscribed to without confirming
+// their availability, and the pairs without a ticker are logged when polled.
+func NewRestProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*RestProvider, error) {
+	if err := ValidateRestFields(endpoints); err != nil {
+		return nil, err
+	}
+
+	provider := &RestProvider{
+		ctx:             ctx,
+		logger:          logger.With().Str("provider", string(ProviderRest)).Logger(),
+		endpoints:       endpoints,
+		client:          endpoints.httpClient(newDefaultHTTPClient()),
+		tickers:         map[string]types.TickerPrice{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+	provider.setSubscribedPairs(pairs...)
+
+	return provider, nil
+}
+
+// ValidateRestFields returns an error if the endpoint of the rest provider
+// has no ticker path or price field, or one of its field paths is invalid.
+func ValidateRestFields(endpoint Endpoint) error {
+	if endpoint.Name != ProviderRest {
+		return nil
+	}
+	if len(endpoint.TickerPath) == 0 {
+		return fmt.Errorf("the rest provider requires a ticker_path")
+	}
+	if len(endpoint.PriceField) == 0 {
+		return fmt.Errorf("the rest provider requires a price_field")
+	}
+
+	// the fields are validated with a pair so that their placeholders are
+	// replaced like they are when polling
+	_, err := endpoint.restTickerFields(types.CurrencyPair{Base: "ATOM", Quote: "USDT"})
+	return err
+}
+
+// restTickerFields returns the JSON paths of the pair's ticker fields.
+func (e Endpoint) restTickerFields(cp types.CurrencyPair) (restTickerFields, error) {
+	var fields restTickerFields
+
+	price, err := ParseJSONPath(expandRestPlaceholders(e.PriceField, cp))
+	if err != nil {
+		return fields, fmt.Errorf("invalid rest price_field: %w", err)
+	}
+	fields.price = price
+
+	if len(e.VolumeField) > 0 {
+		volume, err := ParseJSONPath(expandRestPlaceholders(e.VolumeField, cp))
+		if err != nil {
+			return fields, fmt.Errorf("invalid rest volume_field: %w", err)
+		}
+		fields.volume = &volume
+	}
+	if len(e.TimestampField) > 0 {
+		timestamp, err := ParseJSONPath(expandRestPlaceholders(e.TimestampField, cp))
+		if err != nil {
+			return fields, fmt.Errorf("invalid rest timestamp_field: %w", err)
+		}
+		fields.timestamp = &timestamp
+	}
+	return fields, nil
+}
+
+// expandRestPlaceholders replaces the {base}, {quote} and {symbol}
+// placeholders of a ticker path or field path by the pair's.
+func expandRestPlaceholders(s string, cp types.CurrencyPair) string {
+	return strings.NewReplacer(
+		"{base}", cp.Base,
+		"{quote}", cp.Quote,
+		"{symbol}", cp.String(),
+	).Replace(s)
+}
+
+// StartConnections starts polling the tickers of the subscribed pairs.
+func (p *RestProvider) StartConnections() {
+	go p.pollTickers()
+}
+
+// Describe returns the capabilities of the provider.
+func (p *RestProvider) Describe() Description {
+	return describe(p.endpoints, false)
+}
+
+// SubscribeCurrencyPairs adds the new currency pairs to the pairs polled by
+// the provider.
+func (p *RestProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.setSubscribedPairs(cps...)
+}
+
+// UnsubscribeCurrencyPairs stops polling the tickers of the currency pairs and
+// removes them.
+func (p *RestProvider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, cp := range cps {
+		delete(p.subscribedPairs, cp.String())
+		delete(p.tickers, cp.String())
+	}
+}
+
+// GetTickerPrices returns the latest polled tickers of the provided pairs.
+func (p *RestProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+
+	tickerErrs := 0
+	for _, cp := range pairs {
+		price, err := p.getTickerPrice(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
+			tickerErrs++
+			continue
+		}
+		tickerPrices[cp.String()] = price
+	}
+
+	if tickerErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoTickers.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns no candles since the provider only polls tickers,
+// leaving its pairs to be priced from its tickers.
+func (p *RestProvider) GetCandlePrices(context.Context, ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	return map[string][]types.CandlePrice{}, nil
+}
+
+// GetAvailablePairs returns the subscribed pairs, since a generic rest API has
+// no known listing of its pairs.
+func (p *RestProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	availablePairs := make(map[string]struct{}, len(p.subscribedPairs))
+	for symbol := range p.subscribedPairs {
+		availablePairs[symbol] = struct{}{}
+	}
+	return availablePairs, nil
+}
+
+func (p *RestProvider) getTickerPrice(key string) (types.TickerPrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	ticker, ok := p.tickers[key]
+	if !ok {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+	if isStale(ticker.TimeStamp, p.endpoints.tickerMaxAge()) {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerStale.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	return ticker, nil
+}
+
+// pollTickers updates the tickers of the subscribed pairs every
+// restPollInterval until the provider's context is done.
+func (p *RestProvider) pollTickers() {
+	pollTicker := time.NewTicker(restPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		p.updateTickers()
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-pollTicker.C:
+			continue
+		}
+	}
+}
+
+// updateTickers requests the ticker path of each subscribed pair, once for
+// the pairs sharing the same path, and stores the tickers read from the
+// responses.
+func (p *RestProvider) updateTickers() {
+	p.mtx.RLock()
+	pairs := types.MapPairsToSlice(p.subscribedPairs)
+	p.mtx.RUnlock()
+
+	responses := make(map[string]interface{})
+	for _, cp := range pairs {
+		path := expandRestPlaceholders(p.endpoints.TickerPath, cp)
+		doc, ok := responses[path]
+		if !ok {
+			var err error
+			doc, err = p.get(p.ctx, path)
+			if err != nil {
+				TelemetryFailure(ProviderRest, MessageTypeTicker)
+				p.logger.Error().Err(err).Str("pair", cp.String()).Msg("failed to get rest ticker")
+				continue
+			}
+			responses[path] = doc
+		}
+
+		if err := p.setTickerPrice(cp, doc); err != nil {
+			p.logger.Warn().Err(err).Str("pair", cp.String()).Msg("failed to read rest ticker")
+			continue
+		}
+		telemetryRestPoll(ProviderRest, MessageTypeTicker)
+	}
+}
+
+// setTickerPrice reads the ticker of the pair from the response and stores
+// it, timestamped with the time it was polled at when the endpoint has no
+// timestamp field.
+func (p *RestProvider) setTickerPrice(cp types.CurrencyPair, doc interface{}) error {
+	ticker, err := p.endpoints.readRestTicker(cp, doc, time.Now())
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.tickers[cp.String()] = ticker
+	return nil
+}
+
+// readRestTicker reads the ticker of the pair from the response decoded with
+// json.Decoder.UseNumber. Tickers without a volume field have no volume.
+func (e Endpoint) readRestTicker(cp types.CurrencyPair, doc interface{}, now time.Time) (types.TickerPrice, error) {
+	fields, err := e.restTickerFields(cp)
+	if err != nil {
+		return types.TickerPrice{}, err
+	}
+
+	price, err := readRestDec(doc, fields.price)
+	if err != nil {
+		return types.TickerPrice{}, err
+	}
+	if !price.IsPositive() {
+		telemetryParseError(ProviderRest, ParseErrorBadDecimal)
+		return types.TickerPrice{}, fmt.Errorf("invalid rest price (%s) for %s", price, cp)
+	}
+
+	ticker := types.TickerPrice{
+		Price:     price,
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: now.UnixMilli(),
+	}
+	if fields.volume != nil {
+		if ticker.Volume, err = readRestDec(doc, *fields.volume); err != nil {
+			return types.TickerPrice{}, err
+		}
+	}
+	if fields.timestamp != nil {
+		if ticker.TimeStamp, err = readRestTimestamp(doc, *fields.timestamp); err != nil {
+			return types.TickerPrice{}, err
+		}
+	}
+	return ticker, nil
+}
+
+// readRestDec reads the number, or numeric string, at the path.
+func readRestDec(doc interface{}, path JSONPath) (sdk.Dec, error) {
+	value, ok := path.Lookup(doc)
+	if !ok {
+		return sdk.Dec{}, fmt.Errorf("no value at %s", path)
+	}
+
+	s, ok := restNumber(value)
+	if !ok {
+		return sdk.Dec{}, fmt.Errorf("value at %s isn't a number: %v", path, value)
+	}
+	if dec, err := sdk.NewDecFromStr(s); err == nil {
+		return dec, nil
+	}
+
+	// numbers in scientific notation, ex. 1.5e-7
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		telemetryParseError(ProviderRest, ParseErrorBadDecimal)
+		return sdk.Dec{}, fmt.Errorf("value at %s isn't a number: %s", path, s)
+	}
+	return decmath.NewDecFromFloat(f)
+}
+
+// readRestTimestamp reads the timestamp at the path in milliseconds. Numeric
+// timestamps are in seconds or milliseconds, told apart by their magnitude,
+// and string timestamps are RFC 3339 times.
+func readRestTimestamp(doc interface{}, path JSONPath) (int64, error) {
+	value, ok := path.Lookup(doc)
+	if !ok {
+		return 0, fmt.Errorf("no value at %s", path)
+	}
+
+	s, ok := restNumber(value)
+	if !ok {
+		return 0, fmt.Errorf("value at %s isn't a timestamp: %v", path, value)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		if f < restMaxSecondsTimestamp {
+			return int64(math.Round(f * 1000)), nil
+		}
+		return int64(f), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, fmt.Errorf("value at %s isn't a timestamp: %s", path, s)
+	}
+	return t.UnixMilli(), nil
+}
+
+// restNumber returns the value as a string if it's a number or a string.
+func restNumber(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), true
+	case string:
+		return v, true
+	default:
+		return "", false
+	}
+}
+
+// get requests the path from the rest API with the endpoint's headers and
+// decodes its JSON response, keeping its numbers as json.Number.
+func (p *RestProvider) get(ctx context.Context, path string) (interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoints.Rest+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range p.endpoints.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make rest request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkHTTPStatus(resp); err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rest response body: %w", err)
+	}
+	return doc, nil
+}
+
+// setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
+func (p *RestProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
+	for _, cp := range cps {
+		p.subscribedPairs[cp.String()] = cp
+	}
+}
+
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *RestProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
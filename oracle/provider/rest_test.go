@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestRestProvider_GetTickerPrices(t *testing.T) {
+	var requests int32
+	updatedAt := time.Now().Add(-time.Second).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		require.Equal(t, "rest-key", r.Header.Get("X-Api-Key"))
+		require.Equal(t, "/api/tickers", r.URL.Path)
+		fmt.Fprintf(w, `{"data": [
+			{"pair": "ATOM_USDT", "last": "10.5", "volume": 1200, "ts": %d},
+			{"pair": "OJO_USDT", "last": 0.05, "volume": "300", "ts": %d}
+		]}`, updatedAt.Unix(), updatedAt.UnixMilli())
+	}))
+	defer server.Close()
+
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	ojoUSDT := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+	junoUSDT := types.CurrencyPair{Base: "JUNO", Quote: "USDT"}
+	p, err := NewRestProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{
+			Name:           ProviderRest,
+			Rest:           server.URL,
+			Headers:        map[string]string{"X-Api-Key": "rest-key"},
+			TickerPath:     "/api/tickers",
+			PriceField:     "data[pair={base}_{quote}].last",
+			VolumeField:    "data[pair={base}_{quote}].volume",
+			TimestampField: "data[pair={base}_{quote}].ts",
+		},
+		atomUSDT, ojoUSDT, junoUSDT,
+	)
+	require.NoError(t, err)
+
+	// the pairs sharing the ticker path are read from a single response
+	p.updateTickers()
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	prices, err := p.GetTickerPrices(context.TODO(), atomUSDT, ojoUSDT, junoUSDT)
+	require.NoError(t, err)
+	require.Equal(t, map[string]types.TickerPrice{
+		"ATOMUSDT": {
+			Price:     sdk.MustNewDecFromStr("10.5"),
+			Volume:    sdk.MustNewDecFromStr("1200"),
+			TimeStamp: updatedAt.UnixMilli(),
+		},
+		"OJOUSDT": {
+			Price:     sdk.MustNewDecFromStr("0.05"),
+			Volume:    sdk.MustNewDecFromStr("300"),
+			TimeStamp: updatedAt.UnixMilli(),
+		},
+	}, prices)
+}
+
+func TestEndpoint_ReadRestTicker(t *testing.T) {
+	now := time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC)
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	endpoint := Endpoint{Name: ProviderRest, TickerPath: "/ticker/{symbol}", PriceField: "[0].price"}
+
+	// tickers without a volume or timestamp field have no volume and are
+	// timestamped at the time of the poll
+	ticker, err := endpoint.readRestTicker(atomUSDT, []interface{}{map[string]interface{}{"price": "1.5e-7"}}, now)
+	require.NoError(t, err)
+	require.Equal(t, types.TickerPrice{
+		Price:     sdk.MustNewDecFromStr("0.00000015"),
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: now.UnixMilli(),
+	}, ticker)
+
+	endpoint.TimestampField = "[0].time"
+	ticker, err = endpoint.readRestTicker(atomUSDT, []interface{}{map[string]interface{}{
+		"price": "1.5",
+		"time":  "2024-03-05T13:59:30Z",
+	}}, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-30*time.Second).UnixMilli(), ticker.TimeStamp)
+
+	_, err = endpoint.readRestTicker(atomUSDT, []interface{}{map[string]interface{}{"price": "0"}}, now)
+	require.Error(t, err)
+	_, err = endpoint.readRestTicker(atomUSDT, []interface{}{map[string]interface{}{"price": true}}, now)
+	require.Error(t, err)
+}
+
+func TestValidateRestFields(t *testing.T) {
+	require.NoError(t, ValidateRestFields(Endpoint{Name: ProviderCoinGecko}))
+	require.NoError(t, ValidateRestFields(Endpoint{Name: ProviderRest, TickerPath: "/ticker", PriceField: "last"}))
+	require.Error(t, ValidateRestFields(Endpoint{Name: ProviderRest, PriceField: "last"}))
+	require.Error(t, ValidateRestFields(Endpoint{Name: ProviderRest, TickerPath: "/ticker"}))
+	require.Error(t, ValidateRestFields(Endpoint{
+		Name:        ProviderRest,
+		TickerPath:  "/ticker",
+		PriceField:  "last",
+		VolumeField: "data[{symbol}",
+	}))
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// ParseMaxSpread parses a maximum bid-ask spread percentage, which must be
+// positive.
+func ParseMaxSpread(maxSpread string) (sdk.Dec, error) {
+	spread, err := sdk.NewDecFromStr(maxSpread)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("max_spread must be numeric: %w", err)
+	}
+	if !spread.IsPositive() {
+		return sdk.Dec{}, fmt.Errorf("max_spread must be positive")
+	}
+	return spread, nil
+}
+
+// checkSpread returns an error if the bid-ask spread of the ticker is wider
+// than the endpoint's maximum spread. Tickers without a bid and ask are always
+// accepted.
+func (e Endpoint) checkSpread(symbol string, tp types.TickerPrice) error {
+	if len(e.MaxSpread) == 0 {
+		return nil
+	}
+	spread, ok := tp.Spread()
+	if !ok {
+		return nil
+	}
+
+	maxSpread, err := ParseMaxSpread(e.MaxSpread)
+	if err != nil {
+		return err
+	}
+	if spread.GT(maxSpread) {
+		TelemetrySpreadRejected(e.Name)
+		return fmt.Errorf(
+			types.ErrTickerSpread.Error(),
+			e.Name,
+			symbol,
+			spread.String(),
+			maxSpread.String(),
+		)
+	}
+	return nil
+}
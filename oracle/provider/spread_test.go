@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestEndpoint_CheckSpread(t *testing.T) {
+	tight, err := types.NewTickerPrice("coinbase", "ATOMUSD", "10", "1000")
+	require.NoError(t, err)
+	tight, err = tight.WithBidAsk("coinbase", "ATOMUSD", "9.99", "10.01")
+	require.NoError(t, err)
+
+	wide, err := tight.WithBidAsk("coinbase", "ATOMUSD", "9", "11")
+	require.NoError(t, err)
+
+	noBidAsk, err := types.NewTickerPrice("coinbase", "ATOMUSD", "10", "1000")
+	require.NoError(t, err)
+
+	endpoint := Endpoint{Name: ProviderCoinbase, MaxSpread: "1"}
+	require.NoError(t, endpoint.checkSpread("ATOMUSD", tight))
+	require.ErrorContains(t, endpoint.checkSpread("ATOMUSD", wide), "exceeds the maximum")
+	require.NoError(t, endpoint.checkSpread("ATOMUSD", noBidAsk))
+
+	// no maximum spread accepts any spread
+	require.NoError(t, Endpoint{Name: ProviderCoinbase}.checkSpread("ATOMUSD", wide))
+}
+
+func TestParseMaxSpread(t *testing.T) {
+	_, err := ParseMaxSpread("0.5")
+	require.NoError(t, err)
+
+	_, err = ParseMaxSpread("0")
+	require.Error(t, err)
+
+	_, err = ParseMaxSpread("wide")
+	require.Error(t, err)
+}
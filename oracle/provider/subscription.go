@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// subscriptionFormat declares how a provider subscribes to its currency pairs
+// over websocket. Each message built from it is sent over its own websocket
+// connection, so maxPairs also limits the pairs per connection.
+type subscriptionFormat struct {
+	// channels are the channels subscribed to for every pair, ex. "ticker".
+	channels []string
+
+	// perChannel builds a separate message for each channel instead of a
+	// single message listing all of them.
+	perChannel bool
+
+	// maxPairs is the maximum amount of pairs subscribed to by a single
+	// message, or zero for no limit.
+	maxPairs int
+
+	// batchSize is the maximum amount of pairs of a connection subscribed to
+	// by a single message, or zero for no limit. The pairs beyond it are
+	// subscribed to by further messages sent over the same connection,
+	// batchDelay apart.
+	batchSize  int
+	batchDelay time.Duration
+
+	// symbol returns the provider's symbol of a pair. Providers streaming all
+	// of their pairs without subscribing leave it nil, and a single message is
+	// built for them.
+	symbol func(types.CurrencyPair) string
+
+	// message returns the subscription message of the channels and symbols.
+	message func(channels, symbols []string) interface{}
+}
+
+// subscriptionBatch is the subscription of a connection split in several
+// messages, which are sent in order, delay apart.
+type subscriptionBatch struct {
+	msgs  []interface{}
+	delay time.Duration
+}
+
+// build returns the subscription messages of the pairs, batched by maxPairs.
+// An error is returned if the format is invalid or a pair has no symbol or
+// shares its symbol with another pair.
+func (f subscriptionFormat) build(cps ...types.CurrencyPair) ([]interface{}, error) {
+	if f.message == nil {
+		return nil, fmt.Errorf("subscription format has no message")
+	}
+	if f.maxPairs < 0 {
+		return nil, fmt.Errorf("invalid subscription max pairs %d", f.maxPairs)
+	}
+	if f.batchSize < 0 {
+		return nil, fmt.Errorf("invalid subscription batch size %d", f.batchSize)
+	}
+	if f.perChannel && len(f.channels) == 0 {
+		return nil, fmt.Errorf("subscription format has no channels")
+	}
+	if f.symbol == nil {
+		return []interface{}{f.message(f.channels, nil)}, nil
+	}
+
+	symbols := make([]string, len(cps))
+	seen := make(map[string]struct{}, len(cps))
+	for i, cp := range cps {
+		symbol := f.symbol(cp)
+		if len(symbol) == 0 {
+			return nil, fmt.Errorf("no subscription symbol for %s", cp)
+		}
+		if _, ok := seen[symbol]; ok {
+			return nil, fmt.Errorf("duplicate subscription symbol %s", symbol)
+		}
+		seen[symbol] = struct{}{}
+		symbols[i] = symbol
+	}
+
+	batchSize := len(symbols)
+	if f.maxPairs > 0 && f.maxPairs < batchSize {
+		batchSize = f.maxPairs
+	}
+
+	msgs := []interface{}{}
+	for start := 0; start < len(symbols); start += batchSize {
+		end := start + batchSize
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		batch := symbols[start:end]
+
+		if !f.perChannel {
+			msgs = append(msgs, f.connectionMessage(f.channels, batch))
+			continue
+		}
+		for _, channel := range f.channels {
+			msgs = append(msgs, f.connectionMessage([]string{channel}, batch))
+		}
+	}
+	return msgs, nil
+}
+
+// connectionMessage returns the message subscribing a connection to the
+// channels of the symbols, or a subscriptionBatch if there are more symbols
+// than batchSize.
+func (f subscriptionFormat) connectionMessage(channels, symbols []string) interface{} {
+	if f.batchSize == 0 || len(symbols) <= f.batchSize {
+		return f.message(channels, symbols)
+	}
+
+	batch := subscriptionBatch{delay: f.batchDelay}
+	for start := 0; start < len(symbols); start += f.batchSize {
+		end := start + f.batchSize
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		batch.msgs = append(batch.msgs, f.message(channels, symbols[start:end]))
+	}
+	return batch
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+type testSubscriptionMsg struct {
+	Channels []string
+	Symbols  []string
+}
+
+func newTestSubscriptionMsg(channels, symbols []string) interface{} {
+	return testSubscriptionMsg{Channels: channels, Symbols: symbols}
+}
+
+func TestSubscriptionFormat_Build(t *testing.T) {
+	cps := []types.CurrencyPair{
+		{Base: "ATOM", Quote: "USDT"},
+		{Base: "OJO", Quote: "USDT"},
+		{Base: "BTC", Quote: "USDT"},
+	}
+	symbol := func(cp types.CurrencyPair) string {
+		return cp.Base + "-" + cp.Quote
+	}
+
+	t.Run("single message", func(t *testing.T) {
+		format := subscriptionFormat{
+			channels: []string{"ticker", "candle"},
+			symbol:   symbol,
+			message:  newTestSubscriptionMsg,
+		}
+		msgs, err := format.build(cps...)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			testSubscriptionMsg{
+				Channels: []string{"ticker", "candle"},
+				Symbols:  []string{"ATOM-USDT", "OJO-USDT", "BTC-USDT"},
+			},
+		}, msgs)
+	})
+
+	t.Run("batched per channel", func(t *testing.T) {
+		format := subscriptionFormat{
+			channels:   []string{"ticker", "candle"},
+			perChannel: true,
+			maxPairs:   2,
+			symbol:     symbol,
+			message:    newTestSubscriptionMsg,
+		}
+		msgs, err := format.build(cps...)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			testSubscriptionMsg{Channels: []string{"ticker"}, Symbols: []string{"ATOM-USDT", "OJO-USDT"}},
+			testSubscriptionMsg{Channels: []string{"candle"}, Symbols: []string{"ATOM-USDT", "OJO-USDT"}},
+			testSubscriptionMsg{Channels: []string{"ticker"}, Symbols: []string{"BTC-USDT"}},
+			testSubscriptionMsg{Channels: []string{"candle"}, Symbols: []string{"BTC-USDT"}},
+		}, msgs)
+	})
+
+	t.Run("batched over a connection", func(t *testing.T) {
+		format := subscriptionFormat{
+			channels:   []string{"ticker"},
+			batchSize:  2,
+			batchDelay: time.Second,
+			symbol:     symbol,
+			message:    newTestSubscriptionMsg,
+		}
+		msgs, err := format.build(cps...)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			subscriptionBatch{
+				msgs: []interface{}{
+					testSubscriptionMsg{Channels: []string{"ticker"}, Symbols: []string{"ATOM-USDT", "OJO-USDT"}},
+					testSubscriptionMsg{Channels: []string{"ticker"}, Symbols: []string{"BTC-USDT"}},
+				},
+				delay: time.Second,
+			},
+		}, msgs)
+
+		// pairs within the batch size are subscribed to by a single message
+		msgs, err = format.build(cps[:2]...)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			testSubscriptionMsg{Channels: []string{"ticker"}, Symbols: []string{"ATOM-USDT", "OJO-USDT"}},
+		}, msgs)
+	})
+
+	t.Run("no symbol", func(t *testing.T) {
+		format := subscriptionFormat{message: newTestSubscriptionMsg}
+		msgs, err := format.build(cps...)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{testSubscriptionMsg{}}, msgs)
+	})
+
+	t.Run("no pairs", func(t *testing.T) {
+		format := subscriptionFormat{symbol: symbol, message: newTestSubscriptionMsg}
+		msgs, err := format.build()
+		require.NoError(t, err)
+		require.Empty(t, msgs)
+	})
+
+	t.Run("duplicate symbol", func(t *testing.T) {
+		format := subscriptionFormat{
+			symbol:  func(cp types.CurrencyPair) string { return strings.ToUpper(cp.Base) },
+			message: newTestSubscriptionMsg,
+		}
+		_, err := format.build(types.CurrencyPair{Base: "atom", Quote: "USDT"}, types.CurrencyPair{Base: "ATOM", Quote: "USD"})
+		require.ErrorContains(t, err, "duplicate subscription symbol ATOM")
+	})
+
+	t.Run("empty symbol", func(t *testing.T) {
+		format := subscriptionFormat{
+			symbol:  func(types.CurrencyPair) string { return "" },
+			message: newTestSubscriptionMsg,
+		}
+		_, err := format.build(cps...)
+		require.ErrorContains(t, err, "no subscription symbol for ATOMUSDT")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := subscriptionFormat{symbol: symbol}.build(cps...)
+		require.Error(t, err)
+
+		_, err = subscriptionFormat{maxPairs: -1, message: newTestSubscriptionMsg}.build(cps...)
+		require.Error(t, err)
+
+		_, err = subscriptionFormat{batchSize: -1, message: newTestSubscriptionMsg}.build(cps...)
+		require.Error(t, err)
+
+		_, err = subscriptionFormat{perChannel: true, message: newTestSubscriptionMsg}.build(cps...)
+		require.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"github.com/armon/go-metrics"
 	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
@@ -51,6 +52,22 @@ func telemetryWebsocketReconnect(n Name) {
 	)
 }
 
+// telemetryWebsocketSubscribeFailure gives an standard way to add
+// `price_feeder_websocket_subscribe_failure{provider="x"}` metric.
+func telemetryWebsocketSubscribeFailure(n Name) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"websocket",
+			"subscribe",
+			"failure",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+		},
+	)
+}
+
 // telemetryWebsocketSubscribeCurrencyPairs gives an standard way to add
 // `price_feeder_websocket_subscribe_currency_pairs{provider="x"}` metric.
 func telemetryWebsocketSubscribeCurrencyPairs(n Name, incr int) {
@@ -83,6 +100,38 @@ func telemetryWebsocketMessage(n Name, mt MessageType) {
 	)
 }
 
+// telemetryWebsocketBytes gives an standard way to add
+// `price_feeder_websocket_bytes_received{provider="x"}` metric.
+func telemetryWebsocketBytes(n Name, bytes int) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"websocket",
+			"bytes",
+			"received",
+		},
+		float32(bytes),
+		[]metrics.Label{
+			providerLabel(n),
+		},
+	)
+}
+
+// telemetryRestPoll gives an standard way to add
+// `price_feeder_rest_poll{type="x", provider="x"}` metric.
+func telemetryRestPoll(n Name, mt MessageType) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"rest",
+			"poll",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+			messageTypeLabel(mt),
+		},
+	)
+}
+
 // TelemetryFailure gives an standard way to add
 // `price_feeder_failure_provider{type="x", provider="x"}` metric.
 func TelemetryFailure(n Name, mt MessageType) {
@@ -97,3 +146,196 @@ func TelemetryFailure(n Name, mt MessageType) {
 		},
 	)
 }
+
+// TelemetryOutOfBounds gives an standard way to add
+// `price_feeder_failure_out_of_bounds{type="x", provider="x"}` metric.
+func TelemetryOutOfBounds(n Name, mt MessageType) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"failure",
+			"out_of_bounds",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+			messageTypeLabel(mt),
+		},
+	)
+}
+
+// TelemetryUnavailablePairs gives an standard way to set
+// `price_feeder_pairs_unavailable{provider="x"}` gauge.
+func TelemetryUnavailablePairs(n Name, count int) {
+	telemetry.SetGaugeWithLabels(
+		[]string{
+			"pairs",
+			"unavailable",
+		},
+		float32(count),
+		[]metrics.Label{
+			providerLabel(n),
+		},
+	)
+}
+
+// TelemetryObservedPrice gives an standard way to set
+// `price_feeder_observed_price{provider="x", base="x"}` gauge.
+func TelemetryObservedPrice(n Name, base string, price sdk.Dec) {
+	value, err := price.Float64()
+	if err != nil {
+		return
+	}
+	telemetry.SetGaugeWithLabels(
+		[]string{
+			"observed",
+			"price",
+		},
+		float32(value),
+		[]metrics.Label{
+			providerLabel(n),
+			{
+				Name:  "base",
+				Value: base,
+			},
+		},
+	)
+}
+
+// TelemetryProviderBias gives an standard way to set
+// `price_feeder_provider_bias{provider="x", base="x"}` gauge, in percent of
+// the median price.
+func TelemetryProviderBias(n Name, base string, bias sdk.Dec) {
+	value, err := bias.Float64()
+	if err != nil {
+		return
+	}
+	telemetry.SetGaugeWithLabels(
+		[]string{
+			"provider",
+			"bias",
+		},
+		float32(value),
+		[]metrics.Label{
+			providerLabel(n),
+			{
+				Name:  "base",
+				Value: base,
+			},
+		},
+	)
+}
+
+// TelemetryPriceDeviation gives an standard way to set the
+// `price_feeder_price_deviation{base="x", type="x"}` gauge, the standard
+// deviation of the provider prices of an asset, and the
+// `price_feeder_price_deviation_sigma{base="x", type="x"}` gauge, the largest
+// distance of a provider price from their mean in standard deviations.
+func TelemetryPriceDeviation(base string, mt MessageType, deviation, sigma sdk.Dec) {
+	labels := []metrics.Label{
+		{
+			Name:  "base",
+			Value: base,
+		},
+		messageTypeLabel(mt),
+	}
+	if value, err := deviation.Float64(); err == nil {
+		telemetry.SetGaugeWithLabels(
+			[]string{
+				"price",
+				"deviation",
+			},
+			float32(value),
+			labels,
+		)
+	}
+	if value, err := sigma.Float64(); err == nil {
+		telemetry.SetGaugeWithLabels(
+			[]string{
+				"price",
+				"deviation",
+				"sigma",
+			},
+			float32(value),
+			labels,
+		)
+	}
+}
+
+// telemetryCandleGap gives an standard way to add
+// `price_feeder_candle_gap{provider="x"}` metric.
+func telemetryCandleGap(n Name) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"candle",
+			"gap",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+		},
+	)
+}
+
+// telemetryClockSkew gives an standard way to set
+// `price_feeder_clock_skew{provider="x"}` gauge, in milliseconds.
+func telemetryClockSkew(n Name, skew int64) {
+	telemetry.SetGaugeWithLabels(
+		[]string{
+			"clock",
+			"skew",
+		},
+		float32(skew),
+		[]metrics.Label{
+			providerLabel(n),
+		},
+	)
+}
+
+// telemetryTradeGap gives an standard way to add
+// `price_feeder_trade_gap{provider="x"}` metric.
+func telemetryTradeGap(n Name) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"trade",
+			"gap",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+		},
+	)
+}
+
+// TelemetrySpreadRejected gives an standard way to add
+// `price_feeder_failure_spread{provider="x"}` metric.
+func TelemetrySpreadRejected(n Name) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"failure",
+			"spread",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+		},
+	)
+}
+
+// telemetryParseError gives an standard way to add
+// `price_feeder_failure_parse{provider="x", reason="x"}` metric.
+func telemetryParseError(n Name, reason ParseErrorReason) {
+	telemetry.IncrCounterWithLabels(
+		[]string{
+			"failure",
+			"parse",
+		},
+		1,
+		[]metrics.Label{
+			providerLabel(n),
+			{
+				Name:  "reason",
+				Value: reason.String(),
+			},
+		},
+	)
+}
@@ -0,0 +1,473 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/ojo/util/decmath"
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+const (
+	uniswapRestHost          = "https://cloudflare-eth.com"
+	uniswapPollInterval      = 15 * time.Second
+	uniswapCandleInterval    = time.Minute
+	defaultUniswapTWAPWindow = 5 * time.Minute
+
+	// uniswapObserveSelector is the function selector of
+	// observe(uint32[] secondsAgos).
+	uniswapObserveSelector = "883bdbfd"
+
+	// uniswapTickBase is the price ratio between two adjacent ticks.
+	uniswapTickBase = 1.0001
+)
+
+var _ Provider = (*UniswapProvider)(nil)
+
+// uniswapPools defines the Uniswap v3 pools the provider can read from,
+// indexed by their currency pair symbol.
+var uniswapPools = map[string]UniswapPool{
+	"ETHUSDC": {
+		Address:       "0x88e6a0c2ddd26feeb64f039a2c41296fcb3f5640", // USDC/WETH 0.05%
+		BaseIsToken0:  false,
+		BaseDecimals:  18,
+		QuoteDecimals: 6,
+	},
+	"BTCUSDC": {
+		Address:       "0x99ac8ca7087fa4a2a1fb6357269965a2014abc35", // WBTC/USDC 0.3%
+		BaseIsToken0:  true,
+		BaseDecimals:  8,
+		QuoteDecimals: 6,
+	},
+	"BTCETH": {
+		Address:       "0x4585fe77225b41b697c938b018e2ac67ac5a20c0", // WBTC/WETH 0.05%
+		BaseIsToken0:  true,
+		BaseDecimals:  8,
+		QuoteDecimals: 18,
+	},
+}
+
+type (
+	// UniswapProvider defines an Oracle provider that reads time-weighted
+	// average prices from Uniswap v3 pool observations through an Ethereum
+	// JSON-RPC endpoint.
+	//
+	// It polls the configured pools every uniswapPollInterval.
+	//
+	// REF: https://docs.uniswap.org/contracts/v3/reference/core/UniswapV3Pool#observe
+	UniswapProvider struct {
+		ctx             context.Context
+		logger          zerolog.Logger
+		mtx             sync.RWMutex
+		endpoints       Endpoint
+		client          *http.Client
+		tickers         map[string]types.TickerPrice   // Symbol => TickerPrice
+		candles         map[string][]types.CandlePrice // Symbol => CandlePrice
+		subscribedPairs map[string]types.CurrencyPair  // Symbol => types.CurrencyPair
+	}
+
+	// UniswapPool defines a Uniswap v3 pool and the token metadata required
+	// to convert its ticks into a base/quote price.
+	UniswapPool struct {
+		Address       string // pool contract address
+		BaseIsToken0  bool   // whether the base asset is the pool's token0
+		BaseDecimals  int64  // decimals of the base token
+		QuoteDecimals int64  // decimals of the quote token
+	}
+)
+
+// NewUniswapProvider creates a new UniswapProvider.
+func NewUniswapProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	endpoints Endpoint,
+	pairs ...types.CurrencyPair,
+) (*UniswapProvider, error) {
+	if endpoints.Name != ProviderUniswap {
+		endpoints = Endpoint{
+			Name: ProviderUniswap,
+			Rest: uniswapRestHost,
+		}
+	}
+
+	uniswapLogger := logger.With().Str("provider", string(ProviderUniswap)).Logger()
+
+	provider := &UniswapProvider{
+		ctx:             ctx,
+		logger:          uniswapLogger,
+		endpoints:       endpoints,
+		client:          endpoints.httpClient(newDefaultHTTPClient()),
+		tickers:         map[string]types.TickerPrice{},
+		candles:         map[string][]types.CandlePrice{},
+		subscribedPairs: map[string]types.CurrencyPair{},
+	}
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		provider,
+		provider.endpoints,
+		provider.logger,
+		pairs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.setSubscribedPairs(confirmedPairs...)
+
+	return provider, nil
+}
+
+// StartConnections starts polling the subscribed pools.
+func (p *UniswapProvider) StartConnections() {
+	go p.pollPrices()
+}
+
+// Describe returns the capabilities of the provider.
+func (p *UniswapProvider) Describe() Description {
+	return describe(p.endpoints, true)
+}
+
+// SubscribeCurrencyPairs adds the new currency pairs to the pools polled by
+// the provider.
+func (p *UniswapProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	confirmedPairs, err := ConfirmPairAvailability(
+		p,
+		p.endpoints,
+		p.logger,
+		cps...,
+	)
+	if err != nil {
+		return
+	}
+
+	p.setSubscribedPairs(confirmedPairs...)
+}
+
+// UnsubscribeCurrencyPairs stops polling the pools of the currency pairs and
+// removes their prices.
+func (p *UniswapProvider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, cp := range cps {
+		delete(p.subscribedPairs, cp.String())
+		delete(p.tickers, cp.String())
+		delete(p.candles, cp.String())
+	}
+}
+
+// GetTickerPrices returns the latest polled TWAP of the provided pairs.
+func (p *UniswapProvider) GetTickerPrices(_ context.Context, pairs ...types.CurrencyPair) (map[string]types.TickerPrice, error) {
+	tickerPrices := make(map[string]types.TickerPrice, len(pairs))
+
+	tickerErrs := 0
+	for _, cp := range pairs {
+		price, err := p.getTickerPrice(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get ticker price")
+			tickerErrs++
+			continue
+		}
+		tickerPrices[cp.String()] = price
+	}
+
+	if tickerErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoTickers.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return tickerPrices, nil
+}
+
+// GetCandlePrices returns the TWAP sampled at each candle boundary of the
+// provided pairs.
+func (p *UniswapProvider) GetCandlePrices(_ context.Context, pairs ...types.CurrencyPair) (map[string][]types.CandlePrice, error) {
+	candlePrices := make(map[string][]types.CandlePrice, len(pairs))
+
+	candleErrs := 0
+	for _, cp := range pairs {
+		prices, err := p.getCandlePrices(cp.String())
+		if err != nil {
+			p.logger.Debug().Err(err).Str("pair", cp.String()).Msg("failed to get candle prices")
+			candleErrs++
+			continue
+		}
+		candlePrices[cp.String()] = prices
+	}
+
+	if candleErrs == len(pairs) {
+		return nil, fmt.Errorf(
+			types.ErrNoCandles.Error(),
+			p.endpoints.Name,
+			pairs,
+		)
+	}
+	return candlePrices, nil
+}
+
+// GetAvailablePairs returns all pools the provider knows how to read.
+func (p *UniswapProvider) GetAvailablePairs() (map[string]struct{}, error) {
+	availablePairs := make(map[string]struct{}, len(uniswapPools))
+	for symbol := range uniswapPools {
+		availablePairs[strings.ToUpper(symbol)] = struct{}{}
+	}
+	return availablePairs, nil
+}
+
+func (p *UniswapProvider) getTickerPrice(key string) (types.TickerPrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	ticker, ok := p.tickers[key]
+	if !ok {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+	if isStale(ticker.TimeStamp, p.endpoints.tickerMaxAge()) {
+		return types.TickerPrice{}, fmt.Errorf(
+			types.ErrTickerStale.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	return ticker, nil
+}
+
+func (p *UniswapProvider) getCandlePrices(key string) ([]types.CandlePrice, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	candles, ok := p.candles[key]
+	if !ok {
+		return []types.CandlePrice{}, fmt.Errorf(
+			types.ErrCandleNotFound.Error(),
+			p.endpoints.Name,
+			key,
+		)
+	}
+
+	candleList := []types.CandlePrice{}
+	candleList = append(candleList, candles...)
+
+	return candleList, nil
+}
+
+// pollPrices updates the prices of all subscribed pools every
+// uniswapPollInterval until the provider's context is done.
+func (p *UniswapProvider) pollPrices() {
+	pollTicker := time.NewTicker(uniswapPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		p.updatePrices()
+
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-pollTicker.C:
+			continue
+		}
+	}
+}
+
+// updatePrices reads the pool observations of every subscribed pair and
+// stores the resulting ticker and candles.
+func (p *UniswapProvider) updatePrices() {
+	p.mtx.RLock()
+	pairs := types.MapPairsToSlice(p.subscribedPairs)
+	p.mtx.RUnlock()
+
+	for _, cp := range pairs {
+		symbol := cp.String()
+		pool, ok := uniswapPools[symbol]
+		if !ok {
+			continue
+		}
+
+		ticker, candles, err := p.readPool(pool)
+		if err != nil {
+			TelemetryFailure(ProviderUniswap, MessageTypeTicker)
+			p.logger.Error().Err(err).Str("pair", symbol).Msg("failed to read uniswap pool")
+			continue
+		}
+
+		p.setPrices(symbol, ticker, candles)
+		telemetryRestPoll(ProviderUniswap, MessageTypeTicker)
+		telemetryRestPoll(ProviderUniswap, MessageTypeCandle)
+	}
+}
+
+// readPool observes the pool once for the current TWAP and at every candle
+// boundary within providerCandlePeriod, returning the TWAP as a ticker and
+// the boundary samples as candles.
+func (p *UniswapProvider) readPool(pool UniswapPool) (types.TickerPrice, []types.CandlePrice, error) {
+	now := time.Now()
+	window := p.twapWindow()
+	windowSecs := uint32(window.Seconds())
+
+	// secondsAgos holds (end, start) offsets of each TWAP: the current one
+	// first followed by one per candle boundary.
+	secondsAgos := []uint32{0, windowSecs}
+	boundaries := []time.Time{}
+	for b := now.Truncate(uniswapCandleInterval); now.Sub(b) < providerCandlePeriod; b = b.Add(-uniswapCandleInterval) {
+		ago := uint32(now.Sub(b).Seconds())
+		secondsAgos = append(secondsAgos, ago, ago+windowSecs)
+		boundaries = append(boundaries, b)
+	}
+
+	tickCumulatives, err := p.observe(pool.Address, secondsAgos)
+	if err != nil {
+		return types.TickerPrice{}, nil, err
+	}
+
+	price, err := pool.twapPrice(tickCumulatives[0], tickCumulatives[1], windowSecs)
+	if err != nil {
+		return types.TickerPrice{}, nil, err
+	}
+	ticker := types.TickerPrice{
+		Price: price,
+		// pool observations carry no traded volume
+		Volume:    sdk.ZeroDec(),
+		TimeStamp: now.UnixMilli(),
+	}
+
+	candles := make([]types.CandlePrice, 0, len(boundaries))
+	for i, b := range boundaries {
+		price, err := pool.twapPrice(tickCumulatives[2*i+2], tickCumulatives[2*i+3], windowSecs)
+		if err != nil {
+			return types.TickerPrice{}, nil, err
+		}
+		candles = append(candles, types.CandlePrice{
+			Price:     price,
+			Volume:    sdk.ZeroDec(),
+			TimeStamp: b.UnixMilli(),
+		})
+	}
+
+	return ticker, candles, nil
+}
+
+// observe calls the pool's observe method and returns the tick cumulatives
+// for each of the provided secondsAgos.
+func (p *UniswapProvider) observe(poolAddress string, secondsAgos []uint32) ([]int64, error) {
+	selector, err := hex.DecodeString(uniswapObserveSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	data := append([]byte{}, selector...)
+	data = append(data, ethEncodeUint(ethWordSize)...)
+	data = append(data, ethEncodeUint(uint64(len(secondsAgos)))...)
+	for _, s := range secondsAgos {
+		data = append(data, ethEncodeUint(uint64(s))...)
+	}
+
+	result, err := ethCall(p.client, p.endpoints.Rest, poolAddress, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeUniswapTickCumulatives(result, len(secondsAgos))
+}
+
+// decodeUniswapTickCumulatives decodes the int56[] tickCumulatives returned
+// as the first value of a Uniswap v3 observe call.
+func decodeUniswapTickCumulatives(result []byte, n int) ([]int64, error) {
+	offsetWord, err := ethWord(result, 0)
+	if err != nil {
+		return nil, err
+	}
+	offset := int(ethUint(offsetWord).Int64()) / ethWordSize
+
+	lenWord, err := ethWord(result, offset)
+	if err != nil {
+		return nil, err
+	}
+	if int(ethUint(lenWord).Int64()) != n {
+		return nil, fmt.Errorf("unexpected number of tick cumulatives: %d", ethUint(lenWord).Int64())
+	}
+
+	tickCumulatives := make([]int64, n)
+	for i := range tickCumulatives {
+		word, err := ethWord(result, offset+1+i)
+		if err != nil {
+			return nil, err
+		}
+		tickCumulatives[i] = ethInt(word).Int64()
+	}
+
+	return tickCumulatives, nil
+}
+
+// twapPrice converts the tick cumulatives at the end and start of a window
+// into the pool's base price denominated in the quote token.
+func (pool UniswapPool) twapPrice(endCumulative, startCumulative int64, windowSecs uint32) (sdk.Dec, error) {
+	if windowSecs == 0 {
+		return sdk.Dec{}, fmt.Errorf("uniswap twap window must be positive")
+	}
+
+	// round the mean tick towards negative infinity like the Uniswap
+	// OracleLibrary does
+	delta := endCumulative - startCumulative
+	meanTick := delta / int64(windowSecs)
+	if delta < 0 && delta%int64(windowSecs) != 0 {
+		meanTick--
+	}
+
+	// price of token0 denominated in token1
+	token0Price := math.Pow(uniswapTickBase, float64(meanTick))
+	if pool.BaseIsToken0 {
+		return decmath.NewDecFromFloat(token0Price * math.Pow10(int(pool.BaseDecimals-pool.QuoteDecimals)))
+	}
+	return decmath.NewDecFromFloat(math.Pow10(int(pool.BaseDecimals-pool.QuoteDecimals)) / token0Price)
+}
+
+func (p *UniswapProvider) setPrices(symbol string, ticker types.TickerPrice, candles []types.CandlePrice) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.tickers[symbol] = ticker
+	p.candles[symbol] = candles
+}
+
+// twapWindow returns the configured TWAP window or the default if none was
+// set. Windows are truncated to whole seconds as observed by the pool.
+func (p *UniswapProvider) twapWindow() time.Duration {
+	if p.endpoints.TWAPWindow < time.Second {
+		return defaultUniswapTWAPWindow
+	}
+	return p.endpoints.TWAPWindow
+}
+
+// setSubscribedPairs sets N currency pairs to the map of subscribed pairs.
+func (p *UniswapProvider) setSubscribedPairs(cps ...types.CurrencyPair) {
+	for _, cp := range cps {
+		p.subscribedPairs[cp.String()] = cp
+	}
+}
+
+// GetSubscribedPairs returns a copy of the pairs the provider is subscribed to.
+func (p *UniswapProvider) GetSubscribedPairs() map[string]types.CurrencyPair {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return copyCurrencyPairs(p.subscribedPairs)
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/oracle/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// uniswapObserveResult ABI encodes the (int56[], uint160[]) return values of
+// an observe call.
+func uniswapObserveResult(tickCumulatives []int64) string {
+	encodeInt := func(v int64) []byte {
+		i := big.NewInt(v)
+		if v < 0 {
+			i.Add(i, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		word := make([]byte, ethWordSize)
+		i.FillBytes(word)
+		return word
+	}
+
+	n := uint64(len(tickCumulatives))
+	data := ethEncodeUint(2 * ethWordSize)
+	data = append(data, ethEncodeUint((3+n)*ethWordSize)...)
+	data = append(data, ethEncodeUint(n)...)
+	for _, tc := range tickCumulatives {
+		data = append(data, encodeInt(tc)...)
+	}
+	data = append(data, ethEncodeUint(n)...)
+	for range tickCumulatives {
+		data = append(data, ethEncodeUint(0)...)
+	}
+	return "0x" + hex.EncodeToString(data)
+}
+
+func TestUniswapProvider_GetTickerPrices(t *testing.T) {
+	p, err := NewUniswapProvider(
+		context.TODO(),
+		zerolog.Nop(),
+		Endpoint{},
+		types.CurrencyPair{Base: "ETH", Quote: "USDC"},
+	)
+	require.NoError(t, err)
+
+	t.Run("valid_request_single_ticker", func(t *testing.T) {
+		price := sdk.MustNewDecFromStr("1850.12")
+
+		p.tickers = map[string]types.TickerPrice{
+			"ETHUSDC": {
+				Price:     price,
+				Volume:    sdk.ZeroDec(),
+				TimeStamp: time.Now().UnixMilli(),
+			},
+		}
+
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ETH", Quote: "USDC"})
+		require.NoError(t, err)
+		require.Len(t, prices, 1)
+		require.Equal(t, price, prices["ETHUSDC"].Price)
+	})
+
+	t.Run("invalid_request_stale_ticker", func(t *testing.T) {
+		p.tickers = map[string]types.TickerPrice{
+			"ETHUSDC": {
+				Price:     sdk.MustNewDecFromStr("1850.12"),
+				Volume:    sdk.ZeroDec(),
+				TimeStamp: time.Now().Add(-time.Hour).UnixMilli(),
+			},
+		}
+
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "ETH", Quote: "USDC"})
+		require.Error(t, err)
+		require.Nil(t, prices)
+	})
+
+	t.Run("invalid_request_invalid_ticker", func(t *testing.T) {
+		prices, err := p.GetTickerPrices(context.TODO(), types.CurrencyPair{Base: "FOO", Quote: "BAR"})
+		require.Error(t, err)
+		require.Equal(t, "uniswap has no ticker data for requested pairs: [FOOBAR]", err.Error())
+		require.Nil(t, prices)
+	})
+}
+
+func TestUniswapPool_TWAPPrice(t *testing.T) {
+	testCases := []struct {
+		name      string
+		pool      UniswapPool
+		endTick   int64
+		startTick int64
+		expected  float64
+	}{
+		{
+			// tick 200311 ~= 2e-9 USDC/WETH in raw units => ~2000 USDC per ETH
+			name:      "base_is_token1",
+			pool:      uniswapPools["ETHUSDC"],
+			endTick:   200311 * 300,
+			startTick: 0,
+			expected:  2000,
+		},
+		{
+			// tick 57000 ~= 298.8 raw USDC/WBTC => ~29880 USDC per BTC
+			name:      "base_is_token0",
+			pool:      uniswapPools["BTCUSDC"],
+			endTick:   57000 * 300,
+			startTick: 0,
+			expected:  29878,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := tc.pool.twapPrice(tc.endTick, tc.startTick, 300)
+			require.NoError(t, err)
+			require.InEpsilon(t, tc.expected, price.MustFloat64(), 0.001)
+		})
+	}
+
+	t.Run("negative_ticks_round_down", func(t *testing.T) {
+		pool := UniswapPool{BaseIsToken0: true}
+		price, err := pool.twapPrice(-7, 0, 2)
+		require.NoError(t, err)
+		require.InEpsilon(t, 0.9996, price.MustFloat64(), 0.00001)
+	})
+
+	t.Run("invalid_window", func(t *testing.T) {
+		_, err := UniswapPool{}.twapPrice(0, 0, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestUniswapProvider_ReadPool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req EthRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "eth_call", req.Method)
+
+		// every requested TWAP ends at tick cumulative 0 and starts 300s
+		// earlier at -57000*300, giving a mean tick of 57000
+		params := req.Params[0].(map[string]interface{})
+		data, err := hex.DecodeString(params["data"].(string)[2:])
+		require.NoError(t, err)
+		n := int(ethUint(data[4+ethWordSize : 4+2*ethWordSize]).Int64())
+
+		tickCumulatives := make([]int64, n)
+		for i := 1; i < n; i += 2 {
+			tickCumulatives[i] = -57000 * 300
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(EthRPCResponse{
+			Result: uniswapObserveResult(tickCumulatives),
+		}))
+	}))
+	defer server.Close()
+
+	p := UniswapProvider{
+		logger:    zerolog.Nop(),
+		endpoints: Endpoint{Name: ProviderUniswap, Rest: server.URL},
+		client:    server.Client(),
+	}
+
+	ticker, candles, err := p.readPool(uniswapPools["BTCUSDC"])
+	require.NoError(t, err)
+	require.InEpsilon(t, 29878, ticker.Price.MustFloat64(), 0.001)
+	require.True(t, ticker.Volume.IsZero())
+	require.Len(t, candles, int(providerCandlePeriod/uniswapCandleInterval))
+	for _, candle := range candles {
+		require.Equal(t, ticker.Price, candle.Price)
+	}
+}
+
+func TestUniswapProvider_GetAvailablePairs(t *testing.T) {
+	p := UniswapProvider{}
+	pairs, err := p.GetAvailablePairs()
+	require.NoError(t, err)
+	require.Contains(t, pairs, "ETHUSDC")
+	require.Contains(t, pairs, "BTCUSDC")
+}
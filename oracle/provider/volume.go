@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VolumeConvention defines the asset in which a provider reports the volume
+// of its tickers and candles.
+type VolumeConvention uint8
+
+const (
+	// VolumeBase is used by providers reporting volume in units of the base
+	// asset, ex.: 1000 ATOM traded on ATOM/USDT.
+	VolumeBase VolumeConvention = iota
+	// VolumeQuote is used by providers reporting volume in units of the quote
+	// asset, ex.: 10000 USDT traded on ATOM/USDT.
+	VolumeQuote
+)
+
+// volumeConventions defines the providers which don't report their volume in
+// units of the base asset.
+var volumeConventions = map[Name]VolumeConvention{
+	ProviderHuobi:   VolumeQuote, // "vol" is the accumulated trading value
+	ProviderOsmosis: VolumeQuote, // volumes are reported in USD
+	ProviderNumia:   VolumeQuote, // volumes are reported in USD
+}
+
+// candleIntervals defines the providers whose candles don't span one minute.
+var candleIntervals = map[Name]time.Duration{
+	ProviderOsmosis: 5 * time.Minute, // the 5 minute chart timeframe
+	ProviderFin:     finCandleBinSizeMinutes * time.Minute,
+	ProviderNumia:   numiaCandleInterval * time.Minute,
+}
+
+// VolumeConvention returns the volume convention of the provider.
+func (n Name) VolumeConvention() VolumeConvention {
+	if vc, ok := volumeConventions[n]; ok {
+		return vc
+	}
+	return VolumeBase
+}
+
+// QuoteVolume converts a volume reported by the provider at the given price
+// into units of the quote asset so it can be compared across providers.
+func (n Name) QuoteVolume(price, volume sdk.Dec) sdk.Dec {
+	if n.VolumeConvention() == VolumeQuote {
+		return volume
+	}
+	return volume.Mul(price)
+}
+
+// CandleInterval returns the period of the provider's candles.
+func (n Name) CandleInterval() time.Duration {
+	if interval, ok := candleIntervals[n]; ok {
+		return interval
+	}
+	return time.Minute
+}
+
+// PerMinuteVolume converts the volume of a candle of the provider into its
+// volume per minute, so that providers with longer candles don't weigh more
+// than providers with one minute candles.
+func (n Name) PerMinuteVolume(volume sdk.Dec) sdk.Dec {
+	interval := n.CandleInterval()
+	if interval == time.Minute {
+		return volume
+	}
+	return volume.MulInt64(int64(time.Minute)).QuoInt64(int64(interval))
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName_QuoteVolume(t *testing.T) {
+	price := sdk.MustNewDecFromStr("10.5")
+	volume := sdk.MustNewDecFromStr("1000")
+
+	testCases := []struct {
+		provider Name
+		expected sdk.Dec
+	}{
+		{ProviderBinance, sdk.MustNewDecFromStr("10500")},
+		{ProviderCoinbase, sdk.MustNewDecFromStr("10500")},
+		{ProviderHuobi, volume},
+		{ProviderOsmosis, volume},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.provider.String(), func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.provider.QuoteVolume(price, volume))
+		})
+	}
+}
+
+func TestName_PerMinuteVolume(t *testing.T) {
+	volume := sdk.MustNewDecFromStr("1000")
+
+	testCases := []struct {
+		provider Name
+		interval time.Duration
+		expected sdk.Dec
+	}{
+		{ProviderBinance, time.Minute, volume},
+		{ProviderCoinbase, time.Minute, volume},
+		{ProviderOsmosis, 5 * time.Minute, sdk.MustNewDecFromStr("200")},
+		{ProviderFin, 5 * time.Minute, sdk.MustNewDecFromStr("200")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.provider.String(), func(t *testing.T) {
+			require.Equal(t, tc.interval, tc.provider.CandleInterval())
+			require.Equal(t, tc.expected, tc.provider.PerMinuteVolume(volume))
+		})
+	}
+}
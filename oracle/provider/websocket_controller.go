@@ -1,10 +1,17 @@
 package provider
 
 import (
+	"bytes"
+	"compress/flate"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"math/rand"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,48 +21,121 @@ import (
 )
 
 const (
-	defaultReadNewWSMessage   = 50 * time.Millisecond
-	defaultMaxConnectionTime  = time.Hour * 23 // should be < 24h
-	defaultPingDuration       = 15 * time.Second
-	disabledPingDuration      = time.Duration(0)
-	startingReconnectDuration = 5 * time.Second
-	maxRetryMultiplier        = 25 // max retry duration: 52m5s
+	defaultReadNewWSMessage    = 50 * time.Millisecond
+	defaultMaxConnectionTime   = time.Hour * 23 // should be < 24h
+	defaultPingDuration        = 15 * time.Second
+	defaultPingJitter          = 3 * time.Second
+	disabledPingDuration       = time.Duration(0)
+	startingReconnectDuration  = 5 * time.Second
+	maxRetryMultiplier         = 25 // max retry duration: 52m5s
+	defaultRateLimitBackoff    = 5 * time.Minute
+	defaultSubscribeRetryDelay = 10 * time.Second
+
+	// closeTooManyRequests is the private use close code venues close rate
+	// limited connections with, after the HTTP 429 status.
+	closeTooManyRequests = 4029
 )
 
+const (
+	// ConnectionEventConnect is sent when a websocket connection is first
+	// established.
+	ConnectionEventConnect = ConnectionEventType("connect")
+	// ConnectionEventDisconnect is sent when an established websocket
+	// connection is closed, ex. on a read error or before a reconnect.
+	ConnectionEventDisconnect = ConnectionEventType("disconnect")
+	// ConnectionEventReconnect is sent when a websocket connection is
+	// established again after having been disconnected.
+	ConnectionEventReconnect = ConnectionEventType("reconnect")
+)
+
+var errClosedConnection = errors.New("unable to send JSON on a closed connection")
+
+// rateLimitCloseCodes defines the close codes of the websocket connections a
+// venue closes because they were rate limited.
+var rateLimitCloseCodes = map[int]struct{}{
+	websocket.CloseTryAgainLater: {},
+	closeTooManyRequests:         {},
+}
+
 type (
 	MessageHandler func(int, *WebsocketConnection, []byte)
 
+	// ConnectionEventType is the kind of a ConnectionEvent.
+	ConnectionEventType string
+
+	// ConnectionEvent describes a change of the state of a websocket
+	// connection of a provider.
+	ConnectionEvent struct {
+		Provider Name
+		Type     ConnectionEventType
+		Time     time.Time
+	}
+
+	// ConnectionEventHandler is called with the connection events of a
+	// WebsocketController. It is called from the connection's goroutine so it
+	// must not block.
+	ConnectionEventHandler func(ConnectionEvent)
+
+	// SubscriptionAckKeys returns the keys of the acknowledgements the venue
+	// sends for a subscription message, ex. one per channel and pair, which
+	// the message handler passes to AcknowledgeSubscription when receiving
+	// them. It returns none for a message which isn't acknowledged.
+	SubscriptionAckKeys func(msg interface{}) []string
+
 	WebsocketConnection struct {
 		parentCtx           context.Context
 		websocketCtx        context.Context
 		websocketCancelFunc context.CancelFunc
 		providerName        Name
 		websocketURL        url.URL
+		dialer              *websocket.Dialer
+		header              http.Header
 		subscriptionMsg     interface{}
 		messageHandler      MessageHandler
 		pingDuration        time.Duration
+		pingJitter          time.Duration
 		pingMessageType     uint
+		pingPayload         []byte
+		pongPayload         []byte
+		rateLimitBackoff    time.Duration
+		subscribeRetries    int
+		subscribeRetryDelay time.Duration
+		ackKeys             SubscriptionAckKeys
 		logger              zerolog.Logger
+		eventHandler        ConnectionEventHandler
 
 		mtx              sync.Mutex
 		client           *websocket.Conn
 		reconnectCounter uint
+		hasConnected     bool
+		reconnectDelay   time.Duration
+		pendingAcks      map[string]struct{}
 	}
 
 	// WebsocketController defines a provider agnostic websocket handler
 	// that manages reconnecting, subscribing, and receiving messages.
 	WebsocketController struct {
-		parentCtx    context.Context
-		providerName Name
-		websocketURL url.URL
-		logger       zerolog.Logger
-		connections  []*WebsocketConnection
+		parentCtx           context.Context
+		providerName        Name
+		websocketURL        url.URL
+		dialer              *websocket.Dialer
+		header              http.Header
+		pingJitter          time.Duration
+		pingPayload         []byte
+		pongPayload         []byte
+		rateLimitBackoff    time.Duration
+		subscribeRetries    int
+		subscribeRetryDelay time.Duration
+		ackKeys             SubscriptionAckKeys
+		logger              zerolog.Logger
+		eventHandler        ConnectionEventHandler
+		connections         []*WebsocketConnection
 	}
 )
 
 func NewWebsocketController(
 	ctx context.Context,
-	providerName Name,
+	endpoint Endpoint,
 	websocketURL url.URL,
 	subscriptionMsgs []interface{},
 	messageHandler MessageHandler,
@@ -64,27 +144,114 @@ func NewWebsocketController(
 	logger zerolog.Logger,
 ) *WebsocketController {
 	connections := make([]*WebsocketConnection, 0)
+	dialer := endpoint.websocketDialer()
+	header := endpoint.websocketHeader()
+	pingJitter := endpoint.pingJitter()
+	rateLimitBackoff := endpoint.rateLimitBackoff()
+	subscribeRetryDelay := endpoint.subscribeRetryDelay()
 
 	for _, subMsg := range subscriptionMsgs {
 		connection := &WebsocketConnection{
-			parentCtx:       ctx,
-			providerName:    providerName,
-			websocketURL:    websocketURL,
-			subscriptionMsg: subMsg,
-			messageHandler:  messageHandler,
-			pingDuration:    pingDuration,
-			pingMessageType: pingMessageType,
-			logger:          logger,
+			parentCtx:           ctx,
+			providerName:        endpoint.Name,
+			websocketURL:        websocketURL,
+			dialer:              dialer,
+			header:              header,
+			subscriptionMsg:     subMsg,
+			messageHandler:      messageHandler,
+			pingDuration:        pingDuration,
+			pingJitter:          pingJitter,
+			pingMessageType:     pingMessageType,
+			pingPayload:         ping,
+			rateLimitBackoff:    rateLimitBackoff,
+			subscribeRetries:    endpoint.SubscribeRetries,
+			subscribeRetryDelay: subscribeRetryDelay,
+			logger:              logger,
 		}
 		connections = append(connections, connection)
 	}
 
 	return &WebsocketController{
-		parentCtx:    ctx,
-		providerName: providerName,
-		websocketURL: websocketURL,
-		logger:       logger,
-		connections:  connections,
+		parentCtx:           ctx,
+		providerName:        endpoint.Name,
+		websocketURL:        websocketURL,
+		dialer:              dialer,
+		header:              header,
+		pingJitter:          pingJitter,
+		pingPayload:         ping,
+		rateLimitBackoff:    rateLimitBackoff,
+		subscribeRetries:    endpoint.SubscribeRetries,
+		subscribeRetryDelay: subscribeRetryDelay,
+		logger:              logger,
+		connections:         connections,
+	}
+}
+
+// websocketDialer returns the dialer used to connect to the endpoint's
+// websocket. It keeps the settings of the default dialer unless they are
+// overridden by the endpoint.
+func (e Endpoint) websocketDialer() *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	if e.HandshakeTimeout > 0 {
+		dialer.HandshakeTimeout = e.HandshakeTimeout
+	}
+	dialer.EnableCompression = e.Compression
+	if proxy := e.proxyFunc(); proxy != nil {
+		dialer.Proxy = proxy
+	}
+	if tlsConfig := e.tlsConfig(); tlsConfig != nil {
+		dialer.TLSClientConfig = tlsConfig
+	}
+	return &dialer
+}
+
+// websocketHeader returns the endpoint's custom headers to send with the
+// websocket handshake, or nil if none were configured.
+func (e Endpoint) websocketHeader() http.Header {
+	if len(e.Headers) == 0 {
+		return nil
+	}
+	header := make(http.Header, len(e.Headers))
+	for key, value := range e.Headers {
+		header.Set(key, value)
+	}
+	return header
+}
+
+// SetEventHandler sets the optional handler called whenever a connection of
+// the controller connects, disconnects or reconnects. It must be called
+// before StartConnections.
+func (wsc *WebsocketController) SetEventHandler(handler ConnectionEventHandler) {
+	wsc.eventHandler = handler
+	for _, conn := range wsc.connections {
+		conn.eventHandler = handler
+	}
+}
+
+// SetPingPayload sets the payload of the pings sent by the connections of the
+// controller, which defaults to "ping", ex. {"op":"ping"} for providers
+// expecting a JSON keepalive in a text message rather than a control frame.
+// The messages equal to pong, sent back by the provider, are dropped rather
+// than passed to the message handler. It must be called before
+// StartConnections.
+func (wsc *WebsocketController) SetPingPayload(ping, pong []byte) {
+	wsc.pingPayload = ping
+	wsc.pongPayload = pong
+	for _, conn := range wsc.connections {
+		conn.pingPayload = ping
+		conn.pongPayload = pong
+	}
+}
+
+// SetSubscriptionAcks sets the keys of the acknowledgements the venue sends
+// for the subscription messages. A subscription message which isn't
+// acknowledged within the endpoint's subscribe_retry_delay is sent again, up
+// to subscribe_retries times, after which the failure is logged and counted.
+// It must be called before StartConnections.
+func (wsc *WebsocketController) SetSubscriptionAcks(keys SubscriptionAckKeys) {
+	wsc.ackKeys = keys
+	for _, conn := range wsc.connections {
+		conn.ackKeys = keys
 	}
 }
 
@@ -104,31 +271,72 @@ func (wsc *WebsocketController) AddWebsocketConnection(
 ) {
 	for _, msg := range msgs {
 		conn := &WebsocketConnection{
-			parentCtx:       wsc.parentCtx,
-			providerName:    wsc.providerName,
-			websocketURL:    wsc.websocketURL,
-			subscriptionMsg: msg,
-			messageHandler:  messageHandler,
-			pingDuration:    pingDuration,
-			pingMessageType: pingMessageType,
-			logger:          wsc.logger,
+			parentCtx:           wsc.parentCtx,
+			providerName:        wsc.providerName,
+			websocketURL:        wsc.websocketURL,
+			dialer:              wsc.dialer,
+			header:              wsc.header,
+			subscriptionMsg:     msg,
+			messageHandler:      messageHandler,
+			pingDuration:        pingDuration,
+			pingJitter:          wsc.pingJitter,
+			pingMessageType:     pingMessageType,
+			pingPayload:         wsc.pingPayload,
+			pongPayload:         wsc.pongPayload,
+			rateLimitBackoff:    wsc.rateLimitBackoff,
+			subscribeRetries:    wsc.subscribeRetries,
+			subscribeRetryDelay: wsc.subscribeRetryDelay,
+			ackKeys:             wsc.ackKeys,
+			logger:              wsc.logger,
+			eventHandler:        wsc.eventHandler,
 		}
 		wsc.connections = append(wsc.connections, conn)
 		go conn.start()
 	}
 }
 
+// SendJSON sends a json message, ex. an unsubscription message, to each of the
+// open connections of the controller. The connections which are closed are
+// skipped since they send their subscription message again when reconnecting.
+func (wsc *WebsocketController) SendJSON(msg interface{}) error {
+	for _, conn := range wsc.connections {
+		err := conn.SendJSON(msg)
+		if err != nil && !errors.Is(err, errClosedConnection) {
+			return err
+		}
+	}
+	return nil
+}
+
 // start will continuously loop and attempt connecting to the websocket
 // until a successful connection is made. It then starts the ping
 // service and read listener in new go routines and sends a subscription
 // message using the passed in subscription message.
 func (conn *WebsocketConnection) start() {
+	// a connection closed for being rate limited waits out its backoff first
+	if delay := conn.takeReconnectDelay(); delay > 0 {
+		select {
+		case <-conn.parentCtx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+
 	connectTicker := time.NewTicker(time.Millisecond)
 	defer connectTicker.Stop()
 
 	for {
 		if err := conn.connect(); err != nil {
-			conn.logger.Err(err).Send()
+			if errors.Is(err, types.ErrWebsocketRateLimited) {
+				conn.logger.Warn().Err(err).Dur("backoff", conn.rateLimitBackoff).Msg("rate limited by venue, backing off")
+				select {
+				case <-connectTicker.C:
+				default:
+				}
+				connectTicker.Reset(conn.rateLimitBackoff)
+			} else {
+				conn.logger.Err(err).Send()
+			}
 			select {
 			case <-conn.parentCtx.Done():
 				return
@@ -137,15 +345,29 @@ func (conn *WebsocketConnection) start() {
 				continue
 			}
 		}
+		conn.connected()
+
+		// the acknowledgements are expected before subscribing so that one
+		// received right away isn't missed
+		websocketCtx := conn.websocketCtx
+		awaitAcks := conn.expectSubscriptionAcks()
 
 		go conn.readWebSocket()
 		go conn.pingLoop()
 
-		if err := conn.subscribe(conn.subscriptionMsg); err != nil {
+		if err := conn.subscribe(websocketCtx, conn.subscriptionMsg); err != nil {
+			// a connection closed while subscribing, ex. after the read
+			// loop failed, is already being reconnected by whoever closed it
+			if websocketCtx.Err() != nil {
+				return
+			}
 			conn.logger.Err(err).Send()
 			conn.close()
 			continue
 		}
+		if awaitAcks {
+			go conn.awaitSubscriptionAcks(websocketCtx)
+		}
 		return
 	}
 }
@@ -156,8 +378,14 @@ func (conn *WebsocketConnection) connect() error {
 	defer conn.mtx.Unlock()
 
 	conn.logger.Debug().Msg("connecting to websocket")
-	connection, resp, err := websocket.DefaultDialer.Dial(conn.websocketURL.String(), nil)
+	connection, resp, err := conn.dialer.Dial(conn.websocketURL.String(), conn.header)
 	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusTooManyRequests {
+				return types.ErrWebsocketRateLimited.Wrapf("%s handshake: %s", conn.providerName, resp.Status)
+			}
+		}
 		return fmt.Errorf(types.ErrWebsocketDial.Error(), conn.providerName, err)
 	}
 	defer resp.Body.Close()
@@ -168,6 +396,43 @@ func (conn *WebsocketConnection) connect() error {
 	return nil
 }
 
+// connected sends the connect event of the connection, or the reconnect event
+// if it was connected before.
+func (conn *WebsocketConnection) connected() {
+	conn.mtx.Lock()
+	eventType := ConnectionEventConnect
+	if conn.hasConnected {
+		eventType = ConnectionEventReconnect
+	}
+	conn.hasConnected = true
+	conn.mtx.Unlock()
+
+	conn.sendEvent(eventType)
+}
+
+// sendEvent calls the event handler of the connection, if one is set.
+func (conn *WebsocketConnection) sendEvent(eventType ConnectionEventType) {
+	if conn.eventHandler == nil {
+		return
+	}
+	conn.eventHandler(ConnectionEvent{
+		Provider: conn.providerName,
+		Type:     eventType,
+		Time:     time.Now(),
+	})
+}
+
+// takeReconnectDelay returns the delay before the connection reconnects and
+// resets it.
+func (conn *WebsocketConnection) takeReconnectDelay() time.Duration {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	delay := conn.reconnectDelay
+	conn.reconnectDelay = 0
+	return delay
+}
+
 func (conn *WebsocketConnection) iterateRetryCounter() time.Duration {
 	if conn.reconnectCounter < 25 {
 		conn.reconnectCounter++
@@ -177,7 +442,30 @@ func (conn *WebsocketConnection) iterateRetryCounter() time.Duration {
 }
 
 // subscribe sends the WebsocketConnections subscription message to the websocket.
-func (conn *WebsocketConnection) subscribe(msg interface{}) error {
+// The messages of a subscriptionBatch are sent in order, waiting for its delay
+// between each of them unless the websocket context is done.
+func (conn *WebsocketConnection) subscribe(websocketCtx context.Context, msg interface{}) error {
+	batch, ok := msg.(subscriptionBatch)
+	if !ok {
+		return conn.subscribeMsg(msg)
+	}
+	for i, batchMsg := range batch.msgs {
+		if i > 0 {
+			select {
+			case <-websocketCtx.Done():
+				return websocketCtx.Err()
+			case <-time.After(batch.delay):
+			}
+		}
+		if err := conn.subscribeMsg(batchMsg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribeMsg sends a single subscription message to the websocket.
+func (conn *WebsocketConnection) subscribeMsg(msg interface{}) error {
 	telemetryWebsocketSubscribeCurrencyPairs(conn.providerName, 1)
 	conn.logger.Debug().Interface("msg", msg).Msg("sending subscription message")
 	if err := conn.SendJSON(msg); err != nil {
@@ -186,6 +474,106 @@ func (conn *WebsocketConnection) subscribe(msg interface{}) error {
 	return nil
 }
 
+// subscriptionMsgs returns the messages of a subscription message, which are
+// those of a subscriptionBatch or else the message itself.
+func subscriptionMsgs(msg interface{}) []interface{} {
+	if batch, ok := msg.(subscriptionBatch); ok {
+		return batch.msgs
+	}
+	return []interface{}{msg}
+}
+
+// expectSubscriptionAcks sets the acknowledgements of the connection's
+// subscription as pending and returns true if they should be awaited, which
+// is when subscribe retries are enabled and the venue acknowledges them.
+func (conn *WebsocketConnection) expectSubscriptionAcks() bool {
+	if conn.subscribeRetries <= 0 || conn.ackKeys == nil {
+		return false
+	}
+
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	conn.pendingAcks = make(map[string]struct{})
+	for _, msg := range subscriptionMsgs(conn.subscriptionMsg) {
+		for _, key := range conn.ackKeys(msg) {
+			conn.pendingAcks[key] = struct{}{}
+		}
+	}
+	return len(conn.pendingAcks) > 0
+}
+
+// AcknowledgeSubscription marks the subscription acknowledgement with the key
+// as received, so that its subscription message isn't sent again.
+func (conn *WebsocketConnection) AcknowledgeSubscription(key string) {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+	delete(conn.pendingAcks, key)
+}
+
+// unacknowledgedMsgs returns the subscription messages of which an
+// acknowledgement is still pending, and the keys of those acknowledgements.
+func (conn *WebsocketConnection) unacknowledgedMsgs() ([]interface{}, []string) {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	var (
+		msgs []interface{}
+		keys []string
+	)
+	for _, msg := range subscriptionMsgs(conn.subscriptionMsg) {
+		pending := false
+		for _, key := range conn.ackKeys(msg) {
+			if _, ok := conn.pendingAcks[key]; ok {
+				keys = append(keys, key)
+				pending = true
+			}
+		}
+		if pending {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, keys
+}
+
+// awaitSubscriptionAcks sends the subscription messages which weren't
+// acknowledged within the subscribe retry delay again, up to subscribeRetries
+// times, and then logs and counts the failure. It stops once the connection
+// is closed, since it subscribes again when reconnecting.
+func (conn *WebsocketConnection) awaitSubscriptionAcks(ctx context.Context) {
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(conn.subscribeRetryDelay):
+		}
+
+		msgs, keys := conn.unacknowledgedMsgs()
+		if len(msgs) == 0 {
+			return
+		}
+		if attempt > conn.subscribeRetries {
+			conn.logger.Error().
+				Strs("pending", keys).
+				Int("retries", conn.subscribeRetries).
+				Msg("subscription not acknowledged by venue, giving up")
+			telemetryWebsocketSubscribeFailure(conn.providerName)
+			return
+		}
+
+		conn.logger.Warn().
+			Strs("pending", keys).
+			Int("attempt", attempt).
+			Msg("subscription not acknowledged by venue, retrying")
+		for _, msg := range msgs {
+			if err := conn.subscribeMsg(msg); err != nil {
+				conn.logger.Err(err).Send()
+				return
+			}
+		}
+	}
+}
+
 // SendJSON sends a json message to the websocket connection using the connections
 // mutex to ensure multiple writes do not happen at once.
 func (conn *WebsocketConnection) SendJSON(msg interface{}) error {
@@ -193,7 +581,7 @@ func (conn *WebsocketConnection) SendJSON(msg interface{}) error {
 	defer conn.mtx.Unlock()
 
 	if conn.client == nil {
-		return fmt.Errorf("unable to send JSON on a closed connection")
+		return errClosedConnection
 	}
 	conn.logger.Debug().Interface("msg", msg).Msg("sending websocket message")
 	if err := conn.client.WriteJSON(msg); err != nil {
@@ -202,13 +590,14 @@ func (conn *WebsocketConnection) SendJSON(msg interface{}) error {
 	return nil
 }
 
-// ping sends a ping to the server every defaultPingDuration
+// ping sends a ping to the server every pingDuration, shortened by a random
+// jitter so that the connections don't all ping at the same time.
 func (conn *WebsocketConnection) pingLoop() {
 	if conn.pingDuration == disabledPingDuration {
 		return // disable ping loop if disabledPingDuration
 	}
-	pingTicker := time.NewTicker(conn.pingDuration)
-	defer pingTicker.Stop()
+	pingTimer := time.NewTimer(jitteredPingDuration(conn.pingDuration, conn.pingJitter))
+	defer pingTimer.Stop()
 
 	for {
 		err := conn.ping()
@@ -218,12 +607,25 @@ func (conn *WebsocketConnection) pingLoop() {
 		select {
 		case <-conn.websocketCtx.Done():
 			return
-		case <-pingTicker.C:
-			continue
+		case <-pingTimer.C:
+			pingTimer.Reset(jitteredPingDuration(conn.pingDuration, conn.pingJitter))
 		}
 	}
 }
 
+// jitteredPingDuration returns the ping duration shortened by a random amount
+// of up to jitter, which is capped at half of the duration. Pings are only
+// ever sent early so that venues with an idle timeout don't disconnect.
+func jitteredPingDuration(duration, jitter time.Duration) time.Duration {
+	if jitter > duration/2 {
+		jitter = duration / 2
+	}
+	if jitter <= 0 {
+		return duration
+	}
+	return duration - time.Duration(rand.Int63n(int64(jitter))) //nolint:gosec // jitter needs no secure source
+}
+
 func (conn *WebsocketConnection) ping() error {
 	conn.mtx.Lock()
 	defer conn.mtx.Unlock()
@@ -231,7 +633,7 @@ func (conn *WebsocketConnection) ping() error {
 	if conn.client == nil {
 		return fmt.Errorf("unable to ping closed connection")
 	}
-	err := conn.client.WriteMessage(int(conn.pingMessageType), ping)
+	err := conn.client.WriteMessage(int(conn.pingMessageType), conn.pingPayload)
 	if err != nil {
 		conn.logger.Err(fmt.Errorf(types.ErrWebsocketSend.Error(), conn.providerName, err)).Send()
 	}
@@ -240,7 +642,8 @@ func (conn *WebsocketConnection) ping() error {
 
 // readWebSocket continuously reads from the websocket and relays messages
 // to the passed in messageHandler. On websocket error this function
-// terminates and starts the reconnect process.
+// terminates and starts the reconnect process of this connection only, the
+// other connections of the provider keep receiving messages.
 // Some providers (Binance) will only allow a valid connection for 24 hours
 // so we manually disconnect and reconnect every 23 hours (defaultMaxConnectionTime)
 func (conn *WebsocketConnection) readWebSocket() {
@@ -255,10 +658,21 @@ func (conn *WebsocketConnection) readWebSocket() {
 		case <-time.After(defaultReadNewWSMessage):
 			messageType, bz, err := conn.client.ReadMessage()
 			if err != nil {
-				conn.logger.Err(fmt.Errorf(types.ErrWebsocketRead.Error(), conn.providerName, err)).Send()
+				// a message which can't be decompressed leaves the stream in
+				// an unknown state, so it's recovered from by reconnecting
+				var closeErr *websocket.CloseError
+				switch {
+				case isDecompressionError(err):
+					conn.logger.Warn().Err(err).Msg("failed to decompress websocket message")
+				case errors.As(err, &closeErr):
+					conn.closedByVenue(closeErr)
+				default:
+					conn.logger.Err(fmt.Errorf(types.ErrWebsocketRead.Error(), conn.providerName, err)).Send()
+				}
 				conn.reconnect()
 				return
 			}
+			telemetryWebsocketBytes(conn.providerName, len(bz))
 			conn.readSuccess(messageType, bz)
 		case <-reconnectTicker.C:
 			conn.reconnect()
@@ -267,6 +681,53 @@ func (conn *WebsocketConnection) readWebSocket() {
 	}
 }
 
+// closedByVenue logs the close code and reason of a connection the venue
+// closed, and delays its reconnection by the rate limit backoff, rather than
+// the usual reconnect backoff, if it was closed for being rate limited.
+func (conn *WebsocketConnection) closedByVenue(closeErr *websocket.CloseError) {
+	if !isRateLimitClose(closeErr) {
+		conn.logger.Warn().
+			Int("code", closeErr.Code).
+			Str("reason", closeErr.Text).
+			Msg("websocket closed by venue")
+		return
+	}
+
+	conn.logger.Warn().
+		Int("code", closeErr.Code).
+		Str("reason", closeErr.Text).
+		Dur("backoff", conn.rateLimitBackoff).
+		Msg("websocket closed by venue for rate limiting, backing off")
+
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+	conn.reconnectDelay = conn.rateLimitBackoff
+}
+
+// isRateLimitClose returns true if the connection was closed with a rate
+// limit close code, or with a reason telling it was rate limited.
+func isRateLimitClose(closeErr *websocket.CloseError) bool {
+	if _, ok := rateLimitCloseCodes[closeErr.Code]; ok {
+		return true
+	}
+	reason := strings.ToLower(closeErr.Text)
+	return strings.Contains(reason, "rate limit") || strings.Contains(reason, "too many requests")
+}
+
+// isDecompressionError returns true if the error was caused by a compressed
+// message that couldn't be inflated, whether its data is corrupt or truncated.
+// The websocket itself reports an unexpected EOF as a CloseError, so a bare
+// io.ErrUnexpectedEOF comes from the decompressor.
+func isDecompressionError(err error) bool {
+	var (
+		corruptInput flate.CorruptInputError
+		internal     flate.InternalError
+	)
+	return errors.As(err, &corruptInput) ||
+		errors.As(err, &internal) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
 func (conn *WebsocketConnection) readSuccess(messageType int, bz []byte) {
 	if len(bz) == 0 {
 		return
@@ -275,27 +736,39 @@ func (conn *WebsocketConnection) readSuccess(messageType int, bz []byte) {
 	if string(bz) == "pong" {
 		return
 	}
+	if len(conn.pongPayload) > 0 && bytes.Equal(bz, conn.pongPayload) {
+		return
+	}
 
 	conn.messageHandler(messageType, conn, bz)
 }
 
 // close sends a close message to the websocket and sets the client to nil
 func (conn *WebsocketConnection) close() {
+	if conn.closeClient() {
+		conn.sendEvent(ConnectionEventDisconnect)
+	}
+}
+
+// closeClient closes the websocket client and returns true if it was open.
+func (conn *WebsocketConnection) closeClient() bool {
 	conn.mtx.Lock()
 	defer conn.mtx.Unlock()
 
 	conn.logger.Debug().Msg("closing websocket")
 	conn.websocketCancelFunc()
 	if conn.client == nil {
-		return
+		return false
 	}
 	if err := conn.client.Close(); err != nil {
 		conn.logger.Err(fmt.Errorf(types.ErrWebsocketClose.Error(), conn.providerName, err)).Send()
 	}
 	conn.client = nil
+	return true
 }
 
-// reconnect closes the current websocket and starts a new connection process
+// reconnect closes the current websocket and starts a new connection process,
+// resubscribing to the connection's own subscription message.
 func (conn *WebsocketConnection) reconnect() {
 	conn.close()
 	go conn.start()
@@ -1,9 +1,20 @@
 package provider
 
 import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
 
@@ -60,3 +71,614 @@ func TestWebsocketController_readSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestWebsocketController_DialOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		endpoint := Endpoint{Name: ProviderMock}
+		require.Equal(t, websocket.DefaultDialer.HandshakeTimeout, endpoint.websocketDialer().HandshakeTimeout)
+		require.False(t, endpoint.websocketDialer().EnableCompression)
+		require.Nil(t, endpoint.websocketHeader())
+	})
+
+	t.Run("custom handshake timeout and headers", func(t *testing.T) {
+		userAgent := make(chan string, 1)
+		upgrader := websocket.Upgrader{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgent <- r.Header.Get("User-Agent")
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}))
+		defer server.Close()
+
+		endpoint := Endpoint{
+			Name:             ProviderMock,
+			HandshakeTimeout: 5 * time.Second,
+			// viper lowercases map keys
+			Headers: map[string]string{"user-agent": "price-feeder"},
+		}
+		require.Equal(t, 5*time.Second, endpoint.websocketDialer().HandshakeTimeout)
+		// the default dialer must not be modified
+		require.NotEqual(t, 5*time.Second, websocket.DefaultDialer.HandshakeTimeout)
+
+		wsURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		wsURL.Scheme = "ws"
+
+		wsc := NewWebsocketController(
+			context.Background(),
+			endpoint,
+			*wsURL,
+			[]interface{}{struct{}{}},
+			func(int, *WebsocketConnection, []byte) {},
+			disabledPingDuration,
+			websocket.PingMessage,
+			zerolog.Nop(),
+		)
+		require.NoError(t, wsc.connections[0].connect())
+		require.Equal(t, "price-feeder", <-userAgent)
+		wsc.connections[0].close()
+	})
+}
+
+func TestWebsocketController_Compression(t *testing.T) {
+	extensions := make(chan string, 2)
+	upgrader := websocket.Upgrader{EnableCompression: true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		extensions <- r.Header.Get("Sec-WebSocket-Extensions")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		// a compressed text frame whose payload isn't valid deflate data
+		_, _ = conn.UnderlyingConn().Write([]byte{0xc1, 0x04, 0xff, 0xff, 0xff, 0xff})
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	provider := TestProvider{}
+	wsc := NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderMock, Compression: true},
+		*wsURL,
+		[]interface{}{struct{}{}},
+		provider.messageHandler,
+		disabledPingDuration,
+		websocket.PingMessage,
+		zerolog.Nop(),
+	)
+	wsc.StartConnections()
+
+	// the corrupt message is dropped and the connection is re-established
+	for i := 0; i < 2; i++ {
+		select {
+		case ext := <-extensions:
+			require.Contains(t, ext, "permessage-deflate")
+		case <-time.After(5 * time.Second):
+			t.Fatal("websocket did not reconnect")
+		}
+	}
+	require.False(t, provider.handlerCalled)
+}
+
+func TestWebsocketController_EventHandler(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		// drop the connection after the subscription so the client reconnects
+		_, _, _ = conn.ReadMessage()
+		conn.Close()
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wsc := NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderMock},
+		*wsURL,
+		[]interface{}{struct{}{}},
+		func(int, *WebsocketConnection, []byte) {},
+		disabledPingDuration,
+		websocket.PingMessage,
+		zerolog.Nop(),
+	)
+
+	events := make(chan ConnectionEvent, 10)
+	wsc.SetEventHandler(func(event ConnectionEvent) {
+		events <- event
+	})
+	wsc.StartConnections()
+
+	for _, expected := range []ConnectionEventType{
+		ConnectionEventConnect,
+		ConnectionEventDisconnect,
+		ConnectionEventReconnect,
+	} {
+		select {
+		case event := <-events:
+			require.Equal(t, expected, event.Type)
+			require.Equal(t, ProviderMock, event.Provider)
+			require.False(t, event.Time.IsZero())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %s event received", expected)
+		}
+	}
+}
+
+func TestWebsocketController_SubscriptionBatch(t *testing.T) {
+	connections := make(chan struct{}, 2)
+	received := make(chan string, 3)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections <- struct{}{}
+		for {
+			_, bz, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- strings.TrimSpace(string(bz))
+		}
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wsc := NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderMock},
+		*wsURL,
+		[]interface{}{subscriptionBatch{msgs: []interface{}{"first", "second", "third"}, delay: 10 * time.Millisecond}},
+		func(int, *WebsocketConnection, []byte) {},
+		disabledPingDuration,
+		websocket.PingMessage,
+		zerolog.Nop(),
+	)
+	wsc.StartConnections()
+
+	// the messages of the batch are sent in order over a single connection
+	for _, expected := range []string{`"first"`, `"second"`, `"third"`} {
+		select {
+		case msg := <-received:
+			require.Equal(t, expected, msg)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("subscription message %s not received", expected)
+		}
+	}
+	require.Len(t, connections, 1)
+}
+
+func TestWebsocketController_SubscriptionBatchDropped(t *testing.T) {
+	var (
+		mtx         sync.Mutex
+		connections int
+		received    []string
+	)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		mtx.Lock()
+		connections++
+		first := connections == 1
+		mtx.Unlock()
+
+		for {
+			_, bz, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			// the first connection drops in the middle of the batch
+			if first {
+				return
+			}
+			mtx.Lock()
+			received = append(received, strings.TrimSpace(string(bz)))
+			mtx.Unlock()
+		}
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wsc := NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderMock},
+		*wsURL,
+		[]interface{}{subscriptionBatch{msgs: []interface{}{"first", "second"}, delay: 300 * time.Millisecond}},
+		func(int, *WebsocketConnection, []byte) {},
+		disabledPingDuration,
+		websocket.PingMessage,
+		zerolog.Nop(),
+	)
+	wsc.StartConnections()
+
+	// only the read loop reconnects and subscribes again, the interrupted
+	// batch is abandoned
+	time.Sleep(time.Second)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Equal(t, 2, connections)
+	require.Equal(t, []string{`"first"`, `"second"`}, received)
+}
+
+func TestWebsocketController_ReconnectsFailedConnectionOnly(t *testing.T) {
+	var (
+		mtx         sync.Mutex
+		connections = map[string]int{}
+	)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var sub struct{ Shard string }
+		if err := conn.ReadJSON(&sub); err != nil {
+			return
+		}
+		mtx.Lock()
+		connections[sub.Shard]++
+		mtx.Unlock()
+
+		// the flaky shard drops its connection right after subscribing
+		if sub.Shard == "flaky" {
+			return
+		}
+		for {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(sub.Shard)); err != nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages := make(chan string, 100)
+	wsc := NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderMock},
+		*wsURL,
+		[]interface{}{
+			map[string]string{"shard": "healthy"},
+			map[string]string{"shard": "flaky"},
+		},
+		func(_ int, _ *WebsocketConnection, bz []byte) {
+			select {
+			case messages <- string(bz):
+			default:
+			}
+		},
+		disabledPingDuration,
+		websocket.PingMessage,
+		zerolog.Nop(),
+	)
+
+	reconnected := make(chan struct{}, 10)
+	wsc.SetEventHandler(func(event ConnectionEvent) {
+		if event.Type == ConnectionEventReconnect {
+			reconnected <- struct{}{}
+		}
+	})
+	wsc.StartConnections()
+
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("flaky connection did not reconnect")
+	}
+
+	// the healthy shard keeps delivering messages on its first connection
+	for len(messages) > 0 {
+		<-messages
+	}
+	select {
+	case msg := <-messages:
+		require.Equal(t, "healthy", msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("healthy connection stopped delivering messages")
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Equal(t, 1, connections["healthy"])
+	require.GreaterOrEqual(t, connections["flaky"], 2)
+}
+
+func TestWebsocketController_RateLimitBackoff(t *testing.T) {
+	var (
+		mtx         sync.Mutex
+		connectedAt []time.Time
+	)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		mtx.Lock()
+		connectedAt = append(connectedAt, time.Now())
+		mtx.Unlock()
+
+		// the venue throttles the connection right after its subscription
+		var sub struct{ Shard string }
+		if err := conn.ReadJSON(&sub); err != nil {
+			return
+		}
+		_ = conn.WriteMessage(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(closeTooManyRequests, "too many requests"),
+		)
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backoff := 500 * time.Millisecond
+	wsc := NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderMock, RateLimitBackoff: backoff},
+		*wsURL,
+		[]interface{}{map[string]string{"shard": "throttled"}},
+		func(int, *WebsocketConnection, []byte) {},
+		disabledPingDuration,
+		websocket.PingMessage,
+		zerolog.Nop(),
+	)
+
+	reconnected := make(chan struct{}, 10)
+	wsc.SetEventHandler(func(event ConnectionEvent) {
+		if event.Type == ConnectionEventReconnect {
+			reconnected <- struct{}{}
+		}
+	})
+	wsc.StartConnections()
+
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("rate limited connection did not reconnect")
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.GreaterOrEqual(t, len(connectedAt), 2)
+	require.GreaterOrEqual(t, connectedAt[1].Sub(connectedAt[0]), backoff)
+}
+
+func TestIsRateLimitClose(t *testing.T) {
+	require.True(t, isRateLimitClose(&websocket.CloseError{Code: closeTooManyRequests}))
+	require.True(t, isRateLimitClose(&websocket.CloseError{Code: websocket.CloseTryAgainLater}))
+	require.True(t, isRateLimitClose(&websocket.CloseError{Code: websocket.ClosePolicyViolation, Text: "Rate limit exceeded"}))
+	require.False(t, isRateLimitClose(&websocket.CloseError{Code: websocket.CloseGoingAway, Text: "server restart"}))
+}
+
+func TestIsDecompressionError(t *testing.T) {
+	inflate := func(bz []byte) error {
+		_, err := io.ReadAll(flate.NewReader(bytes.NewReader(bz)))
+		return err
+	}
+
+	// corrupt data
+	require.True(t, isDecompressionError(inflate([]byte{0xff, 0xff, 0xff, 0xff})))
+	// a stored block truncated after its first byte
+	require.True(t, isDecompressionError(inflate([]byte{0x00, 0x20, 0x00, 0xdf, 0xff, 'a'})))
+	require.True(t, isDecompressionError(flate.InternalError("bad state")))
+
+	require.False(t, isDecompressionError(&websocket.CloseError{
+		Code: websocket.CloseAbnormalClosure,
+		Text: io.ErrUnexpectedEOF.Error(),
+	}))
+	require.False(t, isDecompressionError(io.EOF))
+}
+
+func TestJitteredPingDuration(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := jitteredPingDuration(15*time.Second, 3*time.Second)
+		require.LessOrEqual(t, d, 15*time.Second)
+		require.Greater(t, d, 12*time.Second)
+	}
+
+	// the jitter is capped at half of the ping duration
+	for i := 0; i < 100; i++ {
+		d := jitteredPingDuration(4*time.Second, 10*time.Second)
+		require.Greater(t, d, 2*time.Second)
+	}
+
+	require.Equal(t, 15*time.Second, jitteredPingDuration(15*time.Second, 0))
+	require.Equal(t, defaultPingJitter, Endpoint{}.pingJitter())
+}
+
+func TestWebsocketController_PingPayload(t *testing.T) {
+	received := make(chan string, 10)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			messageType, bz, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if messageType == websocket.TextMessage && string(bz) == `{"op":"ping"}` {
+				received <- string(bz)
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"op":"pong"}`))
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"op":"data"}`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	wsURL.Scheme = "ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handled := make(chan string, 10)
+	wsc := NewWebsocketController(
+		ctx,
+		Endpoint{Name: ProviderMock},
+		*wsURL,
+		[]interface{}{struct{}{}},
+		func(_ int, _ *WebsocketConnection, bz []byte) { handled <- string(bz) },
+		time.Minute,
+		websocket.TextMessage,
+		zerolog.Nop(),
+	)
+	wsc.SetPingPayload([]byte(`{"op":"ping"}`), []byte(`{"op":"pong"}`))
+	wsc.StartConnections()
+
+	select {
+	case msg := <-received:
+		require.Equal(t, `{"op":"ping"}`, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ping not received")
+	}
+
+	// the pong is dropped rather than handled
+	select {
+	case msg := <-handled:
+		require.Equal(t, `{"op":"data"}`, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not handled")
+	}
+}
+
+func TestWebsocketController_SubscribeRetries(t *testing.T) {
+	testCases := []struct {
+		name string
+		// ackAfter is the number of subscriptions the venue acknowledges
+		// from, or zero if it never does
+		ackAfter      int
+		subscriptions int
+		acknowledged  bool
+	}{
+		{name: "acknowledged after a retry", ackAfter: 2, subscriptions: 2, acknowledged: true},
+		{name: "never acknowledged", ackAfter: 0, subscriptions: 3, acknowledged: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				mtx           sync.Mutex
+				subscriptions int
+			)
+			upgrader := websocket.Upgrader{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conn, err := upgrader.Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				for {
+					var sub struct{ Channel string }
+					if err := conn.ReadJSON(&sub); err != nil {
+						return
+					}
+					mtx.Lock()
+					subscriptions++
+					ack := tc.ackAfter > 0 && subscriptions >= tc.ackAfter
+					mtx.Unlock()
+					if ack {
+						_ = conn.WriteMessage(websocket.TextMessage, []byte(sub.Channel))
+					}
+				}
+			}))
+			defer server.Close()
+
+			wsURL, err := url.Parse(server.URL)
+			require.NoError(t, err)
+			wsURL.Scheme = "ws"
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			acknowledged := make(chan struct{}, 10)
+			wsc := NewWebsocketController(
+				ctx,
+				Endpoint{Name: ProviderMock, SubscribeRetries: 2, SubscribeRetryDelay: 100 * time.Millisecond},
+				*wsURL,
+				[]interface{}{map[string]string{"channel": "ticker"}},
+				func(_ int, conn *WebsocketConnection, bz []byte) {
+					conn.AcknowledgeSubscription(string(bz))
+					acknowledged <- struct{}{}
+				},
+				disabledPingDuration,
+				websocket.PingMessage,
+				zerolog.Nop(),
+			)
+			wsc.SetSubscriptionAcks(func(msg interface{}) []string {
+				return []string{msg.(map[string]string)["channel"]}
+			})
+			wsc.StartConnections()
+
+			// wait for longer than all the retries
+			time.Sleep(time.Second)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			require.Equal(t, tc.subscriptions, subscriptions)
+			require.Equal(t, tc.acknowledged, len(acknowledged) > 0)
+		})
+	}
+}
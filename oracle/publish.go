@@ -0,0 +1,116 @@
+package oracle
+
+import (
+	"context"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/publisher"
+)
+
+// priceSource defines the providers an aggregated price was computed from and
+// their summed volume, in units of the quote.
+type priceSource struct {
+	providers []provider.Name
+	volume    sdk.Dec
+}
+
+// SetPublisher sets the publisher the prices are published to after each
+// collection. It must be called before Start.
+func (o *Oracle) SetPublisher(p publisher.Publisher) {
+	o.publisher = p
+}
+
+// publishPrices publishes the most recently collected prices, with the
+// providers and volume each of them was computed from.
+func (o *Oracle) publishPrices(ctx context.Context) {
+	if o.publisher == nil {
+		return
+	}
+
+	msgs := o.priceMessages()
+	if err := o.publisher.Publish(ctx, msgs); err != nil {
+		o.logger.Warn().Err(err).Msg("failed to publish prices")
+	}
+}
+
+// priceMessages returns the message of each of the most recently collected
+// prices, sorted by pair.
+func (o *Oracle) priceMessages() []publisher.Message {
+	o.pricesMutex.RLock()
+	defer o.pricesMutex.RUnlock()
+
+	msgs := make([]publisher.Message, 0, len(o.prices))
+	for base, price := range o.prices {
+		msg := publisher.Message{
+			Pair:      base + "/" + config.DenomUSD,
+			Price:     price,
+			Volume:    sdk.ZeroDec(),
+			Providers: []provider.Name{},
+			Timestamp: o.lastPriceSyncTS,
+		}
+		if source, ok := o.priceSources[base]; ok {
+			msg.Providers = source.providers
+			msg.Volume = source.volume
+		}
+		msgs = append(msgs, msg)
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].Pair < msgs[j].Pair
+	})
+	return msgs
+}
+
+// candleSources returns the providers having candles of each base and the sum
+// of the volume of their candles.
+func candleSources(candles provider.AggregatedProviderCandles) map[string]priceSource {
+	sources := make(map[string]priceSource)
+	for providerName, providerCandles := range candles {
+		for base, baseCandles := range providerCandles {
+			if len(baseCandles) == 0 {
+				continue
+			}
+			volume := sdk.ZeroDec()
+			for _, candle := range baseCandles {
+				volume = volume.Add(candle.Volume)
+			}
+			sources[base] = addPriceSource(sources[base], providerName, volume)
+		}
+	}
+	return sortPriceSources(sources)
+}
+
+// tickerSources returns the providers having a ticker of each base and the
+// sum of the volume of their tickers.
+func tickerSources(prices provider.AggregatedProviderPrices) map[string]priceSource {
+	sources := make(map[string]priceSource)
+	for providerName, tickers := range prices {
+		for base, ticker := range tickers {
+			sources[base] = addPriceSource(sources[base], providerName, ticker.Volume)
+		}
+	}
+	return sortPriceSources(sources)
+}
+
+func addPriceSource(source priceSource, providerName provider.Name, volume sdk.Dec) priceSource {
+	if source.volume.IsNil() {
+		source.volume = sdk.ZeroDec()
+	}
+	source.providers = append(source.providers, providerName)
+	source.volume = source.volume.Add(volume)
+	return source
+}
+
+// sortPriceSources sorts the providers of each source by name.
+func sortPriceSources(sources map[string]priceSource) map[string]priceSource {
+	for _, source := range sources {
+		providers := source.providers
+		sort.Slice(providers, func(i, j int) bool {
+			return providers[i] < providers[j]
+		})
+	}
+	return sources
+}
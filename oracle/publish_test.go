@@ -0,0 +1,77 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/publisher"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+type mockPublisher struct {
+	msgs []publisher.Message
+}
+
+func (m *mockPublisher) Publish(_ context.Context, msgs []publisher.Message) error {
+	m.msgs = append(m.msgs, msgs...)
+	return nil
+}
+
+func (m *mockPublisher) Close() error {
+	return nil
+}
+
+func TestOracle_PublishPrices(t *testing.T) {
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	ojoUSD := types.CurrencyPair{Base: "OJO", Quote: "USD"}
+
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderKraken:   {atomUSD, ojoUSD},
+			provider.ProviderCoinbase: {atomUSD},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	pub := &mockPublisher{}
+	o.SetPublisher(pub)
+
+	o.priceProviders[provider.ProviderKraken] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+			"OJOUSD":  {Price: sdk.MustNewDecFromStr("2"), Volume: sdk.MustNewDecFromStr("50")},
+		},
+	}
+	o.priceProviders[provider.ProviderCoinbase] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("300")},
+		},
+	}
+
+	require.NoError(t, o.SetPrices(context.Background()))
+	o.publishPrices(context.Background())
+
+	// volumes are converted to units of the quote
+	require.Len(t, pub.msgs, 2)
+
+	require.Equal(t, "ATOM/USD", pub.msgs[0].Pair)
+	require.Equal(t, sdk.MustNewDecFromStr("10"), pub.msgs[0].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("4000"), pub.msgs[0].Volume)
+	require.Equal(t, []provider.Name{provider.ProviderCoinbase, provider.ProviderKraken}, pub.msgs[0].Providers)
+	require.Equal(t, o.GetLastPriceSyncTimestamp(), pub.msgs[0].Timestamp)
+
+	require.Equal(t, "OJO/USD", pub.msgs[1].Pair)
+	require.Equal(t, sdk.MustNewDecFromStr("2"), pub.msgs[1].Price)
+	require.Equal(t, sdk.MustNewDecFromStr("100"), pub.msgs[1].Volume)
+	require.Equal(t, []provider.Name{provider.ProviderKraken}, pub.msgs[1].Providers)
+}
@@ -0,0 +1,203 @@
+package publisher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// natsConnect defines the CONNECT message of the NATS client protocol.
+//
+// REF: https://docs.nats.io/reference/reference-protocols/nats-protocol
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// natsPublisher publishes messages to a NATS server over a single connection
+// using the core NATS text protocol. The connection is opened on the first
+// publish and opened again on the next publish after it failed.
+type natsPublisher struct {
+	logger  zerolog.Logger
+	url     *url.URL
+	subject string
+
+	mtx  sync.Mutex
+	conn net.Conn
+}
+
+func newNATSPublisher(logger zerolog.Logger, brokerURL *url.URL, subject string) *natsPublisher {
+	return &natsPublisher{
+		logger:  logger.With().Str("module", "publisher").Logger(),
+		url:     brokerURL,
+		subject: subject,
+	}
+}
+
+// Publish publishes each message on the subject of its pair's base.
+func (p *natsPublisher) Publish(ctx context.Context, msgs []Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		base, _, _ := strings.Cut(msg.Pair, "/")
+		fmt.Fprintf(&buf, "PUB %s.%s %d\r\n", p.subject, base, len(payload))
+		buf.Write(payload)
+		buf.WriteString("\r\n")
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.conn == nil {
+		conn, err := p.connect(ctx)
+		if err != nil {
+			return err
+		}
+		p.conn = conn
+		go p.readLoop(conn)
+	}
+
+	if err := p.conn.SetWriteDeadline(time.Now().Add(publishTimeout)); err != nil {
+		return err
+	}
+	if _, err := p.conn.Write(buf.Bytes()); err != nil {
+		p.closeConn(p.conn)
+		return fmt.Errorf("failed to publish to %s: %w", p.url.Host, err)
+	}
+	return nil
+}
+
+// Close closes the connection to the server.
+func (p *natsPublisher) Close() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+// connect opens a connection to the server, reads its INFO and sends the
+// CONNECT message followed by a PING, whose PONG confirms that the server
+// accepted the connection.
+func (p *natsPublisher) connect(ctx context.Context) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.url.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", p.url.Host, err)
+	}
+	if err := p.handshake(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to %s: %w", p.url.Host, err)
+	}
+	return conn, nil
+}
+
+func (p *natsPublisher) handshake(conn net.Conn) error {
+	if err := conn.SetDeadline(time.Now().Add(publishTimeout)); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(conn)
+
+	info, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(info, "INFO") {
+		return fmt.Errorf("unexpected server message: %s", strings.TrimSpace(info))
+	}
+
+	connect := natsConnect{Name: "price-feeder", Lang: "go"}
+	if p.url.User != nil {
+		if pass, ok := p.url.User.Password(); ok {
+			connect.User, connect.Pass = p.url.User.Username(), pass
+		} else {
+			connect.Token = p.url.User.Username()
+		}
+	}
+	bz, err := json.Marshal(connect)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", bz); err != nil {
+		return err
+	}
+
+	// a -ERR is sent instead of the PONG when the connection is refused
+	reply, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(reply, "PONG") {
+		return fmt.Errorf("unexpected server message: %s", strings.TrimSpace(reply))
+	}
+	return conn.SetDeadline(time.Time{})
+}
+
+// readLoop answers the PINGs of the server, which closes connections that
+// don't answer them, and logs its errors until the connection fails.
+func (p *natsPublisher) readLoop(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			p.mtx.Lock()
+			p.closeConn(conn)
+			p.mtx.Unlock()
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(line, "PING"):
+			p.mtx.Lock()
+			err := conn.SetWriteDeadline(time.Now().Add(publishTimeout))
+			if err == nil {
+				_, err = conn.Write([]byte("PONG\r\n"))
+			}
+			p.mtx.Unlock()
+			if err != nil {
+				p.logger.Warn().Err(err).Msg("failed to answer nats ping")
+			}
+
+		case strings.HasPrefix(line, "-ERR"):
+			p.logger.Error().Str("error", strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))).Msg("nats server error")
+		}
+	}
+}
+
+// closeConn closes the connection and forgets it if it's still the current
+// one, so that the next publish connects again. It must be called with the
+// lock held.
+func (p *natsPublisher) closeConn(conn net.Conn) {
+	conn.Close()
+	if p.conn == conn {
+		p.conn = nil
+	}
+}
@@ -0,0 +1,154 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+// natsPub defines a message received by the test server.
+type natsPub struct {
+	subject string
+	payload []byte
+}
+
+// serveNATS accepts a single connection and sends its CONNECT and the messages
+// it publishes to the channels.
+func serveNATS(listener net.Listener, connects chan<- natsConnect, pubs chan<- natsPub) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"test\"}\r\n")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "CONNECT":
+			var connect natsConnect
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &connect); err != nil {
+				return
+			}
+			connects <- connect
+
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+
+		case "PUB":
+			var size int
+			if _, err := fmt.Sscan(fields[2], &size); err != nil {
+				return
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			pubs <- natsPub{subject: fields[1], payload: payload[:size]}
+		}
+	}
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	connects := make(chan natsConnect, 1)
+	pubs := make(chan natsPub, 2)
+	go serveNATS(listener, connects, pubs)
+
+	p, err := New(zerolog.Nop(), "nats://user:pass@"+listener.Addr().String(), "")
+	require.NoError(t, err)
+	defer p.Close()
+
+	timestamp := time.Unix(1700000000, 0).UTC()
+	msgs := []Message{
+		{
+			Pair:      "ATOM/USD",
+			Price:     sdk.MustNewDecFromStr("10.5"),
+			Volume:    sdk.MustNewDecFromStr("1000"),
+			Providers: []provider.Name{provider.ProviderBinance, provider.ProviderKraken},
+			Timestamp: timestamp,
+		},
+		{
+			Pair:      "OJO/USD",
+			Price:     sdk.MustNewDecFromStr("0.5"),
+			Volume:    sdk.ZeroDec(),
+			Providers: []provider.Name{provider.ProviderMexc},
+			Timestamp: timestamp,
+		},
+	}
+	require.NoError(t, p.Publish(context.Background(), msgs))
+
+	connect := <-connects
+	require.Equal(t, "user", connect.User)
+	require.Equal(t, "pass", connect.Pass)
+	require.False(t, connect.Verbose)
+
+	for _, expected := range msgs {
+		pub := <-pubs
+		base, _, _ := strings.Cut(expected.Pair, "/")
+		require.Equal(t, DefaultSubject+"."+base, pub.subject)
+
+		var msg Message
+		require.NoError(t, json.Unmarshal(pub.payload, &msg))
+		require.Equal(t, expected, msg)
+	}
+}
+
+func TestNATSPublisher_Reconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	p, err := New(zerolog.Nop(), "nats://"+addr, "feeds")
+	require.NoError(t, err)
+	defer p.Close()
+
+	msgs := []Message{{Pair: "ATOM/USD", Price: sdk.OneDec(), Volume: sdk.OneDec()}}
+
+	// the server isn't up yet
+	require.Error(t, p.Publish(context.Background(), msgs))
+
+	listener, err = net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	connects := make(chan natsConnect, 1)
+	pubs := make(chan natsPub, 1)
+	go serveNATS(listener, connects, pubs)
+
+	require.NoError(t, p.Publish(context.Background(), msgs))
+	require.Equal(t, "feeds.ATOM", (<-pubs).subject)
+}
+
+func TestParseURL(t *testing.T) {
+	_, err := ParseURL("nats://localhost:4222")
+	require.NoError(t, err)
+
+	_, err = ParseURL("kafka://localhost:9092")
+	require.Error(t, err)
+
+	_, err = ParseURL("nats://")
+	require.Error(t, err)
+}
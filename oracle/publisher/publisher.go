@@ -0,0 +1,72 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+const (
+	// SchemeNATS is the url scheme of NATS brokers.
+	SchemeNATS = "nats"
+
+	// DefaultSubject is the subject prefix of the published messages when
+	// none is configured.
+	DefaultSubject = "prices"
+
+	publishTimeout = 5 * time.Second
+)
+
+type (
+	// Publisher publishes the aggregated prices to a message broker.
+	Publisher interface {
+		Publish(ctx context.Context, msgs []Message) error
+		Close() error
+	}
+
+	// Message defines the message published for each aggregated price.
+	Message struct {
+		Pair      string          `json:"pair"`      // ex.: ATOM/USD
+		Price     sdk.Dec         `json:"price"`     // ex.: 10.21
+		Volume    sdk.Dec         `json:"volume"`    // volume of the contributing providers, in USD
+		Providers []provider.Name `json:"providers"` // providers contributing to the price
+		Timestamp time.Time       `json:"timestamp"` // time the price was aggregated
+	}
+)
+
+// ParseURL parses the url of a message broker and checks that its scheme is
+// supported.
+func ParseURL(rawURL string) (*url.URL, error) {
+	brokerURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid publisher url: %w", err)
+	}
+	if strings.ToLower(brokerURL.Scheme) != SchemeNATS {
+		return nil, fmt.Errorf("unsupported publisher url scheme %q, must be %s", brokerURL.Scheme, SchemeNATS)
+	}
+	if len(brokerURL.Host) == 0 {
+		return nil, fmt.Errorf("publisher url %s has no host", rawURL)
+	}
+	return brokerURL, nil
+}
+
+// New returns a publisher to the broker of the url, publishing each message
+// on the subject followed by the base of its pair, ex. "prices.ATOM".
+func New(logger zerolog.Logger, rawURL, subject string) (Publisher, error) {
+	brokerURL, err := ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(subject) == 0 {
+		subject = DefaultSubject
+	}
+
+	return newNATSPublisher(logger, brokerURL, subject), nil
+}
@@ -0,0 +1,89 @@
+package oracle
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+)
+
+// SetReadinessGate sets the gate holding the first pre-vote until every
+// asset has a price from enough providers. The wait is measured from the
+// first time the gate is set, so that a config reload doesn't restart it.
+// Without a gate the oracle is ready as soon as it has prices.
+func (o *Oracle) SetReadinessGate(gate config.ReadinessGate) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.readinessGate = &gate
+	if o.readinessStart.IsZero() {
+		o.readinessStart = time.Now()
+	}
+}
+
+// IsReady returns whether the readiness gate is open, and otherwise the
+// reasons it's held. Once open, the gate stays open.
+func (o *Oracle) IsReady() (bool, []string) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	if o.ready || o.readinessGate == nil {
+		return true, nil
+	}
+
+	pending := o.pendingReadiness()
+	if len(pending) == 0 {
+		o.logger.Info().Msg("readiness gate open, every asset has a price from enough providers")
+		o.ready = true
+		return true, nil
+	}
+
+	if time.Since(o.readinessStart) >= o.readinessGate.MaxWait && !o.readinessGate.AbstainOnTimeout {
+		o.logger.Warn().
+			Strs("pending", pending).
+			Msg("readiness gate timed out, voting with the available prices")
+		telemetry.IncrCounter(1, "readiness", "timeout")
+		o.ready = true
+		return true, nil
+	}
+	return false, pending
+}
+
+// pendingReadiness returns the assets of the last price collection which
+// don't have a price from enough of their voting providers. It must be called
+// with the configMtx held.
+func (o *Oracle) pendingReadiness() []string {
+	baseProviders := make(map[string]map[provider.Name]struct{})
+	for providerName, pairs := range o.votingPairs() {
+		for _, pair := range pairs {
+			if _, ok := baseProviders[pair.Base]; !ok {
+				baseProviders[pair.Base] = make(map[provider.Name]struct{})
+			}
+			baseProviders[pair.Base][providerName] = struct{}{}
+		}
+	}
+
+	o.pricesMutex.RLock()
+	defer o.pricesMutex.RUnlock()
+
+	if o.lastPriceSyncTS.IsZero() {
+		return []string{"no prices collected yet"}
+	}
+
+	pending := []string{}
+	for base, providers := range baseProviders {
+		required := o.readinessGate.MinProviders
+		if len(providers) < required {
+			required = len(providers)
+		}
+		if reporting := len(o.priceSources[base].providers); reporting < required {
+			pending = append(pending, fmt.Sprintf("%s has a price from %d of %d providers", base, reporting, required))
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
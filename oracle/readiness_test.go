@@ -0,0 +1,91 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestOracle_IsReady(t *testing.T) {
+	newOracle := func() *Oracle {
+		o := New(
+			zerolog.Nop(),
+			client.OracleClient{},
+			map[provider.Name][]types.CurrencyPair{
+				provider.ProviderKraken:   {{Base: "ATOM", Quote: "USD"}, {Base: "OJO", Quote: "USD"}},
+				provider.ProviderCoinbase: {{Base: "ATOM", Quote: "USD"}},
+			},
+			time.Second,
+			make(map[string]sdk.Dec),
+			make(map[provider.Name]provider.Endpoint),
+		)
+		o.priceProviders[provider.ProviderKraken] = mockProvider{
+			prices: map[string]types.TickerPrice{
+				"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+				"OJOUSD":  {Price: sdk.MustNewDecFromStr("1"), Volume: sdk.MustNewDecFromStr("100")},
+			},
+		}
+		o.priceProviders[provider.ProviderCoinbase] = mockProvider{
+			prices: map[string]types.TickerPrice{},
+		}
+		return o
+	}
+
+	t.Run("without gate", func(t *testing.T) {
+		o := newOracle()
+		ready, pending := o.IsReady()
+		require.True(t, ready)
+		require.Empty(t, pending)
+	})
+
+	t.Run("held until providers report", func(t *testing.T) {
+		o := newOracle()
+		o.SetReadinessGate(config.ReadinessGate{MinProviders: 3, MaxWait: time.Hour})
+
+		ready, pending := o.IsReady()
+		require.False(t, ready)
+		require.Equal(t, []string{"no prices collected yet"}, pending)
+
+		// OJO only has one provider, but ATOM lacks coinbase's price
+		require.NoError(t, o.SetPrices(context.Background()))
+		ready, pending = o.IsReady()
+		require.False(t, ready)
+		require.Equal(t, []string{"ATOM has a price from 1 of 2 providers"}, pending)
+
+		o.priceProviders[provider.ProviderCoinbase] = mockProvider{
+			prices: map[string]types.TickerPrice{
+				"ATOMUSD": {Price: sdk.MustNewDecFromStr("10.1"), Volume: sdk.MustNewDecFromStr("100")},
+			},
+		}
+		require.NoError(t, o.SetPrices(context.Background()))
+		ready, _ = o.IsReady()
+		require.True(t, ready)
+
+		// the gate stays open
+		o.priceProviders[provider.ProviderCoinbase] = mockProvider{prices: map[string]types.TickerPrice{}}
+		require.NoError(t, o.SetPrices(context.Background()))
+		ready, _ = o.IsReady()
+		require.True(t, ready)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		o := newOracle()
+		o.SetReadinessGate(config.ReadinessGate{MinProviders: 3, AbstainOnTimeout: true})
+		require.NoError(t, o.SetPrices(context.Background()))
+		ready, _ := o.IsReady()
+		require.False(t, ready)
+
+		o.SetReadinessGate(config.ReadinessGate{MinProviders: 3})
+		ready, _ = o.IsReady()
+		require.True(t, ready)
+	})
+}
@@ -0,0 +1,141 @@
+package oracle
+
+import (
+	"context"
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// Reload applies a new, already validated, configuration to the running
+// oracle. Providers that were added or whose endpoint changed are created
+// before anything is applied so that a failing provider leaves the oracle
+// untouched. Providers that are kept subscribe to their newly added pairs
+// while keeping their existing connections, and providers that were removed
+// are stopped. Pairs removed from a kept provider are unsubscribed from, and
+// so no longer requested from it. Providers whose symbol aliases changed are
+// replaced too. Disabled providers stay disabled and are only created once
+// enabled.
+func (o *Oracle) Reload(
+	ctx context.Context,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	deviations map[string]sdk.Dec,
+	endpoints map[provider.Name]provider.Endpoint,
+	aggregations map[string]config.Aggregation,
+	symbolAliases map[provider.Name]provider.SymbolAliases,
+) error {
+	// create the new providers without holding the lock since confirming
+	// their pairs can take a while, from the settings copied under it
+	type replacedProvider struct {
+		endpoint provider.Endpoint
+		aliases  provider.SymbolAliases
+	}
+	o.configMtx.Lock()
+	replacedProviders := make(map[provider.Name]replacedProvider)
+	for providerName := range providerPairs {
+		if _, ok := o.disabledProviders[providerName]; ok {
+			continue
+		}
+		_, ok := o.priceProviders[providerName]
+		if !ok ||
+			!reflect.DeepEqual(o.endpoints[providerName], endpoints[providerName]) ||
+			!reflect.DeepEqual(o.symbolAliases[providerName], symbolAliases[providerName]) {
+			replacedProviders[providerName] = replacedProvider{
+				endpoint: endpoints[providerName],
+				aliases:  symbolAliases[providerName],
+			}
+		}
+	}
+	limiter := o.providerLimiter
+	o.configMtx.Unlock()
+
+	newProviders := make(map[provider.Name]provider.Provider, len(replacedProviders))
+	newCancels := make(map[provider.Name]context.CancelFunc, len(replacedProviders))
+	for providerName, replaced := range replacedProviders {
+		newProvider, cancel, err := limiter.newPriceProvider(
+			ctx,
+			providerName,
+			o.logger,
+			replaced.endpoint,
+			replaced.aliases,
+			providerPairs[providerName]...,
+		)
+		if err != nil {
+			for _, cancel := range newCancels {
+				cancel()
+			}
+			return err
+		}
+		newProviders[providerName] = newProvider
+		newCancels[providerName] = cancel
+	}
+
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	for providerName, priceProvider := range o.priceProviders {
+		_, replaced := newProviders[providerName]
+		if _, ok := providerPairs[providerName]; !ok || replaced {
+			o.stopProvider(providerName)
+			continue
+		}
+
+		addedPairs := pairsDifference(providerPairs[providerName], o.providerPairs[providerName])
+		if len(addedPairs) > 0 {
+			priceProvider.SubscribeCurrencyPairs(addedPairs...)
+		}
+		removedPairs := pairsDifference(o.providerPairs[providerName], providerPairs[providerName])
+		if len(removedPairs) > 0 {
+			priceProvider.UnsubscribeCurrencyPairs(removedPairs...)
+		}
+	}
+
+	for providerName, newProvider := range newProviders {
+		newProvider.StartConnections()
+		o.priceProviders[providerName] = newProvider
+		o.providerCancels[providerName] = newCancels[providerName]
+	}
+
+	o.providerPairs = providerPairs
+	o.deviations = deviations
+	o.endpoints = endpoints
+	o.aggregations = aggregations
+	o.symbolAliases = symbolAliases
+
+	o.logger.Info().
+		Int("providers", len(providerPairs)).
+		Int("restarted_providers", len(newProviders)).
+		Msg("reloaded oracle configuration")
+
+	return nil
+}
+
+// stopProvider cancels the context of a provider and removes it from the
+// oracle. It must be called while holding the configMtx.
+func (o *Oracle) stopProvider(providerName provider.Name) {
+	if cancel, ok := o.providerCancels[providerName]; ok {
+		cancel()
+	}
+	delete(o.priceProviders, providerName)
+	delete(o.providerCancels, providerName)
+}
+
+// pairsDifference returns the pairs of a which are not in b.
+func pairsDifference(a, b []types.CurrencyPair) []types.CurrencyPair {
+	existing := make(map[string]struct{}, len(b))
+	for _, cp := range b {
+		existing[cp.String()] = struct{}{}
+	}
+
+	difference := []types.CurrencyPair{}
+	for _, cp := range a {
+		if _, ok := existing[cp.String()]; !ok {
+			difference = append(difference, cp)
+		}
+	}
+	return difference
+}
@@ -0,0 +1,157 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+type subscribingProvider struct {
+	mockProvider
+
+	subscribed   []types.CurrencyPair
+	unsubscribed []types.CurrencyPair
+}
+
+func (p *subscribingProvider) SubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.subscribed = append(p.subscribed, cps...)
+}
+
+func (p *subscribingProvider) UnsubscribeCurrencyPairs(cps ...types.CurrencyPair) {
+	p.unsubscribed = append(p.unsubscribed, cps...)
+}
+
+func TestOracle_Reload(t *testing.T) {
+	ojoPair := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+	atomPair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	binance := &subscribingProvider{}
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance: {ojoPair},
+		},
+		0,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	o.priceProviders[provider.ProviderBinance] = binance
+
+	t.Run("add pairs and providers", func(t *testing.T) {
+		providerPairs := map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance: {ojoPair, atomPair},
+			provider.ProviderMock:    {atomPair},
+		}
+		deviations := map[string]sdk.Dec{"ATOM": sdk.OneDec()}
+
+		err := o.Reload(
+			context.Background(),
+			providerPairs,
+			deviations,
+			make(map[provider.Name]provider.Endpoint),
+			make(map[string]config.Aggregation),
+			nil,
+		)
+		require.NoError(t, err)
+
+		// the existing provider is kept and only subscribes to the new pair
+		require.Same(t, binance, o.priceProviders[provider.ProviderBinance])
+		require.Equal(t, []types.CurrencyPair{atomPair}, binance.subscribed)
+		require.Contains(t, o.priceProviders, provider.ProviderMock)
+		require.Equal(t, providerPairs, o.providerPairs)
+		require.Equal(t, deviations, o.deviations)
+	})
+
+	t.Run("invalid provider rolls back", func(t *testing.T) {
+		err := o.Reload(
+			context.Background(),
+			map[provider.Name][]types.CurrencyPair{
+				provider.ProviderBinance: {ojoPair},
+				"foo":                    {atomPair},
+			},
+			make(map[string]sdk.Dec),
+			make(map[provider.Name]provider.Endpoint),
+			make(map[string]config.Aggregation),
+			nil,
+		)
+		require.Error(t, err)
+		require.Len(t, o.providerPairs[provider.ProviderBinance], 2)
+		require.Contains(t, o.priceProviders, provider.ProviderMock)
+	})
+
+	t.Run("changed aliases replace providers", func(t *testing.T) {
+		mock := o.priceProviders[provider.ProviderMock]
+		symbolAliases := map[provider.Name]provider.SymbolAliases{
+			provider.ProviderMock: {"ATOM": "COSMOS"},
+		}
+		err := o.Reload(
+			context.Background(),
+			map[provider.Name][]types.CurrencyPair{
+				provider.ProviderBinance: {ojoPair, atomPair},
+				provider.ProviderMock:    {atomPair},
+			},
+			make(map[string]sdk.Dec),
+			make(map[provider.Name]provider.Endpoint),
+			make(map[string]config.Aggregation),
+			symbolAliases,
+		)
+		require.NoError(t, err)
+
+		// the new provider is created with the new aliases
+		require.Same(t, binance, o.priceProviders[provider.ProviderBinance])
+		require.NotSame(t, mock, o.priceProviders[provider.ProviderMock])
+		require.Equal(t, symbolAliases, o.symbolAliases)
+	})
+
+	t.Run("remove providers", func(t *testing.T) {
+		err := o.Reload(
+			context.Background(),
+			map[provider.Name][]types.CurrencyPair{
+				provider.ProviderBinance: {ojoPair},
+			},
+			make(map[string]sdk.Dec),
+			make(map[provider.Name]provider.Endpoint),
+			make(map[string]config.Aggregation),
+			nil,
+		)
+		require.NoError(t, err)
+		require.Same(t, binance, o.priceProviders[provider.ProviderBinance])
+		require.NotContains(t, o.priceProviders, provider.ProviderMock)
+		require.NotContains(t, o.providerCancels, provider.ProviderMock)
+		require.Len(t, binance.subscribed, 1)
+
+		// the kept provider unsubscribes from its removed pair
+		require.Equal(t, []types.CurrencyPair{atomPair}, binance.unsubscribed)
+	})
+}
+
+func TestOracle_SetSymbolAliases(t *testing.T) {
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		make(map[provider.Name][]types.CurrencyPair),
+		0,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	binance := &subscribingProvider{}
+	kraken := &subscribingProvider{}
+	o.priceProviders[provider.ProviderBinance] = binance
+	o.priceProviders[provider.ProviderKraken] = kraken
+
+	// only the provider whose aliases changed is stopped
+	o.SetSymbolAliases(map[provider.Name]provider.SymbolAliases{
+		provider.ProviderKraken: {"MATIC": "POL"},
+	})
+	require.Same(t, binance, o.priceProviders[provider.ProviderBinance])
+	require.NotContains(t, o.priceProviders, provider.ProviderKraken)
+}
@@ -0,0 +1,157 @@
+package oracle
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// quoteRouteSeparator separates a provider's name from the quote of the
+// route its prices were collected through, ex. kraken:USDT.
+const quoteRouteSeparator = ":"
+
+// QuoteRouteName returns the name the prices of the provider's pair are
+// collected under. A provider listing the pair's base through several quotes,
+// ex. ATOM/USD and ATOM/USDT, has one primary route, its USD pair or else its
+// first pair of the base, collected under the provider's name. Each of its
+// other routes is collected under the provider's name suffixed with the
+// route's quote, so that every route is converted to USD with its own quote
+// rather than overwriting the others.
+func QuoteRouteName(
+	providerName provider.Name,
+	pair types.CurrencyPair,
+	pairs []types.CurrencyPair,
+) provider.Name {
+	var primary *types.CurrencyPair
+	for i, cp := range pairs {
+		if cp.Base != pair.Base {
+			continue
+		}
+		if strings.ToUpper(cp.Quote) == config.DenomUSD {
+			primary = &pairs[i]
+			break
+		}
+		if primary == nil {
+			primary = &pairs[i]
+		}
+	}
+	if primary == nil || primary.Quote == pair.Quote {
+		return providerName
+	}
+	return provider.Name(providerName.String() + quoteRouteSeparator + pair.Quote)
+}
+
+// SplitQuoteRoutes returns the pairs of the providers keyed by the name of
+// their quote route, see QuoteRouteName.
+func SplitQuoteRoutes(
+	providerPairs map[provider.Name][]types.CurrencyPair,
+) map[provider.Name][]types.CurrencyPair {
+	routePairs := make(map[provider.Name][]types.CurrencyPair, len(providerPairs))
+	for providerName, pairs := range providerPairs {
+		for _, pair := range pairs {
+			routeName := QuoteRouteName(providerName, pair, pairs)
+			routePairs[routeName] = append(routePairs[routeName], pair)
+		}
+	}
+	return routePairs
+}
+
+// quoteRouteProvider returns the provider of the quote route.
+func quoteRouteProvider(routeName provider.Name) provider.Name {
+	providerName, _, _ := strings.Cut(routeName.String(), quoteRouteSeparator)
+	return provider.Name(providerName)
+}
+
+// routePrice returns the provider's price of the base from the prices keyed
+// by quote route: the price of its primary route, or else of the first of its
+// other routes, in name order, which has a price for it.
+func routePrice(
+	prices map[provider.Name]map[string]sdk.Dec,
+	providerName provider.Name,
+	base string,
+) (sdk.Dec, bool) {
+	if p, ok := prices[providerName][base]; ok {
+		return p, true
+	}
+	for _, routeName := range sortedPriceProviders(prices) {
+		if quoteRouteProvider(routeName) != providerName {
+			continue
+		}
+		if p, ok := prices[routeName][base]; ok {
+			return p, true
+		}
+	}
+	return sdk.Dec{}, false
+}
+
+// MergeQuoteRouteCandles merges the USD converted candles of the secondary
+// quote routes of the providers into the candles of their provider, so that a
+// provider reaching a base through several quotes contributes to its price
+// once, weighted by the volume of all of its routes.
+func MergeQuoteRouteCandles(candles provider.AggregatedProviderCandles) provider.AggregatedProviderCandles {
+	merged := make(provider.AggregatedProviderCandles, len(candles))
+	for routeName, routeCandles := range candles {
+		providerName := quoteRouteProvider(routeName)
+		if _, ok := merged[providerName]; !ok {
+			merged[providerName] = make(map[string][]types.CandlePrice, len(routeCandles))
+		}
+		for base, baseCandles := range routeCandles {
+			merged[providerName][base] = append(merged[providerName][base], baseCandles...)
+		}
+	}
+	return merged
+}
+
+// MergeQuoteRouteTickers merges the USD converted tickers of the secondary
+// quote routes of the providers into the ticker of their provider, whose
+// price is the volume weighted average of its routes' prices and whose volume
+// is their sum.
+func MergeQuoteRouteTickers(prices provider.AggregatedProviderPrices) provider.AggregatedProviderPrices {
+	merged := make(provider.AggregatedProviderPrices, len(prices))
+	for routeName, routePrices := range prices {
+		providerName := quoteRouteProvider(routeName)
+		if _, ok := merged[providerName]; !ok {
+			merged[providerName] = make(map[string]types.TickerPrice, len(routePrices))
+		}
+		for base, tp := range routePrices {
+			existing, ok := merged[providerName][base]
+			if !ok {
+				merged[providerName][base] = tp
+				continue
+			}
+			merged[providerName][base] = mergeTickers(existing, tp)
+		}
+	}
+	return merged
+}
+
+// mergeTickers returns the ticker of the two routes of a provider's base. Its
+// price is the volume weighted average of their prices, or their average when
+// neither has a volume.
+func mergeTickers(a, b types.TickerPrice) types.TickerPrice {
+	volume := a.Volume.Add(b.Volume)
+	merged := types.TickerPrice{
+		Volume:    volume,
+		TimeStamp: a.TimeStamp,
+	}
+	if b.TimeStamp > merged.TimeStamp {
+		merged.TimeStamp = b.TimeStamp
+	}
+	if volume.IsPositive() {
+		merged.Price = a.Price.Mul(a.Volume).Add(b.Price.Mul(b.Volume)).Quo(volume)
+	} else {
+		merged.Price = a.Price.Add(b.Price).Quo(sdk.NewDec(2))
+	}
+	return merged
+}
+
+// isDerivedRoute returns whether the provider of the quote route derives its
+// prices from other venues' prices.
+func isDerivedRoute(routeName provider.Name) bool {
+	providerName := quoteRouteProvider(routeName)
+	return providerName.IsDerived()
+}
@@ -0,0 +1,141 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestQuoteRouteName(t *testing.T) {
+	atomUSD := types.CurrencyPair{Base: "ATOM", Quote: "USD"}
+	atomUSDT := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	atomUSDC := types.CurrencyPair{Base: "ATOM", Quote: "USDC"}
+	usdtUSD := types.CurrencyPair{Base: "USDT", Quote: "USD"}
+
+	// the USD pair is the primary route wherever it's listed
+	pairs := []types.CurrencyPair{atomUSDT, usdtUSD, atomUSD}
+	require.Equal(t, provider.ProviderKraken, QuoteRouteName(provider.ProviderKraken, atomUSD, pairs))
+	require.Equal(t, provider.Name("kraken:USDT"), QuoteRouteName(provider.ProviderKraken, atomUSDT, pairs))
+	require.Equal(t, provider.ProviderKraken, QuoteRouteName(provider.ProviderKraken, usdtUSD, pairs))
+
+	// otherwise the first pair of the base is
+	pairs = []types.CurrencyPair{atomUSDT, atomUSDC}
+	require.Equal(t, provider.ProviderKraken, QuoteRouteName(provider.ProviderKraken, atomUSDT, pairs))
+	require.Equal(t, provider.Name("kraken:USDC"), QuoteRouteName(provider.ProviderKraken, atomUSDC, pairs))
+
+	require.Equal(t, map[provider.Name][]types.CurrencyPair{
+		provider.ProviderKraken:   {atomUSD, usdtUSD},
+		"kraken:USDT":             {atomUSDT},
+		provider.ProviderCoinbase: {atomUSDT},
+	}, SplitQuoteRoutes(map[provider.Name][]types.CurrencyPair{
+		provider.ProviderKraken:   {atomUSD, atomUSDT, usdtUSD},
+		provider.ProviderCoinbase: {atomUSDT},
+	}))
+}
+
+func TestMergeQuoteRoutes(t *testing.T) {
+	now := provider.PastUnixTime(0)
+	tickers := provider.AggregatedProviderPrices{
+		provider.ProviderKraken: {
+			"ATOM": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("300"), TimeStamp: now - 1000},
+			"OJO":  {Price: sdk.MustNewDecFromStr("1"), Volume: sdk.MustNewDecFromStr("50"), TimeStamp: now},
+		},
+		"kraken:USDT": {
+			"ATOM": {Price: sdk.MustNewDecFromStr("12"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now},
+		},
+	}
+	require.Equal(t, provider.AggregatedProviderPrices{
+		provider.ProviderKraken: {
+			"ATOM": {Price: sdk.MustNewDecFromStr("10.5"), Volume: sdk.MustNewDecFromStr("400"), TimeStamp: now},
+			"OJO":  {Price: sdk.MustNewDecFromStr("1"), Volume: sdk.MustNewDecFromStr("50"), TimeStamp: now},
+		},
+	}, MergeQuoteRouteTickers(tickers))
+
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderKraken: {
+			"ATOM": {{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("3"), TimeStamp: now}},
+		},
+		"kraken:USDT": {
+			"ATOM": {{Price: sdk.MustNewDecFromStr("12"), Volume: sdk.MustNewDecFromStr("1"), TimeStamp: now}},
+		},
+	}
+	require.Equal(t, provider.AggregatedProviderCandles{
+		provider.ProviderKraken: {
+			"ATOM": {
+				{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("3"), TimeStamp: now},
+				{Price: sdk.MustNewDecFromStr("12"), Volume: sdk.MustNewDecFromStr("1"), TimeStamp: now},
+			},
+		},
+	}, sortedRouteCandles(MergeQuoteRouteCandles(candles)))
+}
+
+// sortedRouteCandles orders the merged candles of each base by price, as the
+// routes are merged in map order.
+func sortedRouteCandles(candles provider.AggregatedProviderCandles) provider.AggregatedProviderCandles {
+	for _, providerCandles := range candles {
+		for _, baseCandles := range providerCandles {
+			if len(baseCandles) == 2 && baseCandles[0].Price.GT(baseCandles[1].Price) {
+				baseCandles[0], baseCandles[1] = baseCandles[1], baseCandles[0]
+			}
+		}
+	}
+	return candles
+}
+
+func TestOracle_DuplicateQuotes(t *testing.T) {
+	newOracle := func(policy string) *Oracle {
+		o := New(
+			zerolog.Nop(),
+			client.OracleClient{},
+			map[provider.Name][]types.CurrencyPair{
+				provider.ProviderKraken: {
+					{Base: "ATOM", Quote: "USDT"},
+					{Base: "ATOM", Quote: "USD"},
+					{Base: "USDT", Quote: "USD"},
+				},
+			},
+			time.Second,
+			make(map[string]sdk.Dec),
+			make(map[provider.Name]provider.Endpoint),
+		)
+		o.SetDuplicateQuotes(policy)
+		o.priceProviders[provider.ProviderKraken] = mockProvider{
+			prices: map[string]types.TickerPrice{
+				"ATOMUSD":  {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+				"ATOMUSDT": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+				"USDTUSD":  {Price: sdk.MustNewDecFromStr("0.5"), Volume: sdk.MustNewDecFromStr("100")},
+			},
+		}
+		return o
+	}
+
+	t.Run("merge", func(t *testing.T) {
+		o := newOracle(config.DuplicateQuotesMerge)
+		require.NoError(t, o.SetPrices(context.Background()))
+
+		// the USDT route is worth 5 USD with half the USD volume of the USD
+		// route, both contributing to kraken's single price
+		tvwaps := o.GetTvwapPrices()
+		require.Len(t, tvwaps, 1)
+		require.Equal(t, sdk.NewDec(25).QuoInt64(3), tvwaps[provider.ProviderKraken]["ATOM"])
+		require.Equal(t, sdk.NewDec(25).QuoInt64(3), o.GetPrices()["ATOM"])
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		o := newOracle(config.DuplicateQuotesDistinct)
+		require.NoError(t, o.SetPrices(context.Background()))
+
+		tvwaps := o.GetTvwapPrices()
+		require.Equal(t, sdk.NewDec(10), tvwaps[provider.ProviderKraken]["ATOM"])
+		require.Equal(t, sdk.NewDec(5), tvwaps["kraken:USDT"]["ATOM"])
+	})
+}
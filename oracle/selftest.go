@@ -0,0 +1,146 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+// selfTestPollInterval is the time between each attempt to read the prices of
+// a provider under test.
+const selfTestPollInterval = 500 * time.Millisecond
+
+// SelfTestResult defines the outcome of testing a single provider against its
+// live venue.
+type SelfTestResult struct {
+	Provider provider.Name
+	Pair     types.CurrencyPair
+	Ticker   bool
+	Candle   bool
+	Duration time.Duration
+	Err      error
+}
+
+// OK returns true if the provider returned both a ticker and a candle.
+func (r SelfTestResult) OK() bool {
+	return r.Ticker && r.Candle
+}
+
+// SelfTest creates each provider with its first pair using the same
+// constructors as the oracle, and waits up to timeout for it to return at
+// least one ticker and one candle of the pair. The providers are tested
+// concurrently and stopped once done. The results are sorted by provider name.
+func SelfTest(
+	ctx context.Context,
+	logger zerolog.Logger,
+	providerPairs map[provider.Name][]types.CurrencyPair,
+	endpoints map[provider.Name]provider.Endpoint,
+	symbolAliases map[provider.Name]provider.SymbolAliases,
+	timeout time.Duration,
+) []SelfTestResult {
+	var (
+		mtx     sync.Mutex
+		wg      sync.WaitGroup
+		results = make([]SelfTestResult, 0, len(providerPairs))
+	)
+
+	for providerName, pairs := range providerPairs {
+		if len(pairs) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(providerName provider.Name, cp types.CurrencyPair) {
+			defer wg.Done()
+
+			result := selfTestProvider(ctx, logger, providerName, endpoints[providerName], symbolAliases[providerName], cp, timeout)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			results = append(results, result)
+		}(providerName, pairs[0])
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Provider < results[j].Provider
+	})
+	return results
+}
+
+// selfTestProvider creates and starts the provider, checks it and stops it.
+func selfTestProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	providerName provider.Name,
+	endpoint provider.Endpoint,
+	aliases provider.SymbolAliases,
+	cp types.CurrencyPair,
+	timeout time.Duration,
+) SelfTestResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	priceProvider, stop, err := newPriceProvider(ctx, providerName, logger, endpoint, aliases, cp)
+	if err != nil {
+		return SelfTestResult{
+			Provider: providerName,
+			Pair:     cp,
+			Duration: time.Since(start),
+			Err:      fmt.Errorf("failed to create provider: %w", err),
+		}
+	}
+	defer stop()
+
+	priceProvider.StartConnections()
+	result := checkProvider(ctx, priceProvider, cp)
+	result.Provider = providerName
+	result.Duration = time.Since(start)
+	return result
+}
+
+// checkProvider polls the provider until it returns a ticker and a candle of
+// the pair or the context is done. The last error returned by the provider is
+// kept if it never returned both.
+func checkProvider(ctx context.Context, priceProvider provider.Provider, cp types.CurrencyPair) SelfTestResult {
+	result := SelfTestResult{Pair: cp}
+	for {
+		if !result.Ticker {
+			prices, err := priceProvider.GetTickerPrices(ctx, cp)
+			if _, ok := prices[cp.String()]; ok {
+				result.Ticker = true
+			} else if err != nil {
+				result.Err = err
+			}
+		}
+		if !result.Candle {
+			candles, err := priceProvider.GetCandlePrices(ctx, cp)
+			if len(candles[cp.String()]) > 0 {
+				result.Candle = true
+			} else if err != nil {
+				result.Err = err
+			}
+		}
+		if result.OK() {
+			result.Err = nil
+			return result
+		}
+
+		select {
+		case <-ctx.Done():
+			if result.Err == nil {
+				result.Err = fmt.Errorf("no ticker and candle of %s received in time", cp)
+			}
+			return result
+		case <-time.After(selfTestPollInterval):
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestCheckProvider(t *testing.T) {
+	cp := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	t.Run("ticker and candle", func(t *testing.T) {
+		p := mockProvider{
+			prices: map[string]types.TickerPrice{
+				"ATOMUSDT": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+			},
+		}
+
+		result := checkProvider(context.Background(), p, cp)
+		require.True(t, result.OK())
+		require.NoError(t, result.Err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		result := checkProvider(ctx, mockProvider{}, cp)
+		require.False(t, result.Ticker)
+		require.False(t, result.Candle)
+		require.Error(t, result.Err)
+	})
+}
+
+func TestSelfTest_InvalidProvider(t *testing.T) {
+	results := SelfTest(
+		context.Background(),
+		zerolog.Nop(),
+		map[provider.Name][]types.CurrencyPair{
+			"foo": {{Base: "ATOM", Quote: "USDT"}},
+		},
+		make(map[provider.Name]provider.Endpoint),
+		make(map[provider.Name]provider.SymbolAliases),
+		time.Second,
+	)
+	require.Len(t, results, 1)
+	require.Equal(t, provider.Name("foo"), results[0].Provider)
+	require.False(t, results[0].OK())
+	require.ErrorContains(t, results[0].Err, "failed to create provider")
+}
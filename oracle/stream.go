@@ -0,0 +1,99 @@
+package oracle
+
+import (
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// defaultStreamBuffer is the amount of updates buffered for a subscriber
+// before further updates are dropped.
+const defaultStreamBuffer = 16
+
+type (
+	// PricesUpdate defines the aggregated prices of a price sync and the time
+	// they were computed at.
+	PricesUpdate struct {
+		Prices    map[string]sdk.Dec `json:"prices"`
+		Timestamp time.Time          `json:"timestamp"`
+	}
+
+	// PriceStream fans out the aggregated prices to its subscribers. Each
+	// subscriber has a buffered channel, and the updates a subscriber is too
+	// slow to receive are dropped so that publishing never blocks the price
+	// sync.
+	PriceStream struct {
+		subscribers map[chan PricesUpdate]struct{}
+		mx          sync.Mutex
+	}
+)
+
+// NewPriceStream creates a PriceStream without subscribers.
+func NewPriceStream() *PriceStream {
+	return &PriceStream{
+		subscribers: make(map[chan PricesUpdate]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the prices published from now on,
+// buffering up to buffer updates, and the function unsubscribing it. The
+// channel is closed once unsubscribed.
+func (s *PriceStream) Subscribe(buffer int) (<-chan PricesUpdate, func()) {
+	if buffer <= 0 {
+		buffer = defaultStreamBuffer
+	}
+	ch := make(chan PricesUpdate, buffer)
+
+	s.mx.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mx.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mx.Lock()
+			delete(s.subscribers, ch)
+			s.mx.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish sends the prices computed at the given time to the subscribers,
+// dropping the update for the subscribers whose buffer is full. It returns
+// the amount of subscribers the update was dropped for. Publishing to a nil
+// PriceStream does nothing.
+func (s *PriceStream) Publish(timestamp time.Time, prices map[string]sdk.Dec) int {
+	if s == nil {
+		return 0
+	}
+
+	// the subscribers share a copy of the prices, which they must not modify
+	update := PricesUpdate{
+		Prices:    make(map[string]sdk.Dec, len(prices)),
+		Timestamp: timestamp,
+	}
+	for base, price := range prices {
+		update.Prices[base] = price
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	dropped := 0
+	for ch := range s.subscribers {
+		select {
+		case ch <- update:
+		default:
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// SubscribePrices subscribes to the aggregated prices of each price sync, see
+// PriceStream.Subscribe.
+func (o *Oracle) SubscribePrices(buffer int) (<-chan PricesUpdate, func()) {
+	return o.priceStream.Subscribe(buffer)
+}
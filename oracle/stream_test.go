@@ -0,0 +1,41 @@
+package oracle
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceStream(t *testing.T) {
+	stream := NewPriceStream()
+	fast, unsubscribeFast := stream.Subscribe(2)
+	slow, unsubscribeSlow := stream.Subscribe(1)
+	defer unsubscribeSlow()
+
+	prices := map[string]sdk.Dec{"ATOM": sdk.MustNewDecFromStr("34.84")}
+	now := time.Now()
+	require.Equal(t, 0, stream.Publish(now, prices))
+	// the slow subscriber's buffer is full, the update is dropped for it
+	require.Equal(t, 1, stream.Publish(now.Add(time.Second), prices))
+
+	update := <-fast
+	require.Equal(t, now, update.Timestamp)
+	require.Equal(t, prices, update.Prices)
+	update = <-fast
+	require.Equal(t, now.Add(time.Second), update.Timestamp)
+
+	update = <-slow
+	require.Equal(t, now, update.Timestamp)
+	require.Len(t, slow, 0)
+
+	unsubscribeFast()
+	unsubscribeFast()
+	_, ok := <-fast
+	require.False(t, ok)
+	require.Equal(t, 0, stream.Publish(now, prices))
+
+	var nilStream *PriceStream
+	require.Equal(t, 0, nilStream.Publish(now, prices))
+}
@@ -0,0 +1,72 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetStrict sets whether the oracle runs in strict mode, where the failures
+// it otherwise only logs are treated as errors: the oracle abstains from
+// voting whenever a provider or an expected asset failed to report a price
+// during the last price collection.
+func (o *Oracle) SetStrict(strict bool) {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	o.strict = strict
+}
+
+// strictFailures returns the failures of the last price collection which
+// prevent the oracle from voting in strict mode, or nil if it isn't strict.
+func (o *Oracle) strictFailures() []string {
+	o.configMtx.Lock()
+	strict := o.strict
+	o.configMtx.Unlock()
+	if !strict {
+		return nil
+	}
+
+	o.pricesMutex.RLock()
+	defer o.pricesMutex.RUnlock()
+
+	return o.degraded
+}
+
+// CheckProviders creates every enabled provider and returns an error listing
+// the providers which couldn't be created and the pairs the others couldn't
+// subscribe to, ex. pairs they don't list. It's meant to fail the startup of
+// a strict oracle rather than having it collect prices without them.
+func (o *Oracle) CheckProviders(ctx context.Context) error {
+	o.configMtx.Lock()
+	defer o.configMtx.Unlock()
+
+	failures := []string{}
+	for providerName, pairs := range o.providerPairs {
+		if _, ok := o.disabledProviders[providerName]; ok {
+			continue
+		}
+
+		priceProvider, err := o.getOrSetProvider(ctx, providerName)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s couldn't be created: %s", providerName, err))
+			continue
+		}
+
+		// providers fetching prices on demand don't subscribe to their pairs
+		subscribedPairs := priceProvider.GetSubscribedPairs()
+		if subscribedPairs == nil {
+			continue
+		}
+		for _, cp := range unsubscribedPairs(pairs, subscribedPairs) {
+			failures = append(failures, fmt.Sprintf("%s couldn't subscribe to %s", providerName, cp))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	sort.Strings(failures)
+	return fmt.Errorf("providers failed their checks: %s", strings.Join(failures, "; "))
+}
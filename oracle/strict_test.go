@@ -0,0 +1,96 @@
+package oracle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ojo-network/price-feeder/oracle/client"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
+)
+
+func TestOracle_StrictFailures(t *testing.T) {
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderKraken: {
+				{Base: "ATOM", Quote: "USD"},
+				{Base: "OJO", Quote: "USD"},
+			},
+			provider.ProviderBinance: {
+				{Base: "ATOM", Quote: "USD"},
+			},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+
+	// kraken misses the OJO price and binance fails
+	o.priceProviders[provider.ProviderKraken] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+		},
+	}
+	o.priceProviders[provider.ProviderBinance] = failingProvider{}
+	require.NoError(t, o.SetPrices(context.Background()))
+
+	// the failures are only reported in strict mode
+	require.Nil(t, o.strictFailures())
+
+	o.SetStrict(true)
+	require.Equal(t, []string{
+		"binance failed to report prices: unable to get ticker prices",
+		"kraken reported no price for OJOUSD",
+		"no price for expected asset OJO",
+	}, o.strictFailures())
+
+	// a clean collection clears the failures
+	o.priceProviders[provider.ProviderKraken] = mockProvider{
+		prices: map[string]types.TickerPrice{
+			"ATOMUSD": {Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100")},
+			"OJOUSD":  {Price: sdk.MustNewDecFromStr("2"), Volume: sdk.MustNewDecFromStr("100")},
+		},
+	}
+	o.priceProviders[provider.ProviderBinance] = o.priceProviders[provider.ProviderKraken]
+	require.NoError(t, o.SetPrices(context.Background()))
+	require.Empty(t, o.strictFailures())
+}
+
+func TestOracle_CheckProviders(t *testing.T) {
+	ojoPair := types.CurrencyPair{Base: "OJO", Quote: "USDT"}
+	atomPair := types.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	o := New(
+		zerolog.Nop(),
+		client.OracleClient{},
+		map[provider.Name][]types.CurrencyPair{
+			provider.ProviderBinance: {ojoPair, atomPair},
+			provider.ProviderKraken:  {atomPair},
+		},
+		time.Second,
+		make(map[string]sdk.Dec),
+		make(map[provider.Name]provider.Endpoint),
+	)
+	o.priceProviders[provider.ProviderBinance] = &listingProvider{
+		listed:     map[string]struct{}{"ATOMUSDT": {}},
+		subscribed: map[string]types.CurrencyPair{"ATOMUSDT": atomPair},
+	}
+	o.priceProviders[provider.ProviderKraken] = &listingProvider{
+		listed:     map[string]struct{}{"ATOMUSDT": {}},
+		subscribed: map[string]types.CurrencyPair{"ATOMUSDT": atomPair},
+	}
+
+	err := o.CheckProviders(context.Background())
+	require.EqualError(t, err, "providers failed their checks: binance couldn't subscribe to OJOUSDT")
+
+	// disabled providers aren't checked
+	o.disabledProviders[provider.ProviderBinance] = struct{}{}
+	require.NoError(t, o.CheckProviders(context.Background()))
+}
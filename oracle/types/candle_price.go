@@ -7,10 +7,19 @@ import (
 )
 
 // CandlePrice defines price, volume, and time information for an exchange rate.
+// Price is the close of the candle, and the open, high and low are only set by
+// providers sending or computing them.
 type CandlePrice struct {
 	Price     sdk.Dec // last trade price
 	Volume    sdk.Dec // volume
 	TimeStamp int64   // timestamp
+	Open      sdk.Dec // first trade price, nil if not provided
+	High      sdk.Dec // highest trade price, nil if not provided
+	Low       sdk.Dec // lowest trade price, nil if not provided
+
+	// VolumeUnreliable is set by providers computing the volume from trades
+	// when trades of the candle may have been missed.
+	VolumeUnreliable bool
 }
 
 // NewCandlePrice parses the lastPrice and volume to a decimal and returns a CandlePrice
@@ -27,3 +36,51 @@ func NewCandlePrice(provider, symbol, lastPrice, volume string, timeStamp int64)
 
 	return CandlePrice{Price: price, Volume: volumeDec, TimeStamp: timeStamp}, nil
 }
+
+// WithOHLC parses the open, high and low of the candle, whose close is its
+// price. Empty values are left unset for providers which don't always send
+// them. An error is returned if the high and low don't bound the open and close.
+func (cp CandlePrice) WithOHLC(provider, symbol, open, high, low string) (CandlePrice, error) {
+	if len(open) == 0 || len(high) == 0 || len(low) == 0 {
+		return cp, nil
+	}
+
+	openDec, err := sdk.NewDecFromStr(open)
+	if err != nil {
+		return CandlePrice{}, fmt.Errorf("failed to parse %s open (%s) for %s: %w", provider, open, symbol, err)
+	}
+
+	highDec, err := sdk.NewDecFromStr(high)
+	if err != nil {
+		return CandlePrice{}, fmt.Errorf("failed to parse %s high (%s) for %s: %w", provider, high, symbol, err)
+	}
+
+	lowDec, err := sdk.NewDecFromStr(low)
+	if err != nil {
+		return CandlePrice{}, fmt.Errorf("failed to parse %s low (%s) for %s: %w", provider, low, symbol, err)
+	}
+
+	cp.Open = openDec
+	cp.High = highDec
+	cp.Low = lowDec
+	if !cp.validOHLC() {
+		return CandlePrice{}, fmt.Errorf(
+			"invalid %s candle for %s: high (%s) and low (%s) must bound open (%s) and close (%s)",
+			provider, symbol, high, low, open, cp.Price,
+		)
+	}
+	return cp, nil
+}
+
+// HasOHLC returns true if the open, high and low of the candle are set.
+func (cp CandlePrice) HasOHLC() bool {
+	return !cp.Open.IsNil() && !cp.High.IsNil() && !cp.Low.IsNil()
+}
+
+func (cp CandlePrice) validOHLC() bool {
+	if cp.Price.IsNil() || cp.Low.IsNegative() {
+		return false
+	}
+	return cp.High.GTE(cp.Open) && cp.High.GTE(cp.Price) &&
+		cp.Low.LTE(cp.Open) && cp.Low.LTE(cp.Price)
+}
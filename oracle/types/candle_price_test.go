@@ -35,3 +35,37 @@ func TestNewCandlePrice(t *testing.T) {
 		require.NotNil(t, err, "expected the returned error to not be nil")
 	})
 }
+
+func TestCandlePrice_WithOHLC(t *testing.T) {
+	candle, err := NewCandlePrice("binance", "ATOMUSDT", "10.2", "100", 1)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		ohlc, err := candle.WithOHLC("binance", "ATOMUSDT", "10.1", "10.4", "9.9")
+		require.NoError(t, err)
+		require.True(t, ohlc.HasOHLC())
+		require.Equal(t, sdk.MustNewDecFromStr("10.1"), ohlc.Open)
+		require.Equal(t, sdk.MustNewDecFromStr("10.4"), ohlc.High)
+		require.Equal(t, sdk.MustNewDecFromStr("9.9"), ohlc.Low)
+		require.Equal(t, candle.Price, ohlc.Price)
+	})
+
+	t.Run("not provided", func(t *testing.T) {
+		ohlc, err := candle.WithOHLC("binance", "ATOMUSDT", "", "", "")
+		require.NoError(t, err)
+		require.False(t, ohlc.HasOHLC())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := candle.WithOHLC("binance", "ATOMUSDT", "bad_open", "10.4", "9.9")
+		require.Error(t, err)
+
+		// the close is above the high
+		_, err = candle.WithOHLC("binance", "ATOMUSDT", "10.1", "10.15", "9.9")
+		require.Error(t, err)
+
+		// the low is above the open
+		_, err = candle.WithOHLC("binance", "ATOMUSDT", "10.1", "10.4", "10.15")
+		require.Error(t, err)
+	})
+}
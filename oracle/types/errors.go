@@ -19,4 +19,13 @@ var (
 	ErrWebsocketClose = errors.Register(ModuleName, 9, "error closing %s websocket: %w")
 	ErrWebsocketSend  = errors.Register(ModuleName, 10, "error sending to %s websocket: %w")
 	ErrWebsocketRead  = errors.Register(ModuleName, 11, "error reading from %s websocket: %w")
+
+	ErrTickerStale  = errors.Register(ModuleName, 12, "%s ticker price for %s is stale")
+	ErrTickerSpread = errors.Register(ModuleName, 13, "%s ticker spread for %s of %s%% exceeds the maximum of %s%%")
+
+	ErrAsOfUnsupported = errors.Register(ModuleName, 14, "as-of candles unsupported")
+
+	ErrWebsocketRateLimited = errors.Register(ModuleName, 15, "websocket rate limited")
+
+	ErrAPIKeyFromFile = errors.Register(ModuleName, 16, "API key is read from a file")
 )
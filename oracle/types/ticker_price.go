@@ -8,8 +8,11 @@ import (
 
 // TickerPrice defines price and volume information for a symbol or ticker exchange rate.
 type TickerPrice struct {
-	Price  sdk.Dec // last trade price
-	Volume sdk.Dec // 24h volume
+	Price     sdk.Dec // last trade price
+	Volume    sdk.Dec // 24h volume
+	TimeStamp int64   // time the ticker was received in unix epoch ms
+	Bid       sdk.Dec // best bid, nil if not provided
+	Ask       sdk.Dec // best ask, nil if not provided
 }
 
 // NewTickerPrice parses the lastPrice and volume to a decimal and returns a TickerPrice
@@ -26,3 +29,44 @@ func NewTickerPrice(provider, symbol, lastPrice, volume string) (TickerPrice, er
 
 	return TickerPrice{Price: price, Volume: volumeDec}, nil
 }
+
+// WithBidAsk parses the best bid and ask of the ticker. Empty values are left
+// unset for providers which only send them in some messages.
+func (tp TickerPrice) WithBidAsk(provider, symbol, bid, ask string) (TickerPrice, error) {
+	if len(bid) == 0 || len(ask) == 0 {
+		return tp, nil
+	}
+
+	bidDec, err := sdk.NewDecFromStr(bid)
+	if err != nil {
+		return TickerPrice{}, fmt.Errorf("failed to parse %s bid (%s) for %s: %w", provider, bid, symbol, err)
+	}
+
+	askDec, err := sdk.NewDecFromStr(ask)
+	if err != nil {
+		return TickerPrice{}, fmt.Errorf("failed to parse %s ask (%s) for %s: %w", provider, ask, symbol, err)
+	}
+
+	tp.Bid = bidDec
+	tp.Ask = askDec
+	return tp, nil
+}
+
+// MidPrice returns the mid price of the ticker's bid and ask, or false if the
+// ticker has no valid bid and ask.
+func (tp TickerPrice) MidPrice() (sdk.Dec, bool) {
+	if tp.Bid.IsNil() || tp.Ask.IsNil() || !tp.Bid.IsPositive() || !tp.Ask.IsPositive() {
+		return sdk.Dec{}, false
+	}
+	return tp.Bid.Add(tp.Ask).QuoInt64(2), true
+}
+
+// Spread returns the bid-ask spread of the ticker as a percentage of its mid
+// price, or false if the ticker has no valid bid and ask.
+func (tp TickerPrice) Spread() (sdk.Dec, bool) {
+	mid, ok := tp.MidPrice()
+	if !ok {
+		return sdk.Dec{}, false
+	}
+	return tp.Ask.Sub(tp.Bid).Quo(mid).MulInt64(100), true
+}
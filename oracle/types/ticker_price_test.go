@@ -32,3 +32,34 @@ func TestNewTicketPrice(t *testing.T) {
 		require.NotNil(t, err, "expected the returned error to not be nil")
 	})
 }
+
+func TestTickerPrice_Spread(t *testing.T) {
+	tp, err := NewTickerPrice("coinbase", "ATOMUSD", "10", "1000")
+	require.NoError(t, err)
+
+	_, ok := tp.Spread()
+	require.False(t, ok)
+
+	tp, err = tp.WithBidAsk("coinbase", "ATOMUSD", "9.9", "10.1")
+	require.NoError(t, err)
+	spread, ok := tp.Spread()
+	require.True(t, ok)
+	require.Equal(t, sdk.MustNewDecFromStr("2"), spread)
+
+	_, err = tp.WithBidAsk("coinbase", "ATOMUSD", "bad_bid", "10.1")
+	require.Error(t, err)
+}
+
+func TestTickerPrice_MidPrice(t *testing.T) {
+	tp, err := NewTickerPrice("coinbase", "ATOMUSD", "10", "1000")
+	require.NoError(t, err)
+
+	_, ok := tp.MidPrice()
+	require.False(t, ok)
+
+	tp, err = tp.WithBidAsk("coinbase", "ATOMUSD", "9.8", "10.1")
+	require.NoError(t, err)
+	mid, ok := tp.MidPrice()
+	require.True(t, ok)
+	require.Equal(t, sdk.MustNewDecFromStr("9.95"), mid)
+}
@@ -2,11 +2,16 @@ package oracle
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"sort"
+	"strconv"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
 )
 
 var (
@@ -47,8 +52,8 @@ func ComputeVWAP(prices provider.AggregatedProviderPrices) map[string]sdk.Dec {
 		volumeSum      = make(map[string]sdk.Dec)
 	)
 
-	for _, providerPrices := range prices {
-		for base, tp := range providerPrices {
+	for _, providerName := range prices.Providers() {
+		for base, tp := range prices[providerName] {
 			if _, ok := weightedPrices[base]; !ok {
 				weightedPrices[base] = sdk.ZeroDec()
 			}
@@ -74,14 +79,26 @@ func ComputeVWAP(prices provider.AggregatedProviderPrices) map[string]sdk.Dec {
 //
 // Ref : https://en.wikipedia.org/wiki/Time-weighted_average_price
 func ComputeTVWAP(prices provider.AggregatedProviderCandles) (map[string]sdk.Dec, error) {
+	return ComputeTVWAPWithWindows(prices, nil)
+}
+
+// ComputeTVWAPWithWindows computes the TVWAP like ComputeTVWAP but averages
+// the candles of the bases in windows over their window instead of the
+// default tvwapCandlePeriod. The time weights of a windowed base start at its
+// oldest candle within the window, so a window that isn't fully covered by
+// candles yet, ex. after a restart, averages the candles available.
+func ComputeTVWAPWithWindows(
+	prices provider.AggregatedProviderCandles,
+	windows map[string]time.Duration,
+) (map[string]sdk.Dec, error) {
 	var (
 		weightedPrices = make(map[string]sdk.Dec)
 		volumeSum      = make(map[string]sdk.Dec)
 		now            = provider.PastUnixTime(0)
-		timePeriod     = provider.PastUnixTime(tvwapCandlePeriod)
 	)
 
-	for _, providerPrices := range prices {
+	for _, providerName := range prices.Providers() {
+		providerPrices := prices[providerName]
 		for base := range providerPrices {
 			cp := providerPrices[base]
 			if len(cp) == 0 {
@@ -100,7 +117,20 @@ func ComputeTVWAP(prices provider.AggregatedProviderCandles) (map[string]sdk.Dec
 				return cp[i].TimeStamp < cp[j].TimeStamp
 			})
 
-			period := sdk.NewDec(now - cp[0].TimeStamp)
+			timePeriod := provider.PastUnixTime(tvwapCandlePeriod)
+			oldest := cp[0].TimeStamp
+			if window, ok := windows[base]; ok {
+				timePeriod = provider.PastUnixTime(window)
+				i := sort.Search(len(cp), func(i int) bool {
+					return cp[i].TimeStamp > timePeriod
+				})
+				if i == len(cp) {
+					continue
+				}
+				oldest = cp[i].TimeStamp
+			}
+
+			period := sdk.NewDec(now - oldest)
 			if period.Equal(sdk.ZeroDec()) {
 				return nil, fmt.Errorf("unable to divide by zero")
 			}
@@ -145,8 +175,8 @@ func StandardDeviation(
 		priceSums  = make(map[string]sdk.Dec)
 	)
 
-	for _, providerPrices := range prices {
-		for base, p := range providerPrices {
+	for _, providerName := range sortedPriceProviders(prices) {
+		for base, p := range prices[providerName] {
 			if _, ok := priceSums[base]; !ok {
 				priceSums[base] = sdk.ZeroDec()
 			}
@@ -193,6 +223,16 @@ func StandardDeviation(
 	return deviations, means, nil
 }
 
+// sortedPriceProviders returns the names of the providers with prices,
+// sorted.
+func sortedPriceProviders(prices map[provider.Name]map[string]sdk.Dec) []provider.Name {
+	names := make([]provider.Name, 0, len(prices))
+	for name := range prices {
+		names = append(names, name)
+	}
+	return provider.SortNames(names)
+}
+
 // ComputeTvwapsByProvider computes the tvwap prices from candles for each provider separately and returns them
 // in a map separated by provider name
 func ComputeTvwapsByProvider(prices provider.AggregatedProviderCandles) (map[provider.Name]map[string]sdk.Dec, error) {
@@ -220,3 +260,394 @@ func ComputeVwapsByProvider(prices provider.AggregatedProviderPrices) map[provid
 	}
 	return vwaps
 }
+
+// TrimmedMean sorts the prices, discards trimFraction of them from each side
+// and returns the mean of the remaining prices. The number of trimmed prices
+// is clamped so that at least one price is always kept.
+func TrimmedMean(prices []sdk.Dec, trimFraction sdk.Dec) (sdk.Dec, error) {
+	if len(prices) == 0 {
+		return sdk.Dec{}, fmt.Errorf("unable to compute trimmed mean of no prices")
+	}
+
+	sorted := make([]sdk.Dec, len(prices))
+	copy(sorted, prices)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LT(sorted[j])
+	})
+
+	trim := trimFraction.MulInt64(int64(len(sorted))).TruncateInt64()
+	if maxTrim := int64(len(sorted)-1) / 2; trim > maxTrim {
+		trim = maxTrim
+	}
+	if trim < 0 {
+		trim = 0
+	}
+	kept := sorted[trim : int64(len(sorted))-trim]
+
+	sum := sdk.ZeroDec()
+	for _, p := range kept {
+		sum = sum.Add(p)
+	}
+	return sum.QuoInt64(int64(len(kept))), nil
+}
+
+// ComputeTrimmedMeans computes the trimmed mean of the provider prices of
+// each base in trimFractions. The provided prices argument reflects a mapping
+// of provider => {<base> => <price>, ...}.
+func ComputeTrimmedMeans(
+	prices map[provider.Name]map[string]sdk.Dec,
+	trimFractions map[string]sdk.Dec,
+) (map[string]sdk.Dec, error) {
+	priceSlice := make(map[string][]sdk.Dec)
+	for _, providerName := range sortedPriceProviders(prices) {
+		for base, p := range prices[providerName] {
+			if _, ok := trimFractions[base]; ok {
+				priceSlice[base] = append(priceSlice[base], p)
+			}
+		}
+	}
+
+	trimmedMeans := make(map[string]sdk.Dec, len(priceSlice))
+	for base, ps := range priceSlice {
+		mean, err := TrimmedMean(ps, trimFractions[base])
+		if err != nil {
+			return nil, err
+		}
+		trimmedMeans[base] = mean
+	}
+	return trimmedMeans, nil
+}
+
+// ComputeFailoverPrices returns the price of each base in failoverOrders from
+// the first of its providers, in order, which has a price for it. The provided
+// prices argument reflects a mapping of provider => {<base> => <price>, ...},
+// where the providers may be quote routes, see routePrice, and are expected
+// to be filtered already: stale tickers and candles are dropped by the
+// providers and out of bounds prices by FilterPriceBounds, so the first
+// price found is the first fresh, in-bounds one. Bases none of their
+// providers have a price for are left out.
+func ComputeFailoverPrices(
+	prices map[provider.Name]map[string]sdk.Dec,
+	failoverOrders map[string][]provider.Name,
+) map[string]sdk.Dec {
+	failoverPrices := make(map[string]sdk.Dec, len(failoverOrders))
+	for base, order := range failoverOrders {
+		for _, providerName := range order {
+			if p, ok := routePrice(prices, providerName, base); ok {
+				failoverPrices[base] = p
+				break
+			}
+		}
+	}
+	return failoverPrices
+}
+
+// WeightedMedian returns the first of the sorted prices at which the
+// cumulated weight reaches half of the total weight. The prices are weighted
+// equally if their weights sum to zero.
+//
+// Ties are broken towards the lower price: with an even count of equally
+// weighted prices, the lower of the two middle prices is returned rather
+// than their mean, ex. 2 for [1, 2, 3, 4]. Equal prices keep the order they
+// were provided in, so callers must provide them in a deterministic order.
+func WeightedMedian(prices, weights []sdk.Dec) (sdk.Dec, error) {
+	if len(prices) == 0 || len(prices) != len(weights) {
+		return sdk.Dec{}, fmt.Errorf("unable to compute weighted median of %d prices and %d weights", len(prices), len(weights))
+	}
+
+	indexes := make([]int, len(prices))
+	total := sdk.ZeroDec()
+	for i, w := range weights {
+		indexes[i] = i
+		total = total.Add(w)
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return prices[indexes[i]].LT(prices[indexes[j]])
+	})
+
+	weight := func(i int) sdk.Dec { return weights[i] }
+	if !total.IsPositive() {
+		total = sdk.NewDec(int64(len(prices)))
+		weight = func(int) sdk.Dec { return sdk.OneDec() }
+	}
+
+	half := total.QuoInt64(2)
+	cumulated := sdk.ZeroDec()
+	for _, i := range indexes {
+		cumulated = cumulated.Add(weight(i))
+		if cumulated.GTE(half) {
+			return prices[i], nil
+		}
+	}
+	return prices[indexes[len(indexes)-1]], nil
+}
+
+// ApplyCandleDecay weights the volume of the candles of each base in halfLives
+// by 0.5^(age/halfLife), so that recent candles weigh more in the TVWAP of
+// fast markets. Candles of other bases are returned unchanged. The provided
+// candles are not modified.
+func ApplyCandleDecay(
+	candles provider.AggregatedProviderCandles,
+	halfLives map[string]time.Duration,
+) (provider.AggregatedProviderCandles, error) {
+	if len(halfLives) == 0 {
+		return candles, nil
+	}
+
+	now := provider.PastUnixTime(0)
+	decayed := make(provider.AggregatedProviderCandles, len(candles))
+	for providerName, providerCandles := range candles {
+		decayed[providerName] = make(map[string][]types.CandlePrice, len(providerCandles))
+		for base, cp := range providerCandles {
+			halfLife, ok := halfLives[base]
+			if !ok {
+				decayed[providerName][base] = cp
+				continue
+			}
+
+			weighted := make([]types.CandlePrice, len(cp))
+			for i, candle := range cp {
+				age := time.Duration(now-candle.TimeStamp) * time.Millisecond
+				if age < 0 {
+					age = 0
+				}
+				weight, err := decayWeight(age, halfLife)
+				if err != nil {
+					return nil, err
+				}
+
+				// decay the minimum volume TVWAP would otherwise use, and keep
+				// old candles from reaching zero which TVWAP would bump back up
+				if candle.Volume.IsZero() {
+					candle.Volume = minimumCandleVolume
+				}
+				candle.Volume = candle.Volume.Mul(weight)
+				if candle.Volume.IsZero() {
+					candle.Volume = sdk.SmallestDec()
+				}
+				weighted[i] = candle
+			}
+			decayed[providerName][base] = weighted
+		}
+	}
+	return decayed, nil
+}
+
+// decayWeight returns 0.5^(age/halfLife), truncated to the precision of
+// sdk.Dec and kept from reaching zero, since the weights of candles many
+// half-lives old have more decimals than sdk.Dec can parse.
+func decayWeight(age, halfLife time.Duration) (sdk.Dec, error) {
+	weight := math.Pow(0.5, float64(age)/float64(halfLife))
+	dec, err := sdk.NewDecFromStr(strconv.FormatFloat(weight, 'f', sdk.Precision, 64))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	if !dec.IsPositive() {
+		return sdk.SmallestDec(), nil
+	}
+	return dec, nil
+}
+
+// ApplyMidPrices replaces the price of the tickers of the symbols in
+// midPricePairs with the mid price of their best bid and ask. Tickers without
+// a valid bid and ask keep their last trade price. The provided tickers are not
+// modified.
+func ApplyMidPrices(
+	prices map[string]types.TickerPrice,
+	midPricePairs map[string]struct{},
+) map[string]types.TickerPrice {
+	if len(midPricePairs) == 0 {
+		return prices
+	}
+
+	midPrices := make(map[string]types.TickerPrice, len(prices))
+	for symbol, tp := range prices {
+		if _, ok := midPricePairs[symbol]; ok {
+			if mid, ok := tp.MidPrice(); ok {
+				tp.Price = mid
+			}
+		}
+		midPrices[symbol] = tp
+	}
+	return midPrices
+}
+
+// ApplyScaleFactors multiplies the prices of the symbols with a scale factor
+// by it, and divides their volumes by it. The provided tickers and candles
+// are not modified.
+func ApplyScaleFactors(
+	prices map[string]types.TickerPrice,
+	candles map[string][]types.CandlePrice,
+	scaleFactors map[string]sdk.Dec,
+) (map[string]types.TickerPrice, map[string][]types.CandlePrice) {
+	if len(scaleFactors) == 0 {
+		return prices, candles
+	}
+
+	scaledPrices := make(map[string]types.TickerPrice, len(prices))
+	for symbol, tp := range prices {
+		if factor, ok := scaleFactors[symbol]; ok {
+			tp.Price = tp.Price.Mul(factor)
+			tp.Volume = tp.Volume.Quo(factor)
+			tp.Bid = scaleDec(tp.Bid, factor)
+			tp.Ask = scaleDec(tp.Ask, factor)
+		}
+		scaledPrices[symbol] = tp
+	}
+
+	scaledCandles := make(map[string][]types.CandlePrice, len(candles))
+	for symbol, cp := range candles {
+		factor, ok := scaleFactors[symbol]
+		if !ok {
+			scaledCandles[symbol] = cp
+			continue
+		}
+		scaled := make([]types.CandlePrice, len(cp))
+		for i, candle := range cp {
+			candle.Price = candle.Price.Mul(factor)
+			candle.Volume = candle.Volume.Quo(factor)
+			candle.Open = scaleDec(candle.Open, factor)
+			candle.High = scaleDec(candle.High, factor)
+			candle.Low = scaleDec(candle.Low, factor)
+			scaled[i] = candle
+		}
+		scaledCandles[symbol] = scaled
+	}
+	return scaledPrices, scaledCandles
+}
+
+// scaleDec multiplies the optional price by the factor, leaving it nil if it
+// isn't set.
+func scaleDec(price, factor sdk.Dec) sdk.Dec {
+	if price.IsNil() {
+		return price
+	}
+	return price.Mul(factor)
+}
+
+// ApplyPriceSources replaces the prices of the symbols with a price source by
+// the price of that source. With the ticker source, the symbol's candles are
+// replaced by a candle of its ticker, so that the provider contributes its
+// ticker price even when the candles are aggregated. With the candle source,
+// its candles are reduced to the latest one and its ticker takes that
+// candle's close, so that the provider contributes its latest close even when
+// the tickers are aggregated. A symbol without the selected price is left
+// out. The provided tickers and candles are not modified.
+func ApplyPriceSources(
+	providerName provider.Name,
+	prices map[string]types.TickerPrice,
+	candles map[string][]types.CandlePrice,
+	priceSources map[string]string,
+) (map[string]types.TickerPrice, map[string][]types.CandlePrice) {
+	if len(priceSources) == 0 {
+		return prices, candles
+	}
+
+	sourcedPrices := make(map[string]types.TickerPrice, len(prices))
+	for symbol, tp := range prices {
+		sourcedPrices[symbol] = tp
+	}
+	sourcedCandles := make(map[string][]types.CandlePrice, len(candles))
+	for symbol, cp := range candles {
+		sourcedCandles[symbol] = cp
+	}
+
+	// the ticker volumes span a day while the candle volumes span the
+	// provider's candle interval
+	candlesPerDay := int64(24 * time.Hour / providerName.CandleInterval())
+	for symbol, source := range priceSources {
+		switch source {
+		case config.PriceSourceTicker:
+			delete(sourcedCandles, symbol)
+			tp, ok := prices[symbol]
+			if !ok {
+				continue
+			}
+			timeStamp := tp.TimeStamp
+			if timeStamp == 0 {
+				timeStamp = provider.PastUnixTime(0)
+			}
+			sourcedCandles[symbol] = []types.CandlePrice{{
+				Price:     tp.Price,
+				Volume:    tp.Volume.QuoInt64(candlesPerDay),
+				TimeStamp: timeStamp,
+			}}
+
+		case config.PriceSourceCandle:
+			delete(sourcedPrices, symbol)
+			latest, ok := latestCandle(candles[symbol])
+			if !ok {
+				delete(sourcedCandles, symbol)
+				continue
+			}
+			sourcedCandles[symbol] = []types.CandlePrice{latest}
+			tp, ok := prices[symbol]
+			if !ok {
+				tp = types.TickerPrice{Volume: latest.Volume.MulInt64(candlesPerDay)}
+			}
+			tp.Price = latest.Price
+			tp.TimeStamp = latest.TimeStamp
+			sourcedPrices[symbol] = tp
+		}
+	}
+	return sourcedPrices, sourcedCandles
+}
+
+// latestCandle returns the most recent of the candles, or false if there are
+// none.
+func latestCandle(candles []types.CandlePrice) (types.CandlePrice, bool) {
+	if len(candles) == 0 {
+		return types.CandlePrice{}, false
+	}
+	latest := candles[0]
+	for _, candle := range candles[1:] {
+		if candle.TimeStamp > latest.TimeStamp {
+			latest = candle
+		}
+	}
+	return latest, true
+}
+
+// RoundPrices returns the prices rounded to the precision of the rounding using
+// its mode. The prices are returned unchanged if no rounding mode is set or if
+// the precision keeps all of their decimal places.
+func RoundPrices(prices map[string]sdk.Dec, rounding config.Rounding) map[string]sdk.Dec {
+	if rounding.Mode == "" || rounding.Precision >= sdk.Precision {
+		return prices
+	}
+
+	rounded := make(map[string]sdk.Dec, len(prices))
+	for base, price := range prices {
+		rounded[base] = roundDec(price, rounding.Mode, rounding.Precision)
+	}
+	return rounded
+}
+
+// roundDec rounds the decimal to precision decimal places using the rounding
+// mode.
+func roundDec(d sdk.Dec, mode string, precision int64) sdk.Dec {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision-precision), nil)
+	quo, rem := new(big.Int).QuoRem(d.BigInt(), unit, new(big.Int))
+
+	// compare the dropped digits to half a unit
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(unit)
+
+	roundAway := false
+	switch mode {
+	case config.RoundingHalfUp:
+		roundAway = cmp >= 0
+	case config.RoundingHalfEven:
+		roundAway = cmp > 0 || (cmp == 0 && quo.Bit(0) == 1)
+	}
+	if roundAway {
+		if rem.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return sdk.NewDecFromBigIntWithPrec(quo.Mul(quo, unit), sdk.Precision)
+}
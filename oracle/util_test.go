@@ -5,6 +5,7 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle"
 	"github.com/ojo-network/price-feeder/oracle/provider"
 	"github.com/ojo-network/price-feeder/oracle/types"
@@ -381,3 +382,541 @@ func TestStandardDeviation(t *testing.T) {
 		})
 	}
 }
+
+func TestTrimmedMean(t *testing.T) {
+	prices := []sdk.Dec{
+		sdk.MustNewDecFromStr("10"),
+		sdk.MustNewDecFromStr("1"),
+		sdk.MustNewDecFromStr("3"),
+		sdk.MustNewDecFromStr("100"),
+		sdk.MustNewDecFromStr("2"),
+	}
+
+	testCases := map[string]struct {
+		prices       []sdk.Dec
+		trimFraction sdk.Dec
+		expected     sdk.Dec
+		expectErr    bool
+	}{
+		"no trim": {
+			prices:       prices,
+			trimFraction: sdk.ZeroDec(),
+			expected:     sdk.MustNewDecFromStr("23.2"),
+		},
+		"trim one from each side": {
+			prices:       prices,
+			trimFraction: sdk.MustNewDecFromStr("0.2"),
+			expected:     sdk.MustNewDecFromStr("5"),
+		},
+		"trim rounds down": {
+			prices:       prices,
+			trimFraction: sdk.MustNewDecFromStr("0.39"),
+			expected:     sdk.MustNewDecFromStr("5"),
+		},
+		"trim everything keeps median of odd set": {
+			prices:       prices,
+			trimFraction: sdk.MustNewDecFromStr("0.5"),
+			expected:     sdk.MustNewDecFromStr("3"),
+		},
+		"trim everything keeps middle of even set": {
+			prices:       prices[:4],
+			trimFraction: sdk.MustNewDecFromStr("0.5"),
+			expected:     sdk.MustNewDecFromStr("6.5"),
+		},
+		"single price": {
+			prices:       prices[:1],
+			trimFraction: sdk.MustNewDecFromStr("0.5"),
+			expected:     sdk.MustNewDecFromStr("10"),
+		},
+		"no prices": {
+			prices:       []sdk.Dec{},
+			trimFraction: sdk.MustNewDecFromStr("0.2"),
+			expectErr:    true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			mean, err := oracle.TrimmedMean(tc.prices, tc.trimFraction)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, mean)
+		})
+	}
+
+	// the input must not be reordered
+	require.Equal(t, sdk.MustNewDecFromStr("10"), prices[0])
+}
+
+func TestWeightedMedian(t *testing.T) {
+	prices := []sdk.Dec{
+		sdk.MustNewDecFromStr("1.001"),
+		sdk.MustNewDecFromStr("0.95"),
+		sdk.MustNewDecFromStr("0.999"),
+	}
+
+	testCases := map[string]struct {
+		prices    []sdk.Dec
+		weights   []sdk.Dec
+		expected  sdk.Dec
+		expectErr bool
+	}{
+		"equal weights": {
+			prices:   prices,
+			weights:  []sdk.Dec{sdk.OneDec(), sdk.OneDec(), sdk.OneDec()},
+			expected: sdk.MustNewDecFromStr("0.999"),
+		},
+		"heaviest price": {
+			prices:   prices,
+			weights:  []sdk.Dec{sdk.NewDec(10), sdk.OneDec(), sdk.OneDec()},
+			expected: sdk.MustNewDecFromStr("1.001"),
+		},
+		"even count": {
+			prices: []sdk.Dec{
+				sdk.MustNewDecFromStr("4"),
+				sdk.MustNewDecFromStr("1"),
+				sdk.MustNewDecFromStr("3"),
+				sdk.MustNewDecFromStr("2"),
+			},
+			weights:  []sdk.Dec{sdk.OneDec(), sdk.OneDec(), sdk.OneDec(), sdk.OneDec()},
+			expected: sdk.MustNewDecFromStr("2"),
+		},
+		"zero weights": {
+			prices:   prices,
+			weights:  []sdk.Dec{sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()},
+			expected: sdk.MustNewDecFromStr("0.999"),
+		},
+		"no prices": {
+			prices:    []sdk.Dec{},
+			weights:   []sdk.Dec{},
+			expectErr: true,
+		},
+		"mismatched weights": {
+			prices:    prices,
+			weights:   []sdk.Dec{sdk.OneDec()},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			median, err := oracle.WeightedMedian(tc.prices, tc.weights)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, median)
+		})
+	}
+}
+
+func TestComputeTrimmedMeans(t *testing.T) {
+	prices := map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderBinance: {
+			"ATOM": sdk.MustNewDecFromStr("28.1"),
+			"OJO":  sdk.MustNewDecFromStr("1.1"),
+		},
+		provider.ProviderKraken: {
+			"ATOM": sdk.MustNewDecFromStr("28.3"),
+			"OJO":  sdk.MustNewDecFromStr("1.3"),
+		},
+		provider.ProviderCoinbase: {
+			"ATOM": sdk.MustNewDecFromStr("35.0"),
+		},
+	}
+
+	trimmedMeans, err := oracle.ComputeTrimmedMeans(prices, map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("0.34"),
+	})
+	require.NoError(t, err)
+	require.Len(t, trimmedMeans, 1)
+	require.Equal(t, sdk.MustNewDecFromStr("28.3"), trimmedMeans["ATOM"])
+}
+
+func TestComputeVWAP_Deterministic(t *testing.T) {
+	prices := provider.AggregatedProviderPrices{}
+	for i, providerName := range []provider.Name{
+		provider.ProviderBinance,
+		provider.ProviderKraken,
+		provider.ProviderCoinbase,
+		provider.ProviderOkx,
+		provider.ProviderGate,
+		provider.ProviderHuobi,
+	} {
+		prices[providerName] = map[string]types.TickerPrice{
+			"ATOM": {
+				Price:  sdk.MustNewDecFromStr("28.123456789").Add(sdk.NewDecWithPrec(int64(i), 9)),
+				Volume: sdk.MustNewDecFromStr("1.333333333333333333").MulInt64(int64(i + 1)),
+			},
+		}
+	}
+
+	// the providers are combined in the same order whatever the map order
+	expected := oracle.ComputeVWAP(prices)["ATOM"].String()
+	for i := 0; i < 50; i++ {
+		require.Equal(t, expected, oracle.ComputeVWAP(prices)["ATOM"].String())
+	}
+}
+
+func TestComputeFailoverPrices(t *testing.T) {
+	prices := map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderBinance: {
+			"ATOM": sdk.MustNewDecFromStr("28.1"),
+			"OJO":  sdk.MustNewDecFromStr("1.1"),
+		},
+		provider.ProviderKraken: {
+			"OJO": sdk.MustNewDecFromStr("1.3"),
+		},
+	}
+
+	failoverPrices := oracle.ComputeFailoverPrices(prices, map[string][]provider.Name{
+		// the primary provider has no price, so the backup's is used
+		"ATOM": {provider.ProviderKraken, provider.ProviderBinance},
+		"OJO":  {provider.ProviderKraken, provider.ProviderBinance},
+		"UMEE": {provider.ProviderKraken},
+	})
+	require.Equal(t, map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("28.1"),
+		"OJO":  sdk.MustNewDecFromStr("1.3"),
+	}, failoverPrices)
+}
+
+func TestComputeFailoverPrices_QuoteRoutes(t *testing.T) {
+	// with distinct quote routes, kraken's ATOM/USDT route is kept apart
+	// from its provider
+	prices := map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderBinance: {
+			"ATOM": sdk.MustNewDecFromStr("28.1"),
+		},
+		"kraken:USDT": {
+			"ATOM": sdk.MustNewDecFromStr("28.3"),
+		},
+	}
+
+	failoverPrices := oracle.ComputeFailoverPrices(prices, map[string][]provider.Name{
+		"ATOM": {provider.ProviderKraken, provider.ProviderBinance},
+	})
+	require.Equal(t, map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("28.3"),
+	}, failoverPrices)
+}
+
+func TestApplyCandleDecay(t *testing.T) {
+	now := provider.PastUnixTime(0)
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderBinance: {
+			"ATOM": []types.CandlePrice{
+				{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now - 120000},
+				{Price: sdk.MustNewDecFromStr("20"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now},
+			},
+			"OJO": []types.CandlePrice{
+				{Price: sdk.MustNewDecFromStr("1"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now - 120000},
+			},
+		},
+	}
+
+	decayed, err := oracle.ApplyCandleDecay(candles, map[string]time.Duration{"ATOM": time.Minute})
+	require.NoError(t, err)
+
+	// a candle two half-lives old keeps a quarter of its volume
+	atom := decayed[provider.ProviderBinance]["ATOM"]
+	require.InEpsilon(t, 25, atom[0].Volume.MustFloat64(), 0.01)
+	require.InEpsilon(t, 100, atom[1].Volume.MustFloat64(), 0.01)
+	require.Equal(t, candles[provider.ProviderBinance]["OJO"], decayed[provider.ProviderBinance]["OJO"])
+
+	// the provided candles are left untouched
+	require.Equal(t, sdk.MustNewDecFromStr("100"), candles[provider.ProviderBinance]["ATOM"][0].Volume)
+
+	tvwap, err := oracle.ComputeTVWAP(candles)
+	require.NoError(t, err)
+	decayedTvwap, err := oracle.ComputeTVWAP(decayed)
+	require.NoError(t, err)
+	require.True(t, decayedTvwap["ATOM"].GT(tvwap["ATOM"]))
+
+	unchanged, err := oracle.ApplyCandleDecay(candles, nil)
+	require.NoError(t, err)
+	require.Equal(t, candles, unchanged)
+}
+
+func TestApplyCandleDecay_OldCandles(t *testing.T) {
+	now := provider.PastUnixTime(0)
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderBinance: {
+			"ATOM": []types.CandlePrice{
+				// 10 and 100 half-lives old, with weights of more decimals than sdk.Dec
+				{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now - 10*60000},
+				{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now - 100*60000},
+				{Price: sdk.MustNewDecFromStr("20"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now},
+			},
+		},
+	}
+
+	decayed, err := oracle.ApplyCandleDecay(candles, map[string]time.Duration{"ATOM": time.Minute})
+	require.NoError(t, err)
+
+	atom := decayed[provider.ProviderBinance]["ATOM"]
+	require.InEpsilon(t, 100.0/1024, atom[0].Volume.MustFloat64(), 0.01)
+	require.True(t, atom[1].Volume.IsPositive())
+	require.True(t, atom[1].Volume.LT(atom[0].Volume))
+
+	tvwap, err := oracle.ComputeTVWAP(decayed)
+	require.NoError(t, err)
+	require.InDelta(t, 20, tvwap["ATOM"].MustFloat64(), 0.05)
+}
+
+func TestComputeTVWAPWithWindows(t *testing.T) {
+	candles := func() provider.AggregatedProviderCandles {
+		return provider.AggregatedProviderCandles{
+			provider.ProviderBinance: {
+				"ATOM": []types.CandlePrice{
+					{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: provider.PastUnixTime(8 * time.Minute)},
+					{Price: sdk.MustNewDecFromStr("20"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: provider.PastUnixTime(2 * time.Minute)},
+					{Price: sdk.MustNewDecFromStr("30"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: provider.PastUnixTime(time.Minute)},
+				},
+				"OJO": []types.CandlePrice{
+					{Price: sdk.MustNewDecFromStr("1"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: provider.PastUnixTime(8 * time.Minute)},
+				},
+			},
+		}
+	}
+
+	// the default period leaves out the candles older than five minutes
+	tvwap, err := oracle.ComputeTVWAP(candles())
+	require.NoError(t, err)
+	require.True(t, tvwap["ATOM"].GT(sdk.MustNewDecFromStr("20")))
+	require.NotContains(t, tvwap, "OJO")
+
+	// a longer window includes them
+	windowed, err := oracle.ComputeTVWAPWithWindows(candles(), map[string]time.Duration{
+		"ATOM": 10 * time.Minute,
+		"OJO":  10 * time.Minute,
+	})
+	require.NoError(t, err)
+	require.True(t, windowed["ATOM"].LT(tvwap["ATOM"]))
+	require.Equal(t, sdk.OneDec(), windowed["OJO"])
+
+	// a shorter window only averages its candles, weighting them from the
+	// oldest candle within the window
+	windowed, err = oracle.ComputeTVWAPWithWindows(candles(), map[string]time.Duration{"ATOM": 90 * time.Second})
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("30"), windowed["ATOM"])
+}
+
+func TestComputeTVWAPWithWindows_PartialWindow(t *testing.T) {
+	// after a restart, the candles only cover part of the window
+	candles := provider.AggregatedProviderCandles{
+		provider.ProviderBinance: {
+			"ATOM": []types.CandlePrice{
+				{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: provider.PastUnixTime(2 * time.Minute)},
+				{Price: sdk.MustNewDecFromStr("20"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: provider.PastUnixTime(time.Minute)},
+			},
+		},
+	}
+	windows := map[string]time.Duration{"ATOM": 10 * time.Minute}
+
+	windowed, err := oracle.ComputeTVWAPWithWindows(candles, windows)
+	require.NoError(t, err)
+	tvwap, err := oracle.ComputeTVWAP(candles)
+	require.NoError(t, err)
+	require.Equal(t, tvwap["ATOM"], windowed["ATOM"])
+	require.True(t, windowed["ATOM"].GT(sdk.MustNewDecFromStr("10")))
+	require.True(t, windowed["ATOM"].LT(sdk.MustNewDecFromStr("20")))
+
+	// no price is computed until a candle is within the window
+	stale := provider.AggregatedProviderCandles{
+		provider.ProviderBinance: {
+			"ATOM": []types.CandlePrice{
+				{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: provider.PastUnixTime(11 * time.Minute)},
+			},
+		},
+	}
+	windowed, err = oracle.ComputeTVWAPWithWindows(stale, windows)
+	require.NoError(t, err)
+	require.Empty(t, windowed)
+}
+
+func TestApplyMidPrices(t *testing.T) {
+	atom, err := types.NewTickerPrice("coinbase", "ATOMUSD", "10", "1000")
+	require.NoError(t, err)
+	atom, err = atom.WithBidAsk("coinbase", "ATOMUSD", "9.8", "10.1")
+	require.NoError(t, err)
+	ojo, err := types.NewTickerPrice("coinbase", "OJOUSD", "1", "1000")
+	require.NoError(t, err)
+	ojo, err = ojo.WithBidAsk("coinbase", "OJOUSD", "0.9", "1.3")
+	require.NoError(t, err)
+	btc, err := types.NewTickerPrice("coinbase", "BTCUSD", "30000", "10")
+	require.NoError(t, err)
+
+	prices := map[string]types.TickerPrice{"ATOMUSD": atom, "OJOUSD": ojo, "BTCUSD": btc}
+	midPrices := oracle.ApplyMidPrices(prices, map[string]struct{}{"ATOMUSD": {}, "BTCUSD": {}})
+
+	require.Equal(t, sdk.MustNewDecFromStr("9.95"), midPrices["ATOMUSD"].Price)
+	// pairs using the last trade price and tickers without a bid and ask are
+	// left unchanged
+	require.Equal(t, ojo, midPrices["OJOUSD"])
+	require.Equal(t, btc, midPrices["BTCUSD"])
+
+	// the provided tickers are left untouched
+	require.Equal(t, sdk.MustNewDecFromStr("10"), prices["ATOMUSD"].Price)
+
+	require.Equal(t, prices, oracle.ApplyMidPrices(prices, nil))
+}
+
+func TestApplyScaleFactors(t *testing.T) {
+	now := provider.PastUnixTime(0)
+	shib := types.TickerPrice{
+		Price:     sdk.MustNewDecFromStr("0.00001"),
+		Volume:    sdk.MustNewDecFromStr("5000000"),
+		TimeStamp: now,
+		Bid:       sdk.MustNewDecFromStr("0.000009"),
+	}
+	atom := types.TickerPrice{Price: sdk.MustNewDecFromStr("10"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now}
+	shibCandles := []types.CandlePrice{
+		{Price: sdk.MustNewDecFromStr("0.000011"), Volume: sdk.MustNewDecFromStr("2000000"), TimeStamp: now},
+	}
+	prices := map[string]types.TickerPrice{"SHIBUSD": shib, "ATOMUSD": atom}
+	candles := map[string][]types.CandlePrice{"SHIBUSD": shibCandles}
+
+	scaledPrices, scaledCandles := oracle.ApplyScaleFactors(
+		prices,
+		candles,
+		map[string]sdk.Dec{"SHIBUSD": sdk.NewDec(1000000)},
+	)
+	require.Equal(t, types.TickerPrice{
+		Price:     sdk.MustNewDecFromStr("10"),
+		Volume:    sdk.MustNewDecFromStr("5"),
+		TimeStamp: now,
+		Bid:       sdk.MustNewDecFromStr("9"),
+	}, scaledPrices["SHIBUSD"])
+	require.Equal(t, atom, scaledPrices["ATOMUSD"])
+	require.Equal(t, []types.CandlePrice{
+		{Price: sdk.MustNewDecFromStr("11"), Volume: sdk.MustNewDecFromStr("2"), TimeStamp: now},
+	}, scaledCandles["SHIBUSD"])
+
+	// the provided prices are left as they were
+	require.Equal(t, shib, prices["SHIBUSD"])
+	require.Equal(t, shibCandles[0].Price, candles["SHIBUSD"][0].Price)
+
+	unscaledPrices, unscaledCandles := oracle.ApplyScaleFactors(prices, candles, nil)
+	require.Equal(t, prices, unscaledPrices)
+	require.Equal(t, candles, unscaledCandles)
+}
+
+func TestApplyPriceSources(t *testing.T) {
+	now := provider.PastUnixTime(0)
+	atom := types.TickerPrice{
+		Price:     sdk.MustNewDecFromStr("10"),
+		Volume:    sdk.MustNewDecFromStr("1440"),
+		TimeStamp: now,
+	}
+	atomCandles := []types.CandlePrice{
+		{Price: sdk.MustNewDecFromStr("9.8"), Volume: sdk.MustNewDecFromStr("2"), TimeStamp: now - 60000},
+		{Price: sdk.MustNewDecFromStr("9.9"), Volume: sdk.MustNewDecFromStr("3"), TimeStamp: now},
+	}
+	ojo := types.TickerPrice{Price: sdk.MustNewDecFromStr("1"), Volume: sdk.MustNewDecFromStr("100"), TimeStamp: now}
+	ojoCandles := []types.CandlePrice{
+		{Price: sdk.MustNewDecFromStr("1.1"), Volume: sdk.MustNewDecFromStr("5"), TimeStamp: now},
+	}
+	prices := map[string]types.TickerPrice{"ATOMUSD": atom, "OJOUSD": ojo}
+	candles := map[string][]types.CandlePrice{"ATOMUSD": atomCandles, "OJOUSD": ojoCandles}
+
+	t.Run("ticker", func(t *testing.T) {
+		sourcedPrices, sourcedCandles := oracle.ApplyPriceSources(
+			provider.ProviderCoinbase,
+			prices,
+			candles,
+			map[string]string{"ATOMUSD": config.PriceSourceTicker},
+		)
+		require.Equal(t, prices, sourcedPrices)
+		// the ticker replaces the candles, its daily volume spread per minute
+		require.Equal(t, []types.CandlePrice{{
+			Price:     sdk.MustNewDecFromStr("10"),
+			Volume:    sdk.MustNewDecFromStr("1"),
+			TimeStamp: now,
+		}}, sourcedCandles["ATOMUSD"])
+		require.Equal(t, ojoCandles, sourcedCandles["OJOUSD"])
+	})
+
+	t.Run("candle", func(t *testing.T) {
+		sourcedPrices, sourcedCandles := oracle.ApplyPriceSources(
+			provider.ProviderCoinbase,
+			prices,
+			candles,
+			map[string]string{"ATOMUSD": config.PriceSourceCandle},
+		)
+		// the latest close replaces the ticker price
+		require.Equal(t, []types.CandlePrice{atomCandles[1]}, sourcedCandles["ATOMUSD"])
+		require.Equal(t, sdk.MustNewDecFromStr("9.9"), sourcedPrices["ATOMUSD"].Price)
+		require.Equal(t, atom.Volume, sourcedPrices["ATOMUSD"].Volume)
+		require.Equal(t, ojo, sourcedPrices["OJOUSD"])
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		sourcedPrices, sourcedCandles := oracle.ApplyPriceSources(
+			provider.ProviderCoinbase,
+			map[string]types.TickerPrice{"OJOUSD": ojo},
+			map[string][]types.CandlePrice{"ATOMUSD": atomCandles},
+			map[string]string{"ATOMUSD": config.PriceSourceTicker, "OJOUSD": config.PriceSourceCandle},
+		)
+		// a provider doesn't contribute a pair without its selected price
+		require.Empty(t, sourcedPrices)
+		require.Empty(t, sourcedCandles)
+	})
+
+	// the provided tickers and candles are left untouched
+	require.Equal(t, atom, prices["ATOMUSD"])
+	require.Equal(t, atomCandles, candles["ATOMUSD"])
+
+	sourcedPrices, sourcedCandles := oracle.ApplyPriceSources(provider.ProviderCoinbase, prices, candles, nil)
+	require.Equal(t, prices, sourcedPrices)
+	require.Equal(t, candles, sourcedCandles)
+}
+
+func TestRoundPrices(t *testing.T) {
+	prices := map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("10.125"),
+		"OJO":  sdk.MustNewDecFromStr("0.135"),
+		"BTC":  sdk.MustNewDecFromStr("30000.12401"),
+		"NEG":  sdk.MustNewDecFromStr("-1.125"),
+	}
+
+	testCases := map[string]struct {
+		mode     string
+		expected map[string]string
+	}{
+		config.RoundingHalfEven: {
+			mode:     config.RoundingHalfEven,
+			expected: map[string]string{"ATOM": "10.12", "OJO": "0.14", "BTC": "30000.12", "NEG": "-1.12"},
+		},
+		config.RoundingHalfUp: {
+			mode:     config.RoundingHalfUp,
+			expected: map[string]string{"ATOM": "10.13", "OJO": "0.14", "BTC": "30000.12", "NEG": "-1.13"},
+		},
+		config.RoundingTruncate: {
+			mode:     config.RoundingTruncate,
+			expected: map[string]string{"ATOM": "10.12", "OJO": "0.13", "BTC": "30000.12", "NEG": "-1.12"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rounded := oracle.RoundPrices(prices, config.Rounding{Mode: tc.mode, Precision: 2})
+			require.Len(t, rounded, len(tc.expected))
+			for base, expected := range tc.expected {
+				require.Equal(t, sdk.MustNewDecFromStr(expected), rounded[base], base)
+			}
+		})
+	}
+
+	// the full precision and an unset mode leave the prices unchanged
+	require.Equal(t, prices, oracle.RoundPrices(prices, config.Rounding{Mode: config.RoundingHalfUp, Precision: 18}))
+	require.Equal(t, prices, oracle.RoundPrices(prices, config.Rounding{}))
+}
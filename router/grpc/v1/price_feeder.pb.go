@@ -0,0 +1,584 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1-devel
+// 	protoc        (unknown)
+// source: pricefeeder/v1/price_feeder.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PriceKind selects which prices of the providers are returned.
+type PriceKind int32
+
+const (
+	// PRICE_KIND_UNSPECIFIED defaults to PRICE_KIND_TVWAP.
+	PriceKind_PRICE_KIND_UNSPECIFIED PriceKind = 0
+	// PRICE_KIND_TVWAP returns the TVWAP of each provider's candles.
+	PriceKind_PRICE_KIND_TVWAP PriceKind = 1
+	// PRICE_KIND_VWAP returns the VWAP of each provider's tickers.
+	PriceKind_PRICE_KIND_VWAP PriceKind = 2
+	// PRICE_KIND_OBSERVED returns the prices of the observe-only providers.
+	PriceKind_PRICE_KIND_OBSERVED PriceKind = 3
+)
+
+// Enum value maps for PriceKind.
+var (
+	PriceKind_name = map[int32]string{
+		0: "PRICE_KIND_UNSPECIFIED",
+		1: "PRICE_KIND_TVWAP",
+		2: "PRICE_KIND_VWAP",
+		3: "PRICE_KIND_OBSERVED",
+	}
+	PriceKind_value = map[string]int32{
+		"PRICE_KIND_UNSPECIFIED": 0,
+		"PRICE_KIND_TVWAP":       1,
+		"PRICE_KIND_VWAP":        2,
+		"PRICE_KIND_OBSERVED":    3,
+	}
+)
+
+func (x PriceKind) Enum() *PriceKind {
+	p := new(PriceKind)
+	*p = x
+	return p
+}
+
+func (x PriceKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_pricefeeder_v1_price_feeder_proto_enumTypes[0].Descriptor()
+}
+
+func (PriceKind) Type() protoreflect.EnumType {
+	return &file_pricefeeder_v1_price_feeder_proto_enumTypes[0]
+}
+
+func (x PriceKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceKind.Descriptor instead.
+func (PriceKind) EnumDescriptor() ([]byte, []int) {
+	return file_pricefeeder_v1_price_feeder_proto_rawDescGZIP(), []int{0}
+}
+
+type PricesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PricesRequest) Reset() {
+	*x = PricesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricesRequest) ProtoMessage() {}
+
+func (x *PricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricesRequest.ProtoReflect.Descriptor instead.
+func (*PricesRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeeder_v1_price_feeder_proto_rawDescGZIP(), []int{0}
+}
+
+// PricesResponse holds the aggregated prices, as decimal strings keyed by
+// base, and the time they were computed at.
+type PricesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prices    map[string]string      `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *PricesResponse) Reset() {
+	*x = PricesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricesResponse) ProtoMessage() {}
+
+func (x *PricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricesResponse.ProtoReflect.Descriptor instead.
+func (*PricesResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeeder_v1_price_feeder_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PricesResponse) GetPrices() map[string]string {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *PricesResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type ProviderPricesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind PriceKind `protobuf:"varint,1,opt,name=kind,proto3,enum=pricefeeder.v1.PriceKind" json:"kind,omitempty"`
+}
+
+func (x *ProviderPricesRequest) Reset() {
+	*x = ProviderPricesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderPricesRequest) ProtoMessage() {}
+
+func (x *ProviderPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderPricesRequest.ProtoReflect.Descriptor instead.
+func (*ProviderPricesRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeeder_v1_price_feeder_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProviderPricesRequest) GetKind() PriceKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PriceKind_PRICE_KIND_UNSPECIFIED
+}
+
+// ProviderPrices holds the prices of a provider, as decimal strings keyed by
+// base.
+type ProviderPrices struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prices map[string]string `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ProviderPrices) Reset() {
+	*x = ProviderPrices{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderPrices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderPrices) ProtoMessage() {}
+
+func (x *ProviderPrices) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderPrices.ProtoReflect.Descriptor instead.
+func (*ProviderPrices) Descriptor() ([]byte, []int) {
+	return file_pricefeeder_v1_price_feeder_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProviderPrices) GetPrices() map[string]string {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+// ProviderPricesResponse holds the prices of each provider, keyed by provider
+// name.
+type ProviderPricesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Providers map[string]*ProviderPrices `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ProviderPricesResponse) Reset() {
+	*x = ProviderPricesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderPricesResponse) ProtoMessage() {}
+
+func (x *ProviderPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderPricesResponse.ProtoReflect.Descriptor instead.
+func (*ProviderPricesResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeeder_v1_price_feeder_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProviderPricesResponse) GetProviders() map[string]*ProviderPrices {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type StreamPricesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamPricesRequest) Reset() {
+	*x = StreamPricesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPricesRequest) ProtoMessage() {}
+
+func (x *StreamPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeeder_v1_price_feeder_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPricesRequest.ProtoReflect.Descriptor instead.
+func (*StreamPricesRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeeder_v1_price_feeder_proto_rawDescGZIP(), []int{5}
+}
+
+var File_pricefeeder_v1_price_feeder_proto protoreflect.FileDescriptor
+
+var file_pricefeeder_v1_price_feeder_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31,
+	0x2f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x0f, 0x0a, 0x0d, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc9, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x06, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x46, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4b,
+	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x0e, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x06,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73,
+	0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcb, 0x01, 0x0a, 0x16,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x5c, 0x0a, 0x0e, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2a, 0x6b, 0x0a, 0x09, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1a, 0x0a,
+	0x16, 0x50, 0x52, 0x49, 0x43, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x49,
+	0x43, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x54, 0x56, 0x57, 0x41, 0x50, 0x10, 0x01, 0x12,
+	0x13, 0x0a, 0x0f, 0x50, 0x52, 0x49, 0x43, 0x45, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x56, 0x57,
+	0x41, 0x50, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x52, 0x49, 0x43, 0x45, 0x5f, 0x4b, 0x49,
+	0x4e, 0x44, 0x5f, 0x4f, 0x42, 0x53, 0x45, 0x52, 0x56, 0x45, 0x44, 0x10, 0x03, 0x32, 0x8e, 0x02,
+	0x0a, 0x0b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x64, 0x65, 0x72, 0x12, 0x47, 0x0a,
+	0x06, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66,
+	0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65,
+	0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x26, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x50, 0x72, 0x69, 0x63, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66,
+	0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x37,
+	0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6a, 0x6f,
+	0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x2d, 0x66,
+	0x65, 0x65, 0x64, 0x65, 0x72, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70,
+	0x63, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pricefeeder_v1_price_feeder_proto_rawDescOnce sync.Once
+	file_pricefeeder_v1_price_feeder_proto_rawDescData = file_pricefeeder_v1_price_feeder_proto_rawDesc
+)
+
+func file_pricefeeder_v1_price_feeder_proto_rawDescGZIP() []byte {
+	file_pricefeeder_v1_price_feeder_proto_rawDescOnce.Do(func() {
+		file_pricefeeder_v1_price_feeder_proto_rawDescData = protoimpl.X.CompressGZIP(file_pricefeeder_v1_price_feeder_proto_rawDescData)
+	})
+	return file_pricefeeder_v1_price_feeder_proto_rawDescData
+}
+
+var file_pricefeeder_v1_price_feeder_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pricefeeder_v1_price_feeder_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pricefeeder_v1_price_feeder_proto_goTypes = []interface{}{
+	(PriceKind)(0),                 // 0: pricefeeder.v1.PriceKind
+	(*PricesRequest)(nil),          // 1: pricefeeder.v1.PricesRequest
+	(*PricesResponse)(nil),         // 2: pricefeeder.v1.PricesResponse
+	(*ProviderPricesRequest)(nil),  // 3: pricefeeder.v1.ProviderPricesRequest
+	(*ProviderPrices)(nil),         // 4: pricefeeder.v1.ProviderPrices
+	(*ProviderPricesResponse)(nil), // 5: pricefeeder.v1.ProviderPricesResponse
+	(*StreamPricesRequest)(nil),    // 6: pricefeeder.v1.StreamPricesRequest
+	nil,                            // 7: pricefeeder.v1.PricesResponse.PricesEntry
+	nil,                            // 8: pricefeeder.v1.ProviderPrices.PricesEntry
+	nil,                            // 9: pricefeeder.v1.ProviderPricesResponse.ProvidersEntry
+	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
+}
+var file_pricefeeder_v1_price_feeder_proto_depIdxs = []int32{
+	7,  // 0: pricefeeder.v1.PricesResponse.prices:type_name -> pricefeeder.v1.PricesResponse.PricesEntry
+	10, // 1: pricefeeder.v1.PricesResponse.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 2: pricefeeder.v1.ProviderPricesRequest.kind:type_name -> pricefeeder.v1.PriceKind
+	8,  // 3: pricefeeder.v1.ProviderPrices.prices:type_name -> pricefeeder.v1.ProviderPrices.PricesEntry
+	9,  // 4: pricefeeder.v1.ProviderPricesResponse.providers:type_name -> pricefeeder.v1.ProviderPricesResponse.ProvidersEntry
+	4,  // 5: pricefeeder.v1.ProviderPricesResponse.ProvidersEntry.value:type_name -> pricefeeder.v1.ProviderPrices
+	1,  // 6: pricefeeder.v1.PriceFeeder.Prices:input_type -> pricefeeder.v1.PricesRequest
+	3,  // 7: pricefeeder.v1.PriceFeeder.ProviderPrices:input_type -> pricefeeder.v1.ProviderPricesRequest
+	6,  // 8: pricefeeder.v1.PriceFeeder.StreamPrices:input_type -> pricefeeder.v1.StreamPricesRequest
+	2,  // 9: pricefeeder.v1.PriceFeeder.Prices:output_type -> pricefeeder.v1.PricesResponse
+	5,  // 10: pricefeeder.v1.PriceFeeder.ProviderPrices:output_type -> pricefeeder.v1.ProviderPricesResponse
+	2,  // 11: pricefeeder.v1.PriceFeeder.StreamPrices:output_type -> pricefeeder.v1.PricesResponse
+	9,  // [9:12] is the sub-list for method output_type
+	6,  // [6:9] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pricefeeder_v1_price_feeder_proto_init() }
+func file_pricefeeder_v1_price_feeder_proto_init() {
+	if File_pricefeeder_v1_price_feeder_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pricefeeder_v1_price_feeder_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PricesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeeder_v1_price_feeder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PricesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeeder_v1_price_feeder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProviderPricesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeeder_v1_price_feeder_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProviderPrices); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeeder_v1_price_feeder_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProviderPricesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeeder_v1_price_feeder_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamPricesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pricefeeder_v1_price_feeder_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pricefeeder_v1_price_feeder_proto_goTypes,
+		DependencyIndexes: file_pricefeeder_v1_price_feeder_proto_depIdxs,
+		EnumInfos:         file_pricefeeder_v1_price_feeder_proto_enumTypes,
+		MessageInfos:      file_pricefeeder_v1_price_feeder_proto_msgTypes,
+	}.Build()
+	File_pricefeeder_v1_price_feeder_proto = out.File
+	file_pricefeeder_v1_price_feeder_proto_rawDesc = nil
+	file_pricefeeder_v1_price_feeder_proto_goTypes = nil
+	file_pricefeeder_v1_price_feeder_proto_depIdxs = nil
+}
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle"
+)
+
+// Oracle defines the Oracle interface contract that the gRPC server depends
+// on.
+type Oracle interface {
+	GetLastPriceSyncTimestamp() time.Time
+	GetPrices() map[string]sdk.Dec
+	GetTvwapPrices() oracle.PricesByProvider
+	GetVwapPrices() oracle.PricesByProvider
+	GetObservedPrices() oracle.PricesByProvider
+	SubscribePrices(int) (<-chan oracle.PricesUpdate, func())
+}
+
+// Server implements the PriceFeeder gRPC service on top of the oracle.
+type Server struct {
+	UnimplementedPriceFeederServer
+
+	logger       zerolog.Logger
+	oracle       Oracle
+	streamBuffer int
+}
+
+// NewServer returns a Server serving the oracle's prices.
+func NewServer(logger zerolog.Logger, cfg config.Config, oracle Oracle) *Server {
+	return &Server{
+		logger:       logger.With().Str("module", "grpc").Logger(),
+		oracle:       oracle,
+		streamBuffer: cfg.Server.StreamBuffer,
+	}
+}
+
+// Register registers the PriceFeeder service on the gRPC server, along with
+// the reflection service describing it to tools such as grpcurl.
+func (s *Server) Register(srv *grpc.Server) {
+	RegisterPriceFeederServer(srv, s)
+	reflection.Register(srv)
+}
+
+func (s *Server) Prices(_ context.Context, _ *PricesRequest) (*PricesResponse, error) {
+	return pricesResponse(s.oracle.GetPrices(), s.oracle.GetLastPriceSyncTimestamp()), nil
+}
+
+func (s *Server) ProviderPrices(_ context.Context, req *ProviderPricesRequest) (*ProviderPricesResponse, error) {
+	var prices oracle.PricesByProvider
+	switch req.Kind {
+	case PriceKind_PRICE_KIND_VWAP:
+		prices = s.oracle.GetVwapPrices()
+	case PriceKind_PRICE_KIND_OBSERVED:
+		prices = s.oracle.GetObservedPrices()
+	default:
+		prices = s.oracle.GetTvwapPrices()
+	}
+
+	resp := &ProviderPricesResponse{
+		Providers: make(map[string]*ProviderPrices, len(prices)),
+	}
+	for providerName, providerPrices := range prices {
+		resp.Providers[providerName.String()] = &ProviderPrices{Prices: decStrings(providerPrices)}
+	}
+	return resp, nil
+}
+
+// StreamPrices sends the prices of each price sync until the client cancels
+// the stream. The updates the client is too slow to receive are dropped
+// rather than holding up the price sync.
+func (s *Server) StreamPrices(_ *StreamPricesRequest, stream PriceFeeder_StreamPricesServer) error {
+	updates, unsubscribe := s.oracle.SubscribePrices(s.streamBuffer)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(pricesResponse(update.Prices, update.Timestamp)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func pricesResponse(prices map[string]sdk.Dec, timestamp time.Time) *PricesResponse {
+	return &PricesResponse{
+		Prices:    decStrings(prices),
+		Timestamp: timestamppb.New(timestamp),
+	}
+}
+
+// decStrings returns the decimal strings of the prices, which keep their
+// precision unlike floating point numbers.
+func decStrings(prices map[string]sdk.Dec) map[string]string {
+	strs := make(map[string]string, len(prices))
+	for base, price := range prices {
+		strs[base] = price.String()
+	}
+	return strs
+}
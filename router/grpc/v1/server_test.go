@@ -0,0 +1,177 @@
+package v1_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	v1 "github.com/ojo-network/price-feeder/router/grpc/v1"
+)
+
+var (
+	_ v1.Oracle = (*mockOracle)(nil)
+
+	mockTimestamp = time.Unix(1700000000, 0).UTC()
+
+	mockPrices = map[string]sdk.Dec{
+		"ATOM": sdk.MustNewDecFromStr("34.84"),
+		"OJO":  sdk.MustNewDecFromStr("4.21"),
+	}
+
+	mockTvwapPrices = oracle.PricesByProvider{
+		provider.ProviderBinance: {
+			"ATOM": sdk.MustNewDecFromStr("28.21000000"),
+		},
+	}
+
+	mockVwapPrices = oracle.PricesByProvider{
+		provider.ProviderKraken: {
+			"ATOM": sdk.MustNewDecFromStr("28.268700"),
+		},
+	}
+
+	mockObservedPrices = oracle.PricesByProvider{
+		provider.ProviderMexc: {
+			"ATOM": sdk.MustNewDecFromStr("28.30000000"),
+		},
+	}
+)
+
+type mockOracle struct{}
+
+func (m mockOracle) GetLastPriceSyncTimestamp() time.Time {
+	return mockTimestamp
+}
+
+func (m mockOracle) GetPrices() map[string]sdk.Dec {
+	return mockPrices
+}
+
+func (m mockOracle) GetTvwapPrices() oracle.PricesByProvider {
+	return mockTvwapPrices
+}
+
+func (m mockOracle) GetVwapPrices() oracle.PricesByProvider {
+	return mockVwapPrices
+}
+
+func (m mockOracle) GetObservedPrices() oracle.PricesByProvider {
+	return mockObservedPrices
+}
+
+func (m mockOracle) SubscribePrices(int) (<-chan oracle.PricesUpdate, func()) {
+	ch := make(chan oracle.PricesUpdate, 2)
+	ch <- oracle.PricesUpdate{Prices: mockPrices, Timestamp: mockTimestamp}
+	ch <- oracle.PricesUpdate{Prices: mockPrices, Timestamp: mockTimestamp.Add(5 * time.Second)}
+	return ch, func() {}
+}
+
+type ServerTestSuite struct {
+	suite.Suite
+
+	srv    *grpc.Server
+	conn   *grpc.ClientConn
+	client v1.PriceFeederClient
+}
+
+func (rts *ServerTestSuite) SetupSuite() {
+	listener := bufconn.Listen(1024 * 1024)
+
+	rts.srv = grpc.NewServer()
+	v1.NewServer(zerolog.Nop(), config.Config{}, mockOracle{}).Register(rts.srv)
+	go func() {
+		_ = rts.srv.Serve(listener)
+	}()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	rts.Require().NoError(err)
+
+	rts.conn = conn
+	rts.client = v1.NewPriceFeederClient(conn)
+}
+
+func (rts *ServerTestSuite) TearDownSuite() {
+	rts.conn.Close()
+	rts.srv.Stop()
+}
+
+// TestServerTestSuite is the entrypoint for the gRPC server test suite.
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (rts *ServerTestSuite) TestPrices() {
+	resp, err := rts.client.Prices(context.Background(), &v1.PricesRequest{})
+	rts.Require().NoError(err)
+	rts.Require().Equal(mockPrices["ATOM"].String(), resp.Prices["ATOM"])
+	rts.Require().Equal(mockPrices["OJO"].String(), resp.Prices["OJO"])
+	rts.Require().Equal(mockTimestamp, resp.Timestamp.AsTime())
+}
+
+func (rts *ServerTestSuite) TestProviderPrices() {
+	testCases := []struct {
+		name     string
+		kind     v1.PriceKind
+		expected oracle.PricesByProvider
+	}{
+		{
+			name:     "unspecified defaults to tvwap",
+			kind:     v1.PriceKind_PRICE_KIND_UNSPECIFIED,
+			expected: mockTvwapPrices,
+		},
+		{
+			name:     "vwap",
+			kind:     v1.PriceKind_PRICE_KIND_VWAP,
+			expected: mockVwapPrices,
+		},
+		{
+			name:     "observed",
+			kind:     v1.PriceKind_PRICE_KIND_OBSERVED,
+			expected: mockObservedPrices,
+		},
+	}
+
+	for _, tc := range testCases {
+		rts.Run(tc.name, func() {
+			resp, err := rts.client.ProviderPrices(context.Background(), &v1.ProviderPricesRequest{Kind: tc.kind})
+			rts.Require().NoError(err)
+			rts.Require().Len(resp.Providers, len(tc.expected))
+			for providerName, prices := range tc.expected {
+				rts.Require().Contains(resp.Providers, providerName.String())
+				rts.Require().Equal(prices["ATOM"].String(), resp.Providers[providerName.String()].Prices["ATOM"])
+			}
+		})
+	}
+}
+
+func (rts *ServerTestSuite) TestStreamPrices() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := rts.client.StreamPrices(ctx, &v1.StreamPricesRequest{})
+	rts.Require().NoError(err)
+
+	for i := 0; i < 2; i++ {
+		resp, err := stream.Recv()
+		rts.Require().NoError(err)
+		rts.Require().Equal(mockPrices["ATOM"].String(), resp.Prices["ATOM"])
+		rts.Require().Equal(mockTimestamp.Add(time.Duration(i)*5*time.Second), resp.Timestamp.AsTime())
+	}
+}
@@ -5,12 +5,22 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ojo-network/price-feeder/oracle"
+	"github.com/ojo-network/price-feeder/oracle/provider"
 )
 
 // Oracle defines the Oracle interface contract that the v1 router depends on.
 type Oracle interface {
 	GetLastPriceSyncTimestamp() time.Time
+	IsReady() (bool, []string)
 	GetPrices() map[string]sdk.Dec
 	GetTvwapPrices() oracle.PricesByProvider
 	GetVwapPrices() oracle.PricesByProvider
+	GetObservedPrices() oracle.PricesByProvider
+	GetPriceHistory(string) []oracle.PriceSample
+	SubscribePrices(int) (<-chan oracle.PricesUpdate, func())
+	DisableProvider(provider.Name) error
+	EnableProvider(provider.Name) error
+	GetDisabledProviders() []provider.Name
+	DescribeProviders() []provider.Description
+	RotateAPIKey(provider.Name, string) error
 }
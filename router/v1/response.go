@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/price-feeder/oracle"
 	"github.com/ojo-network/price-feeder/oracle/provider"
 )
 
@@ -23,15 +25,54 @@ type (
 		} `json:"oracle"`
 	}
 
+	// ReadyResponse defines the response type for the readiness handler,
+	// listing why the oracle isn't ready to vote yet.
+	ReadyResponse struct {
+		Ready   bool     `json:"ready"`
+		Pending []string `json:"pending,omitempty"`
+	}
+
 	// PricesResponse defines the response type for getting the latest exchange
-	// rates from the oracle.
+	// rates from the oracle, along with the time they were computed at.
 	PricesResponse struct {
-		Prices map[string]sdk.Dec `json:"prices"`
+		Prices    map[string]sdk.Dec `json:"prices"`
+		Timestamp time.Time          `json:"timestamp"`
+	}
+
+	// PriceHistoryResponse defines the response type for getting the retained
+	// exchange rates of a pair, oldest first.
+	PriceHistoryResponse struct {
+		Pair   string               `json:"pair"`
+		Prices []oracle.PriceSample `json:"prices"`
 	}
 
 	PricesPerProviderResponse struct {
 		Prices map[provider.Name]map[string]sdk.Dec `json:"providers"`
 	}
+
+	// DisabledProvidersResponse defines the response type for the admin
+	// handlers listing and toggling the providers disabled at runtime.
+	DisabledProvidersResponse struct {
+		Disabled []provider.Name `json:"disabled"`
+	}
+
+	// ProvidersResponse defines the response type for the admin handler
+	// describing the capabilities of the running providers.
+	ProvidersResponse struct {
+		Providers []provider.Description `json:"providers"`
+	}
+
+	// RotateAPIKeyRequest defines the request body of the admin handler
+	// rotating the API key of a provider.
+	RotateAPIKeyRequest struct {
+		APIKey string `json:"apikey"`
+	}
+
+	// RotateAPIKeyResponse defines the response type for the admin handler
+	// rotating the API key of a provider.
+	RotateAPIKeyResponse struct {
+		Provider provider.Name `json:"provider"`
+	}
 )
 
 // errorResponse defines the attributes of a JSON error response.
@@ -1,6 +1,9 @@
 package v1
 
 import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,6 +14,8 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/ojo-network/price-feeder/config"
+	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
 	"github.com/ojo-network/price-feeder/pkg/httputil"
 	"github.com/ojo-network/price-feeder/router/middleware"
 )
@@ -65,6 +70,11 @@ func (r *Router) RegisterRoutes(rtr *mux.Router, prefix string) {
 		mChain.ThenFunc(r.healthzHandler()),
 	).Methods(httputil.MethodGET)
 
+	v1Router.Handle(
+		"/ready",
+		mChain.ThenFunc(r.readyHandler()),
+	).Methods(httputil.MethodGET)
+
 	v1Router.Handle(
 		"/prices",
 		mChain.ThenFunc(r.pricesHandler()),
@@ -80,12 +90,136 @@ func (r *Router) RegisterRoutes(rtr *mux.Router, prefix string) {
 		mChain.ThenFunc(r.tickerPricesHandler()),
 	).Methods(httputil.MethodGET)
 
+	v1Router.Handle(
+		"/prices/providers/observed",
+		mChain.ThenFunc(r.observedPricesHandler()),
+	).Methods(httputil.MethodGET)
+
+	v1Router.Handle(
+		"/prices/stream",
+		mChain.ThenFunc(r.pricesStreamHandler()),
+	).Methods(httputil.MethodGET)
+
+	if r.cfg.Server.PriceHistorySize > 0 {
+		v1Router.Handle(
+			"/prices/history",
+			mChain.ThenFunc(r.priceHistoryHandler()),
+		).Methods(httputil.MethodGET)
+	}
+
 	if r.cfg.Telemetry.Enabled {
 		v1Router.Handle(
 			"/metrics",
 			mChain.ThenFunc(r.metricsHandler()),
 		).Methods(httputil.MethodGET)
 	}
+
+	if len(r.cfg.Server.AdminToken) > 0 {
+		v1Router.Handle(
+			"/admin/providers",
+			mChain.ThenFunc(r.adminHandler(r.providersHandler())),
+		).Methods(httputil.MethodGET)
+
+		v1Router.Handle(
+			"/admin/providers/disabled",
+			mChain.ThenFunc(r.adminHandler(r.disabledProvidersHandler())),
+		).Methods(httputil.MethodGET)
+
+		v1Router.Handle(
+			"/admin/providers/{provider}/disable",
+			mChain.ThenFunc(r.adminHandler(r.toggleProviderHandler(r.oracle.DisableProvider))),
+		).Methods(httputil.MethodPOST)
+
+		v1Router.Handle(
+			"/admin/providers/{provider}/enable",
+			mChain.ThenFunc(r.adminHandler(r.toggleProviderHandler(r.oracle.EnableProvider))),
+		).Methods(httputil.MethodPOST)
+
+		v1Router.Handle(
+			"/admin/providers/{provider}/apikey",
+			mChain.ThenFunc(r.adminHandler(r.rotateAPIKeyHandler())),
+		).Methods(httputil.MethodPOST)
+	}
+}
+
+// adminHandler only calls the handler for requests authorized with the admin
+// token as their bearer token.
+func (r *Router) adminHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(r.cfg.Server.AdminToken)) != 1 {
+			writeErrorResponse(w, http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		handler(w, req)
+	}
+}
+
+func (r *Router) providersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp := ProvidersResponse{
+			Providers: r.oracle.DescribeProviders(),
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+	}
+}
+
+func (r *Router) disabledProvidersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp := DisabledProvidersResponse{
+			Disabled: r.oracle.GetDisabledProviders(),
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+	}
+}
+
+// toggleProviderHandler applies toggle to the provider of the request path and
+// responds with the providers that are disabled afterwards.
+func (r *Router) toggleProviderHandler(toggle func(provider.Name) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		providerName := provider.Name(mux.Vars(req)["provider"])
+		if err := toggle(providerName); err != nil {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		resp := DisabledProvidersResponse{
+			Disabled: r.oracle.GetDisabledProviders(),
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+	}
+}
+
+// rotateAPIKeyHandler replaces the API key of the provider of the request
+// path by the apikey of the request's JSON body. A key read from a file
+// conflicts with the request.
+func (r *Router) rotateAPIKeyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var body RotateAPIKeyRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil || len(body.APIKey) == 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "request body must hold an apikey")
+			return
+		}
+
+		providerName := provider.Name(mux.Vars(req)["provider"])
+		if err := r.oracle.RotateAPIKey(providerName, body.APIKey); err != nil {
+			if errors.Is(err, types.ErrAPIKeyFromFile) {
+				writeErrorResponse(w, http.StatusConflict, err.Error())
+				return
+			}
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		resp := RotateAPIKeyResponse{
+			Provider: providerName,
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+	}
 }
 
 func (r *Router) healthzHandler() http.HandlerFunc {
@@ -100,10 +234,101 @@ func (r *Router) healthzHandler() http.HandlerFunc {
 	}
 }
 
+// readyHandler responds with 200 once the oracle's readiness gate opened,
+// and 503 until then, for readiness probes.
+func (r *Router) readyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ready, pending := r.oracle.IsReady()
+		resp := ReadyResponse{
+			Ready:   ready,
+			Pending: pending,
+		}
+
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
+		}
+		httputil.RespondWithJSON(w, status, resp)
+	}
+}
+
 func (r *Router) pricesHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		resp := PricesResponse{
-			Prices: r.oracle.GetPrices(),
+			Prices:    r.oracle.GetPrices(),
+			Timestamp: r.oracle.GetLastPriceSyncTimestamp(),
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+	}
+}
+
+// pricesStreamHandler streams the prices of each price sync as server-sent
+// events until the client disconnects. The updates a client is too slow to
+// receive are dropped rather than holding up the price sync.
+func (r *Router) pricesStreamHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeErrorResponse(w, http.StatusInternalServerError, "streaming is not supported")
+			return
+		}
+
+		updates, unsubscribe := r.oracle.SubscribePrices(r.cfg.Server.StreamBuffer)
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			select {
+			case <-req.Context().Done():
+				return
+
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				bz, err := json.Marshal(PricesResponse(update))
+				if err != nil {
+					r.logger.Error().Err(err).Msg("failed to marshal prices update")
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "event: prices\ndata: %s\n\n", bz); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// priceHistoryHandler responds with the retained prices of the pair of the
+// request, ex. ATOMUSD. The prices are denominated in USD, so the pair can also
+// be given as its base.
+func (r *Router) priceHistoryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		pair := strings.ToUpper(strings.TrimSpace(req.FormValue("pair")))
+		if len(pair) == 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "missing pair")
+			return
+		}
+
+		prices := r.oracle.GetPriceHistory(pair)
+		if base := strings.TrimSuffix(pair, config.DenomUSD); len(prices) == 0 && len(base) > 0 {
+			prices = r.oracle.GetPriceHistory(base)
+		}
+		if len(prices) == 0 {
+			writeErrorResponse(w, http.StatusNotFound, fmt.Sprintf("no price history for pair %s", pair))
+			return
+		}
+
+		resp := PriceHistoryResponse{
+			Pair:   pair,
+			Prices: prices,
 		}
 
 		httputil.RespondWithJSON(w, http.StatusOK, resp)
@@ -130,6 +355,16 @@ func (r *Router) tickerPricesHandler() http.HandlerFunc {
 	}
 }
 
+func (r *Router) observedPricesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		resp := PricesPerProviderResponse{
+			Prices: r.oracle.GetObservedPrices(),
+		}
+
+		httputil.RespondWithJSON(w, http.StatusOK, resp)
+	}
+}
+
 func (r *Router) metricsHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		format := strings.TrimSpace(req.FormValue("format"))
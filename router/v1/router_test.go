@@ -1,9 +1,13 @@
 package v1_test
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +20,7 @@ import (
 	"github.com/ojo-network/price-feeder/config"
 	"github.com/ojo-network/price-feeder/oracle"
 	"github.com/ojo-network/price-feeder/oracle/provider"
+	"github.com/ojo-network/price-feeder/oracle/types"
 	v1 "github.com/ojo-network/price-feeder/router/v1"
 )
 
@@ -37,14 +42,34 @@ var (
 			"OJO":  sdk.MustNewDecFromStr("1.13000000"),
 		},
 	}
+
+	mockPriceHistory = map[string][]oracle.PriceSample{
+		"ATOM": {
+			{Price: sdk.MustNewDecFromStr("34.80"), Timestamp: time.Unix(1700000000, 0).UTC()},
+			{Price: sdk.MustNewDecFromStr("34.84"), Timestamp: time.Unix(1700000005, 0).UTC()},
+		},
+	}
+
+	mockObservedPrices = map[provider.Name]map[string]sdk.Dec{
+		provider.ProviderMexc: {
+			"ATOM": sdk.MustNewDecFromStr("28.30000000"),
+		},
+	}
 )
 
-type mockOracle struct{}
+type mockOracle struct {
+	disabled map[provider.Name]struct{}
+	pending  []string
+}
 
 func (m mockOracle) GetLastPriceSyncTimestamp() time.Time {
 	return time.Now()
 }
 
+func (m *mockOracle) IsReady() (bool, []string) {
+	return len(m.pending) == 0, m.pending
+}
+
 func (m mockOracle) GetPrices() map[string]sdk.Dec {
 	return mockPrices
 }
@@ -57,6 +82,60 @@ func (m mockOracle) GetVwapPrices() oracle.PricesByProvider {
 	return mockComputedPrices
 }
 
+func (m mockOracle) GetObservedPrices() oracle.PricesByProvider {
+	return mockObservedPrices
+}
+
+func (m mockOracle) GetPriceHistory(base string) []oracle.PriceSample {
+	return mockPriceHistory[base]
+}
+
+func (m mockOracle) SubscribePrices(buffer int) (<-chan oracle.PricesUpdate, func()) {
+	updates := make(chan oracle.PricesUpdate, 1)
+	updates <- oracle.PricesUpdate{Prices: mockPrices, Timestamp: time.Unix(1700000005, 0).UTC()}
+	return updates, func() {}
+}
+
+func (m mockOracle) DisableProvider(providerName provider.Name) error {
+	if _, ok := mockComputedPrices[providerName]; !ok {
+		return fmt.Errorf("provider %s is not configured", providerName)
+	}
+	m.disabled[providerName] = struct{}{}
+	return nil
+}
+
+func (m mockOracle) EnableProvider(providerName provider.Name) error {
+	if _, ok := mockComputedPrices[providerName]; !ok {
+		return fmt.Errorf("provider %s is not configured", providerName)
+	}
+	delete(m.disabled, providerName)
+	return nil
+}
+
+func (m mockOracle) GetDisabledProviders() []provider.Name {
+	disabled := []provider.Name{}
+	for providerName := range m.disabled {
+		disabled = append(disabled, providerName)
+	}
+	return disabled
+}
+
+func (m mockOracle) DescribeProviders() []provider.Description {
+	return []provider.Description{
+		{Name: provider.ProviderBinance, Channels: []string{"ticker", "kline_1m"}, Candles: true},
+	}
+}
+
+func (m mockOracle) RotateAPIKey(providerName provider.Name, apiKey string) error {
+	if providerName == provider.ProviderNumia {
+		return types.ErrAPIKeyFromFile.Wrapf("provider %s reads its API key from a file", providerName)
+	}
+	if _, ok := mockComputedPrices[providerName]; !ok {
+		return fmt.Errorf("provider %s is not configured", providerName)
+	}
+	return nil
+}
+
 type mockMetrics struct{}
 
 func (mockMetrics) Gather(format string) (telemetry.GatherResponse, error) {
@@ -68,6 +147,7 @@ type RouterTestSuite struct {
 
 	mux    *mux.Router
 	router *v1.Router
+	oracle *mockOracle
 }
 
 // SetupSuite executes once before the suite's tests are executed.
@@ -75,16 +155,20 @@ func (rts *RouterTestSuite) SetupSuite() {
 	mux := mux.NewRouter()
 	cfg := config.Config{
 		Server: config.Server{
-			AllowedOrigins: []string{},
-			VerboseCORS:    false,
+			AllowedOrigins:   []string{},
+			VerboseCORS:      false,
+			AdminToken:       "admin-token",
+			PriceHistorySize: 2,
 		},
 	}
 
-	r := v1.New(zerolog.Nop(), cfg, mockOracle{}, mockMetrics{})
+	oracle := &mockOracle{disabled: map[provider.Name]struct{}{}}
+	r := v1.New(zerolog.Nop(), cfg, oracle, mockMetrics{})
 	r.RegisterRoutes(mux, v1.APIPathPrefix)
 
 	rts.mux = mux
 	rts.router = r
+	rts.oracle = oracle
 }
 
 func TestServiceTestSuite(t *testing.T) {
@@ -110,6 +194,26 @@ func (rts *RouterTestSuite) TestHealthz() {
 	rts.Require().Equal(respBody["status"], v1.StatusAvailable)
 }
 
+func (rts *RouterTestSuite) TestReady() {
+	req, err := http.NewRequest("GET", "/api/v1/ready", nil)
+	rts.Require().NoError(err)
+
+	rts.oracle.pending = []string{"ATOM has a price from 1 of 3 providers"}
+	response := rts.executeRequest(req)
+	rts.Require().Equal(http.StatusServiceUnavailable, response.Code)
+
+	var respBody v1.ReadyResponse
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().False(respBody.Ready)
+	rts.Require().Equal(rts.oracle.pending, respBody.Pending)
+
+	rts.oracle.pending = nil
+	response = rts.executeRequest(req)
+	rts.Require().Equal(http.StatusOK, response.Code)
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().True(respBody.Ready)
+}
+
 func (rts *RouterTestSuite) TestPrices() {
 	req, err := http.NewRequest("GET", "/api/v1/prices", nil)
 	rts.Require().NoError(err)
@@ -122,6 +226,7 @@ func (rts *RouterTestSuite) TestPrices() {
 	rts.Require().Equal(respBody.Prices["ATOM"], mockPrices["ATOM"])
 	rts.Require().Equal(respBody.Prices["OJO"], mockPrices["OJO"])
 	rts.Require().Equal(respBody.Prices["FOO"], sdk.Dec{})
+	rts.Require().False(respBody.Timestamp.IsZero())
 }
 
 func (rts *RouterTestSuite) TestTvwap() {
@@ -151,3 +256,131 @@ func (rts *RouterTestSuite) TestVwap() {
 		mockComputedPrices[provider.ProviderBinance]["ATOM"],
 	)
 }
+
+func (rts *RouterTestSuite) TestObservedPrices() {
+	req, err := http.NewRequest("GET", "/api/v1/prices/providers/observed", nil)
+	rts.Require().NoError(err)
+	response := rts.executeRequest(req)
+	rts.Require().Equal(http.StatusOK, response.Code)
+
+	var respBody v1.PricesPerProviderResponse
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().Equal(
+		respBody.Prices[provider.ProviderMexc]["ATOM"],
+		mockObservedPrices[provider.ProviderMexc]["ATOM"],
+	)
+}
+
+func (rts *RouterTestSuite) TestPriceHistory() {
+	for _, pair := range []string{"ATOMUSD", "atom"} {
+		req, err := http.NewRequest("GET", "/api/v1/prices/history?pair="+pair, nil)
+		rts.Require().NoError(err)
+		response := rts.executeRequest(req)
+		rts.Require().Equal(http.StatusOK, response.Code)
+
+		var respBody v1.PriceHistoryResponse
+		rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+		rts.Require().Equal(mockPriceHistory["ATOM"], respBody.Prices)
+	}
+
+	req, err := http.NewRequest("GET", "/api/v1/prices/history?pair=FOOUSD", nil)
+	rts.Require().NoError(err)
+	rts.Require().Equal(http.StatusNotFound, rts.executeRequest(req).Code)
+
+	req, err = http.NewRequest("GET", "/api/v1/prices/history", nil)
+	rts.Require().NoError(err)
+	rts.Require().Equal(http.StatusBadRequest, rts.executeRequest(req).Code)
+}
+
+func (rts *RouterTestSuite) TestPricesStream() {
+	srv := httptest.NewServer(rts.mux)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"/api/v1/prices/stream", nil)
+	rts.Require().NoError(err)
+
+	resp, err := http.DefaultClient.Do(req)
+	rts.Require().NoError(err)
+	defer resp.Body.Close()
+	rts.Require().Equal(http.StatusOK, resp.StatusCode)
+	rts.Require().Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	rts.Require().NoError(err)
+	rts.Require().Equal("event: prices\n", line)
+
+	line, err = reader.ReadString('\n')
+	rts.Require().NoError(err)
+	var respBody v1.PricesResponse
+	rts.Require().NoError(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &respBody))
+	rts.Require().Equal(mockPrices["ATOM"], respBody.Prices["ATOM"])
+	rts.Require().Equal(time.Unix(1700000005, 0).UTC(), respBody.Timestamp)
+}
+
+func (rts *RouterTestSuite) TestAdminProviders() {
+	adminRequest := func(method, path, token string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, nil)
+		rts.Require().NoError(err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return rts.executeRequest(req)
+	}
+
+	response := adminRequest("POST", "/api/v1/admin/providers/binance/disable", "wrong-token")
+	rts.Require().Equal(http.StatusUnauthorized, response.Code)
+
+	response = adminRequest("POST", "/api/v1/admin/providers/binance/disable", "admin-token")
+	rts.Require().Equal(http.StatusOK, response.Code)
+
+	var respBody v1.DisabledProvidersResponse
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().Equal([]provider.Name{provider.ProviderBinance}, respBody.Disabled)
+
+	response = adminRequest("GET", "/api/v1/admin/providers/disabled", "admin-token")
+	rts.Require().Equal(http.StatusOK, response.Code)
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().Equal([]provider.Name{provider.ProviderBinance}, respBody.Disabled)
+
+	response = adminRequest("POST", "/api/v1/admin/providers/binance/enable", "admin-token")
+	rts.Require().Equal(http.StatusOK, response.Code)
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().Empty(respBody.Disabled)
+
+	response = adminRequest("POST", "/api/v1/admin/providers/foo/disable", "admin-token")
+	rts.Require().Equal(http.StatusNotFound, response.Code)
+
+	response = adminRequest("GET", "/api/v1/admin/providers", "admin-token")
+	rts.Require().Equal(http.StatusOK, response.Code)
+	var providersBody v1.ProvidersResponse
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &providersBody))
+	rts.Require().Len(providersBody.Providers, 1)
+	rts.Require().Equal(provider.ProviderBinance, providersBody.Providers[0].Name)
+	rts.Require().Equal([]string{"ticker", "kline_1m"}, providersBody.Providers[0].Channels)
+}
+
+func (rts *RouterTestSuite) TestAdminRotateAPIKey() {
+	rotateRequest := func(path, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", path, strings.NewReader(body))
+		rts.Require().NoError(err)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		return rts.executeRequest(req)
+	}
+
+	response := rotateRequest("/api/v1/admin/providers/binance/apikey", `{"apikey": "new-key"}`)
+	rts.Require().Equal(http.StatusOK, response.Code)
+
+	var respBody v1.RotateAPIKeyResponse
+	rts.Require().NoError(json.Unmarshal(response.Body.Bytes(), &respBody))
+	rts.Require().Equal(provider.ProviderBinance, respBody.Provider)
+
+	response = rotateRequest("/api/v1/admin/providers/binance/apikey", `{}`)
+	rts.Require().Equal(http.StatusBadRequest, response.Code)
+
+	response = rotateRequest("/api/v1/admin/providers/foo/apikey", `{"apikey": "new-key"}`)
+	rts.Require().Equal(http.StatusNotFound, response.Code)
+
+	response = rotateRequest("/api/v1/admin/providers/numia/apikey", `{"apikey": "new-key"}`)
+	rts.Require().Equal(http.StatusConflict, response.Code)
+}
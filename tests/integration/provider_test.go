@@ -79,10 +79,10 @@ func (s *IntegrationTestSuite) TestSubscribeCurrencyPairs() {
 }
 
 func checkForPrices(t *testing.T, pvd provider.Provider, currencyPairs []types.CurrencyPair, providerName string) {
-	tickerPrices, err := pvd.GetTickerPrices(currencyPairs...)
+	tickerPrices, err := pvd.GetTickerPrices(context.TODO(), currencyPairs...)
 	require.NoError(t, err)
 
-	candlePrices, err := pvd.GetCandlePrices(currencyPairs...)
+	candlePrices, err := pvd.GetCandlePrices(context.TODO(), currencyPairs...)
 	require.NoError(t, err)
 
 	for _, cp := range currencyPairs {